@@ -0,0 +1,88 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+// Condition types used in the status of the operator custom resources
+const (
+	// ConditionReady reports that the object is fully applied
+	ConditionReady = "Ready"
+	// ConditionDegraded reports that applying the object failed at least partially
+	ConditionDegraded = "Degraded"
+	// ConditionConflicting reports that the object is overridden by another object
+	ConditionConflicting = "Conflicting"
+)
+
+// Condition reasons used for SriovNetworkNodePolicy conditions
+const (
+	PolicyReasonAllNodesSynced  = "AllNodesSynced"
+	PolicyReasonNodesSyncing    = "NodesSyncing"
+	PolicyReasonNoMatchingNodes = "NoMatchingNodes"
+	PolicyReasonSyncFailed      = "SyncFailed"
+	PolicyReasonNoSyncFailures  = "NoSyncFailures"
+	PolicyReasonOverridden      = "OverriddenByOtherPolicy"
+	PolicyReasonNoConflicts     = "NoConflicts"
+	PolicyReasonDryRun          = "DryRun"
+	PolicyReasonPlanFailed      = "PlanEvaluationFailed"
+)
+
+// Condition types used in the status of the SriovNetworkNodeState
+const (
+	// ConditionSynced reports that the config daemon applied the latest generation of the node state
+	ConditionSynced = "Synced"
+	// ConditionDrainRequired reports that the node must be drained before the configuration is applied
+	ConditionDrainRequired = "DrainRequired"
+	// ConditionRebootRequired reports that the node must be rebooted to apply the configuration
+	ConditionRebootRequired = "RebootRequired"
+	// ConditionDevicePluginBlocked reports that the device plugin waits for the node configuration
+	ConditionDevicePluginBlocked = "DevicePluginBlocked"
+	// ConditionVfioUnsafe reports that VFs bound to vfio-pci can't be safely isolated,
+	// the IOMMU is disabled or the VFs share their IOMMU group with other devices
+	ConditionVfioUnsafe = "VfioUnsafe"
+)
+
+// Condition reasons used for SriovNetworkNodeState conditions
+const (
+	NodeStateReasonSyncSucceeded          = "SyncSucceeded"
+	NodeStateReasonSyncInProgress         = "SyncInProgress"
+	NodeStateReasonSyncFailed             = "SyncFailed"
+	NodeStateReasonRolledBack             = "RolledBack"
+	NodeStateReasonPaused                 = "Paused"
+	NodeStateReasonWaitingForCanaries     = "WaitingForCanaryNodes"
+	NodeStateReasonNoSyncFailures         = "NoSyncFailures"
+	NodeStateReasonDrainRequested         = "DrainRequested"
+	NodeStateReasonWaitingForWindow       = "WaitingForMaintenanceWindow"
+	NodeStateReasonDrainNotRequested      = "DrainNotRequested"
+	NodeStateReasonRebootRequested        = "RebootRequested"
+	NodeStateReasonRebootInitiated        = "RebootInitiated"
+	NodeStateReasonRebootNotRequested     = "RebootNotRequested"
+	NodeStateReasonWaitingForConfig       = "WaitingForConfiguration"
+	NodeStateReasonDevicePluginUnblocked  = "DevicePluginUnblocked"
+	NodeStateReasonNoInterfacesConfigured = "NoInterfacesConfigured"
+	NodeStateReasonIommuDisabled          = "IommuDisabled"
+	NodeStateReasonSharedIommuGroup       = "SharedIommuGroup"
+	NodeStateReasonVfioIsolated           = "VfioIsolated"
+)
+
+// Condition reasons used for SriovNetwork, SriovIBNetwork and OVSNetwork conditions
+const (
+	NetworkReasonNetAttachDefSynced    = "NetworkAttachmentDefinitionSynced"
+	NetworkReasonResourceNotAdvertised = "ResourceNotAdvertised"
+	NetworkReasonNamespaceNotFound     = "NamespaceNotFound"
+	NetworkReasonNetAttachDefConflict  = "NetworkAttachmentDefinitionConflict"
+	NetworkReasonInvalidNamespace      = "InvalidNetworkNamespace"
+	NetworkReasonSyncFailed            = "SyncFailed"
+)
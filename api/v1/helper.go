@@ -18,8 +18,13 @@ package v1
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"net"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -39,8 +44,8 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/drivers"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/render"
-	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
 const (
@@ -222,6 +227,16 @@ func NeedToUpdateSriov(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
 		log.V(0).Info("NeedToUpdateSriov(): NumVfs needs update", "desired", ifaceSpec.NumVfs, "current", ifaceStatus.NumVfs)
 		return true
 	}
+	if ifaceSpec.NumSfs != ifaceStatus.NumSfs {
+		log.V(0).Info("NeedToUpdateSriov(): NumSfs needs update", "desired", ifaceSpec.NumSfs, "current", ifaceStatus.NumSfs)
+		return true
+	}
+	for _, sfStatus := range ifaceStatus.SFs {
+		if sfStatus.State != consts.SfStateActive {
+			log.V(0).Info("NeedToUpdateSriov(): SF state needs update", "sfnum", sfStatus.SfNumber, "current", sfStatus.State)
+			return true
+		}
+	}
 
 	if ifaceStatus.LinkAdminState == consts.LinkAdminStateDown {
 		log.V(0).Info("NeedToUpdateSriov(): PF link status needs update", "desired to include", "up", "current", ifaceStatus.LinkAdminState)
@@ -238,13 +253,13 @@ func NeedToUpdateSriov(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
 						return true
 					}
 					if groupSpec.DeviceType != "" && groupSpec.DeviceType != consts.DeviceTypeNetDevice {
-						if groupSpec.DeviceType != vfStatus.Driver {
+						if drivers.GetDriver(groupSpec.DeviceType) != vfStatus.Driver {
 							log.V(0).Info("NeedToUpdateSriov(): Driver needs update",
 								"desired", groupSpec.DeviceType, "current", vfStatus.Driver)
 							return true
 						}
 					} else {
-						if StringInArray(vfStatus.Driver, vars.DpdkDrivers) {
+						if drivers.IsUserspaceDriver(vfStatus.Driver) {
 							log.V(0).Info("NeedToUpdateSriov(): Driver needs update",
 								"desired", groupSpec.DeviceType, "current", vfStatus.Driver)
 							return true
@@ -264,6 +279,16 @@ func NeedToUpdateSriov(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
 									"vf", vfStatus.VfID, "current", vfStatus.GUID)
 								return true
 							}
+							if ifaceSpec.IbGUIDAllocation != nil && vfStatus.GUID != "" {
+								desired, err := ifaceSpec.IbGUIDAllocation.VfGUID(vfStatus.VfID)
+								if err != nil {
+									log.Error(err, "NeedToUpdateSriov(): invalid GUID allocation", "vf", vfStatus.VfID)
+								} else if !strings.EqualFold(desired, vfStatus.GUID) {
+									log.V(0).Info("NeedToUpdateSriov(): VF GUID needs update",
+										"vf", vfStatus.VfID, "desired", desired, "current", vfStatus.GUID)
+									return true
+								}
+							}
 						}
 						// this is needed to be sure the admin mac address is configured as expected
 						if ifaceSpec.ExternallyManaged {
@@ -277,6 +302,13 @@ func NeedToUpdateSriov(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
 							"desired", groupSpec.VdpaType, "current", vfStatus.VdpaType)
 						return true
 					}
+					if groupSpec.VdpaType != "" && needToUpdateVdpaAttributes(&groupSpec, &vfStatus) {
+						return true
+					}
+					if strings.EqualFold(ifaceStatus.LinkType, consts.LinkTypeIB) && ifaceStatus.Driver == consts.Mlx4DriverName &&
+						needToUpdateVfPKeys(&groupSpec, &vfStatus) {
+						return true
+					}
 					break
 				}
 			}
@@ -302,6 +334,17 @@ func (a ByPriority) Swap(i, j int) {
 	a[i], a[j] = a[j], a[i]
 }
 
+// IsDryRun returns true if the policy is only rendered in the planned configuration of the nodes
+func (p *SriovNetworkNodePolicy) IsDryRun() bool {
+	return p.GetAnnotations()[consts.PolicyDryRunAnnotation] == "true"
+}
+
+// PlannedSpecHash returns a short hash identifying the planned spec annotation of a SriovNetworkNodeState
+func PlannedSpecHash(plannedSpec string) string {
+	h := sha256.Sum256([]byte(plannedSpec))
+	return hex.EncodeToString(h[:8])
+}
+
 // Match check if node is selected by NodeSelector
 func (p *SriovNetworkNodePolicy) Selected(node *corev1.Node) bool {
 	for k, v := range p.Spec.NodeSelector {
@@ -375,14 +418,20 @@ func (p *SriovNetworkNodePolicy) Apply(state *SriovNetworkNodeState, equalPriori
 				LinkType:          p.Spec.LinkType,
 				EswitchMode:       p.Spec.EswitchMode,
 				NumVfs:            p.Spec.NumVfs,
+				NumSfs:            p.Spec.NumSfs,
 				ExternallyManaged: p.Spec.ExternallyManaged,
+				PfSettings:        p.Spec.PfSettings.DeepCopy(),
+				DevlinkParams:     maps.Clone(p.Spec.DevlinkParams),
+				DdpPackage:        p.Spec.DdpPackage,
 			}
-			if p.Spec.NumVfs > 0 {
-				group, err := p.generatePfNameVfGroup(&iface)
-				if err != nil {
-					return err
+			if p.Spec.NumVfs > 0 || p.Spec.NumSfs > 0 {
+				if p.Spec.NumVfs > 0 {
+					group, err := p.generatePfNameVfGroup(&iface)
+					if err != nil {
+						return err
+					}
+					result.VfGroups = []VfGroup{*group}
 				}
-				result.VfGroups = []VfGroup{*group}
 				found := false
 				for i := range state.Spec.Interfaces {
 					if state.Spec.Interfaces[i].PciAddress == result.PciAddress {
@@ -419,11 +468,12 @@ func (p *SriovNetworkNodePolicy) ApplyBridgeConfig(state *SriovNetworkNodeState)
 			return fmt.Errorf("software bridge management can't be used when link is externally managed")
 		}
 	}
+	var bondUplink *InterfaceExt
 	for _, iface := range state.Status.Interfaces {
 		if p.Spec.NicSelector.Selected(&iface) {
-			if p.Spec.Bridge.OVS == nil {
+			if p.Spec.Bridge.OVS == nil || p.Spec.Bond != nil {
 				// The policy has no OVS bridge config, this means that the node's state should have no managed OVS bridges for the interfaces that match the policy.
-				// Currently PF to OVS bridge mapping is always 1 to 1 (bonding is not supported at the moment), meaning we can remove the OVS bridge
+				// The uplink of a bridge is a single PF or the bond of the PFs (its pci address is the one of the first slave), meaning we can remove the OVS bridge
 				// config from the node's state if it has the interface (that matches "empty-bridge" policy) in the uplink section.
 				state.Spec.Bridges.OVS = slices.DeleteFunc(state.Spec.Bridges.OVS, func(br OVSConfigExt) bool {
 					return slices.ContainsFunc(br.Uplinks, func(uplink OVSUplinkConfigExt) bool {
@@ -433,6 +483,10 @@ func (p *SriovNetworkNodePolicy) ApplyBridgeConfig(state *SriovNetworkNodeState)
 				if len(state.Spec.Bridges.OVS) == 0 {
 					state.Spec.Bridges.OVS = nil
 				}
+				if p.Spec.Bridge.OVS != nil && (bondUplink == nil || iface.PciAddress < bondUplink.PciAddress) {
+					// the bond of the PFs is the uplink of a single bridge added once all the PFs are processed
+					bondUplink = iface.DeepCopy()
+				}
 				continue
 			}
 			ovsBridge := OVSConfigExt{
@@ -449,18 +503,113 @@ func (p *SriovNetworkNodePolicy) ApplyBridgeConfig(state *SriovNetworkNodeState)
 				ovsBridge.Uplinks[0].Interface.MTURequest = &mtu
 			}
 			log.Info("Update bridge for interface", "name", iface.Name, "bridge", ovsBridge.Name)
+			state.Spec.Bridges.setOVSBridge(ovsBridge)
+		}
+	}
+	if bondUplink != nil {
+		ovsBridge := OVSConfigExt{
+			Name:   GenerateBondBridgeName(p.Spec.Bond.Name),
+			Bridge: p.Spec.Bridge.OVS.Bridge,
+			Uplinks: []OVSUplinkConfigExt{{
+				PciAddress: bondUplink.PciAddress,
+				Name:       p.Spec.Bond.Name,
+				Interface:  p.Spec.Bridge.OVS.Uplink.Interface,
+			}},
+		}
+		if p.Spec.Mtu > 0 {
+			mtu := p.Spec.Mtu
+			ovsBridge.Uplinks[0].Interface.MTURequest = &mtu
+		}
+		log.Info("Update bridge for bond", "name", p.Spec.Bond.Name, "bridge", ovsBridge.Name)
+		state.Spec.Bridges.setOVSBridge(ovsBridge)
+	}
+	return nil
+}
 
-			// We need to keep slices with bridges ordered to avoid unnecessary updates in the K8S API.
-			// Use binary search to insert (or update) the bridge config to the right place in the slice to keep it sorted.
-			pos, exist := slices.BinarySearchFunc(state.Spec.Bridges.OVS, ovsBridge, func(x, y OVSConfigExt) int {
-				return strings.Compare(x.Name, y.Name)
-			})
-			if exist {
-				state.Spec.Bridges.OVS[pos] = ovsBridge
-			} else {
-				state.Spec.Bridges.OVS = slices.Insert(state.Spec.Bridges.OVS, pos, ovsBridge)
-			}
+// setOVSBridge inserts or updates the OVS bridge config
+func (b *Bridges) setOVSBridge(ovsBridge OVSConfigExt) {
+	// We need to keep slices with bridges ordered to avoid unnecessary updates in the K8S API.
+	// Use binary search to insert (or update) the bridge config to the right place in the slice to keep it sorted.
+	pos, exist := slices.BinarySearchFunc(b.OVS, ovsBridge, func(x, y OVSConfigExt) int {
+		return strings.Compare(x.Name, y.Name)
+	})
+	if exist {
+		b.OVS[pos] = ovsBridge
+	} else {
+		b.OVS = slices.Insert(b.OVS, pos, ovsBridge)
+	}
+}
+
+// ApplyBondConfig applies bond configuration from the policy to the provided state
+func (p *SriovNetworkNodePolicy) ApplyBondConfig(state *SriovNetworkNodeState) error {
+	if p.Spec.NicSelector.IsEmpty() {
+		// Empty NicSelector match none
+		return nil
+	}
+	// sanity check the policy
+	if p.Spec.Bond != nil {
+		if p.Spec.EswitchMode != ESwithModeSwitchDev {
+			return fmt.Errorf("eSwitchMode must be switchdev to bond the PFs")
+		}
+		if p.Spec.LinkType != "" && !strings.EqualFold(p.Spec.LinkType, consts.LinkTypeETH) {
+			return fmt.Errorf("linkType must be eth or ETH to bond the PFs")
 		}
+		if p.Spec.ExternallyManaged {
+			return fmt.Errorf("PFs can't be bonded when link is externally managed")
+		}
+	}
+	slaves := []BondSlave{}
+	for _, iface := range state.Status.Interfaces {
+		if p.Spec.NicSelector.Selected(&iface) {
+			slaves = append(slaves, BondSlave{PciAddress: iface.PciAddress, Name: iface.Name})
+		}
+	}
+	if len(slaves) == 0 {
+		return nil
+	}
+	// a PF belongs to a single bond, the bonds of the lower priority policies that contain
+	// one of the PFs are replaced by the bond of this policy or removed if the policy has none
+	state.Spec.Bonds = slices.DeleteFunc(state.Spec.Bonds, func(bond BondConfigExt) bool {
+		return slices.ContainsFunc(bond.Slaves, func(slave BondSlave) bool {
+			return slices.ContainsFunc(slaves, func(s BondSlave) bool { return s.PciAddress == slave.PciAddress })
+		})
+	})
+	if len(state.Spec.Bonds) == 0 {
+		state.Spec.Bonds = nil
+	}
+	if p.Spec.Bond == nil {
+		return nil
+	}
+	if len(slaves) != 2 {
+		return fmt.Errorf("bond %s requires exactly two PFs, the policy %s selects %d PFs on node %s",
+			p.Spec.Bond.Name, p.Name, len(slaves), state.Name)
+	}
+	sort.Slice(slaves, func(i, j int) bool { return slaves[i].PciAddress < slaves[j].PciAddress })
+	bond := BondConfigExt{
+		Name:           p.Spec.Bond.Name,
+		Mode:           p.Spec.Bond.Mode,
+		XmitHashPolicy: p.Spec.Bond.XmitHashPolicy,
+		Mtu:            p.Spec.Mtu,
+		Slaves:         slaves,
+	}
+	if bond.Mode == "" {
+		bond.Mode = consts.BondModeActiveBackup
+	}
+	// the kernel ignores the hash policy of the active-backup mode and uses layer2 by default for the other modes
+	if bond.Mode == consts.BondModeActiveBackup {
+		bond.XmitHashPolicy = ""
+	} else if bond.XmitHashPolicy == "" {
+		bond.XmitHashPolicy = consts.BondXmitHashPolicyLayer2
+	}
+	log.Info("Update bond for interfaces", "bond", bond.Name, "slaves", slaves)
+
+	pos, exist := slices.BinarySearchFunc(state.Spec.Bonds, bond, func(x, y BondConfigExt) int {
+		return strings.Compare(x.Name, y.Name)
+	})
+	if exist {
+		state.Spec.Bonds[pos] = bond
+	} else {
+		state.Spec.Bonds = slices.Insert(state.Spec.Bonds, pos, bond)
 	}
 	return nil
 }
@@ -474,14 +623,39 @@ func (iface Interface) mergeConfigs(input *Interface, equalPriority bool) {
 	// merge VF groups (input.VfGroups already contains the highest priority):
 	// - skip group with same ResourceName,
 	// - skip overlapping groups (use only highest priority)
+	// input has no VF group when the highest priority policy configures only SFs
+	var top *VfGroup
+	if len(input.VfGroups) > 0 {
+		top = &input.VfGroups[0]
+	}
 	for _, gr := range iface.VfGroups {
-		if gr.ResourceName == input.VfGroups[0].ResourceName || gr.isVFRangeOverlapping(input.VfGroups[0]) {
+		if top != nil && (gr.ResourceName == top.ResourceName || gr.isVFRangeOverlapping(*top)) {
 			continue
 		}
 		m = true
 		input.VfGroups = append(input.VfGroups, gr)
 	}
 
+	// the SFs are not partitioned, the lower priority policies configure them only if the highest one doesn't
+	if input.NumSfs == 0 {
+		input.NumSfs = iface.NumSfs
+	}
+
+	// pfSettings of the highest priority policy win, the lower priority policies fill the unset settings
+	input.PfSettings = input.PfSettings.merge(iface.PfSettings)
+	// same for the devlink parameters
+	for name, param := range iface.DevlinkParams {
+		if _, ok := input.DevlinkParams[name]; !ok {
+			if input.DevlinkParams == nil {
+				input.DevlinkParams = map[string]DevlinkParam{}
+			}
+			input.DevlinkParams[name] = param
+		}
+	}
+	if input.DdpPackage == "" {
+		input.DdpPackage = iface.DdpPackage
+	}
+
 	if !equalPriority && !m {
 		return
 	}
@@ -495,6 +669,167 @@ func (iface Interface) mergeConfigs(input *Interface, equalPriority bool) {
 	}
 }
 
+// merge returns the settings completed with the unset settings of other
+func (s *PfSettings) merge(other *PfSettings) *PfSettings {
+	if s == nil {
+		return other.DeepCopy()
+	}
+	if other == nil {
+		return s
+	}
+	if s.RxRingSize == nil {
+		s.RxRingSize = other.RxRingSize
+	}
+	if s.TxRingSize == nil {
+		s.TxRingSize = other.TxRingSize
+	}
+	if s.CombinedChannels == nil {
+		s.CombinedChannels = other.CombinedChannels
+	}
+	for name, enabled := range other.Features {
+		if _, ok := s.Features[name]; !ok {
+			if s.Features == nil {
+				s.Features = map[string]bool{}
+			}
+			s.Features[name] = enabled
+		}
+	}
+	if s.Pause == nil {
+		s.Pause = other.Pause
+	}
+	if s.FecMode == "" {
+		s.FecMode = other.FecMode
+	}
+	return s
+}
+
+// NeedToUpdatePfSettings returns true if the ethtool settings of the PF don't match the spec.
+// They are applied live, they are not part of NeedToUpdateSriov so their drift doesn't drain the node.
+func NeedToUpdatePfSettings(desired, current *PfSettings) bool {
+	if desired == nil {
+		return false
+	}
+	if current == nil {
+		current = &PfSettings{}
+	}
+	intDiffers := func(d, c *int) bool {
+		return d != nil && (c == nil || *d != *c)
+	}
+	boolDiffers := func(d, c *bool) bool {
+		return d != nil && (c == nil || *d != *c)
+	}
+	if intDiffers(desired.RxRingSize, current.RxRingSize) || intDiffers(desired.TxRingSize, current.TxRingSize) {
+		log.V(0).Info("NeedToUpdatePfSettings(): ring sizes need update")
+		return true
+	}
+	if intDiffers(desired.CombinedChannels, current.CombinedChannels) {
+		log.V(0).Info("NeedToUpdatePfSettings(): combined channels need update")
+		return true
+	}
+	for name, enabled := range desired.Features {
+		if state, ok := current.Features[name]; !ok || state != enabled {
+			log.V(0).Info("NeedToUpdatePfSettings(): feature needs update", "feature", name, "desired", enabled)
+			return true
+		}
+	}
+	if desired.Pause != nil {
+		if current.Pause == nil || boolDiffers(desired.Pause.Autoneg, current.Pause.Autoneg) ||
+			boolDiffers(desired.Pause.Rx, current.Pause.Rx) || boolDiffers(desired.Pause.Tx, current.Pause.Tx) {
+			log.V(0).Info("NeedToUpdatePfSettings(): pause settings need update")
+			return true
+		}
+	}
+	if desired.FecMode != "" && desired.FecMode != current.FecMode {
+		log.V(0).Info("NeedToUpdatePfSettings(): FEC mode needs update", "desired", desired.FecMode, "current", current.FecMode)
+		return true
+	}
+	return false
+}
+
+// Actions required to apply the devlink parameters of a PF, ordered by impact
+const (
+	DevlinkParamsActionNone   = ""
+	DevlinkParamsActionApply  = "Apply"
+	DevlinkParamsActionReload = "DriverReload"
+	DevlinkParamsActionReboot = "Reboot"
+)
+
+var devlinkParamsActions = []string{
+	DevlinkParamsActionNone,
+	DevlinkParamsActionApply,
+	DevlinkParamsActionReload,
+	DevlinkParamsActionReboot,
+}
+
+// DevlinkParamCmodes are the configuration modes of the devlink parameters in the order
+// used to select the mode of a parameter when the spec doesn't set it
+var DevlinkParamCmodes = []string{
+	consts.DevlinkParamCmodeRuntime,
+	consts.DevlinkParamCmodeDriverinit,
+	consts.DevlinkParamCmodePermanent,
+}
+
+// FindDevlinkParamStatus returns the value of the parameter in the configuration mode,
+// if the mode is empty the value of the first mode supported by the parameter is returned
+func FindDevlinkParamStatus(params []DevlinkParamStatus, name, cmode string) (DevlinkParamStatus, bool) {
+	for _, mode := range DevlinkParamCmodes {
+		if cmode != "" && cmode != mode {
+			continue
+		}
+		for _, param := range params {
+			if param.Name == name && param.Cmode == mode {
+				return param, true
+			}
+		}
+	}
+	return DevlinkParamStatus{}, false
+}
+
+// GetUnsupportedDevlinkParams returns the sorted names of the devlink parameters of the spec the device
+// doesn't expose in the configuration mode of the spec, or in any mode when the spec doesn't set it
+func GetUnsupportedDevlinkParams(desired map[string]DevlinkParam, current []DevlinkParamStatus) []string {
+	var unsupported []string
+	for name, param := range desired {
+		if _, found := FindDevlinkParamStatus(current, name, param.Cmode); !found {
+			unsupported = append(unsupported, name)
+		}
+	}
+	slices.Sort(unsupported)
+	return unsupported
+}
+
+// GetDevlinkParamsAction returns the action required to apply the devlink parameters of the spec
+// that differ from the status: runtime parameters are applied immediately, driverinit parameters
+// require a driver reload and permanent parameters a reboot.
+// The parameters the device doesn't support are ignored, they are reported in the status of the PF.
+func GetDevlinkParamsAction(desired map[string]DevlinkParam, current []DevlinkParamStatus) string {
+	action := DevlinkParamsActionNone
+	for name, param := range desired {
+		status, found := FindDevlinkParamStatus(current, name, param.Cmode)
+		if !found {
+			log.V(2).Info("GetDevlinkParamsAction(): devlink parameter is not supported by the device, ignoring it",
+				"param", name, "cmode", param.Cmode)
+			continue
+		}
+		if strings.EqualFold(status.Value, param.Value) {
+			continue
+		}
+		paramAction := DevlinkParamsActionApply
+		switch status.Cmode {
+		case consts.DevlinkParamCmodeDriverinit:
+			paramAction = DevlinkParamsActionReload
+		case consts.DevlinkParamCmodePermanent:
+			paramAction = DevlinkParamsActionReboot
+		}
+		log.V(0).Info("GetDevlinkParamsAction(): devlink parameter needs update",
+			"param", name, "desired", param.Value, "current", status.Value, "action", paramAction)
+		if slices.Index(devlinkParamsActions, paramAction) > slices.Index(devlinkParamsActions, action) {
+			action = paramAction
+		}
+	}
+	return action
+}
+
 func (gr VfGroup) isVFRangeOverlapping(group VfGroup) bool {
 	rngSt, rngEnd, err := parseRange(gr.VfRange)
 	if err != nil {
@@ -536,13 +871,16 @@ func (p *SriovNetworkNodePolicy) generatePfNameVfGroup(iface *InterfaceExt) (*Vf
 	}
 	rng := strconv.Itoa(rngStart) + "-" + strconv.Itoa(rngEnd)
 	return &VfGroup{
-		ResourceName: p.Spec.ResourceName,
-		DeviceType:   p.Spec.DeviceType,
-		VfRange:      rng,
-		PolicyName:   p.GetName(),
-		Mtu:          p.Spec.Mtu,
-		IsRdma:       p.Spec.IsRdma,
-		VdpaType:     p.Spec.VdpaType,
+		ResourceName:   p.Spec.ResourceName,
+		DeviceType:     p.Spec.DeviceType,
+		VfRange:        rng,
+		PolicyName:     p.GetName(),
+		Mtu:            p.Spec.Mtu,
+		IsRdma:         p.Spec.IsRdma,
+		VdpaType:       p.Spec.VdpaType,
+		VfAttributes:   p.Spec.VfAttributes.DeepCopy(),
+		VdpaAttributes: p.Spec.VdpaAttributes.DeepCopy(),
+		PKeys:          slices.Clone(p.Spec.PKeys),
 	}, nil
 }
 
@@ -557,6 +895,221 @@ func IndexInRange(i int, r string) bool {
 	return false
 }
 
+// VfAdminMac returns the administrative MAC address of a VF of the group,
+// the address is the MAC of the vfAttributes with the offset of the VF in the range
+func (g *VfGroup) VfAdminMac(vfID int) (net.HardwareAddr, error) {
+	if g.VfAttributes == nil || g.VfAttributes.Mac == "" {
+		return nil, nil
+	}
+	return g.vfMac(g.VfAttributes.Mac, vfID)
+}
+
+// VdpaDeviceAttributes returns the attributes of the VDPA device of a VF of the group,
+// the MAC address is the MAC of the vdpaAttributes with the offset of the VF in the range
+func (g *VfGroup) VdpaDeviceAttributes(vfID int) (*VdpaAttributes, error) {
+	if g.VdpaAttributes == nil {
+		return nil, nil
+	}
+	attrs := g.VdpaAttributes.DeepCopy()
+	if attrs.Mac != "" {
+		mac, err := g.vfMac(attrs.Mac, vfID)
+		if err != nil {
+			return nil, err
+		}
+		attrs.Mac = mac.String()
+	}
+	return attrs, nil
+}
+
+// vfMac returns the base MAC address with the offset of the VF in the range of the group
+func (g *VfGroup) vfMac(baseMac string, vfID int) (net.HardwareAddr, error) {
+	base, err := net.ParseMAC(baseMac)
+	if err != nil {
+		return nil, err
+	}
+	rngSt, _, err := parseRange(g.VfRange)
+	if err != nil {
+		return nil, err
+	}
+	mac := make([]byte, 8)
+	copy(mac[2:], base)
+	value := binary.BigEndian.Uint64(mac) + uint64(vfID-rngSt)
+	binary.BigEndian.PutUint64(mac, value)
+	return net.HardwareAddr(mac[2:]), nil
+}
+
+// needToUpdateVdpaAttributes returns true if the attributes of the VDPA device of the VF
+// don't match the vdpaAttributes of the group
+func needToUpdateVdpaAttributes(groupSpec *VfGroup, vfStatus *VirtualFunction) bool {
+	attrs, err := groupSpec.VdpaDeviceAttributes(vfStatus.VfID)
+	if err != nil {
+		log.Error(err, "NeedToUpdateSriov(): invalid VDPA MAC", "mac", groupSpec.VdpaAttributes.Mac)
+		return false
+	}
+	if attrs == nil {
+		return false
+	}
+	if attrs.Mac != "" && !strings.EqualFold(attrs.Mac, vfStatus.VdpaMac) {
+		log.V(0).Info("NeedToUpdateSriov(): VDPA MAC needs update",
+			"vf", vfStatus.VfID, "desired", attrs.Mac, "current", vfStatus.VdpaMac)
+		return true
+	}
+	if attrs.Mtu != nil && *attrs.Mtu != vfStatus.VdpaMtu {
+		log.V(0).Info("NeedToUpdateSriov(): VDPA MTU needs update",
+			"vf", vfStatus.VfID, "desired", *attrs.Mtu, "current", vfStatus.VdpaMtu)
+		return true
+	}
+	if attrs.MaxVqp != nil && *attrs.MaxVqp != vfStatus.VdpaMaxVqp {
+		log.V(0).Info("NeedToUpdateSriov(): VDPA max virtqueue pairs needs update",
+			"vf", vfStatus.VfID, "desired", *attrs.MaxVqp, "current", vfStatus.VdpaMaxVqp)
+		return true
+	}
+	if attrs.MgmtDevice != "" && attrs.MgmtDevice != vfStatus.VdpaMgmtDevice {
+		log.V(0).Info("NeedToUpdateSriov(): VDPA management device needs update",
+			"vf", vfStatus.VfID, "desired", attrs.MgmtDevice, "current", vfStatus.VdpaMgmtDevice)
+		return true
+	}
+	return false
+}
+
+// ParseIbGUID parses an InfiniBand GUID in the 00:00:00:00:00:00:00:00 form
+func ParseIbGUID(s string) (uint64, error) {
+	ha, err := net.ParseMAC(s)
+	if err != nil {
+		return 0, err
+	}
+	if len(ha) != 8 {
+		return 0, fmt.Errorf("invalid GUID %s: 8 bytes expected", s)
+	}
+	return binary.BigEndian.Uint64(ha), nil
+}
+
+// FormatIbGUID returns the 00:00:00:00:00:00:00:00 form of an InfiniBand GUID
+func FormatIbGUID(guid uint64) string {
+	b := make(net.HardwareAddr, 8)
+	binary.BigEndian.PutUint64(b, guid)
+	return b.String()
+}
+
+// VfGUID returns the GUID of the VF allocated from the block
+func (a *IbGUIDAllocation) VfGUID(vfID int) (string, error) {
+	start, err := ParseIbGUID(a.Start)
+	if err != nil {
+		return "", err
+	}
+	end, err := ParseIbGUID(a.End)
+	if err != nil {
+		return "", err
+	}
+	if end < start || vfID < 0 || uint64(vfID) > end-start {
+		return "", fmt.Errorf("VF %d is out of the GUID block %s-%s", vfID, a.Start, a.End)
+	}
+	return FormatIbGUID(start + uint64(vfID)), nil
+}
+
+// needToUpdateVfPKeys returns true if the PKey table of the VF doesn't hold exactly the PKeys of the group in the same order.
+// The VFs without reported PKeys are skipped, their RDMA device may be allocated to a workload.
+// The groups without PKeys don't manage the table, the tables programmed for a previous policy are reset by the config daemon.
+// Only the tables of the VFs of the mlx4 PFs are compared, the other drivers don't expose them to the host
+func needToUpdateVfPKeys(groupSpec *VfGroup, vfStatus *VirtualFunction) bool {
+	if len(groupSpec.PKeys) == 0 || len(vfStatus.PKeys) == 0 {
+		return false
+	}
+	if len(groupSpec.PKeys) == len(vfStatus.PKeys) {
+		equal := true
+		for idx, pkey := range groupSpec.PKeys {
+			desired, err := strconv.ParseUint(pkey, 0, 16)
+			if err != nil {
+				log.Error(err, "NeedToUpdateSriov(): invalid PKey", "pkey", pkey)
+				return false
+			}
+			current, err := strconv.ParseUint(vfStatus.PKeys[idx], 0, 16)
+			if err != nil || current != desired {
+				equal = false
+				break
+			}
+		}
+		if equal {
+			return false
+		}
+	}
+	log.V(0).Info("NeedToUpdateSriov(): VF PKeys need update",
+		"vf", vfStatus.VfID, "desired", groupSpec.PKeys, "current", vfStatus.PKeys)
+	return true
+}
+
+// GetVfsToUpdateAttributes returns the VFs of the PF whose administrative attributes don't match the vfAttributes
+// of their group. The attributes are configured through the PF without disrupting the VFs, they are not part of
+// NeedToUpdateSriov so their drift doesn't drain the node. The VFs allocated to a pod are skipped, the CNI configures
+// their attributes for the network of the pod.
+func GetVfsToUpdateAttributes(ifaceSpec *Interface, ifaceStatus *InterfaceExt, allocated map[string]bool) []VirtualFunction {
+	if strings.EqualFold(ifaceStatus.LinkType, consts.LinkTypeIB) {
+		return nil
+	}
+	var vfs []VirtualFunction
+	for _, vfStatus := range ifaceStatus.VFs {
+		if allocated[vfStatus.PciAddress] {
+			continue
+		}
+		for _, groupSpec := range ifaceSpec.VfGroups {
+			if IndexInRange(vfStatus.VfID, groupSpec.VfRange) {
+				if needToUpdateVfAttributes(&groupSpec, &vfStatus) {
+					vfs = append(vfs, vfStatus)
+				}
+				break
+			}
+		}
+	}
+	return vfs
+}
+
+// needToUpdateVfAttributes returns true if the administrative attributes of the VF
+// reported by the PF don't match the vfAttributes of the group
+func needToUpdateVfAttributes(groupSpec *VfGroup, vfStatus *VirtualFunction) bool {
+	attrs := groupSpec.VfAttributes
+	if attrs == nil {
+		return false
+	}
+	if attrs.Mac != "" {
+		mac, err := groupSpec.VfAdminMac(vfStatus.VfID)
+		if err != nil {
+			log.Error(err, "GetVfsToUpdateAttributes(): invalid VF admin MAC", "mac", attrs.Mac)
+			return false
+		}
+		if !strings.EqualFold(mac.String(), vfStatus.AdminMac) {
+			log.V(0).Info("GetVfsToUpdateAttributes(): VF admin MAC needs update",
+				"vf", vfStatus.VfID, "desired", mac.String(), "current", vfStatus.AdminMac)
+			return true
+		}
+	}
+	if attrs.Vlan != nil && (*attrs.Vlan != vfStatus.Vlan || (*attrs.Vlan != 0 && attrs.VlanQoS != vfStatus.VlanQoS)) {
+		log.V(0).Info("GetVfsToUpdateAttributes(): VF VLAN needs update",
+			"vf", vfStatus.VfID, "desired", *attrs.Vlan, "current", vfStatus.Vlan)
+		return true
+	}
+	if attrs.SpoofChk != "" && attrs.SpoofChk != vfStatus.SpoofChk {
+		log.V(0).Info("GetVfsToUpdateAttributes(): VF spoof check needs update",
+			"vf", vfStatus.VfID, "desired", attrs.SpoofChk, "current", vfStatus.SpoofChk)
+		return true
+	}
+	if attrs.Trust != "" && attrs.Trust != vfStatus.Trust {
+		log.V(0).Info("GetVfsToUpdateAttributes(): VF trust needs update",
+			"vf", vfStatus.VfID, "desired", attrs.Trust, "current", vfStatus.Trust)
+		return true
+	}
+	if attrs.LinkState != "" && attrs.LinkState != vfStatus.LinkState {
+		log.V(0).Info("GetVfsToUpdateAttributes(): VF link state needs update",
+			"vf", vfStatus.VfID, "desired", attrs.LinkState, "current", vfStatus.LinkState)
+		return true
+	}
+	if (attrs.MinTxRate != nil && *attrs.MinTxRate != vfStatus.MinTxRate) ||
+		(attrs.MaxTxRate != nil && *attrs.MaxTxRate != vfStatus.MaxTxRate) {
+		log.V(0).Info("GetVfsToUpdateAttributes(): VF tx rate needs update", "vf", vfStatus.VfID)
+		return true
+	}
+	return false
+}
+
 func parseRange(r string) (rngSt, rngEnd int, err error) {
 	rng := strings.Split(r, "-")
 	rngSt, err = strconv.Atoi(rng[0])
@@ -690,6 +1243,9 @@ func (cr *SriovIBNetwork) RenderNetAttDef() (*uns.Unstructured, error) {
 		data.Data["SriovCniCapabilities"] = cr.Spec.Capabilities
 	}
 
+	data.Data["PKeyConfigured"] = cr.Spec.PKey != ""
+	data.Data["SriovCniPKey"] = strings.ToLower(cr.Spec.PKey)
+
 	if cr.Spec.IPAM != "" {
 		data.Data["SriovCniIpam"] = SriovCniIpam + ":" + strings.Join(strings.Fields(cr.Spec.IPAM), "")
 	} else {
@@ -723,6 +1279,16 @@ func (cr *SriovIBNetwork) NetworkNamespace() string {
 	return cr.Spec.NetworkNamespace
 }
 
+// NetworkResourceName returns the device plugin resource name requested by the network
+func (cr *SriovIBNetwork) NetworkResourceName() string {
+	return cr.Spec.ResourceName
+}
+
+// GetNetworkStatus returns the status of the network
+func (cr *SriovIBNetwork) GetNetworkStatus() *NetworkStatus {
+	return &cr.Status.NetworkStatus
+}
+
 // RenderNetAttDef renders a net-att-def for sriov CNI
 func (cr *SriovNetwork) RenderNetAttDef() (*uns.Unstructured, error) {
 	logger := log.WithName("RenderNetAttDef")
@@ -842,6 +1408,16 @@ func (cr *SriovNetwork) NetworkNamespace() string {
 	return cr.Spec.NetworkNamespace
 }
 
+// NetworkResourceName returns the device plugin resource name requested by the network
+func (cr *SriovNetwork) NetworkResourceName() string {
+	return cr.Spec.ResourceName
+}
+
+// GetNetworkStatus returns the status of the network
+func (cr *SriovNetwork) GetNetworkStatus() *NetworkStatus {
+	return &cr.Status.NetworkStatus
+}
+
 // RenderNetAttDef renders a net-att-def for sriov CNI
 func (cr *OVSNetwork) RenderNetAttDef() (*uns.Unstructured, error) {
 	logger := log.WithName("RenderNetAttDef")
@@ -905,6 +1481,16 @@ func (cr *OVSNetwork) NetworkNamespace() string {
 	return cr.Spec.NetworkNamespace
 }
 
+// NetworkResourceName returns the device plugin resource name requested by the network
+func (cr *OVSNetwork) NetworkResourceName() string {
+	return cr.Spec.ResourceName
+}
+
+// GetNetworkStatus returns the status of the network
+func (cr *OVSNetwork) GetNetworkStatus() *NetworkStatus {
+	return &cr.Status.NetworkStatus
+}
+
 // NetFilterMatch -- parse netFilter and check for a match
 func NetFilterMatch(netFilter string, netValue string) (isMatch bool) {
 	logger := log.WithName("NetFilterMatch")
@@ -964,12 +1550,202 @@ func (s *SriovNetworkPoolConfig) MaxUnavailable(numOfNodes int) (int, error) {
 	return maxunavail, nil
 }
 
+// CanaryNodes returns the canary nodes of the pool rollout strategy among the provided pool node names
+func (s *SriovNetworkPoolConfig) CanaryNodes(nodeNames []string) ([]string, error) {
+	if s.Spec.RolloutStrategy == nil {
+		return nil, nil
+	}
+
+	sorted := slices.Clone(nodeNames)
+	sort.Strings(sorted)
+	if len(s.Spec.RolloutStrategy.CanaryNodes) > 0 {
+		canaries := []string{}
+		for _, name := range sorted {
+			if slices.Contains(s.Spec.RolloutStrategy.CanaryNodes, name) {
+				canaries = append(canaries, name)
+			}
+		}
+		return canaries, nil
+	}
+
+	count := 1
+	if s.Spec.RolloutStrategy.CanaryCount != nil {
+		intOrPercent := *s.Spec.RolloutStrategy.CanaryCount
+		if intOrPercent.Type == intstrutil.String {
+			v, err := strconv.Atoi(strings.TrimSuffix(intOrPercent.StrVal, "%"))
+			if err != nil || !strings.HasSuffix(intOrPercent.StrVal, "%") {
+				return nil, fmt.Errorf("invalid canaryCount %q: strings needs to be a percentage", intOrPercent.StrVal)
+			}
+			if v > 100 || v < 1 {
+				return nil, fmt.Errorf("invalid canaryCount: percentage needs to be between 1 and 100")
+			}
+		} else if intOrPercent.IntValue() < 1 {
+			return nil, fmt.Errorf("invalid canaryCount: at least one canary node is required")
+		}
+		var err error
+		count, err = intstrutil.GetScaledValueFromIntOrPercent(&intOrPercent, len(sorted), true)
+		if err != nil {
+			return nil, fmt.Errorf("invalid canaryCount: %v", err)
+		}
+	}
+	if count > len(sorted) {
+		count = len(sorted)
+	}
+	return sorted[:count], nil
+}
+
+// InMaintenanceWindow returns true if the nodes of the pool can be drained at the provided time.
+// When the pool is outside of its maintenance windows the start time of the next window is also returned.
+func (s *SriovNetworkPoolConfig) InMaintenanceWindow(now time.Time) (bool, time.Time, error) {
+	if len(s.Spec.MaintenanceWindows) == 0 {
+		return true, time.Time{}, nil
+	}
+
+	next := time.Time{}
+	for i := range s.Spec.MaintenanceWindows {
+		w := &s.Spec.MaintenanceWindows[i]
+		open, err := w.Contains(now)
+		if err != nil {
+			return false, time.Time{}, err
+		}
+		if open {
+			return true, time.Time{}, nil
+		}
+		start, err := w.NextStart(now)
+		if err != nil {
+			return false, time.Time{}, err
+		}
+		if next.IsZero() || start.Before(next) {
+			next = start
+		}
+	}
+	return false, next, nil
+}
+
+// Contains returns true if the provided time is inside the maintenance window
+func (w *MaintenanceWindow) Contains(now time.Time) (bool, error) {
+	starts, duration, err := w.parse(now, -1, 0)
+	if err != nil {
+		return false, err
+	}
+	for _, start := range starts {
+		if !now.Before(start) && now.Before(start.Add(duration)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// NextStart returns the first start of the maintenance window after the provided time
+func (w *MaintenanceWindow) NextStart(now time.Time) (time.Time, error) {
+	starts, _, err := w.parse(now, 0, 7)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, start := range starts {
+		if start.After(now) {
+			return start, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("maintenance window doesn't start in the next week")
+}
+
+// Validate checks the days, times and time zone of the maintenance window
+func (w *MaintenanceWindow) Validate() error {
+	_, _, err := w.parse(time.Now(), 0, 0)
+	return err
+}
+
+// parse returns the start times of the window for the days between now+fromDay and now+toDay, sorted,
+// and the duration of the window
+func (w *MaintenanceWindow) parse(now time.Time, fromDay, toDay int) ([]time.Time, time.Duration, error) {
+	loc := time.UTC
+	if w.TimeZone != "" {
+		var err error
+		loc, err = time.LoadLocation(w.TimeZone)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid time zone %q: %v", w.TimeZone, err)
+		}
+	}
+	start, err := time.Parse("15:04", w.StartTime)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid start time %q: %v", w.StartTime, err)
+	}
+	end, err := time.Parse("15:04", w.EndTime)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid end time %q: %v", w.EndTime, err)
+	}
+	duration := end.Sub(start)
+	if duration <= 0 {
+		duration += 24 * time.Hour
+	}
+
+	days := map[time.Weekday]bool{}
+	for _, d := range w.Days {
+		weekday, ok := weekdays[d]
+		if !ok {
+			return nil, 0, fmt.Errorf("invalid day %q", d)
+		}
+		days[weekday] = true
+	}
+
+	local := now.In(loc)
+	starts := []time.Time{}
+	for i := fromDay; i <= toDay; i++ {
+		day := local.AddDate(0, 0, i)
+		if len(days) > 0 && !days[day.Weekday()] {
+			continue
+		}
+		starts = append(starts, time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, loc))
+	}
+	return starts, duration, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"Sun": time.Sunday,
+	"Mon": time.Monday,
+	"Tue": time.Tuesday,
+	"Wed": time.Wednesday,
+	"Thu": time.Thursday,
+	"Fri": time.Friday,
+	"Sat": time.Saturday,
+}
+
 // GenerateBridgeName generate predictable name for the software bridge
 // current format is: br-0000_00_03.0
 func GenerateBridgeName(iface *InterfaceExt) string {
 	return fmt.Sprintf("br-%s", strings.ReplaceAll(iface.PciAddress, ":", "_"))
 }
 
+// GenerateBondBridgeName returns the name of the OVS bridge of a bond of PFs
+func GenerateBondBridgeName(bondName string) string {
+	return fmt.Sprintf("br-%s", bondName)
+}
+
+// NeedToUpdateBonds returns true if the bonds of PFs of the host require update
+func NeedToUpdateBonds(bondsSpec Bonds, bondsStatus []BondStatus) bool {
+	if len(bondsSpec) != len(bondsStatus) {
+		return true
+	}
+	for _, desired := range bondsSpec {
+		idx := slices.IndexFunc(bondsStatus, func(b BondStatus) bool { return b.Name == desired.Name })
+		if idx == -1 {
+			return true
+		}
+		current := bondsStatus[idx]
+		if desired.Mode != current.Mode || desired.XmitHashPolicy != current.XmitHashPolicy {
+			return true
+		}
+		if desired.Mtu > 0 && desired.Mtu != current.Mtu {
+			return true
+		}
+		if !slices.EqualFunc(desired.Slaves, current.Slaves, func(x, y BondSlave) bool { return x.PciAddress == y.PciAddress }) {
+			return true
+		}
+	}
+	return false
+}
+
 // NeedToUpdateBridges returns true if bridge for the host requires update
 func NeedToUpdateBridges(bridgeSpec, bridgeStatus *Bridges) bool {
 	return !equality.Semantic.DeepEqual(bridgeSpec, bridgeStatus)
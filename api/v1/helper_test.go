@@ -24,11 +24,13 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
 	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
@@ -238,6 +240,18 @@ func TestIBRendering(t *testing.T) {
 				},
 			},
 		},
+		{
+			tname: "ibwithpkey",
+			network: v1.SriovIBNetwork{
+				TypeMeta:   metav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "SriovIBNetwork"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "test"},
+				Spec: v1.SriovIBNetworkSpec{
+					NetworkNamespace: "testnamespace",
+					ResourceName:     "testresource",
+					PKey:             "0x8001",
+				},
+			},
+		},
 	}
 	for _, tc := range testtable {
 		t.Run(tc.tname, func(t *testing.T) {
@@ -752,6 +766,128 @@ func TestSriovNetworkNodePolicyApply(t *testing.T) {
 				},
 			},
 		},
+		{
+			tname: "one policy present same pf merge pfSettings and devlinkParams",
+			currentState: func() *v1.SriovNetworkNodeState {
+				st := newNodeState()
+				st.Spec.Interfaces = []v1.Interface{
+					{
+						Name:       "ens803f1",
+						NumVfs:     4,
+						PciAddress: "0000:86:00.1",
+						VfGroups: []v1.VfGroup{
+							{
+								DeviceType:   consts.DeviceTypeVfioPci,
+								ResourceName: "p2res",
+								VfRange:      "2-3",
+								PolicyName:   "p2",
+							},
+						},
+						PfSettings: &v1.PfSettings{
+							RxRingSize: ptr.To(1024),
+							TxRingSize: ptr.To(1024),
+							Features:   map[string]bool{"rx-gro-hw": true},
+						},
+						DevlinkParams: map[string]v1.DevlinkParam{
+							"flow_steering_mode": {Value: "smfs"},
+							"esw_multiport":      {Value: "false"},
+						},
+						DdpPackage: "ice_comms-1.3.45.0.pkg",
+					},
+				}
+				return st
+			}(),
+			policy: func() *v1.SriovNetworkNodePolicy {
+				p := newNodePolicy()
+				p.Spec.PfSettings = &v1.PfSettings{
+					RxRingSize: ptr.To(4096),
+					Features:   map[string]bool{"rx-vlan-hw-parse": false},
+				}
+				p.Spec.DevlinkParams = map[string]v1.DevlinkParam{"esw_multiport": {Value: "true"}}
+				return p
+			}(),
+			equalP: false,
+			expectedInterfaces: []v1.Interface{
+				{
+					Name:       "ens803f1",
+					NumVfs:     4,
+					PciAddress: "0000:86:00.1",
+					VfGroups: []v1.VfGroup{
+						{
+							DeviceType:   consts.DeviceTypeNetDevice,
+							ResourceName: "p1res",
+							VfRange:      "0-1",
+							PolicyName:   "p1",
+						},
+						{
+							DeviceType:   consts.DeviceTypeVfioPci,
+							ResourceName: "p2res",
+							VfRange:      "2-3",
+							PolicyName:   "p2",
+						},
+					},
+					PfSettings: &v1.PfSettings{
+						RxRingSize: ptr.To(4096),
+						TxRingSize: ptr.To(1024),
+						Features:   map[string]bool{"rx-gro-hw": true, "rx-vlan-hw-parse": false},
+					},
+					DevlinkParams: map[string]v1.DevlinkParam{
+						"flow_steering_mode": {Value: "smfs"},
+						"esw_multiport":      {Value: "true"},
+					},
+					DdpPackage: "ice_comms-1.3.45.0.pkg",
+				},
+			},
+		},
+		{
+			tname: "one policy present same pf add SFs",
+			currentState: func() *v1.SriovNetworkNodeState {
+				st := newNodeState()
+				st.Spec.Interfaces = []v1.Interface{
+					{
+						Name:        "ens803f1",
+						NumVfs:      4,
+						PciAddress:  "0000:86:00.1",
+						EswitchMode: "switchdev",
+						VfGroups: []v1.VfGroup{
+							{
+								DeviceType:   consts.DeviceTypeNetDevice,
+								ResourceName: "p2res",
+								VfRange:      "0-3",
+								PolicyName:   "p2",
+							},
+						},
+					},
+				}
+				return st
+			}(),
+			policy: func() *v1.SriovNetworkNodePolicy {
+				p := newNodePolicy()
+				p.Spec.DeviceType = consts.DeviceTypeSf
+				p.Spec.EswitchMode = "switchdev"
+				p.Spec.NumVfs = 0
+				p.Spec.NumSfs = 8
+				return p
+			}(),
+			equalP: false,
+			expectedInterfaces: []v1.Interface{
+				{
+					Name:        "ens803f1",
+					NumVfs:      4,
+					NumSfs:      8,
+					PciAddress:  "0000:86:00.1",
+					EswitchMode: "switchdev",
+					VfGroups: []v1.VfGroup{
+						{
+							DeviceType:   consts.DeviceTypeNetDevice,
+							ResourceName: "p2res",
+							VfRange:      "0-3",
+							PolicyName:   "p2",
+						},
+					},
+				},
+			},
+		},
 		{
 			tname:        "no selectors",
 			currentState: newNodeState(),
@@ -1069,6 +1205,180 @@ func TestSriovNetworkPoolConfig_MaxUnavailable(t *testing.T) {
 	}
 }
 
+func TestSriovNetworkPoolConfig_InMaintenanceWindow(t *testing.T) {
+	// 2024-01-06 is a Saturday
+	saturdayNight := time.Date(2024, 1, 6, 23, 30, 0, 0, time.UTC)
+	sundayMorning := time.Date(2024, 1, 7, 1, 30, 0, 0, time.UTC)
+	mondayNoon := time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC)
+
+	testtable := []struct {
+		tname        string
+		windows      []v1.MaintenanceWindow
+		now          time.Time
+		expectedOpen bool
+		expectedNext time.Time
+		expectedErr  bool
+	}{
+		{
+			tname:        "no windows",
+			now:          mondayNoon,
+			expectedOpen: true,
+		},
+		{
+			tname:        "inside daily window",
+			windows:      []v1.MaintenanceWindow{{StartTime: "11:00", EndTime: "13:00"}},
+			now:          mondayNoon,
+			expectedOpen: true,
+		},
+		{
+			tname:        "outside daily window",
+			windows:      []v1.MaintenanceWindow{{StartTime: "22:00", EndTime: "02:00"}},
+			now:          mondayNoon,
+			expectedNext: time.Date(2024, 1, 8, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			tname:        "window crossing midnight started the day before",
+			windows:      []v1.MaintenanceWindow{{Days: []string{"Sat"}, StartTime: "22:00", EndTime: "02:00"}},
+			now:          sundayMorning,
+			expectedOpen: true,
+		},
+		{
+			tname:        "window crossing midnight",
+			windows:      []v1.MaintenanceWindow{{Days: []string{"Sat"}, StartTime: "22:00", EndTime: "02:00"}},
+			now:          saturdayNight,
+			expectedOpen: true,
+		},
+		{
+			tname:        "next weekly window",
+			windows:      []v1.MaintenanceWindow{{Days: []string{"Sat"}, StartTime: "22:00", EndTime: "02:00"}},
+			now:          mondayNoon,
+			expectedNext: time.Date(2024, 1, 13, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			tname: "earliest of multiple windows",
+			windows: []v1.MaintenanceWindow{
+				{Days: []string{"Sat"}, StartTime: "22:00", EndTime: "02:00"},
+				{Days: []string{"Tue", "Thu"}, StartTime: "03:00", EndTime: "05:00"},
+			},
+			now:          mondayNoon,
+			expectedNext: time.Date(2024, 1, 9, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			tname:        "time zone",
+			windows:      []v1.MaintenanceWindow{{StartTime: "12:30", EndTime: "14:00", TimeZone: "Europe/Paris"}},
+			now:          mondayNoon,
+			expectedOpen: true,
+		},
+		{
+			tname:       "invalid time zone",
+			windows:     []v1.MaintenanceWindow{{StartTime: "12:30", EndTime: "14:00", TimeZone: "Nowhere/City"}},
+			now:         mondayNoon,
+			expectedErr: true,
+		},
+		{
+			tname:       "invalid day",
+			windows:     []v1.MaintenanceWindow{{Days: []string{"Monday"}, StartTime: "12:30", EndTime: "14:00"}},
+			now:         mondayNoon,
+			expectedErr: true,
+		},
+		{
+			tname:       "invalid start time",
+			windows:     []v1.MaintenanceWindow{{StartTime: "25:00", EndTime: "14:00"}},
+			now:         mondayNoon,
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testtable {
+		t.Run(tc.tname, func(t *testing.T) {
+			pool := v1.SriovNetworkPoolConfig{
+				Spec: v1.SriovNetworkPoolConfigSpec{
+					MaintenanceWindows: tc.windows,
+				},
+			}
+
+			open, next, err := pool.InMaintenanceWindow(tc.now)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOpen, open)
+			assert.True(t, tc.expectedNext.Equal(next), "unexpected next window %s", next)
+		})
+	}
+}
+
+func TestSriovNetworkPoolConfig_CanaryNodes(t *testing.T) {
+	nodes := []string{"worker-3", "worker-1", "worker-2", "worker-4"}
+	testtable := []struct {
+		tname       string
+		strategy    *v1.RolloutStrategy
+		expected    []string
+		expectedErr bool
+	}{
+		{
+			tname: "no rollout strategy",
+		},
+		{
+			tname:    "default canary count",
+			strategy: &v1.RolloutStrategy{},
+			expected: []string{"worker-1"},
+		},
+		{
+			tname:    "named canary nodes",
+			strategy: &v1.RolloutStrategy{CanaryNodes: []string{"worker-4", "worker-2", "other-node"}},
+			expected: []string{"worker-2", "worker-4"},
+		},
+		{
+			tname:    "canary count",
+			strategy: &v1.RolloutStrategy{CanaryCount: ptr.To(intstrutil.FromInt32(2))},
+			expected: []string{"worker-1", "worker-2"},
+		},
+		{
+			tname:    "canary count larger than the pool",
+			strategy: &v1.RolloutStrategy{CanaryCount: ptr.To(intstrutil.FromInt32(10))},
+			expected: []string{"worker-1", "worker-2", "worker-3", "worker-4"},
+		},
+		{
+			tname:    "canary percentage rounded up",
+			strategy: &v1.RolloutStrategy{CanaryCount: ptr.To(intstrutil.FromString("30%"))},
+			expected: []string{"worker-1", "worker-2"},
+		},
+		{
+			tname:       "zero canary count",
+			strategy:    &v1.RolloutStrategy{CanaryCount: ptr.To(intstrutil.FromInt32(0))},
+			expectedErr: true,
+		},
+		{
+			tname:       "invalid canary percentage",
+			strategy:    &v1.RolloutStrategy{CanaryCount: ptr.To(intstrutil.FromString("120%"))},
+			expectedErr: true,
+		},
+		{
+			tname:       "canary count not a percentage",
+			strategy:    &v1.RolloutStrategy{CanaryCount: ptr.To(intstrutil.FromString("two"))},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testtable {
+		t.Run(tc.tname, func(t *testing.T) {
+			pool := v1.SriovNetworkPoolConfig{
+				Spec: v1.SriovNetworkPoolConfigSpec{
+					RolloutStrategy: tc.strategy,
+				},
+			}
+
+			canaries, err := pool.CanaryNodes(nodes)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, canaries)
+		})
+	}
+}
+
 func TestNeedToUpdateSriov(t *testing.T) {
 	type args struct {
 		ifaceSpec   *v1.Interface
@@ -1127,6 +1437,282 @@ func TestNeedToUpdateSriov(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "VF admin attributes drifted",
+			args: args{
+				ifaceSpec: &v1.Interface{
+					NumVfs: 2,
+					VfGroups: []v1.VfGroup{
+						{
+							VfRange:      "0-1",
+							DeviceType:   consts.DeviceTypeVfioPci,
+							VfAttributes: &v1.VfAttributes{Mac: "02:00:00:00:01:00", Vlan: ptr.To(100), Trust: "on"},
+						},
+					},
+				},
+				ifaceStatus: &v1.InterfaceExt{
+					NumVfs:   2,
+					LinkType: consts.LinkTypeETH,
+					VFs: []v1.VirtualFunction{
+						{VfID: 0, Driver: "vfio-pci", AdminMac: "02:00:00:00:01:00", Vlan: 100, Trust: "on"},
+						{VfID: 1, Driver: "vfio-pci", AdminMac: "02:00:00:00:01:01", Vlan: 200, Trust: "off"},
+					},
+				},
+			},
+			// applied without reconfiguring the device
+			want: false,
+		},
+		{
+			name: "no-IOMMU VFs bound to vfio-pci",
+			args: args{
+				ifaceSpec: &v1.Interface{
+					NumVfs:   2,
+					VfGroups: []v1.VfGroup{{VfRange: "0-1", DeviceType: consts.DeviceTypeVfioPciNoIommu}},
+				},
+				ifaceStatus: &v1.InterfaceExt{
+					NumVfs:   2,
+					LinkType: consts.LinkTypeETH,
+					VFs:      []v1.VirtualFunction{{VfID: 0, Driver: "vfio-pci"}, {VfID: 1, Driver: "vfio-pci"}},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "netdevice VF bound to uio_pci_generic",
+			args: args{
+				ifaceSpec: &v1.Interface{
+					NumVfs:   1,
+					VfGroups: []v1.VfGroup{{VfRange: "0-0", DeviceType: consts.DeviceTypeNetDevice}},
+				},
+				ifaceStatus: &v1.InterfaceExt{
+					NumVfs:   1,
+					LinkType: consts.LinkTypeETH,
+					VFs:      []v1.VirtualFunction{{VfID: 0, Driver: "uio_pci_generic"}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "VF GUID out of the allocation of the PF",
+			args: args{
+				ifaceSpec: &v1.Interface{
+					NumVfs:           2,
+					VfGroups:         []v1.VfGroup{{VfRange: "0-1", DeviceType: consts.DeviceTypeNetDevice, IsRdma: true}},
+					IbGUIDAllocation: &v1.IbGUIDAllocation{Pool: "pool", Start: "02:00:00:00:00:00:00:f0", End: "02:00:00:00:00:00:00:f7"},
+				},
+				ifaceStatus: &v1.InterfaceExt{
+					NumVfs:   2,
+					LinkType: consts.LinkTypeIB,
+					VFs: []v1.VirtualFunction{
+						{VfID: 0, Driver: "mlx5_core", GUID: "02:00:00:00:00:00:00:f0"},
+						{VfID: 1, Driver: "mlx5_core", GUID: "00:01:02:03:04:05:06:08"},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "VF GUID from the allocation of the PF",
+			args: args{
+				ifaceSpec: &v1.Interface{
+					NumVfs:           2,
+					VfGroups:         []v1.VfGroup{{VfRange: "0-1", DeviceType: consts.DeviceTypeNetDevice, IsRdma: true}},
+					IbGUIDAllocation: &v1.IbGUIDAllocation{Pool: "pool", Start: "02:00:00:00:00:00:00:f0", End: "02:00:00:00:00:00:00:f7"},
+				},
+				ifaceStatus: &v1.InterfaceExt{
+					NumVfs:   2,
+					LinkType: consts.LinkTypeIB,
+					VFs: []v1.VirtualFunction{
+						{VfID: 0, Driver: "mlx5_core", GUID: "02:00:00:00:00:00:00:F0"},
+						{VfID: 1, Driver: "mlx5_core"},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "VF PKeys drifted",
+			args: args{
+				ifaceSpec: &v1.Interface{
+					NumVfs: 2,
+					VfGroups: []v1.VfGroup{
+						{VfRange: "0-1", DeviceType: consts.DeviceTypeNetDevice, IsRdma: true, PKeys: []string{"0xffff", "0x8001"}},
+					},
+				},
+				ifaceStatus: &v1.InterfaceExt{
+					NumVfs:   2,
+					LinkType: consts.LinkTypeIB,
+					Driver:   consts.Mlx4DriverName,
+					VFs: []v1.VirtualFunction{
+						{VfID: 0, Driver: consts.Mlx4DriverName, GUID: "00:01:02:03:04:05:06:07", PKeys: []string{"0xffff", "0x8001"}},
+						{VfID: 1, Driver: consts.Mlx4DriverName, GUID: "00:01:02:03:04:05:06:08", PKeys: []string{"0xffff"}},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "VF PKey removed from the group",
+			args: args{
+				ifaceSpec: &v1.Interface{
+					NumVfs: 1,
+					VfGroups: []v1.VfGroup{
+						{VfRange: "0-0", DeviceType: consts.DeviceTypeNetDevice, IsRdma: true, PKeys: []string{"0xffff"}},
+					},
+				},
+				ifaceStatus: &v1.InterfaceExt{
+					NumVfs:   1,
+					LinkType: consts.LinkTypeIB,
+					Driver:   consts.Mlx4DriverName,
+					VFs: []v1.VirtualFunction{
+						{VfID: 0, Driver: consts.Mlx4DriverName, GUID: "00:01:02:03:04:05:06:07", PKeys: []string{"0xffff", "0x8001"}},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "VF PKeys in a different order",
+			args: args{
+				ifaceSpec: &v1.Interface{
+					NumVfs: 1,
+					VfGroups: []v1.VfGroup{
+						{VfRange: "0-0", DeviceType: consts.DeviceTypeNetDevice, IsRdma: true, PKeys: []string{"0x8001", "0xffff"}},
+					},
+				},
+				ifaceStatus: &v1.InterfaceExt{
+					NumVfs:   1,
+					LinkType: consts.LinkTypeIB,
+					Driver:   consts.Mlx4DriverName,
+					VFs: []v1.VirtualFunction{
+						{VfID: 0, Driver: consts.Mlx4DriverName, GUID: "00:01:02:03:04:05:06:07", PKeys: []string{"0xffff", "0x8001"}},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "VF PKeys applied or not reported",
+			args: args{
+				ifaceSpec: &v1.Interface{
+					NumVfs: 2,
+					VfGroups: []v1.VfGroup{
+						{VfRange: "0-1", DeviceType: consts.DeviceTypeNetDevice, IsRdma: true, PKeys: []string{"0xFFFF", "0x8001"}},
+					},
+				},
+				ifaceStatus: &v1.InterfaceExt{
+					NumVfs:   2,
+					LinkType: consts.LinkTypeIB,
+					Driver:   consts.Mlx4DriverName,
+					VFs: []v1.VirtualFunction{
+						{VfID: 0, Driver: consts.Mlx4DriverName, GUID: "00:01:02:03:04:05:06:07", PKeys: []string{"0xffff", "0x8001"}},
+						{VfID: 1, Driver: consts.Mlx4DriverName},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "VF PKeys of a PF without VF PKey table",
+			args: args{
+				ifaceSpec: &v1.Interface{
+					NumVfs: 1,
+					VfGroups: []v1.VfGroup{
+						{VfRange: "0-0", DeviceType: consts.DeviceTypeNetDevice, IsRdma: true, PKeys: []string{"0x8001"}},
+					},
+				},
+				ifaceStatus: &v1.InterfaceExt{
+					NumVfs:   1,
+					LinkType: consts.LinkTypeIB,
+					Driver:   "mlx5_core",
+					VFs: []v1.VirtualFunction{
+						{VfID: 0, Driver: "mlx5_core", GUID: "00:01:02:03:04:05:06:07", PKeys: []string{"0xffff"}},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "VDPA device attributes drifted",
+			args: args{
+				ifaceSpec: &v1.Interface{
+					NumVfs: 2,
+					VfGroups: []v1.VfGroup{
+						{
+							VfRange:        "0-1",
+							DeviceType:     consts.DeviceTypeNetDevice,
+							VdpaType:       consts.VdpaTypeVhost,
+							VdpaAttributes: &v1.VdpaAttributes{Mac: "02:00:00:00:01:00", Mtu: ptr.To(9000)},
+						},
+					},
+				},
+				ifaceStatus: &v1.InterfaceExt{
+					NumVfs:   2,
+					LinkType: consts.LinkTypeETH,
+					VFs: []v1.VirtualFunction{
+						{VfID: 0, Driver: "mlx5_core", VdpaType: consts.VdpaTypeVhost, VdpaMac: "02:00:00:00:01:00", VdpaMtu: 9000},
+						{VfID: 1, Driver: "mlx5_core", VdpaType: consts.VdpaTypeVhost, VdpaMac: "02:00:00:00:01:01", VdpaMtu: 1500},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "VDPA device attributes applied",
+			args: args{
+				ifaceSpec: &v1.Interface{
+					NumVfs: 2,
+					VfGroups: []v1.VfGroup{
+						{
+							VfRange:    "0-1",
+							DeviceType: consts.DeviceTypeNetDevice,
+							VdpaType:   consts.VdpaTypeVhost,
+							VdpaAttributes: &v1.VdpaAttributes{Mac: "02:00:00:00:01:ff", MaxVqp: ptr.To(8),
+								MgmtDevice: "pci/0000:d8:00.2"},
+						},
+					},
+				},
+				ifaceStatus: &v1.InterfaceExt{
+					NumVfs:   2,
+					LinkType: consts.LinkTypeETH,
+					VFs: []v1.VirtualFunction{
+						{VfID: 0, Driver: "mlx5_core", VdpaType: consts.VdpaTypeVhost, VdpaMac: "02:00:00:00:01:FF",
+							VdpaMtu: 1500, VdpaMaxVqp: 8, VdpaMgmtDevice: "pci/0000:d8:00.2"},
+						{VfID: 1, Driver: "mlx5_core", VdpaType: consts.VdpaTypeVhost, VdpaMac: "02:00:00:00:02:00",
+							VdpaMtu: 1500, VdpaMaxVqp: 8, VdpaMgmtDevice: "pci/0000:d8:00.2"},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "number of SFs changed",
+			args: args{
+				ifaceSpec:   &v1.Interface{NumSfs: 8},
+				ifaceStatus: &v1.InterfaceExt{NumSfs: 4},
+			},
+			want: true,
+		},
+		{
+			name: "SF not active",
+			args: args{
+				ifaceSpec: &v1.Interface{NumSfs: 2},
+				ifaceStatus: &v1.InterfaceExt{NumSfs: 2, SFs: []v1.ScalableFunction{
+					{SfNumber: 0, State: consts.SfStateActive}, {SfNumber: 1, State: consts.SfStateInactive}}},
+			},
+			want: true,
+		},
+		{
+			name: "PF ethtool settings changed",
+			args: args{
+				ifaceSpec: &v1.Interface{NumVfs: 1, PfSettings: &v1.PfSettings{
+					RxRingSize: ptr.To(4096), Features: map[string]bool{"rx-gro-hw": false}}},
+				ifaceStatus: &v1.InterfaceExt{NumVfs: 1, PfSettings: &v1.PfSettings{
+					RxRingSize: ptr.To(4096), TxRingSize: ptr.To(1024), Features: map[string]bool{"rx-gro-hw": true}}},
+			},
+			// applied without reconfiguring the device
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1137,6 +1723,187 @@ func TestNeedToUpdateSriov(t *testing.T) {
 	}
 }
 
+func TestNeedToUpdatePfSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired *v1.PfSettings
+		current *v1.PfSettings
+		want    bool
+	}{
+		{
+			name:    "not managed",
+			current: &v1.PfSettings{RxRingSize: ptr.To(1024)},
+			want:    false,
+		},
+		{
+			name:    "settings changed",
+			desired: &v1.PfSettings{RxRingSize: ptr.To(4096), Features: map[string]bool{"rx-gro-hw": false}},
+			current: &v1.PfSettings{RxRingSize: ptr.To(4096), TxRingSize: ptr.To(1024), Features: map[string]bool{"rx-gro-hw": true}},
+			want:    true,
+		},
+		{
+			name:    "settings not reported",
+			desired: &v1.PfSettings{FecMode: "rs"},
+			want:    true,
+		},
+		{
+			name:    "settings applied",
+			desired: &v1.PfSettings{RxRingSize: ptr.To(4096), Pause: &v1.PauseSettings{Rx: ptr.To(false)}, FecMode: "rs"},
+			current: &v1.PfSettings{RxRingSize: ptr.To(4096), TxRingSize: ptr.To(1024),
+				Pause:   &v1.PauseSettings{Autoneg: ptr.To(true), Rx: ptr.To(false), Tx: ptr.To(true)},
+				FecMode: "rs"},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v1.NeedToUpdatePfSettings(tt.desired, tt.current); got != tt.want {
+				t.Errorf("NeedToUpdatePfSettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetVfsToUpdateAttributes(t *testing.T) {
+	spec := &v1.Interface{
+		NumVfs: 3,
+		VfGroups: []v1.VfGroup{
+			{
+				VfRange:      "0-1",
+				DeviceType:   consts.DeviceTypeVfioPci,
+				VfAttributes: &v1.VfAttributes{Mac: "02:00:00:00:01:ff", Vlan: ptr.To(100), Trust: "on", MaxTxRate: ptr.To(1000)},
+			},
+			{VfRange: "2-2", DeviceType: consts.DeviceTypeNetDevice},
+		},
+	}
+	tests := []struct {
+		name      string
+		linkType  string
+		vfs       []v1.VirtualFunction
+		allocated map[string]bool
+		want      []int
+	}{
+		{
+			name:     "attributes applied",
+			linkType: consts.LinkTypeETH,
+			vfs: []v1.VirtualFunction{
+				{VfID: 0, PciAddress: "0000:d8:00.2", AdminMac: "02:00:00:00:01:ff", Vlan: 100, Trust: "on", MaxTxRate: 1000},
+				{VfID: 1, PciAddress: "0000:d8:00.3", AdminMac: "02:00:00:00:02:00", Vlan: 100, Trust: "on", MaxTxRate: 1000},
+				{VfID: 2, PciAddress: "0000:d8:00.4", Vlan: 300, Trust: "off"},
+			},
+		},
+		{
+			name:     "attributes drifted",
+			linkType: consts.LinkTypeETH,
+			vfs: []v1.VirtualFunction{
+				{VfID: 0, PciAddress: "0000:d8:00.2", AdminMac: "02:00:00:00:01:ff", Vlan: 200, Trust: "on", MaxTxRate: 1000},
+				{VfID: 1, PciAddress: "0000:d8:00.3", AdminMac: "02:00:00:00:01:01", Vlan: 100, Trust: "on", MaxTxRate: 1000},
+			},
+			want: []int{0, 1},
+		},
+		{
+			name:     "attributes of a VF allocated to a pod",
+			linkType: consts.LinkTypeETH,
+			vfs: []v1.VirtualFunction{
+				{VfID: 0, PciAddress: "0000:d8:00.2", AdminMac: "02:00:00:00:01:ff", Vlan: 200, Trust: "on", MaxTxRate: 1000},
+				{VfID: 1, PciAddress: "0000:d8:00.3", AdminMac: "02:00:00:00:02:00", Vlan: 100, Trust: "off", MaxTxRate: 1000},
+			},
+			allocated: map[string]bool{"0000:d8:00.2": true},
+			want:      []int{1},
+		},
+		{
+			name:     "InfiniBand PF",
+			linkType: consts.LinkTypeIB,
+			vfs:      []v1.VirtualFunction{{VfID: 0, PciAddress: "0000:d8:00.2"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for _, vf := range v1.GetVfsToUpdateAttributes(spec, &v1.InterfaceExt{NumVfs: 3, LinkType: tt.linkType, VFs: tt.vfs}, tt.allocated) {
+				got = append(got, vf.VfID)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetVfsToUpdateAttributes() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetDevlinkParamsAction(t *testing.T) {
+	current := []v1.DevlinkParamStatus{
+		{Name: "flow_steering_mode", Cmode: consts.DevlinkParamCmodeRuntime, Value: "dmfs"},
+		{Name: "enable_roce", Cmode: consts.DevlinkParamCmodeDriverinit, Value: "true"},
+		{Name: "max_macs", Cmode: consts.DevlinkParamCmodeDriverinit, Value: "8"},
+		{Name: "max_macs", Cmode: consts.DevlinkParamCmodePermanent, Value: "8"},
+	}
+	tests := []struct {
+		name    string
+		desired map[string]v1.DevlinkParam
+		want    string
+	}{
+		{
+			name:    "no parameters",
+			desired: nil,
+			want:    v1.DevlinkParamsActionNone,
+		},
+		{
+			name:    "parameters applied",
+			desired: map[string]v1.DevlinkParam{"flow_steering_mode": {Value: "dmfs"}, "enable_roce": {Value: "True"}},
+			want:    v1.DevlinkParamsActionNone,
+		},
+		{
+			name:    "runtime parameter changed",
+			desired: map[string]v1.DevlinkParam{"flow_steering_mode": {Value: "smfs"}, "enable_roce": {Value: "true"}},
+			want:    v1.DevlinkParamsActionApply,
+		},
+		{
+			name:    "driverinit parameter changed",
+			desired: map[string]v1.DevlinkParam{"flow_steering_mode": {Value: "smfs"}, "max_macs": {Value: "16"}},
+			want:    v1.DevlinkParamsActionReload,
+		},
+		{
+			name: "permanent parameter changed",
+			desired: map[string]v1.DevlinkParam{"enable_roce": {Value: "false"},
+				"max_macs": {Value: "16", Cmode: consts.DevlinkParamCmodePermanent}},
+			want: v1.DevlinkParamsActionReboot,
+		},
+		{
+			name:    "unsupported parameter",
+			desired: map[string]v1.DevlinkParam{"foo": {Value: "bar"}},
+			want:    v1.DevlinkParamsActionNone,
+		},
+		{
+			name: "unsupported cmode",
+			desired: map[string]v1.DevlinkParam{"flow_steering_mode": {Value: "smfs", Cmode: consts.DevlinkParamCmodePermanent},
+				"enable_roce": {Value: "false"}},
+			want: v1.DevlinkParamsActionReload,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v1.GetDevlinkParamsAction(tt.desired, current); got != tt.want {
+				t.Errorf("GetDevlinkParamsAction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetUnsupportedDevlinkParams(t *testing.T) {
+	current := []v1.DevlinkParamStatus{
+		{Name: "flow_steering_mode", Cmode: consts.DevlinkParamCmodeRuntime, Value: "dmfs"},
+		{Name: "max_macs", Cmode: consts.DevlinkParamCmodeDriverinit, Value: "8"},
+	}
+	desired := map[string]v1.DevlinkParam{
+		"flow_steering_mode": {Value: "smfs"},
+		"max_macs":           {Value: "16", Cmode: consts.DevlinkParamCmodePermanent},
+		"foo":                {Value: "bar"},
+	}
+	assert.Equal(t, []string{"foo", "max_macs"}, v1.GetUnsupportedDevlinkParams(desired, current))
+	assert.Equal(t, []string{"flow_steering_mode", "foo", "max_macs"}, v1.GetUnsupportedDevlinkParams(desired, nil))
+	assert.Empty(t, v1.GetUnsupportedDevlinkParams(nil, current))
+}
+
 func TestSriovNetworkNodePolicyApplyBridgeConfig(t *testing.T) {
 	testtable := []struct {
 		tname           string
@@ -1495,6 +2262,42 @@ func TestSriovNetworkNodePolicyApplyBridgeConfig(t *testing.T) {
 				},
 			}},
 		},
+		{
+			tname:        "bond uplink",
+			currentState: newNodeState(),
+			policy: &v1.SriovNetworkNodePolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "p1",
+				},
+				Spec: v1.SriovNetworkNodePolicySpec{
+					DeviceType: consts.DeviceTypeNetDevice,
+					NicSelector: v1.SriovNetworkNicSelector{
+						RootDevices: []string{"0000:86:00.1", "0000:86:00.0"},
+					},
+					NodeSelector: map[string]string{
+						"feature.node.kubernetes.io/network-sriov.capable": "true",
+					},
+					NumVfs:       2,
+					Priority:     99,
+					Mtu:          9000,
+					EswitchMode:  "switchdev",
+					ResourceName: "p1res",
+					Bond:         &v1.BondConfig{Name: "bond0", Mode: "active-backup"},
+					Bridge: v1.Bridge{OVS: &v1.OVSConfig{
+						Bridge: v1.OVSBridgeConfig{DatapathType: "test"},
+					}},
+				},
+			},
+			expectedBridges: v1.Bridges{OVS: []v1.OVSConfigExt{{
+				Name:   "br-bond0",
+				Bridge: v1.OVSBridgeConfig{DatapathType: "test"},
+				Uplinks: []v1.OVSUplinkConfigExt{{
+					Name:       "bond0",
+					PciAddress: "0000:86:00.0",
+					Interface:  v1.OVSInterfaceConfig{MTURequest: ptr.To(9000)},
+				}},
+			}}},
+		},
 	}
 	for _, tc := range testtable {
 		t.Run(tc.tname, func(t *testing.T) {
@@ -1511,6 +2314,147 @@ func TestSriovNetworkNodePolicyApplyBridgeConfig(t *testing.T) {
 	}
 }
 
+func TestSriovNetworkNodePolicyApplyBondConfig(t *testing.T) {
+	bondPolicy := func(mode, xmitHashPolicy string, rootDevices ...string) *v1.SriovNetworkNodePolicy {
+		return &v1.SriovNetworkNodePolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "p1",
+			},
+			Spec: v1.SriovNetworkNodePolicySpec{
+				DeviceType: consts.DeviceTypeNetDevice,
+				NicSelector: v1.SriovNetworkNicSelector{
+					RootDevices: rootDevices,
+				},
+				NumVfs:       2,
+				Mtu:          9000,
+				EswitchMode:  "switchdev",
+				ResourceName: "p1res",
+				Bond:         &v1.BondConfig{Name: "bond0", Mode: mode, XmitHashPolicy: xmitHashPolicy},
+			},
+		}
+	}
+	slaves := []v1.BondSlave{
+		{PciAddress: "0000:86:00.0", Name: "ens803f0"},
+		{PciAddress: "0000:86:00.1", Name: "ens803f1"},
+	}
+	testtable := []struct {
+		tname         string
+		currentState  *v1.SriovNetworkNodeState
+		policy        *v1.SriovNetworkNodePolicy
+		expectedBonds v1.Bonds
+		expectedErr   bool
+	}{
+		{
+			tname:         "active-backup bond",
+			currentState:  newNodeState(),
+			policy:        bondPolicy("active-backup", "layer3+4", "0000:86:00.1", "0000:86:00.0"),
+			expectedBonds: v1.Bonds{{Name: "bond0", Mode: "active-backup", Mtu: 9000, Slaves: slaves}},
+		},
+		{
+			tname:         "802.3ad bond with default hash policy",
+			currentState:  newNodeState(),
+			policy:        bondPolicy("802.3ad", "", "0000:86:00.0", "0000:86:00.1"),
+			expectedBonds: v1.Bonds{{Name: "bond0", Mode: "802.3ad", XmitHashPolicy: "layer2", Mtu: 9000, Slaves: slaves}},
+		},
+		{
+			tname:        "policy without bond removes the bond of its PF",
+			currentState: newNodeState(),
+			policy: func() *v1.SriovNetworkNodePolicy {
+				p := bondPolicy("", "", "0000:86:00.1")
+				p.Spec.Bond = nil
+				return p
+			}(),
+			expectedBonds: nil,
+		},
+		{
+			tname:        "not switchdev config",
+			currentState: newNodeState(),
+			policy: func() *v1.SriovNetworkNodePolicy {
+				p := bondPolicy("", "", "0000:86:00.0", "0000:86:00.1")
+				p.Spec.EswitchMode = "legacy"
+				return p
+			}(),
+			expectedErr: true,
+		},
+		{
+			tname:        "three PFs selected",
+			currentState: newNodeState(),
+			policy:       bondPolicy("", "", "0000:86:00.0", "0000:86:00.1", "0000:86:00.2"),
+			expectedErr:  true,
+		},
+	}
+	for _, tc := range testtable {
+		t.Run(tc.tname, func(t *testing.T) {
+			if tc.policy.Spec.Bond == nil {
+				tc.currentState.Spec.Bonds = v1.Bonds{{Name: "bond0", Mode: "active-backup", Slaves: slaves}}
+			}
+			err := tc.policy.ApplyBondConfig(tc.currentState)
+			if tc.expectedErr && err == nil {
+				t.Errorf("ApplyBondConfig expecting error.")
+			} else if !tc.expectedErr && err != nil {
+				t.Errorf("ApplyBondConfig error:\n%s", err)
+			}
+			if diff := cmp.Diff(tc.expectedBonds, tc.currentState.Spec.Bonds); diff != "" {
+				t.Errorf("SriovNetworkNodeState spec diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNeedToUpdateBonds(t *testing.T) {
+	bond := v1.BondConfigExt{
+		Name:   "bond0",
+		Mode:   "active-backup",
+		Mtu:    9000,
+		Slaves: []v1.BondSlave{{PciAddress: "0000:86:00.0"}, {PciAddress: "0000:86:00.1"}},
+	}
+	testtable := []struct {
+		tname          string
+		specBonds      v1.Bonds
+		statusBonds    []v1.BondStatus
+		expectedResult bool
+	}{
+		{
+			tname:          "no update required",
+			specBonds:      v1.Bonds{bond},
+			statusBonds:    []v1.BondStatus{{BondConfigExt: bond, LinkState: "down"}},
+			expectedResult: false,
+		},
+		{
+			tname:          "bond missing",
+			specBonds:      v1.Bonds{bond},
+			expectedResult: true,
+		},
+		{
+			tname:          "bond not in the spec",
+			statusBonds:    []v1.BondStatus{{BondConfigExt: bond}},
+			expectedResult: true,
+		},
+		{
+			tname:     "slave missing",
+			specBonds: v1.Bonds{bond},
+			statusBonds: []v1.BondStatus{{BondConfigExt: v1.BondConfigExt{
+				Name: "bond0", Mode: "active-backup", Mtu: 9000, Slaves: bond.Slaves[:1]}}},
+			expectedResult: true,
+		},
+		{
+			tname:     "mode changed",
+			specBonds: v1.Bonds{bond},
+			statusBonds: []v1.BondStatus{{BondConfigExt: v1.BondConfigExt{
+				Name: "bond0", Mode: "802.3ad", XmitHashPolicy: "layer2", Mtu: 9000, Slaves: bond.Slaves}}},
+			expectedResult: true,
+		},
+	}
+	for _, tc := range testtable {
+		t.Run(tc.tname, func(t *testing.T) {
+			result := v1.NeedToUpdateBonds(tc.specBonds, tc.statusBonds)
+			if result != tc.expectedResult {
+				t.Errorf("unexpected result want: %t got: %t", tc.expectedResult, result)
+			}
+		})
+	}
+}
+
 func TestGenerateBridgeName(t *testing.T) {
 	result := v1.GenerateBridgeName(&v1.InterfaceExt{PciAddress: "0000:86:00.2"})
 	expected := "br-0000_86_00.2"
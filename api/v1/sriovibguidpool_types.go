@@ -0,0 +1,81 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SriovIBGUIDPoolSpec defines the desired state of SriovIBGUIDPool
+type SriovIBGUIDPoolSpec struct {
+	// +kubebuilder:validation:MinItems=1
+	// ranges of the GUIDs of the pool. Each InfiniBand PF of the selected nodes is allocated
+	// a block of contiguous GUIDs, one for each VF the PF supports.
+	Ranges []IbGUIDRange `json:"ranges"`
+
+	// nodeSelector specifies a label selector for the Nodes allocated from the pool, all the Nodes when empty.
+	// A Node selected by several pools is allocated from the first one by name.
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+}
+
+// IbGUIDRange is a range of InfiniBand GUIDs, both ends included
+type IbGUIDRange struct {
+	// +kubebuilder:validation:Pattern=`^([0-9a-fA-F]{2}:){7}[0-9a-fA-F]{2}$`
+	// first GUID of the range, e.g. 02:00:00:00:00:00:00:00
+	Start string `json:"start"`
+	// +kubebuilder:validation:Pattern=`^([0-9a-fA-F]{2}:){7}[0-9a-fA-F]{2}$`
+	// last GUID of the range, e.g. 02:00:00:00:00:00:ff:ff
+	End string `json:"end"`
+}
+
+// SriovIBGUIDPoolStatus defines the observed state of SriovIBGUIDPool
+type SriovIBGUIDPoolStatus struct {
+	// number of GUIDs allocated to the PFs
+	Allocated int `json:"allocated,omitempty"`
+	// PFs of the selected nodes that could not be allocated a block of GUIDs, as <node>/<pci address>.
+	// Their VFs use random GUIDs until GUIDs are released or the ranges are extended.
+	Unallocated []string `json:"unallocated,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Allocated",type=integer,JSONPath=`.status.allocated`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SriovIBGUIDPool is the Schema for the sriovibguidpools API, it allocates the GUIDs of the
+// InfiniBand VFs of the cluster
+type SriovIBGUIDPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SriovIBGUIDPoolSpec   `json:"spec,omitempty"`
+	Status SriovIBGUIDPoolStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// SriovIBGUIDPoolList contains a list of SriovIBGUIDPool
+type SriovIBGUIDPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SriovIBGUIDPool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SriovIBGUIDPool{}, &SriovIBGUIDPoolList{})
+}
@@ -40,6 +40,10 @@ type SriovIBNetworkSpec struct {
 	// VF link state (enable|disable|auto)
 	// +kubebuilder:validation:Enum={"auto","enable","disable"}
 	LinkState string `json:"linkState,omitempty"`
+	// +kubebuilder:validation:Pattern=`^0x[0-9a-fA-F]{1,4}$`
+	// InfiniBand partition key of the network, e.g. 0x8001. It is passed to the ib-sriov CNI,
+	// the VFs of the resource must have the PKey in their PKey table, see the pKeys of the SriovNetworkNodePolicy
+	PKey string `json:"pKey,omitempty"`
 	// MetaPluginsConfig configuration to be used in order to chain metaplugins to the sriov interface returned
 	// by the operator.
 	MetaPluginsConfig string `json:"metaPlugins,omitempty"`
@@ -47,12 +51,14 @@ type SriovIBNetworkSpec struct {
 
 // SriovIBNetworkStatus defines the observed state of SriovIBNetwork
 type SriovIBNetworkStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	NetworkStatus `json:",inline"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Target Namespace",type=string,JSONPath=`.status.targetNamespace`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // SriovIBNetwork is the Schema for the sriovibnetworks API
 type SriovIBNetwork struct {
@@ -75,12 +75,29 @@ type SriovNetworkSpec struct {
 
 // SriovNetworkStatus defines the observed state of SriovNetwork
 type SriovNetworkStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	NetworkStatus `json:",inline"`
+}
+
+// NetworkStatus contains the observed state shared by all the network custom resources
+type NetworkStatus struct {
+	// Name of the NetworkAttachmentDefinition rendered for the network
+	NetAttachDefName string `json:"netAttachDefName,omitempty"`
+	// Namespace where the NetworkAttachmentDefinition is created
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+	// ResourceAdvertised is true when the resourceName of the network is rendered on at least one node
+	ResourceAdvertised bool `json:"resourceAdvertised"`
+	// Conditions represent the latest available observations of the network state.
+	// Known condition types are "Ready".
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Target Namespace",type=string,JSONPath=`.status.targetNamespace`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // SriovNetwork is the Schema for the sriovnetworks API
 type SriovNetwork struct {
@@ -39,11 +39,18 @@ type SriovNetworkNodePolicySpec struct {
 	// +kubebuilder:validation:Minimum=0
 	// Number of VFs for each PF
 	NumVfs int `json:"numVfs"`
+	// +kubebuilder:validation:Minimum=0
+	// Number of scalable functions (SFs) for each PF, valid only for deviceType==sf
+	NumSfs int `json:"numSfs,omitempty"`
 	// NicSelector selects the NICs to be configured
 	NicSelector SriovNetworkNicSelector `json:"nicSelector"`
-	// +kubebuilder:validation:Enum=netdevice;vfio-pci
+	// +kubebuilder:validation:Enum=netdevice;vfio-pci;vfio-pci-noiommu;uio_pci_generic;igb_uio;sf
 	// +kubebuilder:default=netdevice
-	// The driver type for configured VFs. Allowed value "netdevice", "vfio-pci". Defaults to netdevice.
+	// The driver type for configured VFs. Allowed value "netdevice", "vfio-pci", "vfio-pci-noiommu", "uio_pci_generic",
+	// "igb_uio", "sf". Defaults to netdevice.
+	// "vfio-pci-noiommu" binds the VFs to vfio-pci with the unsafe no-IOMMU mode of vfio enabled, the VFs are not isolated.
+	// "igb_uio" requires the out-of-tree igb_uio kernel module to be installed on the nodes.
+	// "sf" configures numSfs Mellanox scalable functions on the PF instead of VFs, it requires eSwitchMode==switchdev.
 	DeviceType string `json:"deviceType,omitempty"`
 	// RDMA mode. Defaults to false.
 	IsRdma bool `json:"isRdma,omitempty"`
@@ -58,6 +65,9 @@ type SriovNetworkNodePolicySpec struct {
 	// +kubebuilder:validation:Enum=virtio;vhost
 	// VDPA device type. Allowed value "virtio", "vhost"
 	VdpaType string `json:"vdpaType,omitempty"`
+	// attributes of the VDPA devices created for the VFs, valid only with vdpaType.
+	// The VDPA devices are re-created when their attributes drift.
+	VdpaAttributes *VdpaAttributes `json:"vdpaAttributes,omitempty"`
 	// Exclude device's NUMA node when advertising this resource by SRIOV network device plugin. Default to false.
 	ExcludeTopology bool `json:"excludeTopology,omitempty"`
 	// don't create the virtual function only allocated them to the device plugin. Defaults to false.
@@ -65,6 +75,125 @@ type SriovNetworkNodePolicySpec struct {
 	// contains bridge configuration for matching PFs,
 	// valid only for eSwitchMode==switchdev
 	Bridge Bridge `json:"bridge,omitempty"`
+	// bonds the two PFs matched by the policy (VF-LAG), the bond is created once the eSwitch
+	// of both PFs is in switchdev mode. Valid only for eSwitchMode==switchdev
+	Bond *BondConfig `json:"bond,omitempty"`
+	// administrative attributes configured on the PF for each VF of the policy,
+	// valid only for ethernet links
+	VfAttributes *VfAttributes `json:"vfAttributes,omitempty"`
+	// +kubebuilder:validation:MaxItems=128
+	// +kubebuilder:validation:items:Pattern=`^0x[0-9a-fA-F]{1,4}$`
+	// InfiniBand partition keys mapped to the PKey table of each VF of the policy in this order, e.g. 0xffff or 0x8001.
+	// The PKeys must be present in the PKey table of the PF configured by the subnet manager.
+	// Valid only for linkType==ib and the PFs bound to the mlx4_core driver, the PKeys of the mlx5 VFs
+	// are assigned to their GUID by the subnet manager
+	PKeys []string `json:"pKeys,omitempty"`
+	// ethtool settings configured on the matching PFs
+	PfSettings *PfSettings `json:"pfSettings,omitempty"`
+	// devlink parameters configured on the matching PFs by their name as listed by `devlink dev param show`,
+	// e.g. flow_steering_mode or esw_multiport
+	DevlinkParams map[string]DevlinkParam `json:"devlinkParams,omitempty"`
+	// +kubebuilder:validation:Pattern=`^[^/]+\.pkg$`
+	// file name of the DDP package loaded by the matching Intel E810 PFs, the package must be present in
+	// /lib/firmware/updates/intel/ice/ddp on the host, e.g. ice_comms-1.3.45.0.pkg.
+	// Changing the package reloads the ice driver of the node
+	DdpPackage string `json:"ddpPackage,omitempty"`
+}
+
+// DevlinkParam contains the desired value of a devlink parameter of the PF
+type DevlinkParam struct {
+	// Value of the parameter, integers and booleans are set as strings, e.g. "dmfs", "8" or "true"
+	Value string `json:"value"`
+	// +kubebuilder:validation:Enum=runtime;driverinit;permanent
+	// Configuration mode of the parameter, equivalent to `devlink dev param set ... cmode <cmode>`.
+	// runtime values are applied immediately, driverinit values require a driver reload and
+	// permanent values a reboot. Defaults to the first mode supported by the parameter in this order.
+	Cmode string `json:"cmode,omitempty"`
+}
+
+// PfSettings contains the ethtool settings of the PF, unset settings are not managed
+type PfSettings struct {
+	// +kubebuilder:validation:Minimum=1
+	// Number of entries of the RX ring, equivalent to `ethtool -G <pf> rx <size>`
+	RxRingSize *int `json:"rxRingSize,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// Number of entries of the TX ring, equivalent to `ethtool -G <pf> tx <size>`
+	TxRingSize *int `json:"txRingSize,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// Number of combined channels, equivalent to `ethtool -L <pf> combined <count>`
+	CombinedChannels *int `json:"combinedChannels,omitempty"`
+	// Features to enable or disable by their kernel name as listed by `ethtool -k <pf>`,
+	// e.g. rx-gro-hw or rx-vlan-hw-parse
+	Features map[string]bool `json:"features,omitempty"`
+	// Pause frames configuration (flow control), equivalent to `ethtool -A <pf>`
+	Pause *PauseSettings `json:"pause,omitempty"`
+	// +kubebuilder:validation:Enum=auto;off;rs;baser;llrs
+	// Forward error correction mode, equivalent to `ethtool --set-fec <pf> encoding <mode>`
+	FecMode string `json:"fecMode,omitempty"`
+}
+
+// PauseSettings contains the pause frames configuration of the PF
+type PauseSettings struct {
+	// Pause frames autonegotiation
+	Autoneg *bool `json:"autoneg,omitempty"`
+	// Receive pause frames
+	Rx *bool `json:"rx,omitempty"`
+	// Transmit pause frames
+	Tx *bool `json:"tx,omitempty"`
+}
+
+// VfAttributes contains the administrative attributes of the VFs configured through the PF.
+// They are applied to the VFs whatever the driver they are bound to, unset attributes are not managed.
+type VfAttributes struct {
+	// Administrative MAC address of the first VF of the range, the following VFs use the next addresses,
+	// e.g. the VF 2 of the range 0-7 uses the address + 2
+	// +kubebuilder:validation:Pattern=`^([0-9a-fA-F]{2}:){5}[0-9a-fA-F]{2}$`
+	Mac string `json:"mac,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4094
+	// VLAN ID to assign for the VFs, 0 removes the VLAN.
+	Vlan *int `json:"vlan,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=7
+	// VLAN QoS ID to assign for the VFs, used only with vlan. Defaults to 0.
+	VlanQoS int `json:"vlanQoS,omitempty"`
+	// VF spoof check, (on|off)
+	// +kubebuilder:validation:Enum={"on","off"}
+	SpoofChk string `json:"spoofChk,omitempty"`
+	// VF trust mode (on|off)
+	// +kubebuilder:validation:Enum={"on","off"}
+	Trust string `json:"trust,omitempty"`
+	// VF link state (enable|disable|auto)
+	// +kubebuilder:validation:Enum={"auto","enable","disable"}
+	LinkState string `json:"linkState,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// Minimum tx rate, in Mbps, for the VFs. 0 disables the rate limiting, min_tx_rate should be <= max_tx_rate.
+	MinTxRate *int `json:"minTxRate,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// Maximum tx rate, in Mbps, for the VFs. 0 disables the rate limiting.
+	MaxTxRate *int `json:"maxTxRate,omitempty"`
+}
+
+// VdpaAttributes contains the attributes of the VDPA devices created for the VFs, unset attributes use the
+// defaults of the management device
+type VdpaAttributes struct {
+	// MAC address of the VDPA device of the first VF of the range, the following VFs use the next addresses,
+	// e.g. the VF 2 of the range 0-7 uses the address + 2
+	// +kubebuilder:validation:Pattern=`^([0-9a-fA-F]{2}:){5}[0-9a-fA-F]{2}$`
+	Mac string `json:"mac,omitempty"`
+	// +kubebuilder:validation:Minimum=68
+	// +kubebuilder:validation:Maximum=65535
+	// MTU of the VDPA devices
+	Mtu *int `json:"mtu,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// Maximum number of virtqueue pairs of the VDPA devices for multi-queue virtio-net.
+	// Defaults to 32, or to the default of the management device if it doesn't support the attribute.
+	MaxVqp *int `json:"maxVqp,omitempty"`
+	// Management device creating the VDPA device, <bus>/<name>, e.g. auxiliary/mlx5_core.sf.2.
+	// Defaults to the PCI device of the VF. Requires the policy to select a single VF of a single node.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9_]+/[^/\s]+$`
+	MgmtDevice string `json:"mgmtDevice,omitempty"`
 }
 
 type SriovNetworkNicSelector struct {
@@ -94,6 +223,21 @@ func (b *Bridge) IsEmpty() bool {
 	return b.OVS == nil
 }
 
+// BondConfig contains the configuration of the bond of the PFs
+type BondConfig struct {
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=15
+	// name of the bond interface
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=active-backup;balance-xor;"802.3ad"
+	// +kubebuilder:default=active-backup
+	// bonding mode, the modes supported by VF-LAG are "active-backup", "balance-xor" and "802.3ad"
+	Mode string `json:"mode,omitempty"`
+	// +kubebuilder:validation:Enum=layer2;layer2+3;layer3+4;encap2+3;encap3+4
+	// transmit hash policy, valid only for the "balance-xor" and "802.3ad" modes
+	XmitHashPolicy string `json:"xmitHashPolicy,omitempty"`
+}
+
 // OVSConfig optional configuration for OVS bridge and uplink Interface
 type OVSConfig struct {
 	// contains bridge level settings
@@ -137,12 +281,65 @@ type OVSInterfaceConfig struct {
 
 // SriovNetworkNodePolicyStatus defines the observed state of SriovNetworkNodePolicy
 type SriovNetworkNodePolicyStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// Number of nodes where the policy matched at least one PF
+	MatchedNodeCount int `json:"matchedNodeCount,omitempty"`
+	// Number of matched nodes that finished applying the configuration
+	ReadyNodeCount int `json:"readyNodeCount,omitempty"`
+	// Per node results of the policy matching and application
+	Nodes []PolicyNodeStatus `json:"nodes,omitempty"`
+	// Conditions represent the latest available observations of the policy state.
+	// Known condition types are "Ready", "Degraded" and "Conflicting".
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// PolicyNodeStatus contains the result of a policy for a single node
+type PolicyNodeStatus struct {
+	// Name of the node
+	Name string `json:"name"`
+	// PFs on the node matched by the policy nicSelector
+	Interfaces []PolicyInterfaceStatus `json:"interfaces,omitempty"`
+	// Sync status reported by the config daemon for the node
+	SyncStatus string `json:"syncStatus,omitempty"`
+	// Synced is true when the node finished applying the configuration rendered for the policy
+	Synced bool `json:"synced"`
+	// Plan contains the preview of the node configuration when the policy is in dry-run mode
+	Plan *PolicyNodePlan `json:"plan,omitempty"`
+}
+
+// PolicyNodePlan contains the preview of the configuration of the dry-run policies for a single node
+type PolicyNodePlan struct {
+	// Changes of the PFs matched by the policy
+	Changes []string `json:"changes,omitempty"`
+	// Evaluated is true when the config daemon evaluated the planned configuration of the node
+	Evaluated bool `json:"evaluated"`
+	// DrainRequired is true if applying the planned configuration requires to drain the node
+	DrainRequired bool `json:"drainRequired,omitempty"`
+	// RebootRequired is true if applying the planned configuration requires to reboot the node
+	RebootRequired bool `json:"rebootRequired,omitempty"`
+	// Error reported by the config daemon while evaluating the planned configuration
+	Error string `json:"error,omitempty"`
+}
+
+// PolicyInterfaceStatus contains the result of a policy for a single PF
+type PolicyInterfaceStatus struct {
+	// PCI address of the PF
+	PciAddress string `json:"pciAddress"`
+	// Name of the PF
+	Name string `json:"name,omitempty"`
+	// VF range allocated to the policy on the PF, empty if the policy doesn't own any VF on the PF
+	VfRange string `json:"vfRange,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Matched Nodes",type=integer,JSONPath=`.status.matchedNodeCount`
+//+kubebuilder:printcolumn:name="Ready Nodes",type=integer,JSONPath=`.status.readyNodeCount`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Degraded",type=string,JSONPath=`.status.conditions[?(@.type=="Degraded")].status`
+//+kubebuilder:printcolumn:name="Conflicting",type=string,JSONPath=`.status.conditions[?(@.type=="Conflicting")].status`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // SriovNetworkNodePolicy is the Schema for the sriovnetworknodepolicies API
 type SriovNetworkNodePolicy struct {
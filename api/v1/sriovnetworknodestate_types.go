@@ -27,6 +27,7 @@ import (
 type SriovNetworkNodeStateSpec struct {
 	Interfaces Interfaces `json:"interfaces,omitempty"`
 	Bridges    Bridges    `json:"bridges,omitempty"`
+	Bonds      Bonds      `json:"bonds,omitempty"`
 	System     System     `json:"system,omitempty"`
 }
 
@@ -35,12 +36,31 @@ type Interfaces []Interface
 type Interface struct {
 	PciAddress        string    `json:"pciAddress"`
 	NumVfs            int       `json:"numVfs,omitempty"`
+	NumSfs            int       `json:"numSfs,omitempty"`
 	Mtu               int       `json:"mtu,omitempty"`
 	Name              string    `json:"name,omitempty"`
 	LinkType          string    `json:"linkType,omitempty"`
 	EswitchMode       string    `json:"eSwitchMode,omitempty"`
 	VfGroups          []VfGroup `json:"vfGroups,omitempty"`
 	ExternallyManaged bool      `json:"externallyManaged,omitempty"`
+	// ethtool settings of the PF
+	PfSettings *PfSettings `json:"pfSettings,omitempty"`
+	// devlink parameters of the PF
+	DevlinkParams map[string]DevlinkParam `json:"devlinkParams,omitempty"`
+	// file name of the DDP package loaded by the PF, Intel E810 only
+	DdpPackage string `json:"ddpPackage,omitempty"`
+	// block of GUIDs allocated to the VFs of the PF from a SriovIBGUIDPool, InfiniBand only
+	IbGUIDAllocation *IbGUIDAllocation `json:"ibGuidAllocation,omitempty"`
+}
+
+// IbGUIDAllocation is a block of GUIDs allocated to an InfiniBand PF, the VF n uses the GUID start+n
+type IbGUIDAllocation struct {
+	// name of the SriovIBGUIDPool the block is allocated from
+	Pool string `json:"pool"`
+	// first GUID of the block
+	Start string `json:"start"`
+	// last GUID of the block
+	End string `json:"end"`
 }
 
 type VfGroup struct {
@@ -51,26 +71,65 @@ type VfGroup struct {
 	Mtu          int    `json:"mtu,omitempty"`
 	IsRdma       bool   `json:"isRdma,omitempty"`
 	VdpaType     string `json:"vdpaType,omitempty"`
+	// administrative attributes configured on the PF for the VFs of the group
+	VfAttributes *VfAttributes `json:"vfAttributes,omitempty"`
+	// attributes of the VDPA devices of the VFs of the group
+	VdpaAttributes *VdpaAttributes `json:"vdpaAttributes,omitempty"`
+	// InfiniBand partition keys mapped to the PKey table of the VFs of the group
+	PKeys []string `json:"pKeys,omitempty"`
 }
 
 type InterfaceExt struct {
-	Name              string            `json:"name,omitempty"`
-	Mac               string            `json:"mac,omitempty"`
-	Driver            string            `json:"driver,omitempty"`
-	PciAddress        string            `json:"pciAddress"`
-	Vendor            string            `json:"vendor,omitempty"`
-	DeviceID          string            `json:"deviceID,omitempty"`
-	NetFilter         string            `json:"netFilter,omitempty"`
-	Mtu               int               `json:"mtu,omitempty"`
-	NumVfs            int               `json:"numVfs,omitempty"`
-	LinkSpeed         string            `json:"linkSpeed,omitempty"`
-	LinkType          string            `json:"linkType,omitempty"`
-	LinkAdminState    string            `json:"linkAdminState,omitempty"`
-	EswitchMode       string            `json:"eSwitchMode,omitempty"`
-	ExternallyManaged bool              `json:"externallyManaged,omitempty"`
-	TotalVfs          int               `json:"totalvfs,omitempty"`
-	VFs               []VirtualFunction `json:"Vfs,omitempty"`
-	AltNames          []string          `json:"altNames,omitempty"`
+	Name              string             `json:"name,omitempty"`
+	Mac               string             `json:"mac,omitempty"`
+	Driver            string             `json:"driver,omitempty"`
+	PciAddress        string             `json:"pciAddress"`
+	Vendor            string             `json:"vendor,omitempty"`
+	DeviceID          string             `json:"deviceID,omitempty"`
+	NetFilter         string             `json:"netFilter,omitempty"`
+	Mtu               int                `json:"mtu,omitempty"`
+	NumVfs            int                `json:"numVfs,omitempty"`
+	LinkSpeed         string             `json:"linkSpeed,omitempty"`
+	LinkType          string             `json:"linkType,omitempty"`
+	LinkAdminState    string             `json:"linkAdminState,omitempty"`
+	EswitchMode       string             `json:"eSwitchMode,omitempty"`
+	ExternallyManaged bool               `json:"externallyManaged,omitempty"`
+	TotalVfs          int                `json:"totalvfs,omitempty"`
+	VFs               []VirtualFunction  `json:"Vfs,omitempty"`
+	NumSfs            int                `json:"numSfs,omitempty"`
+	SFs               []ScalableFunction `json:"sfs,omitempty"`
+	AltNames          []string           `json:"altNames,omitempty"`
+	// ethtool settings of the PF, the features are reported only when they are managed by the spec
+	PfSettings *PfSettings `json:"pfSettings,omitempty"`
+	// devlink parameters of the PF, the parameters are reported only when they are managed by the spec
+	DevlinkParams []DevlinkParamStatus `json:"devlinkParams,omitempty"`
+	// devlink parameters of the spec the PF doesn't support in the configured mode, they are not applied
+	UnsupportedDevlinkParams []string `json:"unsupportedDevlinkParams,omitempty"`
+	// NVM version of the PF, Intel NICs only
+	NvmVersion string `json:"nvmVersion,omitempty"`
+	// name and version of the DDP package active on the PF, Intel E810 only, e.g. "ICE COMMS Package 1.3.45.0"
+	DdpPackage string `json:"ddpPackage,omitempty"`
+	// NUMA node of the PF, not reported when the platform has no NUMA information
+	NumaNode *int `json:"numaNode,omitempty"`
+	// negotiated PCIe link width of the PF, in lanes
+	PcieLinkWidth int `json:"pcieLinkWidth,omitempty"`
+	// negotiated PCIe link speed of the PF, e.g. "16.0 GT/s PCIe"
+	PcieLinkSpeed string `json:"pcieLinkSpeed,omitempty"`
+	// version of the driver of the PF as reported by ethtool
+	DriverVersion string `json:"driverVersion,omitempty"`
+	// firmware version of the PF as reported by ethtool, or the management firmware version reported by devlink
+	FirmwareVersion string `json:"firmwareVersion,omitempty"`
+	// serial number of the NIC as reported by devlink
+	SerialNumber string `json:"serialNumber,omitempty"`
+	// name of the physical slot of the NIC, not reported for the embedded NICs
+	PhysicalSlot string `json:"physicalSlot,omitempty"`
+}
+
+// DevlinkParamStatus contains the value of a devlink parameter in one configuration mode
+type DevlinkParamStatus struct {
+	Name  string `json:"name"`
+	Cmode string `json:"cmode"`
+	Value string `json:"value"`
 }
 type InterfaceExts []InterfaceExt
 
@@ -88,6 +147,42 @@ type VirtualFunction struct {
 	VdpaType        string `json:"vdpaType,omitempty"`
 	RepresentorName string `json:"representorName,omitempty"`
 	GUID            string `json:"guid,omitempty"`
+	// InfiniBand partition keys of the PKey table of the VF, not reported when the VF RDMA device is not on the host
+	PKeys []string `json:"pKeys,omitempty"`
+	// attributes of the VDPA device of the VF
+	VdpaMac        string `json:"vdpaMac,omitempty"`
+	VdpaMtu        int    `json:"vdpaMtu,omitempty"`
+	VdpaMaxVqp     int    `json:"vdpaMaxVqp,omitempty"`
+	VdpaMgmtDevice string `json:"vdpaMgmtDevice,omitempty"`
+	// administrative attributes of the VF reported by the PF
+	AdminMac  string `json:"adminMac,omitempty"`
+	VlanQoS   int    `json:"vlanQoS,omitempty"`
+	SpoofChk  string `json:"spoofChk,omitempty"`
+	Trust     string `json:"trust,omitempty"`
+	LinkState string `json:"linkState,omitempty"`
+	MinTxRate int    `json:"minTxRate,omitempty"`
+	MaxTxRate int    `json:"maxTxRate,omitempty"`
+	// IOMMU group of the VF, not set when the IOMMU is disabled
+	IommuGroup *int `json:"iommuGroup,omitempty"`
+	// other devices of the IOMMU group of the VF, the PCI bridges excluded.
+	// A VF sharing its group with other devices can't be safely isolated with the vfio-pci driver.
+	IommuGroupDevices []string `json:"iommuGroupDevices,omitempty"`
+}
+
+// ScalableFunction contains the status of a scalable function (SF) created on the PF
+type ScalableFunction struct {
+	// name of the SF netdevice
+	Name string `json:"name,omitempty"`
+	// hardware address of the SF
+	Mac string `json:"mac,omitempty"`
+	// SF number as used in `devlink port add ... sfnum <number>`
+	SfNumber int `json:"sfNumber"`
+	// index of the devlink port of the SF
+	PortIndex int `json:"portIndex"`
+	// name of the SF representor netdevice
+	RepresentorName string `json:"representorName,omitempty"`
+	// state of the SF function, active or inactive
+	State string `json:"state,omitempty"`
 }
 
 // Bridges contains list of bridges
@@ -101,34 +196,144 @@ type OVSConfigExt struct {
 	Name string `json:"name"`
 	// bridge-level configuration for the bridge
 	Bridge OVSBridgeConfig `json:"bridge,omitempty"`
-	// uplink-level bridge configuration for each uplink(PF).
+	// uplink-level bridge configuration for each uplink(PF or bond of PFs).
 	// currently must contain only one element
 	Uplinks []OVSUplinkConfigExt `json:"uplinks,omitempty"`
 }
 
-// OVSUplinkConfigExt contains configuration for the concrete OVS uplink(PF)
+// OVSUplinkConfigExt contains configuration for the concrete OVS uplink(PF or bond of PFs)
 type OVSUplinkConfigExt struct {
-	// pci address of the PF
+	// pci address of the PF, for a bond the pci address of its first slave
 	PciAddress string `json:"pciAddress"`
-	// name of the PF interface
+	// name of the PF or of the bond interface
 	Name string `json:"name,omitempty"`
 	// configuration from the Interface OVS table for the PF
 	Interface OVSInterfaceConfig `json:"interface,omitempty"`
 }
 
+// Bonds contains list of bonds of PFs
+type Bonds []BondConfigExt
+
+// BondConfigExt contains configuration for the concrete bond of PFs
+type BondConfigExt struct {
+	// name of the bond interface
+	Name string `json:"name"`
+	// bonding mode
+	Mode string `json:"mode"`
+	// transmit hash policy
+	XmitHashPolicy string `json:"xmitHashPolicy,omitempty"`
+	// MTU of the bond, the kernel propagates it to the slaves
+	Mtu int `json:"mtu,omitempty"`
+	// PFs enslaved to the bond sorted by pci address
+	Slaves []BondSlave `json:"slaves"`
+}
+
+// BondSlave contains information about a PF enslaved to a bond
+type BondSlave struct {
+	// pci address of the PF
+	PciAddress string `json:"pciAddress"`
+	// name of the PF interface
+	Name string `json:"name,omitempty"`
+}
+
+// BondStatus contains the configuration and the state of a bond of PFs
+type BondStatus struct {
+	BondConfigExt `json:",inline"`
+	// operational state of the bond, "up" or "down"
+	LinkState string `json:"linkState,omitempty"`
+	// name of the active slave of an active-backup bond
+	ActiveSlave string `json:"activeSlave,omitempty"`
+}
+
+// ExternalPluginStatus reports the result of the calls of an external vendor plugin
+type ExternalPluginStatus struct {
+	Name string `json:"name"`
+	// errors of the operations whose last call to the plugin failed, empty when the last call of every operation succeeded
+	LastError string `json:"lastError,omitempty"`
+}
+
 type System struct {
 	// +kubebuilder:validation:Enum=shared;exclusive
 	//RDMA subsystem. Allowed value "shared", "exclusive".
 	RdmaMode string `json:"rdmaMode,omitempty"`
+	// IOMMU of the node is enabled, reported in the status only
+	IommuEnabled bool `json:"iommuEnabled,omitempty"`
 }
 
 // SriovNetworkNodeStateStatus defines the observed state of SriovNetworkNodeState
 type SriovNetworkNodeStateStatus struct {
 	Interfaces    InterfaceExts `json:"interfaces,omitempty"`
 	Bridges       Bridges       `json:"bridges,omitempty"`
+	Bonds         []BondStatus  `json:"bonds,omitempty"`
 	System        System        `json:"system,omitempty"`
 	SyncStatus    string        `json:"syncStatus,omitempty"`
 	LastSyncError string        `json:"lastSyncError,omitempty"`
+	// ExternalPlugins reports the external vendor plugins loaded by the config daemon
+	ExternalPlugins []ExternalPluginStatus `json:"externalPlugins,omitempty"`
+	// Generation of the node state last applied by the config daemon
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions represent the latest available observations of the node state.
+	// Known condition types are "Synced", "DrainRequired", "RebootRequired", "Degraded" and "DevicePluginBlocked".
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Result of the evaluation of the planned configuration rendered with the dry-run policies
+	Plan *NodeStatePlan `json:"plan,omitempty"`
+	// Rollback is set when the config daemon restored the last applied configuration
+	// after failing to apply the current generation
+	Rollback *NodeStateRollback `json:"rollback,omitempty"`
+	// ApplyFailures counts the consecutive failures to apply the current generation,
+	// it is set by the config daemon when the automatic rollback is enabled
+	ApplyFailures *NodeStateApplyFailures `json:"applyFailures,omitempty"`
+	// KernelArgs reports the kernel arguments managed by the config daemon
+	KernelArgs *KernelArgsStatus `json:"kernelArgs,omitempty"`
+}
+
+// KernelArgsStatus reports the kernel arguments managed by the config daemon on the running kernel
+// and in the boot configuration
+type KernelArgsStatus struct {
+	// Bootloader used to configure the kernel arguments of the next boot,
+	// empty if the bootloader of the node is not supported
+	Bootloader string `json:"bootloader,omitempty"`
+	// Active are the managed kernel arguments of the running kernel
+	Active []string `json:"active,omitempty"`
+	// PendingAdd are the kernel arguments configured for the next boot, not active until the node reboots
+	PendingAdd []string `json:"pendingAdd,omitempty"`
+	// PendingRemove are the kernel arguments removed from the next boot, active until the node reboots
+	PendingRemove []string `json:"pendingRemove,omitempty"`
+	// OperatorAdded are the kernel arguments added by the config daemon,
+	// they are removed once no policy needs them
+	OperatorAdded []string `json:"operatorAdded,omitempty"`
+}
+
+// NodeStateRollback records the generation that failed to be applied on the node
+type NodeStateRollback struct {
+	// FailedGeneration is the generation of the nodeState that failed to be applied
+	FailedGeneration int64 `json:"failedGeneration"`
+	// RestoredGeneration is the generation of the configuration restored on the node
+	RestoredGeneration int64 `json:"restoredGeneration"`
+	// Failures is the number of consecutive attempts to apply the failed generation
+	Failures int `json:"failures"`
+}
+
+// NodeStateApplyFailures records the consecutive failures to apply a generation of the nodeState
+type NodeStateApplyFailures struct {
+	// Generation of the nodeState that failed to be applied
+	Generation int64 `json:"generation"`
+	// Failures is the number of consecutive attempts to apply the generation that failed
+	Failures int `json:"failures"`
+}
+
+// NodeStatePlan contains the result of the evaluation of the planned spec by the config daemon
+type NodeStatePlan struct {
+	// Hash of the evaluated planned spec annotation
+	PlannedSpecHash string `json:"plannedSpecHash"`
+	// DrainRequired is true if applying the planned spec requires to drain the node
+	DrainRequired bool `json:"drainRequired"`
+	// RebootRequired is true if applying the planned spec requires to reboot the node
+	RebootRequired bool `json:"rebootRequired"`
+	// Error reported by the plugins while evaluating the planned spec
+	Error string `json:"error,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -41,6 +41,50 @@ type SriovNetworkPoolConfigSpec struct {
 	// +kubebuilder:validation:Enum=shared;exclusive
 	// RDMA subsystem. Allowed value "shared", "exclusive".
 	RdmaMode string `json:"rdmaMode,omitempty"`
+
+	// maintenanceWindows restricts the drains and reboots of the nodes in the pool to the defined time ranges.
+	// Nodes that require a drain or a reboot outside of the windows wait until the next window starts,
+	// a drain already started is not interrupted when the window ends.
+	// When empty the nodes can be drained at any time.
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// paused prevents the nodes of the pool from applying new configurations.
+	// The SriovNetworkNodeStates are still rendered and the pending changes are applied
+	// when the pool is resumed. Nodes that already requested a drain complete the current configuration.
+	Paused bool `json:"paused,omitempty"`
+
+	// rolloutStrategy updates a set of canary nodes of the pool first, the rest of the pool
+	// applies the new configuration once the canaries are synced for the soak time.
+	// The rollout stops if a canary node fails to apply the configuration.
+	RolloutStrategy *RolloutStrategy `json:"rolloutStrategy,omitempty"`
+}
+
+// RolloutStrategy defines the canary nodes of the pool
+type RolloutStrategy struct {
+	// canaryNodes is the list of the names of the canary nodes
+	CanaryNodes []string `json:"canaryNodes,omitempty"`
+	// canaryCount is the number or the percentage of the pool nodes used as canaries when canaryNodes is empty,
+	// the canaries are the first nodes of the pool sorted by name. Defaults to 1.
+	CanaryCount *intstr.IntOrString `json:"canaryCount,omitempty"`
+	// soakTime is the time the canary nodes must stay synced before the rest of the pool is updated
+	SoakTime *metav1.Duration `json:"soakTime,omitempty"`
+}
+
+// MaintenanceWindow defines a time range repeated on the selected days of the week
+type MaintenanceWindow struct {
+	// days of the week the window starts on. Allowed values "Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat".
+	// The window starts every day when empty.
+	// +kubebuilder:validation:items:Enum=Sun;Mon;Tue;Wed;Thu;Fri;Sat
+	Days []string `json:"days,omitempty"`
+	// start time of the window in the 24h "HH:MM" format
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+	// end time of the window in the 24h "HH:MM" format,
+	// a window ending before its start time ends the next day
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	EndTime string `json:"endTime"`
+	// IANA time zone of the start and end times, for example "Europe/Paris". Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
 }
 
 type OvsHardwareOffloadConfig struct {
@@ -54,6 +98,24 @@ type OvsHardwareOffloadConfig struct {
 
 // SriovNetworkPoolConfigStatus defines the observed state of SriovNetworkPoolConfig
 type SriovNetworkPoolConfigStatus struct {
+	// Rollout reports the progress of the canary rollout when a rollout strategy is defined
+	Rollout *PoolRolloutStatus `json:"rollout,omitempty"`
+}
+
+// PoolRolloutStatus contains the state of the canary rollout of the pool
+type PoolRolloutStatus struct {
+	// Names of the canary nodes
+	CanaryNodes []string `json:"canaryNodes,omitempty"`
+	// Phase of the rollout, one of "Canary", "Soaking", "Completed" and "Stopped"
+	Phase string `json:"phase,omitempty"`
+	// Message describing what the rest of the pool is waiting for
+	Message string `json:"message,omitempty"`
+	// Generations of the SriovNetworkNodeStates of the canary nodes holding the configuration being rolled out,
+	// a canary is synced once it observed at least this generation
+	TargetGenerations map[string]int64 `json:"targetGenerations,omitempty"`
+	// Start of the soak time of the canary nodes, when they were seen synced with their target generation.
+	// The soak restarts when a node of the pool gets a new configuration
+	SoakStartTimes map[string]metav1.Time `json:"soakStartTimes,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -68,6 +68,48 @@ type SriovOperatorConfigSpec struct {
 	// ConfigDaemonEnvVars allows to specify custom environment variables
 	// for the sriov-network-config-daemon
 	ConfigDaemonEnvVars map[string]string `json:"configDaemonEnvVars,omitempty"`
+	// AutoRollback enables the config daemon to restore the last successfully applied configuration
+	// of the node when applying a new SriovNetworkNodeState generation fails repeatedly
+	AutoRollback *AutoRollbackConfig `json:"autoRollback,omitempty"`
+	// ExternalPlugins registers out-of-tree vendor plugins called by the config daemon
+	// for the devices of their vendors. A change of the list restarts the config daemons.
+	// +listType=map
+	// +listMapKey=name
+	ExternalPlugins []ExternalPlugin `json:"externalPlugins,omitempty"`
+}
+
+// ExternalPlugin is an out-of-tree vendor plugin implementing the external plugin protocol,
+// either as a host binary or as a server listening on a host unix socket. Exactly one of
+// command and socket must be set.
+type ExternalPlugin struct {
+	// Name of the plugin, it is used in the events and the node state status
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// Vendors are the PCI vendor IDs of the devices managed by the plugin, the plugin is loaded
+	// when the node has a device of one of the vendors
+	// +kubebuilder:validation:MinItems=1
+	Vendors []string `json:"vendors"`
+	// Command is the absolute path of the plugin binary on the host
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Command string `json:"command,omitempty"`
+	// Socket is the absolute path on the host of the unix socket of the plugin server,
+	// e.g. shared by a sidecar container through a hostPath volume
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Socket string `json:"socket,omitempty"`
+	// Timeout of a call to the plugin, defaults to 5m
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// AutoRollbackConfig defines when the config daemon rolls back the node configuration
+type AutoRollbackConfig struct {
+	// MaxFailures is the number of consecutive failures to apply a generation before rolling back
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=3
+	MaxFailures int `json:"maxFailures,omitempty"`
 }
 
 // SriovOperatorConfigStatus defines the observed state of SriovOperatorConfig
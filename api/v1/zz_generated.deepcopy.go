@@ -26,6 +26,108 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoRollbackConfig) DeepCopyInto(out *AutoRollbackConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoRollbackConfig.
+func (in *AutoRollbackConfig) DeepCopy() *AutoRollbackConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoRollbackConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BondConfig) DeepCopyInto(out *BondConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BondConfig.
+func (in *BondConfig) DeepCopy() *BondConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BondConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BondConfigExt) DeepCopyInto(out *BondConfigExt) {
+	*out = *in
+	if in.Slaves != nil {
+		in, out := &in.Slaves, &out.Slaves
+		*out = make([]BondSlave, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BondConfigExt.
+func (in *BondConfigExt) DeepCopy() *BondConfigExt {
+	if in == nil {
+		return nil
+	}
+	out := new(BondConfigExt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BondSlave) DeepCopyInto(out *BondSlave) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BondSlave.
+func (in *BondSlave) DeepCopy() *BondSlave {
+	if in == nil {
+		return nil
+	}
+	out := new(BondSlave)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BondStatus) DeepCopyInto(out *BondStatus) {
+	*out = *in
+	in.BondConfigExt.DeepCopyInto(&out.BondConfigExt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BondStatus.
+func (in *BondStatus) DeepCopy() *BondStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BondStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in Bonds) DeepCopyInto(out *Bonds) {
+	{
+		in := &in
+		*out = make(Bonds, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bonds.
+func (in Bonds) DeepCopy() Bonds {
+	if in == nil {
+		return nil
+	}
+	out := new(Bonds)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Bridge) DeepCopyInto(out *Bridge) {
 	*out = *in
@@ -89,13 +191,132 @@ func (in ByPriority) DeepCopy() ByPriority {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DevlinkParam) DeepCopyInto(out *DevlinkParam) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevlinkParam.
+func (in *DevlinkParam) DeepCopy() *DevlinkParam {
+	if in == nil {
+		return nil
+	}
+	out := new(DevlinkParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DevlinkParamStatus) DeepCopyInto(out *DevlinkParamStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevlinkParamStatus.
+func (in *DevlinkParamStatus) DeepCopy() *DevlinkParamStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DevlinkParamStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalPlugin) DeepCopyInto(out *ExternalPlugin) {
+	*out = *in
+	if in.Vendors != nil {
+		in, out := &in.Vendors, &out.Vendors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalPlugin.
+func (in *ExternalPlugin) DeepCopy() *ExternalPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalPluginStatus) DeepCopyInto(out *ExternalPluginStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalPluginStatus.
+func (in *ExternalPluginStatus) DeepCopy() *ExternalPluginStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalPluginStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IbGUIDAllocation) DeepCopyInto(out *IbGUIDAllocation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IbGUIDAllocation.
+func (in *IbGUIDAllocation) DeepCopy() *IbGUIDAllocation {
+	if in == nil {
+		return nil
+	}
+	out := new(IbGUIDAllocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IbGUIDRange) DeepCopyInto(out *IbGUIDRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IbGUIDRange.
+func (in *IbGUIDRange) DeepCopy() *IbGUIDRange {
+	if in == nil {
+		return nil
+	}
+	out := new(IbGUIDRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Interface) DeepCopyInto(out *Interface) {
 	*out = *in
 	if in.VfGroups != nil {
 		in, out := &in.VfGroups, &out.VfGroups
 		*out = make([]VfGroup, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PfSettings != nil {
+		in, out := &in.PfSettings, &out.PfSettings
+		*out = new(PfSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DevlinkParams != nil {
+		in, out := &in.DevlinkParams, &out.DevlinkParams
+		*out = make(map[string]DevlinkParam, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.IbGUIDAllocation != nil {
+		in, out := &in.IbGUIDAllocation, &out.IbGUIDAllocation
+		*out = new(IbGUIDAllocation)
+		**out = **in
 	}
 }
 
@@ -115,6 +336,13 @@ func (in *InterfaceExt) DeepCopyInto(out *InterfaceExt) {
 	if in.VFs != nil {
 		in, out := &in.VFs, &out.VFs
 		*out = make([]VirtualFunction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SFs != nil {
+		in, out := &in.SFs, &out.SFs
+		*out = make([]ScalableFunction, len(*in))
 		copy(*out, *in)
 	}
 	if in.AltNames != nil {
@@ -122,6 +350,26 @@ func (in *InterfaceExt) DeepCopyInto(out *InterfaceExt) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PfSettings != nil {
+		in, out := &in.PfSettings, &out.PfSettings
+		*out = new(PfSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DevlinkParams != nil {
+		in, out := &in.DevlinkParams, &out.DevlinkParams
+		*out = make([]DevlinkParamStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnsupportedDevlinkParams != nil {
+		in, out := &in.UnsupportedDevlinkParams, &out.UnsupportedDevlinkParams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NumaNode != nil {
+		in, out := &in.NumaNode, &out.NumaNode
+		*out = new(int)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceExt.
@@ -176,6 +424,128 @@ func (in Interfaces) DeepCopy() Interfaces {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KernelArgsStatus) DeepCopyInto(out *KernelArgsStatus) {
+	*out = *in
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingAdd != nil {
+		in, out := &in.PendingAdd, &out.PendingAdd
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingRemove != nil {
+		in, out := &in.PendingRemove, &out.PendingRemove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OperatorAdded != nil {
+		in, out := &in.OperatorAdded, &out.OperatorAdded
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelArgsStatus.
+func (in *KernelArgsStatus) DeepCopy() *KernelArgsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KernelArgsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkStatus) DeepCopyInto(out *NetworkStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkStatus.
+func (in *NetworkStatus) DeepCopy() *NetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeStateApplyFailures) DeepCopyInto(out *NodeStateApplyFailures) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStateApplyFailures.
+func (in *NodeStateApplyFailures) DeepCopy() *NodeStateApplyFailures {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeStateApplyFailures)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeStatePlan) DeepCopyInto(out *NodeStatePlan) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatePlan.
+func (in *NodeStatePlan) DeepCopy() *NodeStatePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeStatePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeStateRollback) DeepCopyInto(out *NodeStateRollback) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStateRollback.
+func (in *NodeStateRollback) DeepCopy() *NodeStateRollback {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeStateRollback)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OVSBridgeConfig) DeepCopyInto(out *OVSBridgeConfig) {
 	*out = *in
@@ -287,169 +657,485 @@ func (in *OVSInterfaceConfig) DeepCopy() *OVSInterfaceConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVSNetwork) DeepCopyInto(out *OVSNetwork) {
+func (in *OVSNetwork) DeepCopyInto(out *OVSNetwork) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSNetwork.
+func (in *OVSNetwork) DeepCopy() *OVSNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(OVSNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OVSNetwork) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVSNetworkList) DeepCopyInto(out *OVSNetworkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OVSNetwork, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSNetworkList.
+func (in *OVSNetworkList) DeepCopy() *OVSNetworkList {
+	if in == nil {
+		return nil
+	}
+	out := new(OVSNetworkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OVSNetworkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVSNetworkSpec) DeepCopyInto(out *OVSNetworkSpec) {
+	*out = *in
+	if in.Trunk != nil {
+		in, out := &in.Trunk, &out.Trunk
+		*out = make([]*TrunkConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(TrunkConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSNetworkSpec.
+func (in *OVSNetworkSpec) DeepCopy() *OVSNetworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OVSNetworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVSNetworkStatus) DeepCopyInto(out *OVSNetworkStatus) {
+	*out = *in
+	in.NetworkStatus.DeepCopyInto(&out.NetworkStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSNetworkStatus.
+func (in *OVSNetworkStatus) DeepCopy() *OVSNetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OVSNetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVSUplinkConfig) DeepCopyInto(out *OVSUplinkConfig) {
+	*out = *in
+	in.Interface.DeepCopyInto(&out.Interface)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSUplinkConfig.
+func (in *OVSUplinkConfig) DeepCopy() *OVSUplinkConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OVSUplinkConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVSUplinkConfigExt) DeepCopyInto(out *OVSUplinkConfigExt) {
+	*out = *in
+	in.Interface.DeepCopyInto(&out.Interface)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSUplinkConfigExt.
+func (in *OVSUplinkConfigExt) DeepCopy() *OVSUplinkConfigExt {
+	if in == nil {
+		return nil
+	}
+	out := new(OVSUplinkConfigExt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OvsHardwareOffloadConfig) DeepCopyInto(out *OvsHardwareOffloadConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvsHardwareOffloadConfig.
+func (in *OvsHardwareOffloadConfig) DeepCopy() *OvsHardwareOffloadConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OvsHardwareOffloadConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PauseSettings) DeepCopyInto(out *PauseSettings) {
+	*out = *in
+	if in.Autoneg != nil {
+		in, out := &in.Autoneg, &out.Autoneg
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Rx != nil {
+		in, out := &in.Rx, &out.Rx
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Tx != nil {
+		in, out := &in.Tx, &out.Tx
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PauseSettings.
+func (in *PauseSettings) DeepCopy() *PauseSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(PauseSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PfSettings) DeepCopyInto(out *PfSettings) {
+	*out = *in
+	if in.RxRingSize != nil {
+		in, out := &in.RxRingSize, &out.RxRingSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.TxRingSize != nil {
+		in, out := &in.TxRingSize, &out.TxRingSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.CombinedChannels != nil {
+		in, out := &in.CombinedChannels, &out.CombinedChannels
+		*out = new(int)
+		**out = **in
+	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Pause != nil {
+		in, out := &in.Pause, &out.Pause
+		*out = new(PauseSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PfSettings.
+func (in *PfSettings) DeepCopy() *PfSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(PfSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in PluginNameSlice) DeepCopyInto(out *PluginNameSlice) {
+	{
+		in := &in
+		*out = make(PluginNameSlice, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginNameSlice.
+func (in PluginNameSlice) DeepCopy() PluginNameSlice {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginNameSlice)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyInterfaceStatus) DeepCopyInto(out *PolicyInterfaceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyInterfaceStatus.
+func (in *PolicyInterfaceStatus) DeepCopy() *PolicyInterfaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyInterfaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyNodePlan) DeepCopyInto(out *PolicyNodePlan) {
+	*out = *in
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyNodePlan.
+func (in *PolicyNodePlan) DeepCopy() *PolicyNodePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyNodePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyNodeStatus) DeepCopyInto(out *PolicyNodeStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]PolicyInterfaceStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(PolicyNodePlan)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSNetwork.
-func (in *OVSNetwork) DeepCopy() *OVSNetwork {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyNodeStatus.
+func (in *PolicyNodeStatus) DeepCopy() *PolicyNodeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OVSNetwork)
+	out := new(PolicyNodeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OVSNetwork) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVSNetworkList) DeepCopyInto(out *OVSNetworkList) {
+func (in *PoolRolloutStatus) DeepCopyInto(out *PoolRolloutStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]OVSNetwork, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.CanaryNodes != nil {
+		in, out := &in.CanaryNodes, &out.CanaryNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetGenerations != nil {
+		in, out := &in.TargetGenerations, &out.TargetGenerations
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SoakStartTimes != nil {
+		in, out := &in.SoakStartTimes, &out.SoakStartTimes
+		*out = make(map[string]metav1.Time, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSNetworkList.
-func (in *OVSNetworkList) DeepCopy() *OVSNetworkList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolRolloutStatus.
+func (in *PoolRolloutStatus) DeepCopy() *PoolRolloutStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OVSNetworkList)
+	out := new(PoolRolloutStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OVSNetworkList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVSNetworkSpec) DeepCopyInto(out *OVSNetworkSpec) {
+func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
 	*out = *in
-	if in.Trunk != nil {
-		in, out := &in.Trunk, &out.Trunk
-		*out = make([]*TrunkConfig, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(TrunkConfig)
-				(*in).DeepCopyInto(*out)
-			}
-		}
+	if in.CanaryNodes != nil {
+		in, out := &in.CanaryNodes, &out.CanaryNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CanaryCount != nil {
+		in, out := &in.CanaryCount, &out.CanaryCount
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.SoakTime != nil {
+		in, out := &in.SoakTime, &out.SoakTime
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSNetworkSpec.
-func (in *OVSNetworkSpec) DeepCopy() *OVSNetworkSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategy.
+func (in *RolloutStrategy) DeepCopy() *RolloutStrategy {
 	if in == nil {
 		return nil
 	}
-	out := new(OVSNetworkSpec)
+	out := new(RolloutStrategy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVSNetworkStatus) DeepCopyInto(out *OVSNetworkStatus) {
+func (in *ScalableFunction) DeepCopyInto(out *ScalableFunction) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSNetworkStatus.
-func (in *OVSNetworkStatus) DeepCopy() *OVSNetworkStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalableFunction.
+func (in *ScalableFunction) DeepCopy() *ScalableFunction {
 	if in == nil {
 		return nil
 	}
-	out := new(OVSNetworkStatus)
+	out := new(ScalableFunction)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVSUplinkConfig) DeepCopyInto(out *OVSUplinkConfig) {
+func (in *SriovIBGUIDPool) DeepCopyInto(out *SriovIBGUIDPool) {
 	*out = *in
-	in.Interface.DeepCopyInto(&out.Interface)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSUplinkConfig.
-func (in *OVSUplinkConfig) DeepCopy() *OVSUplinkConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovIBGUIDPool.
+func (in *SriovIBGUIDPool) DeepCopy() *SriovIBGUIDPool {
 	if in == nil {
 		return nil
 	}
-	out := new(OVSUplinkConfig)
+	out := new(SriovIBGUIDPool)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SriovIBGUIDPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVSUplinkConfigExt) DeepCopyInto(out *OVSUplinkConfigExt) {
+func (in *SriovIBGUIDPoolList) DeepCopyInto(out *SriovIBGUIDPoolList) {
 	*out = *in
-	in.Interface.DeepCopyInto(&out.Interface)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SriovIBGUIDPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSUplinkConfigExt.
-func (in *OVSUplinkConfigExt) DeepCopy() *OVSUplinkConfigExt {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovIBGUIDPoolList.
+func (in *SriovIBGUIDPoolList) DeepCopy() *SriovIBGUIDPoolList {
 	if in == nil {
 		return nil
 	}
-	out := new(OVSUplinkConfigExt)
+	out := new(SriovIBGUIDPoolList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SriovIBGUIDPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvsHardwareOffloadConfig) DeepCopyInto(out *OvsHardwareOffloadConfig) {
+func (in *SriovIBGUIDPoolSpec) DeepCopyInto(out *SriovIBGUIDPoolSpec) {
 	*out = *in
+	if in.Ranges != nil {
+		in, out := &in.Ranges, &out.Ranges
+		*out = make([]IbGUIDRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvsHardwareOffloadConfig.
-func (in *OvsHardwareOffloadConfig) DeepCopy() *OvsHardwareOffloadConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovIBGUIDPoolSpec.
+func (in *SriovIBGUIDPoolSpec) DeepCopy() *SriovIBGUIDPoolSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OvsHardwareOffloadConfig)
+	out := new(SriovIBGUIDPoolSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in PluginNameSlice) DeepCopyInto(out *PluginNameSlice) {
-	{
-		in := &in
-		*out = make(PluginNameSlice, len(*in))
+func (in *SriovIBGUIDPoolStatus) DeepCopyInto(out *SriovIBGUIDPoolStatus) {
+	*out = *in
+	if in.Unallocated != nil {
+		in, out := &in.Unallocated, &out.Unallocated
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginNameSlice.
-func (in PluginNameSlice) DeepCopy() PluginNameSlice {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovIBGUIDPoolStatus.
+func (in *SriovIBGUIDPoolStatus) DeepCopy() *SriovIBGUIDPoolStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PluginNameSlice)
+	out := new(SriovIBGUIDPoolStatus)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -458,7 +1144,7 @@ func (in *SriovIBNetwork) DeepCopyInto(out *SriovIBNetwork) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovIBNetwork.
@@ -529,6 +1215,7 @@ func (in *SriovIBNetworkSpec) DeepCopy() *SriovIBNetworkSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SriovIBNetworkStatus) DeepCopyInto(out *SriovIBNetworkStatus) {
 	*out = *in
+	in.NetworkStatus.DeepCopyInto(&out.NetworkStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovIBNetworkStatus.
@@ -547,7 +1234,7 @@ func (in *SriovNetwork) DeepCopyInto(out *SriovNetwork) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetwork.
@@ -631,7 +1318,7 @@ func (in *SriovNetworkNodePolicy) DeepCopyInto(out *SriovNetworkNodePolicy) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkNodePolicy.
@@ -695,7 +1382,39 @@ func (in *SriovNetworkNodePolicySpec) DeepCopyInto(out *SriovNetworkNodePolicySp
 		}
 	}
 	in.NicSelector.DeepCopyInto(&out.NicSelector)
+	if in.VdpaAttributes != nil {
+		in, out := &in.VdpaAttributes, &out.VdpaAttributes
+		*out = new(VdpaAttributes)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Bridge.DeepCopyInto(&out.Bridge)
+	if in.Bond != nil {
+		in, out := &in.Bond, &out.Bond
+		*out = new(BondConfig)
+		**out = **in
+	}
+	if in.VfAttributes != nil {
+		in, out := &in.VfAttributes, &out.VfAttributes
+		*out = new(VfAttributes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PKeys != nil {
+		in, out := &in.PKeys, &out.PKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PfSettings != nil {
+		in, out := &in.PfSettings, &out.PfSettings
+		*out = new(PfSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DevlinkParams != nil {
+		in, out := &in.DevlinkParams, &out.DevlinkParams
+		*out = make(map[string]DevlinkParam, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkNodePolicySpec.
@@ -711,6 +1430,20 @@ func (in *SriovNetworkNodePolicySpec) DeepCopy() *SriovNetworkNodePolicySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SriovNetworkNodePolicyStatus) DeepCopyInto(out *SriovNetworkNodePolicyStatus) {
 	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]PolicyNodeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkNodePolicyStatus.
@@ -793,6 +1526,13 @@ func (in *SriovNetworkNodeStateSpec) DeepCopyInto(out *SriovNetworkNodeStateSpec
 		}
 	}
 	in.Bridges.DeepCopyInto(&out.Bridges)
+	if in.Bonds != nil {
+		in, out := &in.Bonds, &out.Bonds
+		*out = make(Bonds, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.System = in.System
 }
 
@@ -817,7 +1557,46 @@ func (in *SriovNetworkNodeStateStatus) DeepCopyInto(out *SriovNetworkNodeStateSt
 		}
 	}
 	in.Bridges.DeepCopyInto(&out.Bridges)
+	if in.Bonds != nil {
+		in, out := &in.Bonds, &out.Bonds
+		*out = make([]BondStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.System = in.System
+	if in.ExternalPlugins != nil {
+		in, out := &in.ExternalPlugins, &out.ExternalPlugins
+		*out = make([]ExternalPluginStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(NodeStatePlan)
+		**out = **in
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(NodeStateRollback)
+		**out = **in
+	}
+	if in.ApplyFailures != nil {
+		in, out := &in.ApplyFailures, &out.ApplyFailures
+		*out = new(NodeStateApplyFailures)
+		**out = **in
+	}
+	if in.KernelArgs != nil {
+		in, out := &in.KernelArgs, &out.KernelArgs
+		*out = new(KernelArgsStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkNodeStateStatus.
@@ -836,7 +1615,7 @@ func (in *SriovNetworkPoolConfig) DeepCopyInto(out *SriovNetworkPoolConfig) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkPoolConfig.
@@ -903,6 +1682,18 @@ func (in *SriovNetworkPoolConfigSpec) DeepCopyInto(out *SriovNetworkPoolConfigSp
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(RolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkPoolConfigSpec.
@@ -918,6 +1709,11 @@ func (in *SriovNetworkPoolConfigSpec) DeepCopy() *SriovNetworkPoolConfigSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SriovNetworkPoolConfigStatus) DeepCopyInto(out *SriovNetworkPoolConfigStatus) {
 	*out = *in
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(PoolRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkPoolConfigStatus.
@@ -958,6 +1754,7 @@ func (in *SriovNetworkSpec) DeepCopy() *SriovNetworkSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SriovNetworkStatus) DeepCopyInto(out *SriovNetworkStatus) {
 	*out = *in
+	in.NetworkStatus.DeepCopyInto(&out.NetworkStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkStatus.
@@ -1058,6 +1855,18 @@ func (in *SriovOperatorConfigSpec) DeepCopyInto(out *SriovOperatorConfigSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.AutoRollback != nil {
+		in, out := &in.AutoRollback, &out.AutoRollback
+		*out = new(AutoRollbackConfig)
+		**out = **in
+	}
+	if in.ExternalPlugins != nil {
+		in, out := &in.ExternalPlugins, &out.ExternalPlugins
+		*out = make([]ExternalPlugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovOperatorConfigSpec.
@@ -1130,9 +1939,79 @@ func (in *TrunkConfig) DeepCopy() *TrunkConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VdpaAttributes) DeepCopyInto(out *VdpaAttributes) {
+	*out = *in
+	if in.Mtu != nil {
+		in, out := &in.Mtu, &out.Mtu
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxVqp != nil {
+		in, out := &in.MaxVqp, &out.MaxVqp
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VdpaAttributes.
+func (in *VdpaAttributes) DeepCopy() *VdpaAttributes {
+	if in == nil {
+		return nil
+	}
+	out := new(VdpaAttributes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VfAttributes) DeepCopyInto(out *VfAttributes) {
+	*out = *in
+	if in.Vlan != nil {
+		in, out := &in.Vlan, &out.Vlan
+		*out = new(int)
+		**out = **in
+	}
+	if in.MinTxRate != nil {
+		in, out := &in.MinTxRate, &out.MinTxRate
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxTxRate != nil {
+		in, out := &in.MaxTxRate, &out.MaxTxRate
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VfAttributes.
+func (in *VfAttributes) DeepCopy() *VfAttributes {
+	if in == nil {
+		return nil
+	}
+	out := new(VfAttributes)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VfGroup) DeepCopyInto(out *VfGroup) {
 	*out = *in
+	if in.VfAttributes != nil {
+		in, out := &in.VfAttributes, &out.VfAttributes
+		*out = new(VfAttributes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VdpaAttributes != nil {
+		in, out := &in.VdpaAttributes, &out.VdpaAttributes
+		*out = new(VdpaAttributes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PKeys != nil {
+		in, out := &in.PKeys, &out.PKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VfGroup.
@@ -1148,6 +2027,21 @@ func (in *VfGroup) DeepCopy() *VfGroup {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualFunction) DeepCopyInto(out *VirtualFunction) {
 	*out = *in
+	if in.PKeys != nil {
+		in, out := &in.PKeys, &out.PKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IommuGroup != nil {
+		in, out := &in.IommuGroup, &out.IommuGroup
+		*out = new(int)
+		**out = **in
+	}
+	if in.IommuGroupDevices != nil {
+		in, out := &in.IommuGroupDevices, &out.IommuGroupDevices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualFunction.
@@ -46,6 +46,7 @@ import (
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper"
 	snolog "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/log"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/platform"
+	externalplugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins/external"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
@@ -88,6 +89,7 @@ var (
 		parallelNicConfig     bool
 		manageSoftwareBridges bool
 		ovsSocketPath         string
+		metricsBindAddress    string
 	}
 
 	scheme = runtime.NewScheme()
@@ -102,6 +104,8 @@ func init() {
 	startCmd.PersistentFlags().BoolVar(&startOpts.parallelNicConfig, "parallel-nic-config", false, "perform NIC configuration in parallel")
 	startCmd.PersistentFlags().BoolVar(&startOpts.manageSoftwareBridges, "manage-software-bridges", false, "enable management of software bridges")
 	startCmd.PersistentFlags().StringVar(&startOpts.ovsSocketPath, "ovs-socket-path", vars.OVSDBSocketPath, "path for OVSDB socket")
+	startCmd.PersistentFlags().StringVar(&startOpts.metricsBindAddress, "metrics-bind-address", "0",
+		"the address the metrics endpoint binds to, disabled by default as the daemon runs with hostNetwork")
 
 	// Init Scheme
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
@@ -292,6 +296,9 @@ func runStartCmd(cmd *cobra.Command, args []string) error {
 	// init disable drain
 	vars.DisableDrain = operatorConfig.Spec.DisableDrain
 
+	// init external plugins, they are loaded with the vendor plugins
+	externalplugin.SetPlugins(operatorConfig.Spec.ExternalPlugins)
+
 	// Init manager
 	setupLog.V(0).Info("Starting SR-IOV Network Config Daemon")
 	nodeStateSelector, err := fields.ParseSelector(fmt.Sprintf("metadata.name=%s,metadata.namespace=%s", vars.NodeName, vars.Namespace))
@@ -307,7 +314,7 @@ func runStartCmd(cmd *cobra.Command, args []string) error {
 
 	mgr, err := ctrl.NewManager(vars.Config, ctrl.Options{
 		Scheme:  vars.Scheme,
-		Metrics: server.Options{BindAddress: "0"}, // disable metrics server for now as the daemon runs with hostNetwork
+		Metrics: server.Options{BindAddress: startOpts.metricsBindAddress},
 		Cache: cache.Options{ // cache only the SriovNetworkNodeState with the node name
 			ByObject: map[runtimeclient.Object]cache.ByObject{
 				&sriovnetworkv1.SriovNetworkNodeState{}: {Field: nodeStateSelector},
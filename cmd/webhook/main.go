@@ -19,6 +19,8 @@ package main
 import (
 	"flag"
 	"os"
+	// embed the time zone database used to validate the pool maintenance windows
+	_ "time/tzdata"
 
 	"github.com/spf13/cobra"
 	"sigs.k8s.io/controller-runtime/pkg/log"
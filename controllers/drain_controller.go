@@ -52,6 +52,7 @@ type DrainReconcile struct {
 	drainer  drain.DrainInterface
 
 	drainCheckMutex sync.Mutex
+	metrics         *drainStateMetrics
 }
 
 func NewDrainReconcileController(client client.Client, Scheme *runtime.Scheme, recorder events.EventRecorder, orchestrator orchestrator.Interface) (*DrainReconcile, error) {
@@ -65,7 +66,8 @@ func NewDrainReconcileController(client client.Client, Scheme *runtime.Scheme, r
 		Scheme,
 		recorder,
 		drainer,
-		sync.Mutex{}}, nil
+		sync.Mutex{},
+		newDrainStateMetrics()}, nil
 }
 
 //+kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch;update;patch
@@ -91,6 +93,7 @@ func (dr *DrainReconcile) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	}
 	if !found {
 		reqLogger.Info("node not found don't, requeue the request")
+		dr.metrics.remove(req.Name)
 		return ctrl.Result{}, nil
 	}
 
@@ -103,6 +106,7 @@ func (dr *DrainReconcile) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	}
 	if !found {
 		reqLogger.Info("sriovNetworkNodeState not found, don't requeue the request")
+		dr.metrics.remove(req.Name)
 		return ctrl.Result{}, nil
 	}
 
@@ -137,6 +141,7 @@ func (dr *DrainReconcile) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return ctrl.Result{Requeue: true}, nil
 	}
 	reqLogger.V(2).Info("Drain annotations", "nodeAnnotation", nodeDrainAnnotation, "nodeStateAnnotation", nodeStateDrainAnnotationCurrent)
+	dr.recordDrainState(ctx, node, nodeDrainAnnotation, nodeStateDrainAnnotationCurrent)
 
 	// Check the node request
 	if nodeDrainAnnotation == constants.DrainIdle {
@@ -146,6 +151,12 @@ func (dr *DrainReconcile) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		// we don't do anything
 		if nodeStateDrainAnnotationCurrent == constants.DrainIdle {
 			reqLogger.Info("node and nodeState are on idle nothing todo")
+			// the node doesn't wait for a maintenance window anymore
+			err = utils.RemoveAnnotationFromObject(ctx, nodeNetworkState, constants.NodeStateMaintenanceWindowAnnotation, dr.Client)
+			if err != nil {
+				reqLogger.Error(err, "failed to remove annotation", "annotation", constants.NodeStateMaintenanceWindowAnnotation)
+				return ctrl.Result{}, err
+			}
 			return reconcile.Result{}, nil
 		}
 
@@ -171,6 +182,20 @@ func (dr *DrainReconcile) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	return reconcile.Result{}, fmt.Errorf("unexpected node drain annotation")
 }
 
+// recordDrainState reports the drain state of the node in the metrics of its pool.
+// Nodes waiting for the drain to start are reported with the requested state.
+func (dr *DrainReconcile) recordDrainState(ctx context.Context, node *corev1.Node, requested, current string) {
+	pool, _, err := dr.findNodePoolConfig(ctx, node)
+	if err != nil {
+		return
+	}
+	state := current
+	if current == constants.DrainIdle && requested != constants.DrainIdle {
+		state = requested
+	}
+	dr.metrics.set(node.Name, pool.Name, state)
+}
+
 func (dr *DrainReconcile) getObject(ctx context.Context, req ctrl.Request, object client.Object) (bool, error) {
 	err := dr.Get(ctx, req.NamespacedName, object)
 	if err != nil {
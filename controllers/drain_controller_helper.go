@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -173,6 +174,17 @@ func (dr *DrainReconcile) tryDrainNode(ctx context.Context, node *corev1.Node) (
 		return nil, err
 	}
 
+	// wait for the pool maintenance window before starting the drain
+	inWindow, nextWindow, err := nodePool.InMaintenanceWindow(time.Now())
+	if err != nil {
+		reqLogger.Error(err, "failed to check the pool maintenance windows")
+		return nil, err
+	}
+	if !inWindow {
+		reqLogger.Info("node is outside of the pool maintenance windows, waiting for the next window", "nextWindow", nextWindow)
+		return dr.waitForMaintenanceWindow(ctx, node, nextWindow)
+	}
+
 	// check how many nodes we can drain in parallel for the specific pool
 	maxUnv, err := nodePool.MaxUnavailable(len(nodeList))
 	if err != nil {
@@ -226,9 +238,46 @@ func (dr *DrainReconcile) tryDrainNode(ctx context.Context, node *corev1.Node) (
 		return nil, err
 	}
 
+	err = utils.RemoveAnnotationFromObject(ctx, currentSnns, constants.NodeStateMaintenanceWindowAnnotation, dr.Client)
+	if err != nil {
+		reqLogger.Error(err, "failed to remove annotation", "annotation", constants.NodeStateMaintenanceWindowAnnotation)
+		return nil, err
+	}
+
 	return nil, nil
 }
 
+// waitForMaintenanceWindow reports the start of the next maintenance window on the node state
+// and requeues the request until the window starts
+func (dr *DrainReconcile) waitForMaintenanceWindow(ctx context.Context, node *corev1.Node, nextWindow time.Time) (*reconcile.Result, error) {
+	reqLogger := ctx.Value(constants.LoggerContextKey).(logr.Logger).WithName("waitForMaintenanceWindow")
+	snns := &sriovnetworkv1.SriovNetworkNodeState{}
+	err := dr.Get(ctx, client.ObjectKey{Name: node.GetName(), Namespace: vars.Namespace}, snns)
+	if err != nil {
+		return nil, err
+	}
+
+	next := nextWindow.UTC().Format(time.RFC3339)
+	if !utils.ObjectHasAnnotation(snns, constants.NodeStateMaintenanceWindowAnnotation, next) {
+		err = utils.AnnotateObject(ctx, snns, constants.NodeStateMaintenanceWindowAnnotation, next, dr.Client)
+		if err != nil {
+			reqLogger.Error(err, "failed to annotate node state with annotation", "annotation", constants.NodeStateMaintenanceWindowAnnotation)
+			return nil, err
+		}
+		dr.recorder.Eventf(snns, nil,
+			corev1.EventTypeNormal,
+			"DrainController",
+			"WaitForMaintenanceWindow",
+			"node drain postponed to the maintenance window starting at %s", next)
+	}
+
+	requeueAfter := time.Until(nextWindow)
+	if requeueAfter > constants.MaintenanceWindowRequeueTime {
+		requeueAfter = constants.MaintenanceWindowRequeueTime
+	}
+	return &reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
 func (dr *DrainReconcile) findNodePoolConfig(ctx context.Context, node *corev1.Node) (*sriovnetworkv1.SriovNetworkPoolConfig, []corev1.Node, error) {
 	logger := ctx.Value(constants.LoggerContextKey).(logr.Logger).WithName("findNodePoolConfig")
 	// get all the sriov network pool configs
@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	dto "github.com/prometheus/client_model/go"
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -328,6 +329,37 @@ var _ = Describe("Drain Controller", Ordered, func() {
 			expectNodeIsNotSchedulable(node3)
 		})
 
+		It("should finish the drains requested before the pool was paused", func(ctx context.Context) {
+			node1, nodeState1 := createNode(ctx, "node1", nil)
+			node2, nodeState2 := createNode(ctx, "node2", nil)
+
+			maxun := intstr.Parse("1")
+			poolConfig := &sriovnetworkv1.SriovNetworkPoolConfig{}
+			poolConfig.SetNamespace(testNamespace)
+			poolConfig.SetName("test-workers")
+			poolConfig.Spec = sriovnetworkv1.SriovNetworkPoolConfigSpec{MaxUnavailable: &maxun, NodeSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"test": "",
+				},
+			}}
+			Expect(k8sClient.Create(context.TODO(), poolConfig)).Should(Succeed())
+
+			simulateDaemonSetAnnotation(node1, constants.DrainRequired)
+			simulateDaemonSetAnnotation(node2, constants.DrainRequired)
+			expectNumberOfDrainingNodes(1, nodeState1, nodeState2)
+
+			poolConfig.Spec.Paused = true
+			Expect(k8sClient.Update(context.TODO(), poolConfig)).Should(Succeed())
+
+			// the node waiting for the other one to complete its drain is drained in the paused pool
+			drained, waiting := node1, nodeState2
+			if utils.ObjectHasAnnotation(nodeState2, constants.NodeStateDrainAnnotationCurrent, constants.DrainComplete) {
+				drained, waiting = node2, nodeState1
+			}
+			simulateDaemonSetAnnotation(drained, constants.DrainIdle)
+			expectNodeStateAnnotation(waiting, constants.DrainComplete)
+		})
+
 		It("should drain in parallel nodes from two different pools, one custom and one default", func() {
 			node1, nodeState1 := createNode(ctx, "node1", nil)
 			node2, nodeState2 := createNodeWithLabel(ctx, "node2", "pool")
@@ -533,3 +565,34 @@ func createPodOnNode(ctx context.Context, podName, nodeName string) {
 			NodeName: nodeName, TerminationGracePeriodSeconds: ptr.To[int64](60)}}
 	Expect(k8sClient.Create(ctx, &pod)).ToNot(HaveOccurred())
 }
+
+var _ = Describe("drainStateMetrics", func() {
+	drainNodesValue := func(pool, state string) float64 {
+		metric := &dto.Metric{}
+		Expect(drainNodes.WithLabelValues(pool, state).Write(metric)).To(Succeed())
+		return metric.GetGauge().GetValue()
+	}
+
+	It("should count the nodes in each drain state per pool", func() {
+		m := newDrainStateMetrics()
+		defer func() {
+			m.remove("node1")
+			m.remove("node2")
+		}()
+
+		m.set("node1", "pool1", constants.DrainIdle)
+		m.set("node2", "pool1", constants.DrainIdle)
+		Expect(drainNodesValue("pool1", constants.DrainIdle)).To(Equal(2.0))
+
+		m.set("node1", "pool1", constants.Draining)
+		m.set("node1", "pool1", constants.Draining)
+		Expect(drainNodesValue("pool1", constants.DrainIdle)).To(Equal(1.0))
+		Expect(drainNodesValue("pool1", constants.Draining)).To(Equal(1.0))
+
+		m.set("node2", "pool2", constants.DrainIdle)
+		m.remove("node1")
+		Expect(drainNodesValue("pool1", constants.DrainIdle)).To(Equal(0.0))
+		Expect(drainNodesValue("pool1", constants.Draining)).To(Equal(0.0))
+		Expect(drainNodesValue("pool2", constants.DrainIdle)).To(Equal(1.0))
+	})
+})
@@ -0,0 +1,77 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var drainNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "sriov_operator_drain_nodes",
+	Help: "Number of nodes in each drain state per SriovNetworkPoolConfig",
+}, []string{"pool", "state"})
+
+func init() {
+	metrics.Registry.MustRegister(drainNodes)
+}
+
+type nodeDrainState struct {
+	pool  string
+	state string
+}
+
+// drainStateMetrics keeps the drain state of the nodes reconciled by the drain controller
+// to report the number of nodes in each state per pool
+type drainStateMetrics struct {
+	mu    sync.Mutex
+	nodes map[string]nodeDrainState
+}
+
+func newDrainStateMetrics() *drainStateMetrics {
+	return &drainStateMetrics{nodes: map[string]nodeDrainState{}}
+}
+
+// set records the pool and the drain state of the node
+func (m *drainStateMetrics) set(nodeName, pool, state string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	current := nodeDrainState{pool: pool, state: state}
+	previous, ok := m.nodes[nodeName]
+	if ok && previous == current {
+		return
+	}
+	if ok {
+		drainNodes.WithLabelValues(previous.pool, previous.state).Dec()
+	}
+	drainNodes.WithLabelValues(current.pool, current.state).Inc()
+	m.nodes[nodeName] = current
+}
+
+// remove stops reporting the node, used when the node or its SriovNetworkNodeState is deleted
+func (m *drainStateMetrics) remove(nodeName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	previous, ok := m.nodes[nodeName]
+	if !ok {
+		return
+	}
+	drainNodes.WithLabelValues(previous.pool, previous.state).Dec()
+	delete(m.nodes, nodeName)
+}
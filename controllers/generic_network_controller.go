@@ -24,17 +24,20 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
@@ -49,6 +52,10 @@ type NetworkCRInstance interface {
 	RenderNetAttDef() (*uns.Unstructured, error)
 	// return name of the target namespace for the network
 	NetworkNamespace() string
+	// return the device plugin resource name requested by the network
+	NetworkResourceName() string
+	// return the status of the network
+	GetNetworkStatus() *sriovnetworkv1.NetworkStatus
 }
 
 // interface which controller should implement to be compatible with genericNetworkReconciler
@@ -109,7 +116,11 @@ func (r *genericNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			".metadata.namespace", instance.GetNamespace(),
 			".spec.networkNamespace", instance.NetworkNamespace(),
 		)
-		return reconcile.Result{}, nil
+		return reconcile.Result{}, r.updateStatus(ctx, instance, metav1.Condition{
+			Status:  metav1.ConditionFalse,
+			Reason:  sriovnetworkv1.NetworkReasonInvalidNamespace,
+			Message: ".spec.networkNamespace can't be specified if the resource belongs to a namespace other than the operator's",
+		})
 	}
 
 	// examine DeletionTimestamp to determine if object is under deletion
@@ -126,20 +137,43 @@ func (r *genericNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		err = r.cleanResourcesAndFinalizers(ctx, instance)
 		return reconcile.Result{}, err
 	}
+
+	readyCondition, err := r.syncNetAttDef(ctx, instance)
+	if err != nil {
+		readyCondition = metav1.Condition{
+			Status:  metav1.ConditionFalse,
+			Reason:  sriovnetworkv1.NetworkReasonSyncFailed,
+			Message: err.Error(),
+		}
+	}
+	if statusErr := r.updateStatus(ctx, instance, readyCondition); statusErr != nil {
+		reqLogger.Error(statusErr, "Couldn't update network status")
+		if err == nil {
+			err = statusErr
+		}
+	}
+	return ctrl.Result{}, err
+}
+
+// syncNetAttDef renders the NetworkAttachmentDefinition for the network and creates or updates it in the target namespace.
+// It returns the Ready condition to report in the network status.
+func (r *genericNetworkReconciler) syncNetAttDef(ctx context.Context, instance NetworkCRInstance) (metav1.Condition, error) {
+	reqLogger := log.FromContext(ctx).WithValues(r.controller.Name(), client.ObjectKeyFromObject(instance))
+
 	raw, err := instance.RenderNetAttDef()
 	if err != nil {
-		return reconcile.Result{}, err
+		return metav1.Condition{}, err
 	}
 	netAttDef := &netattdefv1.NetworkAttachmentDefinition{}
 	err = r.Scheme.Convert(raw, netAttDef, nil)
 	if err != nil {
-		return reconcile.Result{}, err
+		return metav1.Condition{}, err
 	}
 	// format CNI config json in CR for easier readability
 	netAttDef.Spec.Config, err = formatJSON(netAttDef.Spec.Config)
 	if err != nil {
 		reqLogger.Error(err, "Couldn't process rendered NetworkAttachmentDefinition config", "Namespace", netAttDef.Namespace, "Name", netAttDef.Name)
-		return reconcile.Result{}, err
+		return metav1.Condition{}, err
 	}
 	if lnns, ok := instance.GetAnnotations()[sriovnetworkv1.LASTNETWORKNAMESPACE]; ok && netAttDef.GetNamespace() != lnns {
 		err = r.Delete(ctx, &netattdefv1.NetworkAttachmentDefinition{
@@ -150,14 +184,14 @@ func (r *genericNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		})
 		if err != nil {
 			reqLogger.Error(err, "Couldn't delete NetworkAttachmentDefinition CR", "Namespace", instance.GetName(), "Name", lnns)
-			return reconcile.Result{}, err
+			return metav1.Condition{}, err
 		}
 	}
 
 	if instance.GetNamespace() == netAttDef.Namespace {
 		// If the NetAttachDef is in the same namespace of the resource, then we can leverage the OwnerReference field for garbage collector
 		if err := controllerutil.SetOwnerReference(instance, netAttDef, r.Scheme); err != nil {
-			return reconcile.Result{}, err
+			return metav1.Condition{}, err
 		}
 	}
 
@@ -170,23 +204,27 @@ func (r *genericNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			err = r.Get(ctx, types.NamespacedName{Name: netAttDef.Namespace}, targetNamespace)
 			if errors.IsNotFound(err) {
 				reqLogger.Info("Target namespace doesn't exist, NetworkAttachmentDefinition will be created when namespace is available", "Namespace", netAttDef.Namespace, "Name", netAttDef.Name)
-				return reconcile.Result{}, nil
+				return metav1.Condition{
+					Status:  metav1.ConditionFalse,
+					Reason:  sriovnetworkv1.NetworkReasonNamespaceNotFound,
+					Message: fmt.Sprintf("target namespace %s doesn't exist", netAttDef.Namespace),
+				}, nil
 			}
 
 			reqLogger.Info("NetworkAttachmentDefinition CR not exist, creating")
 			err = r.Create(ctx, netAttDef)
 			if err != nil {
 				reqLogger.Error(err, "Couldn't create NetworkAttachmentDefinition CR", "Namespace", netAttDef.Namespace, "Name", netAttDef.Name)
-				return reconcile.Result{}, err
+				return metav1.Condition{}, err
 			}
 
 			err = utils.AnnotateObject(ctx, instance, sriovnetworkv1.LASTNETWORKNAMESPACE, netAttDef.Namespace, r.Client)
 			if err != nil {
-				return reconcile.Result{}, err
+				return metav1.Condition{}, err
 			}
 		} else {
 			reqLogger.Error(err, "Couldn't get NetworkAttachmentDefinition CR", "Namespace", netAttDef.Namespace, "Name", netAttDef.Name)
-			return reconcile.Result{}, err
+			return metav1.Condition{}, err
 		}
 	} else {
 		reqLogger.Info("NetworkAttachmentDefinition CR already exist")
@@ -201,7 +239,11 @@ func (r *genericNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 				"Namespace", netAttDef.Namespace, "Name", netAttDef.Name,
 				"CurrentOwner", foundOwner, "ExpectedOwner", expectedOwner,
 			)
-			return reconcile.Result{}, nil
+			return metav1.Condition{
+				Status:  metav1.ConditionFalse,
+				Reason:  sriovnetworkv1.NetworkReasonNetAttachDefConflict,
+				Message: fmt.Sprintf("NetworkAttachmentDefinition %s/%s belongs to %s", netAttDef.Namespace, netAttDef.Name, foundOwner),
+			}, nil
 		}
 
 		if !equality.Semantic.DeepEqual(found.Spec, netAttDef.Spec) || !equality.Semantic.DeepEqual(found.GetAnnotations(), netAttDef.GetAnnotations()) {
@@ -211,12 +253,89 @@ func (r *genericNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			err = r.Update(ctx, netAttDef)
 			if err != nil {
 				reqLogger.Error(err, "Couldn't update NetworkAttachmentDefinition CR", "Namespace", netAttDef.Namespace, "Name", netAttDef.Name)
-				return reconcile.Result{}, err
+				return metav1.Condition{}, err
 			}
 		}
 	}
 
-	return ctrl.Result{}, nil
+	return metav1.Condition{
+		Status:  metav1.ConditionTrue,
+		Reason:  sriovnetworkv1.NetworkReasonNetAttachDefSynced,
+		Message: fmt.Sprintf("NetworkAttachmentDefinition %s/%s is up to date", netAttDef.Namespace, netAttDef.Name),
+	}, nil
+}
+
+// updateStatus reports the NetworkAttachmentDefinition of the network, whether the requested resource is advertised
+// on at least one node and the Ready condition. The network can't be ready if the resource is not advertised.
+func (r *genericNetworkReconciler) updateStatus(ctx context.Context, instance NetworkCRInstance, readyCondition metav1.Condition) error {
+	advertised, err := r.isResourceAdvertised(ctx, instance.NetworkResourceName())
+	if err != nil {
+		return err
+	}
+
+	original := instance.DeepCopyObject().(NetworkCRInstance)
+	status := instance.GetNetworkStatus()
+	status.TargetNamespace = instance.NetworkNamespace()
+	if status.TargetNamespace == "" {
+		status.TargetNamespace = instance.GetNamespace()
+	}
+	status.NetAttachDefName = ""
+	if readyCondition.Status == metav1.ConditionTrue {
+		status.NetAttachDefName = instance.GetName()
+	}
+	status.ResourceAdvertised = advertised
+	if readyCondition.Status == metav1.ConditionTrue && !advertised {
+		readyCondition.Status = metav1.ConditionFalse
+		readyCondition.Reason = sriovnetworkv1.NetworkReasonResourceNotAdvertised
+		readyCondition.Message = fmt.Sprintf("resource %s is not advertised on any node", instance.NetworkResourceName())
+	}
+	readyCondition.Type = sriovnetworkv1.ConditionReady
+	readyCondition.ObservedGeneration = instance.GetGeneration()
+	meta.SetStatusCondition(&status.Conditions, readyCondition)
+
+	if equality.Semantic.DeepEqual(original.GetNetworkStatus(), status) {
+		return nil
+	}
+	err = r.Status().Patch(ctx, instance, client.MergeFrom(original))
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// isResourceAdvertised returns true if the resource is rendered in the spec of at least one SriovNetworkNodeState,
+// a policy that doesn't select any PF doesn't advertise its resource. The VFs of the resource are found by the
+// resourceName of their group, the SFs by the PFs selected by the SriovNetworkNodePolicies of the resource.
+func (r *genericNetworkReconciler) isResourceAdvertised(ctx context.Context, resourceName string) (bool, error) {
+	policyList := &sriovnetworkv1.SriovNetworkNodePolicyList{}
+	if err := r.List(ctx, policyList, client.InNamespace(vars.Namespace)); err != nil {
+		return false, fmt.Errorf("failed to list SriovNetworkNodePolicies: %w", err)
+	}
+	nodeStateList := &sriovnetworkv1.SriovNetworkNodeStateList{}
+	if err := r.List(ctx, nodeStateList, client.InNamespace(vars.Namespace)); err != nil {
+		return false, fmt.Errorf("failed to list SriovNetworkNodeStates: %w", err)
+	}
+	for i := range nodeStateList.Items {
+		ns := &nodeStateList.Items[i]
+		for _, iface := range ns.Spec.Interfaces {
+			for _, group := range iface.VfGroups {
+				if group.ResourceName == resourceName {
+					return true, nil
+				}
+			}
+			ifaceStatus := ns.GetInterfaceStateByPciAddress(iface.PciAddress)
+			if iface.NumSfs == 0 || ifaceStatus == nil {
+				continue
+			}
+			for _, p := range policyList.Items {
+				if p.Spec.ResourceName == resourceName && p.Spec.NumSfs > 0 && !p.IsDryRun() &&
+					p.Spec.NicSelector.Selected(ifaceStatus) {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -229,6 +348,10 @@ func (r *genericNetworkReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(r.controller.GetObject()).
 		Watches(&netattdefv1.NetworkAttachmentDefinition{}, handler.EnqueueRequestsFromMapFunc(r.handleNetAttDef)).
 		Watches(&corev1.Namespace{}, &namespaceHandler).
+		Watches(&sriovnetworkv1.SriovNetworkNodePolicy{}, handler.EnqueueRequestsFromMapFunc(r.handlePolicy)).
+		// the spec of the node states changes when the rendered policies change, not on the status updates
+		Watches(&sriovnetworkv1.SriovNetworkNodeState{}, handler.EnqueueRequestsFromMapFunc(r.handleNodeState),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Complete(r.controller)
 }
 
@@ -258,6 +381,57 @@ func (r *genericNetworkReconciler) handleNetAttDef(ctx context.Context, obj clie
 	return ret
 }
 
+// handlePolicy reconciles the networks requesting the resource of the policy to refresh their status
+func (r *genericNetworkReconciler) handlePolicy(ctx context.Context, obj client.Object) []reconcile.Request {
+	ret := []reconcile.Request{}
+	policy, ok := obj.(*sriovnetworkv1.SriovNetworkNodePolicy)
+	if !ok {
+		return ret
+	}
+	networkList := r.controller.GetObjectList()
+	if err := r.List(ctx, networkList); err != nil {
+		log.Log.WithName(r.controller.Name()+" handlePolicy").Error(err, "can't list networks")
+		return ret
+	}
+	networks, err := meta.ExtractList(networkList)
+	if err != nil {
+		log.Log.WithName(r.controller.Name()+" handlePolicy").Error(err, "can't extract networks from list")
+		return ret
+	}
+	for _, o := range networks {
+		network, ok := o.(NetworkCRInstance)
+		if !ok || network.NetworkResourceName() != policy.Spec.ResourceName {
+			continue
+		}
+		ret = append(ret, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(network)})
+	}
+	return ret
+}
+
+// handleNodeState reconciles all the networks when the spec of a node state changes, the resources
+// advertised on the node may have changed
+func (r *genericNetworkReconciler) handleNodeState(ctx context.Context, obj client.Object) []reconcile.Request {
+	ret := []reconcile.Request{}
+	networkList := r.controller.GetObjectList()
+	if err := r.List(ctx, networkList); err != nil {
+		log.Log.WithName(r.controller.Name()+" handleNodeState").Error(err, "can't list networks")
+		return ret
+	}
+	networks, err := meta.ExtractList(networkList)
+	if err != nil {
+		log.Log.WithName(r.controller.Name()+" handleNodeState").Error(err, "can't extract networks from list")
+		return ret
+	}
+	for _, o := range networks {
+		network, ok := o.(NetworkCRInstance)
+		if !ok {
+			continue
+		}
+		ret = append(ret, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(network)})
+	}
+	return ret
+}
+
 func (r *genericNetworkReconciler) namespaceHandlerCreate(ctx context.Context, e event.TypedCreateEvent[client.Object], w workqueue.TypedRateLimitingInterface[reconcile.Request]) {
 	networkList := r.controller.GetObjectList()
 	err := r.List(ctx,
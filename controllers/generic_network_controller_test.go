@@ -6,6 +6,7 @@ import (
 	"time"
 
 	netattdefv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -145,6 +146,14 @@ var _ = Describe("All Network Controllers", Ordered, func() {
 					g.Expect(netAttDef.Spec.Config).To(ContainSubstring(`"sriov"`))
 					g.Expect(netAttDef.Spec.Config).ToNot(ContainSubstring(`"ib-sriov"`))
 				}).WithPolling(30 * time.Millisecond).WithTimeout(300 * time.Millisecond).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&cr2), &cr2)).To(Succeed())
+					ready := meta.FindStatusCondition(cr2.Status.Conditions, sriovnetworkv1.ConditionReady)
+					g.Expect(ready).ToNot(BeNil())
+					g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+					g.Expect(ready.Reason).To(Equal(sriovnetworkv1.NetworkReasonNetAttachDefConflict))
+				}, util.APITimeout, util.RetryInterval).Should(Succeed())
 			})
 
 			It("when using the same network type with the same name, in different namespaces", func() {
@@ -182,6 +191,65 @@ var _ = Describe("All Network Controllers", Ordered, func() {
 		})
 	})
 
+	Context("status", func() {
+		AfterEach(func() {
+			cleanNetworksInNamespace(testNamespace)
+			cleanNetworksInNamespace("default")
+			Expect(k8sClient.DeleteAllOf(context.Background(), &sriovnetworkv1.SriovNetworkNodePolicy{}, client.InNamespace(testNamespace))).To(Succeed())
+			Expect(k8sClient.DeleteAllOf(context.Background(), &sriovnetworkv1.SriovNetworkNodeState{}, client.InNamespace(testNamespace))).To(Succeed())
+		})
+
+		It("reports the NetAttachDef and whether the resource is advertised", func() {
+			cr := sriovnetworkv1.SriovNetwork{
+				ObjectMeta: metav1.ObjectMeta{Name: "net-status", Namespace: testNamespace},
+				Spec:       sriovnetworkv1.SriovNetworkSpec{NetworkNamespace: "default", ResourceName: "resource_status"},
+			}
+			Expect(k8sClient.Create(ctx, &cr)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&cr), &cr)).To(Succeed())
+				g.Expect(cr.Status.NetAttachDefName).To(Equal("net-status"))
+				g.Expect(cr.Status.TargetNamespace).To(Equal("default"))
+				g.Expect(cr.Status.ResourceAdvertised).To(BeFalse())
+				ready := meta.FindStatusCondition(cr.Status.Conditions, sriovnetworkv1.ConditionReady)
+				g.Expect(ready).ToNot(BeNil())
+				g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(ready.Reason).To(Equal(sriovnetworkv1.NetworkReasonResourceNotAdvertised))
+			}, util.APITimeout, util.RetryInterval).Should(Succeed())
+
+			policy := &sriovnetworkv1.SriovNetworkNodePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy-status", Namespace: testNamespace},
+				Spec: sriovnetworkv1.SriovNetworkNodePolicySpec{
+					ResourceName: "resource_status",
+					NodeSelector: map[string]string{"feature.node.kubernetes.io/network-sriov.capable": "true"},
+					NumVfs:       1,
+					NicSelector:  sriovnetworkv1.SriovNetworkNicSelector{PfNames: []string{"ens0"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+			// the policy doesn't select any PF yet
+			Consistently(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&cr), &cr)).To(Succeed())
+				g.Expect(cr.Status.ResourceAdvertised).To(BeFalse())
+			}, "1s", util.RetryInterval).Should(Succeed())
+
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-status", Namespace: testNamespace},
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{PciAddress: "0000:86:00.0", Name: "ens0", NumVfs: 1,
+						VfGroups: []sriovnetworkv1.VfGroup{{ResourceName: "resource_status", PolicyName: "policy-status", VfRange: "0-0"}}}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, nodeState)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&cr), &cr)).To(Succeed())
+				g.Expect(cr.Status.ResourceAdvertised).To(BeTrue())
+				g.Expect(meta.IsStatusConditionTrue(cr.Status.Conditions, sriovnetworkv1.ConditionReady)).To(BeTrue())
+			}, util.APITimeout, util.RetryInterval).Should(Succeed())
+		})
+	})
 })
 
 func cleanNetworksInNamespace(namespace string) {
@@ -0,0 +1,289 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+)
+
+// guidRange is a range of GUIDs, both ends included
+type guidRange struct {
+	start uint64
+	end   uint64
+}
+
+func (g guidRange) size() uint64 {
+	return g.end - g.start + 1
+}
+
+// ibGUIDBlock is a block of GUIDs allocated to a PF from a pool
+type ibGUIDBlock struct {
+	guidRange
+	pool string
+}
+
+type ibGUIDPool struct {
+	pool        *sriovnetworkv1.SriovIBGUIDPool
+	selector    labels.Selector
+	ranges      []guidRange
+	unallocated []string
+}
+
+// ibGUIDAllocator allocates the blocks of GUIDs of the InfiniBand PFs from the SriovIBGUIDPools while the
+// SriovNetworkNodeStates are synced. The blocks recorded in the node states are kept as long as the PF is
+// allocated from the same pool and supports the same number of VFs, so the GUIDs of the VFs are stable.
+// A PF keeps the pool of its block while the pool selects its node, only the PFs without a block are allocated
+// from the first pool by name selecting the node, so adding a pool doesn't reallocate the configured PFs.
+type ibGUIDAllocator struct {
+	pools []*ibGUIDPool
+	// blocks allocated to the PFs by <node>/<pci address>
+	blocks map[string]ibGUIDBlock
+}
+
+func newIbGUIDAllocator(pools []sriovnetworkv1.SriovIBGUIDPool, states []sriovnetworkv1.SriovNetworkNodeState) *ibGUIDAllocator {
+	logger := log.Log.WithName("ibGUIDAllocator")
+	a := &ibGUIDAllocator{blocks: map[string]ibGUIDBlock{}}
+
+	sort.Slice(pools, func(i, j int) bool { return pools[i].Name < pools[j].Name })
+	for i := range pools {
+		p := &ibGUIDPool{pool: &pools[i], selector: labels.Everything()}
+		if pools[i].Spec.NodeSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(pools[i].Spec.NodeSelector)
+			if err != nil {
+				logger.Error(err, "invalid node selector, ignoring the pool", "pool", pools[i].Name)
+				continue
+			}
+			p.selector = selector
+		}
+		for _, r := range pools[i].Spec.Ranges {
+			gr, err := parseGUIDRange(r.Start, r.End)
+			if err != nil {
+				logger.Error(err, "invalid GUID range, ignoring it", "pool", pools[i].Name)
+				continue
+			}
+			p.ranges = append(p.ranges, gr)
+		}
+		a.pools = append(a.pools, p)
+	}
+
+	// the blocks already recorded in the node states are reserved, unless they don't belong to their pool anymore
+	for _, ns := range states {
+		for _, iface := range ns.Spec.Interfaces {
+			if iface.IbGUIDAllocation == nil {
+				continue
+			}
+			block, err := parseGUIDRange(iface.IbGUIDAllocation.Start, iface.IbGUIDAllocation.End)
+			if err != nil {
+				continue
+			}
+			pool := a.getPool(iface.IbGUIDAllocation.Pool)
+			if pool == nil || !slices.ContainsFunc(pool.ranges, func(r guidRange) bool {
+				return block.start >= r.start && block.end <= r.end
+			}) {
+				logger.Info("releasing GUID block out of its pool", "node", ns.Name, "pciAddress", iface.PciAddress,
+					"pool", iface.IbGUIDAllocation.Pool)
+				continue
+			}
+			a.blocks[ibGUIDBlockKey(ns.Name, iface.PciAddress)] = ibGUIDBlock{guidRange: block, pool: pool.pool.Name}
+		}
+	}
+	return a
+}
+
+// allocate records in the node state spec the blocks of GUIDs of the InfiniBand PFs of the node,
+// the blocks of the PFs that are not configured anymore are released
+func (a *ibGUIDAllocator) allocate(ns *sriovnetworkv1.SriovNetworkNodeState, node *corev1.Node) {
+	logger := log.Log.WithName("ibGUIDAllocator")
+	configured := map[string]bool{}
+	for _, iface := range ns.Spec.Interfaces {
+		configured[ibGUIDBlockKey(node.Name, iface.PciAddress)] = true
+	}
+	for key := range a.blocks {
+		if strings.HasPrefix(key, node.Name+"/") && !configured[key] {
+			delete(a.blocks, key)
+		}
+	}
+
+	nodePool := a.getNodePool(node)
+	for i := range ns.Spec.Interfaces {
+		iface := &ns.Spec.Interfaces[i]
+		key := ibGUIDBlockKey(node.Name, iface.PciAddress)
+		size := ibGUIDBlockSize(ns, iface)
+		block, exist := a.blocks[key]
+		pool := nodePool
+		if exist {
+			if blockPool := a.getPool(block.pool); blockPool != nil && blockPool.selector.Matches(labels.Set(node.Labels)) {
+				pool = blockPool
+			}
+		}
+		if pool == nil || size == 0 {
+			delete(a.blocks, key)
+			iface.IbGUIDAllocation = nil
+			continue
+		}
+		if !exist || block.pool != pool.pool.Name || block.size() != size {
+			delete(a.blocks, key)
+			start, ok := a.findFreeBlock(pool, size)
+			if !ok {
+				logger.Info("no free GUID block in the pool", "pool", pool.pool.Name, "node", node.Name,
+					"pciAddress", iface.PciAddress, "size", size)
+				pool.unallocated = append(pool.unallocated, key)
+				iface.IbGUIDAllocation = nil
+				continue
+			}
+			block = ibGUIDBlock{guidRange: guidRange{start: start, end: start + size - 1}, pool: pool.pool.Name}
+			a.blocks[key] = block
+			logger.Info("allocated GUID block", "pool", pool.pool.Name, "node", node.Name, "pciAddress", iface.PciAddress,
+				"start", sriovnetworkv1.FormatIbGUID(block.start), "end", sriovnetworkv1.FormatIbGUID(block.end))
+		}
+		iface.IbGUIDAllocation = &sriovnetworkv1.IbGUIDAllocation{
+			Pool:  block.pool,
+			Start: sriovnetworkv1.FormatIbGUID(block.start),
+			End:   sriovnetworkv1.FormatIbGUID(block.end),
+		}
+	}
+}
+
+// findFreeBlock returns the start of the first free block of the given size in the ranges of the pool.
+// The blocks of all the pools are considered so overlapping pools don't allocate the same GUIDs.
+func (a *ibGUIDAllocator) findFreeBlock(pool *ibGUIDPool, size uint64) (uint64, bool) {
+	used := make([]guidRange, 0, len(a.blocks))
+	for _, block := range a.blocks {
+		used = append(used, block.guidRange)
+	}
+	sort.Slice(used, func(i, j int) bool { return used[i].start < used[j].start })
+
+	for _, r := range pool.ranges {
+		start, free := r.start, true
+		for _, b := range used {
+			if b.end < start || b.start > start+size-1 {
+				continue
+			}
+			if b.end == math.MaxUint64 || b.end >= r.end {
+				free = false
+				break
+			}
+			start = b.end + 1
+		}
+		if free && r.end-start >= size-1 {
+			return start, true
+		}
+	}
+	return 0, false
+}
+
+func (a *ibGUIDAllocator) getPool(name string) *ibGUIDPool {
+	for _, p := range a.pools {
+		if p.pool.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// getNodePool returns the first pool by name selecting the node, the pool of the PFs without a block
+func (a *ibGUIDAllocator) getNodePool(node *corev1.Node) *ibGUIDPool {
+	for _, p := range a.pools {
+		if p.selector.Matches(labels.Set(node.Labels)) {
+			return p
+		}
+	}
+	return nil
+}
+
+// syncStatuses updates the status of the pools with their allocated GUIDs
+func (a *ibGUIDAllocator) syncStatuses(ctx context.Context, c client.Client) error {
+	for _, p := range a.pools {
+		status := sriovnetworkv1.SriovIBGUIDPoolStatus{}
+		for _, block := range a.blocks {
+			if block.pool == p.pool.Name {
+				status.Allocated += int(block.size())
+			}
+		}
+		if len(p.unallocated) > 0 {
+			status.Unallocated = slices.Sorted(slices.Values(p.unallocated))
+		}
+		if equality.Semantic.DeepEqual(status, p.pool.Status) {
+			continue
+		}
+		p.pool.Status = status
+		if err := c.Status().Update(ctx, p.pool); err != nil {
+			return fmt.Errorf("failed to update the status of the SriovIBGUIDPool %s: %v", p.pool.Name, err)
+		}
+	}
+	return nil
+}
+
+// ibGUIDBlockSize returns the number of GUIDs needed by the PF, one for each VF the PF supports.
+// The PFs that are not InfiniBand or have no VFs need none.
+func ibGUIDBlockSize(ns *sriovnetworkv1.SriovNetworkNodeState, iface *sriovnetworkv1.Interface) uint64 {
+	if iface.NumVfs == 0 {
+		return 0
+	}
+	linkType := iface.LinkType
+	size := iface.NumVfs
+	for _, ifaceStatus := range ns.Status.Interfaces {
+		if ifaceStatus.PciAddress != iface.PciAddress {
+			continue
+		}
+		if linkType == "" {
+			linkType = ifaceStatus.LinkType
+		}
+		if ifaceStatus.TotalVfs > size {
+			size = ifaceStatus.TotalVfs
+		}
+		break
+	}
+	if !strings.EqualFold(linkType, constants.LinkTypeIB) {
+		return 0
+	}
+	return uint64(size)
+}
+
+func ibGUIDBlockKey(nodeName, pciAddress string) string {
+	return nodeName + "/" + pciAddress
+}
+
+func parseGUIDRange(start, end string) (guidRange, error) {
+	s, err := sriovnetworkv1.ParseIbGUID(start)
+	if err != nil {
+		return guidRange{}, err
+	}
+	e, err := sriovnetworkv1.ParseIbGUID(end)
+	if err != nil {
+		return guidRange{}, err
+	}
+	if e < s {
+		return guidRange{}, fmt.Errorf("GUID range end %s is lower than its start %s", end, start)
+	}
+	return guidRange{start: s, end: e}, nil
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+// Phases of the canary rollout of a SriovNetworkPoolConfig
+const (
+	RolloutPhaseCanary    = "Canary"
+	RolloutPhaseSoaking   = "Soaking"
+	RolloutPhaseCompleted = "Completed"
+	RolloutPhaseStopped   = "Stopped"
+)
+
+// poolRollout tracks the canary rollout of a pool while the SriovNetworkNodeStates are synced.
+// The canary nodes are synced first, the rest of the pool is held until the canaries applied
+// their configuration and stayed synced for the soak time.
+type poolRollout struct {
+	pool     *sriovnetworkv1.SriovNetworkPoolConfig
+	canaries []string
+	// generations of the canary node states holding the configuration being rolled out,
+	// kept in the pool status across the reconciles
+	targetGenerations map[string]int64
+	// start of the soak time of the canaries synced with their target generation, kept in the pool status
+	soakStartTimes map[string]metav1.Time
+
+	evaluated    bool
+	status       sriovnetworkv1.PoolRolloutStatus
+	requeueAfter time.Duration
+}
+
+func newPoolRollout(pool *sriovnetworkv1.SriovNetworkPoolConfig, nodes []corev1.Node) (*poolRollout, error) {
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	canaries, err := pool.CanaryNodes(names)
+	if err != nil {
+		return nil, err
+	}
+	targetGenerations := map[string]int64{}
+	soakStartTimes := map[string]metav1.Time{}
+	if pool.Status.Rollout != nil {
+		for _, name := range canaries {
+			if generation, ok := pool.Status.Rollout.TargetGenerations[name]; ok {
+				targetGenerations[name] = generation
+			}
+			if start, ok := pool.Status.Rollout.SoakStartTimes[name]; ok {
+				soakStartTimes[name] = start
+			}
+		}
+	}
+	return &poolRollout{pool: pool, canaries: canaries, targetGenerations: targetGenerations, soakStartTimes: soakStartTimes}, nil
+}
+
+func (pr *poolRollout) isCanary(nodeName string) bool {
+	return slices.Contains(pr.canaries, nodeName)
+}
+
+// setTargetGeneration records the generation of the canary node state once its configuration is synced,
+// the pool status may be older than the node state so the generation only moves forward.
+// The soak time of the canary starts again once it is synced with the new generation.
+func (pr *poolRollout) setTargetGeneration(nodeName string, generation int64) {
+	if generation > pr.targetGenerations[nodeName] {
+		pr.targetGenerations[nodeName] = generation
+		delete(pr.soakStartTimes, nodeName)
+	}
+}
+
+// holdReason returns why the non canary node must wait, or an empty string if it can apply its configuration.
+// The canaries are evaluated once, after they are synced. A new configuration of the node after the rollout
+// completed restarts the soak time of the canaries, even if their own configuration didn't change.
+func (pr *poolRollout) holdReason(ctx context.Context, c client.Reader, specChanged bool) (string, error) {
+	if !pr.evaluated {
+		if err := pr.evaluate(ctx, c); err != nil {
+			return "", err
+		}
+		pr.evaluated = true
+	}
+	if specChanged && pr.status.Phase == RolloutPhaseCompleted {
+		clear(pr.soakStartTimes)
+		if err := pr.evaluate(ctx, c); err != nil {
+			return "", err
+		}
+	}
+	if pr.status.Phase == RolloutPhaseCompleted {
+		return "", nil
+	}
+	return pr.status.Message, nil
+}
+
+// evaluate checks the canary node states, they must be read from the API server as they may have been
+// updated in the same reconcile. The soak time of a canary starts when it is first seen synced with its
+// target generation.
+func (pr *poolRollout) evaluate(ctx context.Context, c client.Reader) error {
+	var soakTime time.Duration
+	if pr.pool.Spec.RolloutStrategy.SoakTime != nil {
+		soakTime = pr.pool.Spec.RolloutStrategy.SoakTime.Duration
+	}
+
+	var failed, pending, soaking []string
+	for _, name := range pr.canaries {
+		ns := &sriovnetworkv1.SriovNetworkNodeState{}
+		err := c.Get(ctx, types.NamespacedName{Namespace: vars.Namespace, Name: name}, ns)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if ns.Status.SyncStatus == constants.SyncStatusFailed {
+			failed = append(failed, name)
+			delete(pr.soakStartTimes, name)
+			continue
+		}
+		if ns.Generation < pr.targetGenerations[name] {
+			// not the node state holding the configuration being rolled out
+			pending = append(pending, name)
+			delete(pr.soakStartTimes, name)
+			continue
+		}
+		if ns.Status.SyncStatus != constants.SyncStatusSucceeded || ns.Status.ObservedGeneration != ns.Generation {
+			pending = append(pending, name)
+			delete(pr.soakStartTimes, name)
+			continue
+		}
+		start, ok := pr.soakStartTimes[name]
+		if !ok {
+			// the status holds the time at the second
+			start = metav1.Now().Rfc3339Copy()
+			pr.soakStartTimes[name] = start
+		}
+		remaining := soakTime - time.Since(start.Time)
+		if remaining > 0 {
+			soaking = append(soaking, name)
+			if remaining > pr.requeueAfter {
+				pr.requeueAfter = remaining
+			}
+		}
+	}
+
+	pr.status = sriovnetworkv1.PoolRolloutStatus{CanaryNodes: pr.canaries}
+	if len(pr.targetGenerations) > 0 {
+		pr.status.TargetGenerations = maps.Clone(pr.targetGenerations)
+	}
+	if len(pr.soakStartTimes) > 0 {
+		pr.status.SoakStartTimes = maps.Clone(pr.soakStartTimes)
+	}
+	switch {
+	case len(failed) > 0:
+		pr.status.Phase = RolloutPhaseStopped
+		pr.status.Message = fmt.Sprintf("rollout stopped, canary nodes failed to apply the configuration: %s", strings.Join(failed, ", "))
+		// no need to requeue, the canary sync status update triggers a new reconcile
+		pr.requeueAfter = 0
+	case len(pending) > 0:
+		pr.status.Phase = RolloutPhaseCanary
+		pr.status.Message = fmt.Sprintf("waiting for canary nodes to apply the configuration: %s", strings.Join(pending, ", "))
+		pr.requeueAfter = 0
+	case len(soaking) > 0:
+		pr.status.Phase = RolloutPhaseSoaking
+		pr.status.Message = fmt.Sprintf("waiting for the soak time of canary nodes: %s", strings.Join(soaking, ", "))
+	default:
+		pr.status.Phase = RolloutPhaseCompleted
+	}
+	return nil
+}
+
+// syncPoolRolloutStatus reports the rollout progress in the status of the pool
+func (r *SriovNetworkNodePolicyReconciler) syncPoolRolloutStatus(ctx context.Context, pr *poolRollout) error {
+	logger := log.Log.WithName("syncPoolRolloutStatus")
+	if !pr.evaluated {
+		// all the nodes of the pool are canaries
+		if err := pr.evaluate(ctx, r.UncachedAPIReader); err != nil {
+			return err
+		}
+		pr.evaluated = true
+	}
+
+	pool := &sriovnetworkv1.SriovNetworkPoolConfig{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(pr.pool), pool); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if equality.Semantic.DeepEqual(pool.Status.Rollout, &pr.status) {
+		return nil
+	}
+	newVersion := pool.DeepCopy()
+	newVersion.Status.Rollout = pr.status.DeepCopy()
+	if err := r.Status().Patch(ctx, newVersion, client.MergeFrom(pool)); err != nil {
+		return fmt.Errorf("couldn't update SriovNetworkPoolConfig status: %v", err)
+	}
+	logger.Info("rollout status updated", "pool", pool.Name, "phase", pr.status.Phase)
+	return nil
+}
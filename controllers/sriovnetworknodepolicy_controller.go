@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -29,6 +30,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -46,6 +48,7 @@ import (
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/drivers"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/featuregate"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
@@ -58,11 +61,15 @@ type SriovNetworkNodePolicyReconciler struct {
 	client.Client
 	Scheme      *runtime.Scheme
 	FeatureGate featuregate.FeatureGate
+	// reads the SriovNetworkNodeStates updated in the same reconcile, the cache may not have observed the update yet
+	UncachedAPIReader client.Reader
 }
 
 //+kubebuilder:rbac:groups=sriovnetwork.openshift.io,resources=sriovnetworknodepolicies,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=sriovnetwork.openshift.io,resources=sriovnetworknodepolicies/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=sriovnetwork.openshift.io,resources=sriovnetworknodepolicies/finalizers,verbs=update
+//+kubebuilder:rbac:groups=sriovnetwork.openshift.io,resources=sriovibguidpools,verbs=get;list;watch
+//+kubebuilder:rbac:groups=sriovnetwork.openshift.io,resources=sriovibguidpools/status,verbs=get;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -128,16 +135,25 @@ func (r *SriovNetworkNodePolicyReconciler) Reconcile(ctx context.Context, req ct
 	// it will remain in the same order and not trigger a pod recreation
 	sort.Sort(sriovnetworkv1.ByPriority(policyList.Items))
 	// Sync SriovNetworkNodeState objects
-	if err = r.syncAllSriovNetworkNodeStates(ctx, defaultOpConf, policyList, nodeList); err != nil {
+	requeueAfter, err := r.syncAllSriovNetworkNodeStates(ctx, defaultOpConf, policyList, nodeList)
+	if err != nil {
 		return reconcile.Result{}, err
 	}
 	// Sync Sriov device plugin ConfigMap object
 	if err = r.syncDevicePluginConfigMap(ctx, defaultOpConf, policyList, nodeList); err != nil {
 		return reconcile.Result{}, err
 	}
+	// Sync the status of the SriovNetworkNodePolicy objects
+	if err = r.syncPolicyStatuses(ctx, policyList, nodeList); err != nil {
+		return reconcile.Result{}, err
+	}
 
 	// All was successful. Request that this be re-triggered after ResyncPeriod,
 	// so we can reconcile state again.
+	// A pool rollout waiting for the soak time of its canaries is retried earlier.
+	if requeueAfter > 0 && requeueAfter < constants.ResyncPeriod {
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
 	return reconcile.Result{RequeueAfter: constants.ResyncPeriod}, nil
 }
 
@@ -195,6 +211,26 @@ func (r *SriovNetworkNodePolicyReconciler) SetupWithManager(mgr ctrl.Manager) er
 		},
 	}
 
+	// update the policies status when the daemon reports a change of the status fields the policy conditions use
+	nodeStateEventHandler := handler.Funcs{
+		UpdateFunc: func(c context.Context, e event.TypedUpdateEvent[client.Object], w workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			oldState, ok := e.ObjectOld.(*sriovnetworkv1.SriovNetworkNodeState)
+			if !ok {
+				return
+			}
+			newState, ok := e.ObjectNew.(*sriovnetworkv1.SriovNetworkNodeState)
+			if !ok {
+				return
+			}
+			if !policyStatusInputChanged(oldState, newState) {
+				return
+			}
+			log.Log.WithName("SriovNetworkNodePolicy").
+				Info("Enqueuing sync for node state status update event", "resource", e.ObjectNew.GetName())
+			qHandler(w)
+		},
+	}
+
 	// send initial sync event to trigger reconcile when controller is started
 	var eventChan = make(chan event.GenericEvent, 1)
 	eventChan <- event.GenericEvent{Object: &sriovnetworkv1.SriovNetworkNodePolicy{
@@ -206,6 +242,8 @@ func (r *SriovNetworkNodePolicyReconciler) SetupWithManager(mgr ctrl.Manager) er
 		Watches(&corev1.Node{}, nodeEvenHandler).
 		Watches(&sriovnetworkv1.SriovNetworkNodePolicy{}, delayedEventHandler).
 		Watches(&sriovnetworkv1.SriovNetworkPoolConfig{}, delayedEventHandler).
+		Watches(&sriovnetworkv1.SriovIBGUIDPool{}, delayedEventHandler).
+		Watches(&sriovnetworkv1.SriovNetworkNodeState{}, nodeStateEventHandler).
 		WatchesRawSource(source.Channel(eventChan, &handler.EnqueueRequestForObject{})).
 		Complete(r)
 }
@@ -290,30 +328,116 @@ func (r *SriovNetworkNodePolicyReconciler) syncDevicePluginConfigMap(ctx context
 	return nil
 }
 
-func (r *SriovNetworkNodePolicyReconciler) syncAllSriovNetworkNodeStates(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig, npl *sriovnetworkv1.SriovNetworkNodePolicyList, nl *corev1.NodeList) error {
+// syncAllSriovNetworkNodeStates renders the SriovNetworkNodeState of every node and removes the stale ones.
+// It returns the time after which the sync must be retried when the rollout of a pool waits for the soak time
+// of its canary nodes.
+func (r *SriovNetworkNodePolicyReconciler) syncAllSriovNetworkNodeStates(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig, npl *sriovnetworkv1.SriovNetworkNodePolicyList, nl *corev1.NodeList) (time.Duration, error) {
 	logger := log.Log.WithName("syncAllSriovNetworkNodeStates")
 	logger.V(1).Info("Start to sync all SriovNetworkNodeState custom resource")
 	found := &corev1.ConfigMap{}
 	if err := r.Get(ctx, types.NamespacedName{Namespace: vars.Namespace, Name: constants.ConfigMapName}, found); err != nil {
 		logger.V(1).Info("Fail to get", "ConfigMap", constants.ConfigMapName)
 	}
-	for _, node := range nl.Items {
+
+	nodePools := map[string]*sriovnetworkv1.SriovNetworkPoolConfig{}
+	rollouts := map[string]*poolRollout{}
+	for i := range nl.Items {
+		node := &nl.Items[i]
+		netPoolConfig, poolNodes, err := findNodePoolConfig(ctx, node, r.Client)
+		if err != nil {
+			logger.Error(err, "failed to get SriovNetworkPoolConfig for the current node")
+			continue
+		}
+		nodePools[node.Name] = netPoolConfig
+		if netPoolConfig.Spec.RolloutStrategy == nil {
+			continue
+		}
+		if _, ok := rollouts[netPoolConfig.Name]; ok {
+			continue
+		}
+		// only the nodes running the config daemon can be canaries
+		daemonNodes := []corev1.Node{}
+		for _, n := range poolNodes {
+			if slices.ContainsFunc(nl.Items, func(item corev1.Node) bool { return item.Name == n.Name }) {
+				daemonNodes = append(daemonNodes, n)
+			}
+		}
+		rollout, err := newPoolRollout(netPoolConfig, daemonNodes)
+		if err != nil {
+			logger.Error(err, "invalid rollout strategy, ignoring it", "pool", netPoolConfig.Name)
+			continue
+		}
+		rollouts[netPoolConfig.Name] = rollout
+	}
+	rolloutOf := func(nodeName string) *poolRollout {
+		if pool, ok := nodePools[nodeName]; ok {
+			return rollouts[pool.Name]
+		}
+		return nil
+	}
+
+	// the GUIDs of the InfiniBand PFs are allocated from the SriovIBGUIDPools, the blocks already
+	// recorded in the node states are kept
+	guidPools := &sriovnetworkv1.SriovIBGUIDPoolList{}
+	if err := r.List(ctx, guidPools); err != nil {
+		logger.Error(err, "Fail to list SriovIBGUIDPool CRs")
+		return 0, err
+	}
+	currentStates := &sriovnetworkv1.SriovNetworkNodeStateList{}
+	// the blocks of GUIDs recorded by the previous syncs must be reserved, the cache may not hold them yet
+	if err := r.UncachedAPIReader.List(ctx, currentStates, client.InNamespace(vars.Namespace)); err != nil {
+		logger.Error(err, "Fail to list SriovNetworkNodeState CRs")
+		return 0, err
+	}
+	guidAllocator := newIbGUIDAllocator(guidPools.Items, currentStates.Items)
+
+	// sync the canary nodes first so the rest of their pool waits for the new configuration
+	nodes := slices.Clone(nl.Items)
+	sort.SliceStable(nodes, func(i, j int) bool {
+		ri, rj := rolloutOf(nodes[i].Name), rolloutOf(nodes[j].Name)
+		return ri != nil && ri.isCanary(nodes[i].Name) && (rj == nil || !rj.isCanary(nodes[j].Name))
+	})
+
+	for _, node := range nodes {
 		logger.V(1).Info("Sync SriovNetworkNodeState CR", "name", node.Name)
 		ns := &sriovnetworkv1.SriovNetworkNodeState{}
 		ns.Name = node.Name
 		ns.Namespace = vars.Namespace
-		netPoolConfig, _, err := findNodePoolConfig(ctx, &node, r.Client)
-		if err != nil {
-			logger.Error(err, "failed to get SriovNetworkPoolConfig for the current node")
-		}
-		if netPoolConfig != nil {
+		ns.Annotations = map[string]string{}
+		if netPoolConfig, ok := nodePools[node.Name]; ok {
 			ns.Spec.System.RdmaMode = netPoolConfig.Spec.RdmaMode
+			if netPoolConfig.Spec.Paused {
+				ns.Annotations[constants.NodeStatePausedAnnotation] = "true"
+			}
+		}
+		// the non canary nodes are held once their spec is rendered, a new spec restarts the canary soak
+		var holdingRollout *poolRollout
+		if rollout := rolloutOf(node.Name); rollout != nil && !rollout.isCanary(node.Name) {
+			holdingRollout = rollout
 		}
 		j, _ := json.Marshal(ns)
 		logger.V(2).Info("SriovNetworkNodeState CR", "content", j)
-		if err := r.syncSriovNetworkNodeState(ctx, dc, npl, ns, &node); err != nil {
+		if err := r.syncSriovNetworkNodeState(ctx, dc, npl, ns, &node, guidAllocator, holdingRollout); err != nil {
 			logger.Error(err, "Fail to sync", "SriovNetworkNodeState", ns.Name)
-			return err
+			return 0, err
+		}
+		if rollout := rolloutOf(node.Name); rollout != nil && rollout.isCanary(node.Name) {
+			rollout.setTargetGeneration(node.Name, ns.Generation)
+		}
+	}
+	if err := guidAllocator.syncStatuses(ctx, r.Client); err != nil {
+		logger.Error(err, "Fail to sync SriovIBGUIDPool statuses")
+		return 0, err
+	}
+
+	var requeueAfter time.Duration
+	for _, rollout := range rollouts {
+		if err := r.syncPoolRolloutStatus(ctx, rollout); err != nil {
+			logger.Error(err, "Fail to sync rollout status", "SriovNetworkPoolConfig", rollout.pool.Name)
+			return 0, err
+		}
+		if rollout.requeueAfter > 0 && (requeueAfter == 0 || rollout.requeueAfter < requeueAfter) {
+			requeueAfter = rollout.requeueAfter
 		}
 	}
 
@@ -323,7 +447,7 @@ func (r *SriovNetworkNodePolicyReconciler) syncAllSriovNetworkNodeStates(ctx con
 	if err != nil {
 		if !errors.IsNotFound(err) {
 			logger.Error(err, "Fail to list SriovNetworkNodeState CRs")
-			return err
+			return 0, err
 		}
 	} else {
 		for _, ns := range nsList.Items {
@@ -340,15 +464,15 @@ func (r *SriovNetworkNodePolicyReconciler) syncAllSriovNetworkNodeStates(ctx con
 				err = utils.RemoveLabelFromNode(ctx, ns.Name, constants.SriovDevicePluginLabel, r.Client)
 				if err != nil && !errors.IsNotFound(err) {
 					logger.Error(err, "Fail to remove device plugin label from node", "node", ns.Name)
-					return err
+					return 0, err
 				}
 				if err := r.handleStaleNodeState(ctx, &ns); err != nil {
-					return err
+					return 0, err
 				}
 			}
 		}
 	}
-	return nil
+	return requeueAfter, nil
 }
 
 // handleStaleNodeState handles stale SriovNetworkNodeState CR (the CR which no longer have a corresponding node with the daemon).
@@ -405,9 +529,12 @@ func (r *SriovNetworkNodePolicyReconciler) syncSriovNetworkNodeState(ctx context
 	dc *sriovnetworkv1.SriovOperatorConfig,
 	npl *sriovnetworkv1.SriovNetworkNodePolicyList,
 	ns *sriovnetworkv1.SriovNetworkNodeState,
-	node *corev1.Node) error {
+	node *corev1.Node,
+	guidAllocator *ibGUIDAllocator,
+	rollout *poolRollout) error {
 	logger := log.Log.WithName("syncSriovNetworkNodeState")
 	logger.V(1).Info("Start to sync SriovNetworkNodeState", "Name", ns.Name)
+	// the generation of the synced node state is reported in ns
 
 	if err := controllerutil.SetControllerReference(dc, ns, r.Scheme); err != nil {
 		return err
@@ -417,6 +544,9 @@ func (r *SriovNetworkNodePolicyReconciler) syncSriovNetworkNodeState(ctx context
 	if err != nil {
 		logger.Error(err, "Fail to get SriovNetworkNodeState", "namespace", ns.Namespace, "name", ns.Name)
 		if errors.IsNotFound(err) {
+			if err := r.setRolloutHold(ctx, rollout, ns, false); err != nil {
+				return err
+			}
 			err = r.Create(ctx, ns)
 			if err != nil {
 				return fmt.Errorf("couldn't create SriovNetworkNodeState: %v", err)
@@ -436,6 +566,7 @@ func (r *SriovNetworkNodePolicyReconciler) syncSriovNetworkNodeState(ctx context
 					return fmt.Errorf("couldn't update SriovNetworkNodeState: %v", err)
 				}
 			}
+			ns.Generation = found.Generation
 			return nil
 		}
 
@@ -444,53 +575,468 @@ func (r *SriovNetworkNodePolicyReconciler) syncSriovNetworkNodeState(ctx context
 		newVersion.Spec = ns.Spec
 		newVersion.OwnerReferences = ns.OwnerReferences
 
-		// Previous Policy Priority(ppp) records the priority of previous evaluated policy in node policy list.
-		// Since node policy list is already sorted with priority number, comparing current priority with ppp shall
-		// be sufficient.
-		// ppp is set to 100 as initial value to avoid matching with the first policy in policy list, although
-		// it should not matter since the flag used in p.Apply() will only be applied when VF partition is detected.
-		ppp := 100
-		for _, p := range npl.Items {
-			// Note(adrianc): default policy is deprecated and ignored.
-			if p.Name == constants.DefaultPolicyName {
+		if err := r.applyPolicies(newVersion, npl, node, false); err != nil {
+			return err
+		}
+		if err := r.renderPlannedSpec(newVersion, ns.Spec, npl, node); err != nil {
+			return err
+		}
+		guidAllocator.allocate(newVersion, node)
+		if err := r.setRolloutHold(ctx, rollout, ns, !equality.Semantic.DeepEqual(newVersion.Spec, found.Spec)); err != nil {
+			return err
+		}
+		// the pool pause annotation is rendered by syncAllSriovNetworkNodeStates, the rollout hold annotation above
+		for _, key := range []string{constants.NodeStatePausedAnnotation, constants.NodeStateRolloutHoldAnnotation} {
+			value, ok := ns.GetAnnotations()[key]
+			if !ok {
+				delete(newVersion.Annotations, key)
 				continue
 			}
-			if p.Selected(node) {
-				logger.Info("apply", "policy", p.Name, "node", node.Name)
-				// Merging only for policies with the same priority (ppp == p.Spec.Priority)
-				// This boolean flag controls merging of PF configuration (e.g. mtu, numvfs etc)
-				// when VF partition is configured.
-				err = p.Apply(newVersion, ppp == p.Spec.Priority)
-				if err != nil {
-					return err
-				}
-				if r.FeatureGate.IsEnabled(constants.ManageSoftwareBridgesFeatureGate) {
-					err = p.ApplyBridgeConfig(newVersion)
-					if err != nil {
-						return err
-					}
-				}
-				// record the evaluated policy priority for next loop
-				ppp = p.Spec.Priority
+			if newVersion.Annotations == nil {
+				newVersion.Annotations = map[string]string{}
 			}
+			newVersion.Annotations[key] = value
 		}
 
 		// Note(adrianc): we check same ownerReferences since SriovNetworkNodeState
 		// was owned by a default SriovNetworkNodePolicy. if we encounter a descripancy
 		// we need to update.
 		if !keepUntilAnnotationUpdated && equality.Semantic.DeepEqual(newVersion.OwnerReferences, found.OwnerReferences) &&
-			equality.Semantic.DeepEqual(newVersion.Spec, found.Spec) {
+			equality.Semantic.DeepEqual(newVersion.Spec, found.Spec) &&
+			equality.Semantic.DeepEqual(newVersion.Annotations, found.Annotations) {
 			logger.V(1).Info("SriovNetworkNodeState did not change, not updating")
+			ns.Generation = found.Generation
 			return nil
 		}
 		err = r.Update(ctx, newVersion)
 		if err != nil {
 			return fmt.Errorf("couldn't update SriovNetworkNodeState: %v", err)
 		}
+		ns.Generation = newVersion.Generation
+	}
+	return nil
+}
+
+// setRolloutHold sets the rollout hold annotation of the node state of a node waiting for the canary nodes of its pool,
+// the rollout is nil for the canary nodes and the nodes of a pool without rollout strategy
+func (r *SriovNetworkNodePolicyReconciler) setRolloutHold(ctx context.Context, rollout *poolRollout,
+	ns *sriovnetworkv1.SriovNetworkNodeState, specChanged bool) error {
+	if rollout == nil {
+		return nil
+	}
+	hold, err := rollout.holdReason(ctx, r.UncachedAPIReader, specChanged)
+	if err != nil {
+		return err
+	}
+	if hold != "" {
+		ns.Annotations[constants.NodeStateRolloutHoldAnnotation] = hold
+	}
+	return nil
+}
+
+// applyPolicies renders the policies selected for the node in the node state spec.
+// Dry-run policies are rendered only when withDryRun is true.
+func (r *SriovNetworkNodePolicyReconciler) applyPolicies(ns *sriovnetworkv1.SriovNetworkNodeState,
+	npl *sriovnetworkv1.SriovNetworkNodePolicyList, node *corev1.Node, withDryRun bool) error {
+	logger := log.Log.WithName("applyPolicies")
+	// Previous Policy Priority(ppp) records the priority of previous evaluated policy in node policy list.
+	// Since node policy list is already sorted with priority number, comparing current priority with ppp shall
+	// be sufficient.
+	// ppp is set to 100 as initial value to avoid matching with the first policy in policy list, although
+	// it should not matter since the flag used in p.Apply() will only be applied when VF partition is detected.
+	ppp := 100
+	for _, p := range npl.Items {
+		// Note(adrianc): default policy is deprecated and ignored.
+		if p.Name == constants.DefaultPolicyName {
+			continue
+		}
+		if p.IsDryRun() && !withDryRun {
+			continue
+		}
+		if p.Selected(node) {
+			logger.Info("apply", "policy", p.Name, "node", node.Name, "dryRun", p.IsDryRun())
+			// Merging only for policies with the same priority (ppp == p.Spec.Priority)
+			// This boolean flag controls merging of PF configuration (e.g. mtu, numvfs etc)
+			// when VF partition is configured.
+			err := p.Apply(ns, ppp == p.Spec.Priority)
+			if err != nil {
+				return err
+			}
+			err = p.ApplyBondConfig(ns)
+			if err != nil {
+				return err
+			}
+			if r.FeatureGate.IsEnabled(constants.ManageSoftwareBridgesFeatureGate) {
+				err = p.ApplyBridgeConfig(ns)
+				if err != nil {
+					return err
+				}
+			}
+			// record the evaluated policy priority for next loop
+			ppp = p.Spec.Priority
+		}
+	}
+	return nil
+}
+
+// renderPlannedSpec stores in the planned spec annotation of the node state the spec rendered with
+// both the regular and the dry-run policies. The annotation is removed when no dry-run policy selects
+// the node or when the dry-run policies don't change the node state spec.
+func (r *SriovNetworkNodePolicyReconciler) renderPlannedSpec(ns *sriovnetworkv1.SriovNetworkNodeState,
+	baseSpec sriovnetworkv1.SriovNetworkNodeStateSpec,
+	npl *sriovnetworkv1.SriovNetworkNodePolicyList, node *corev1.Node) error {
+	hasDryRun := false
+	for i := range npl.Items {
+		if npl.Items[i].IsDryRun() && npl.Items[i].Selected(node) {
+			hasDryRun = true
+			break
+		}
+	}
+
+	plannedSpec := ""
+	if hasDryRun {
+		planned := ns.DeepCopy()
+		planned.Spec = *baseSpec.DeepCopy()
+		if err := r.applyPolicies(planned, npl, node, true); err != nil {
+			return err
+		}
+		if !equality.Semantic.DeepEqual(planned.Spec, ns.Spec) {
+			data, err := json.Marshal(planned.Spec)
+			if err != nil {
+				return fmt.Errorf("failed to marshal planned spec: %v", err)
+			}
+			plannedSpec = string(data)
+		}
+	}
+
+	if plannedSpec == "" {
+		delete(ns.Annotations, constants.NodeStatePlannedSpecAnnotation)
+		return nil
+	}
+	if ns.Annotations == nil {
+		ns.Annotations = map[string]string{}
+	}
+	ns.Annotations[constants.NodeStatePlannedSpecAnnotation] = plannedSpec
+	return nil
+}
+
+// syncPolicyStatuses updates the status of every policy with the nodes and PFs matched by the policy
+// and with the sync results reported by the config daemon in the SriovNetworkNodeState objects
+func (r *SriovNetworkNodePolicyReconciler) syncPolicyStatuses(ctx context.Context,
+	npl *sriovnetworkv1.SriovNetworkNodePolicyList, nl *corev1.NodeList) error {
+	logger := log.Log.WithName("syncPolicyStatuses")
+	logger.V(1).Info("Start to sync SriovNetworkNodePolicy status")
+
+	// the conflicts are computed from the spec rendered by syncAllSriovNetworkNodeStates, the cache may not hold it yet
+	nsList := &sriovnetworkv1.SriovNetworkNodeStateList{}
+	if err := r.UncachedAPIReader.List(ctx, nsList, client.InNamespace(vars.Namespace)); err != nil {
+		return fmt.Errorf("failed to list SriovNetworkNodeStates: %v", err)
+	}
+	nodeStates := map[string]*sriovnetworkv1.SriovNetworkNodeState{}
+	for i := range nsList.Items {
+		nodeStates[nsList.Items[i].Name] = &nsList.Items[i]
+	}
+
+	for i := range npl.Items {
+		p := &npl.Items[i]
+		// Note(adrianc): default policy is deprecated and ignored.
+		if p.Name == constants.DefaultPolicyName {
+			continue
+		}
+		newStatus := renderPolicyStatus(p, nl.Items, nodeStates)
+		if equality.Semantic.DeepEqual(p.Status, newStatus) {
+			continue
+		}
+		newVersion := p.DeepCopy()
+		newVersion.Status = newStatus
+		if err := r.Status().Patch(ctx, newVersion, client.MergeFrom(p)); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("couldn't update SriovNetworkNodePolicy status: %v", err)
+		}
+		logger.V(1).Info("SriovNetworkNodePolicy status updated", "name", p.Name)
 	}
 	return nil
 }
 
+// renderPolicyStatus computes the status of the policy from the SriovNetworkNodeState of the selected nodes.
+// A PF is reported as matched if the policy nicSelector selects it, the VF range is taken from the VF group
+// rendered for the policy in the node state spec. The policy is conflicting on a node if it should create VFs
+// on a matched PF but another policy took over the PF.
+// For dry-run policies the VF ranges are taken from the planned spec and the nodes report the planned changes
+// instead of the sync results.
+func renderPolicyStatus(p *sriovnetworkv1.SriovNetworkNodePolicy,
+	nodes []corev1.Node,
+	nodeStates map[string]*sriovnetworkv1.SriovNetworkNodeState) sriovnetworkv1.SriovNetworkNodePolicyStatus {
+	status := sriovnetworkv1.SriovNetworkNodePolicyStatus{}
+	for _, c := range p.Status.Conditions {
+		status.Conditions = append(status.Conditions, *c.DeepCopy())
+	}
+
+	var notSyncedNodes, failedNodes, conflictingNodes, notEvaluatedNodes []string
+	for i := range nodes {
+		node := &nodes[i]
+		if !p.Selected(node) || p.Spec.NicSelector.IsEmpty() {
+			continue
+		}
+		ns, ok := nodeStates[node.Name]
+		if !ok {
+			continue
+		}
+
+		nodeStatus := sriovnetworkv1.PolicyNodeStatus{Name: node.Name, SyncStatus: ns.Status.SyncStatus}
+		conflicting := false
+		synced := ns.Status.SyncStatus == constants.SyncStatusSucceeded &&
+			ns.Status.ObservedGeneration == ns.Generation
+		specInterfaces := ns.Spec.Interfaces
+		if p.IsDryRun() {
+			nodeStatus.Plan = &sriovnetworkv1.PolicyNodePlan{}
+			specInterfaces, nodeStatus.Plan.Error = plannedInterfaces(ns)
+		}
+		for j := range ns.Status.Interfaces {
+			iface := &ns.Status.Interfaces[j]
+			if !p.Spec.NicSelector.Selected(iface) {
+				continue
+			}
+			ifaceStatus := sriovnetworkv1.PolicyInterfaceStatus{PciAddress: iface.PciAddress, Name: iface.Name}
+			for _, ifaceSpec := range specInterfaces {
+				if ifaceSpec.PciAddress != iface.PciAddress {
+					continue
+				}
+				for _, group := range ifaceSpec.VfGroups {
+					if group.PolicyName == p.Name {
+						ifaceStatus.VfRange = group.VfRange
+						break
+					}
+				}
+				// the daemon didn't create the VFs yet
+				if ifaceStatus.VfRange != "" && ifaceSpec.NumVfs != iface.NumVfs {
+					synced = false
+				}
+			}
+			if p.Spec.NumVfs > 0 && ifaceStatus.VfRange == "" {
+				conflicting = true
+			}
+			nodeStatus.Interfaces = append(nodeStatus.Interfaces, ifaceStatus)
+		}
+		if len(nodeStatus.Interfaces) == 0 {
+			continue
+		}
+
+		if p.IsDryRun() {
+			renderPolicyNodePlan(nodeStatus.Plan, ns, specInterfaces, nodeStatus.Interfaces)
+			status.MatchedNodeCount++
+			if !nodeStatus.Plan.Evaluated {
+				notEvaluatedNodes = append(notEvaluatedNodes, node.Name)
+			}
+			if nodeStatus.Plan.Error != "" {
+				failedNodes = append(failedNodes, node.Name)
+			}
+			if conflicting {
+				conflictingNodes = append(conflictingNodes, node.Name)
+			}
+			status.Nodes = append(status.Nodes, nodeStatus)
+			continue
+		}
+
+		nodeStatus.Synced = synced
+		status.MatchedNodeCount++
+		if synced {
+			status.ReadyNodeCount++
+		} else {
+			notSyncedNodes = append(notSyncedNodes, node.Name)
+		}
+		if ns.Status.SyncStatus == constants.SyncStatusFailed {
+			failedNodes = append(failedNodes, node.Name)
+		}
+		if conflicting {
+			conflictingNodes = append(conflictingNodes, node.Name)
+		}
+		status.Nodes = append(status.Nodes, nodeStatus)
+	}
+
+	readyCondition := metav1.Condition{
+		Type:               sriovnetworkv1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             sriovnetworkv1.PolicyReasonAllNodesSynced,
+		Message:            fmt.Sprintf("policy applied on %d nodes", status.MatchedNodeCount),
+		ObservedGeneration: p.Generation,
+	}
+	if p.IsDryRun() {
+		readyCondition.Status = metav1.ConditionFalse
+		readyCondition.Reason = sriovnetworkv1.PolicyReasonDryRun
+		readyCondition.Message = fmt.Sprintf("policy is in dry-run mode, planned configuration evaluated on %d of %d nodes",
+			status.MatchedNodeCount-len(notEvaluatedNodes), status.MatchedNodeCount)
+	} else if status.MatchedNodeCount == 0 {
+		readyCondition.Status = metav1.ConditionFalse
+		readyCondition.Reason = sriovnetworkv1.PolicyReasonNoMatchingNodes
+		readyCondition.Message = "policy doesn't match any PF on the selected nodes"
+	} else if len(notSyncedNodes) > 0 {
+		readyCondition.Status = metav1.ConditionFalse
+		readyCondition.Reason = sriovnetworkv1.PolicyReasonNodesSyncing
+		readyCondition.Message = fmt.Sprintf("%d of %d nodes are not synced: %s",
+			len(notSyncedNodes), status.MatchedNodeCount, strings.Join(notSyncedNodes, ", "))
+	}
+	meta.SetStatusCondition(&status.Conditions, readyCondition)
+
+	degradedCondition := metav1.Condition{
+		Type:               sriovnetworkv1.ConditionDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             sriovnetworkv1.PolicyReasonNoSyncFailures,
+		ObservedGeneration: p.Generation,
+	}
+	if len(failedNodes) > 0 {
+		degradedCondition.Status = metav1.ConditionTrue
+		degradedCondition.Reason = sriovnetworkv1.PolicyReasonSyncFailed
+		degradedCondition.Message = fmt.Sprintf("sync failed on nodes: %s", strings.Join(failedNodes, ", "))
+		if p.IsDryRun() {
+			degradedCondition.Reason = sriovnetworkv1.PolicyReasonPlanFailed
+			degradedCondition.Message = fmt.Sprintf("planned configuration evaluation failed on nodes: %s", strings.Join(failedNodes, ", "))
+		}
+	}
+	meta.SetStatusCondition(&status.Conditions, degradedCondition)
+
+	conflictingCondition := metav1.Condition{
+		Type:               sriovnetworkv1.ConditionConflicting,
+		Status:             metav1.ConditionFalse,
+		Reason:             sriovnetworkv1.PolicyReasonNoConflicts,
+		ObservedGeneration: p.Generation,
+	}
+	if len(conflictingNodes) > 0 {
+		conflictingCondition.Status = metav1.ConditionTrue
+		conflictingCondition.Reason = sriovnetworkv1.PolicyReasonOverridden
+		conflictingCondition.Message = fmt.Sprintf("matched PFs are configured by another policy on nodes: %s",
+			strings.Join(conflictingNodes, ", "))
+	}
+	meta.SetStatusCondition(&status.Conditions, conflictingCondition)
+
+	return status
+}
+
+// policyStatusInputChanged returns true if the status of the node state changed in one of the fields
+// renderPolicyStatus uses: the sync status and generation, the plan of the dry-run policies, and the
+// attributes of the PFs the nic selectors match and their number of VFs
+func policyStatusInputChanged(oldState, newState *sriovnetworkv1.SriovNetworkNodeState) bool {
+	if oldState.Status.SyncStatus != newState.Status.SyncStatus ||
+		oldState.Status.ObservedGeneration != newState.Status.ObservedGeneration ||
+		!equality.Semantic.DeepEqual(oldState.Status.Plan, newState.Status.Plan) {
+		return true
+	}
+	return !equality.Semantic.DeepEqual(policyStatusInterfaces(oldState), policyStatusInterfaces(newState))
+}
+
+// policyStatusInterfaces returns the PFs of the node state status with only the fields used by renderPolicyStatus
+func policyStatusInterfaces(ns *sriovnetworkv1.SriovNetworkNodeState) sriovnetworkv1.InterfaceExts {
+	ifaces := make(sriovnetworkv1.InterfaceExts, 0, len(ns.Status.Interfaces))
+	for _, iface := range ns.Status.Interfaces {
+		ifaces = append(ifaces, sriovnetworkv1.InterfaceExt{
+			PciAddress: iface.PciAddress,
+			Name:       iface.Name,
+			AltNames:   iface.AltNames,
+			Vendor:     iface.Vendor,
+			DeviceID:   iface.DeviceID,
+			NetFilter:  iface.NetFilter,
+			NumVfs:     iface.NumVfs,
+		})
+	}
+	return ifaces
+}
+
+// plannedInterfaces returns the interfaces of the planned spec annotation of the node state,
+// the node state spec is returned when the dry-run policies don't change the node configuration
+func plannedInterfaces(ns *sriovnetworkv1.SriovNetworkNodeState) (sriovnetworkv1.Interfaces, string) {
+	plannedSpec, ok := ns.GetAnnotations()[constants.NodeStatePlannedSpecAnnotation]
+	if !ok {
+		return ns.Spec.Interfaces, ""
+	}
+	spec := sriovnetworkv1.SriovNetworkNodeStateSpec{}
+	if err := json.Unmarshal([]byte(plannedSpec), &spec); err != nil {
+		return ns.Spec.Interfaces, fmt.Sprintf("failed to parse planned spec: %v", err)
+	}
+	return spec.Interfaces, ""
+}
+
+// renderPolicyNodePlan fills the plan with the changes of the PFs matched by the policy and with
+// the result of the evaluation of the planned spec reported by the config daemon
+func renderPolicyNodePlan(plan *sriovnetworkv1.PolicyNodePlan,
+	ns *sriovnetworkv1.SriovNetworkNodeState,
+	planned sriovnetworkv1.Interfaces,
+	matched []sriovnetworkv1.PolicyInterfaceStatus) {
+	for _, iface := range matched {
+		current := sriovnetworkv1.Interface{PciAddress: iface.PciAddress}
+		for _, i := range ns.Spec.Interfaces {
+			if i.PciAddress == iface.PciAddress {
+				current = i
+				break
+			}
+		}
+		next := sriovnetworkv1.Interface{PciAddress: iface.PciAddress}
+		for _, i := range planned {
+			if i.PciAddress == iface.PciAddress {
+				next = i
+				break
+			}
+		}
+		plan.Changes = append(plan.Changes, interfaceChanges(&current, &next)...)
+	}
+
+	plannedSpec, ok := ns.GetAnnotations()[constants.NodeStatePlannedSpecAnnotation]
+	if !ok {
+		// nothing to apply on the node
+		plan.Evaluated = plan.Error == ""
+		return
+	}
+	if ns.Status.Plan == nil || ns.Status.Plan.PlannedSpecHash != sriovnetworkv1.PlannedSpecHash(plannedSpec) {
+		return
+	}
+	plan.Evaluated = true
+	plan.DrainRequired = ns.Status.Plan.DrainRequired
+	plan.RebootRequired = ns.Status.Plan.RebootRequired
+	if plan.Error == "" {
+		plan.Error = ns.Status.Plan.Error
+	}
+}
+
+// interfaceChanges returns a human readable description of the differences between
+// the current and the planned configuration of a PF
+func interfaceChanges(current, planned *sriovnetworkv1.Interface) []string {
+	changes := []string{}
+	addChange := func(field string, from, to interface{}) {
+		changes = append(changes, fmt.Sprintf("%s %s %v -> %v", planned.PciAddress, field, from, to))
+	}
+	if current.NumVfs != planned.NumVfs {
+		addChange("numVfs", current.NumVfs, planned.NumVfs)
+	}
+	if current.NumSfs != planned.NumSfs {
+		addChange("numSfs", current.NumSfs, planned.NumSfs)
+	}
+	if current.Mtu != planned.Mtu {
+		addChange("mtu", current.Mtu, planned.Mtu)
+	}
+	if current.LinkType != planned.LinkType {
+		addChange("linkType", current.LinkType, planned.LinkType)
+	}
+	if current.EswitchMode != planned.EswitchMode {
+		addChange("eSwitchMode", current.EswitchMode, planned.EswitchMode)
+	}
+	if current.ExternallyManaged != planned.ExternallyManaged {
+		addChange("externallyManaged", current.ExternallyManaged, planned.ExternallyManaged)
+	}
+	if !equality.Semantic.DeepEqual(current.VfGroups, planned.VfGroups) {
+		addChange("vfGroups", formatVfGroups(current.VfGroups), formatVfGroups(planned.VfGroups))
+	}
+	return changes
+}
+
+func formatVfGroups(groups []sriovnetworkv1.VfGroup) string {
+	items := make([]string, 0, len(groups))
+	for _, g := range groups {
+		items = append(items, fmt.Sprintf("%s:%s:%s", g.PolicyName, g.VfRange, g.DeviceType))
+	}
+	return "[" + strings.Join(items, " ") + "]"
+}
+
 func (r *SriovNetworkNodePolicyReconciler) renderDevicePluginConfigData(ctx context.Context, pl *sriovnetworkv1.SriovNetworkNodePolicyList, node *corev1.Node) (dptypes.ResourceConfList, error) {
 	logger := log.Log.WithName("renderDevicePluginConfigData")
 	logger.V(1).Info("Start to render device plugin config data", "node", node.Name)
@@ -502,7 +1048,7 @@ func (r *SriovNetworkNodePolicyReconciler) renderDevicePluginConfigData(ctx cont
 		}
 
 		// render node specific data for device plugin config
-		if !p.Selected(node) {
+		if !p.Selected(node) || p.IsDryRun() {
 			continue
 		}
 
@@ -569,6 +1115,16 @@ func resolvePfNames(pfNames []string, nodeState *sriovnetworkv1.SriovNetworkNode
 func createDevicePluginResource(
 	p *sriovnetworkv1.SriovNetworkNodePolicy,
 	nodeState *sriovnetworkv1.SriovNetworkNodeState) (*dptypes.ResourceConfig, error) {
+	if p.Spec.DeviceType == constants.DeviceTypeSf {
+		rc := &dptypes.ResourceConfig{
+			ResourceName: p.Spec.ResourceName,
+			DeviceType:   constants.DevicePluginAuxNetDevice,
+		}
+		if err := updateSfDevicePluginResource(rc, p, nodeState); err != nil {
+			return nil, err
+		}
+		return rc, nil
+	}
 	netDeviceSelectors := dptypes.NetDeviceSelectors{}
 
 	rc := &dptypes.ResourceConfig{
@@ -597,8 +1153,8 @@ func createDevicePluginResource(
 		resolvedPfNames := resolvePfNames(p.Spec.NicSelector.PfNames, nodeState)
 		netDeviceSelectors.PfNames = sriovnetworkv1.UniqueAppend(netDeviceSelectors.PfNames, resolvedPfNames...)
 	}
-	// vfio-pci device link type is not detectable
-	if p.Spec.DeviceType != constants.DeviceTypeVfioPci {
+	// the link type of the devices bound to a userspace driver is not detectable
+	if !drivers.IsUserspaceDeviceType(p.Spec.DeviceType) {
 		if p.Spec.LinkType != "" {
 			linkType := constants.LinkTypeEthernet
 			if strings.EqualFold(p.Spec.LinkType, constants.LinkTypeIB) {
@@ -607,6 +1163,9 @@ func createDevicePluginResource(
 			netDeviceSelectors.LinkTypes = sriovnetworkv1.UniqueAppend(netDeviceSelectors.LinkTypes, linkType)
 		}
 	}
+	if driver := getDriverSelector(p); driver != "" {
+		netDeviceSelectors.Drivers = append(netDeviceSelectors.Drivers, driver)
+	}
 	if len(p.Spec.NicSelector.RootDevices) > 0 {
 		netDeviceSelectors.RootDevices = append(netDeviceSelectors.RootDevices, p.Spec.NicSelector.RootDevices...)
 	}
@@ -637,10 +1196,30 @@ func createDevicePluginResource(
 	return rc, nil
 }
 
+// getDriverSelector returns the driver selected by the device plugin for the VFs of a policy, the vfio-pci
+// resources don't select the driver to keep the config of the existing device plugins.
+// It returns an empty string for the VFs bound to their kernel driver.
+func getDriverSelector(p *sriovnetworkv1.SriovNetworkNodePolicy) string {
+	if p.Spec.DeviceType == constants.DeviceTypeVfioPci {
+		return ""
+	}
+	driver, ok := drivers.Get(p.Spec.DeviceType)
+	if !ok {
+		return ""
+	}
+	return driver.Driver
+}
+
 func updateDevicePluginResource(
 	rc *dptypes.ResourceConfig,
 	p *sriovnetworkv1.SriovNetworkNodePolicy,
 	nodeState *sriovnetworkv1.SriovNetworkNodeState) error {
+	if (rc.DeviceType == constants.DevicePluginAuxNetDevice) != (p.Spec.DeviceType == constants.DeviceTypeSf) {
+		return fmt.Errorf("resource %s can't contain both SFs and VFs, policy %s", rc.ResourceName, p.Name)
+	}
+	if rc.DeviceType == constants.DevicePluginAuxNetDevice {
+		return updateSfDevicePluginResource(rc, p, nodeState)
+	}
 	netDeviceSelectors := dptypes.NetDeviceSelectors{}
 
 	if err := json.Unmarshal(*rc.Selectors, &netDeviceSelectors); err != nil {
@@ -666,8 +1245,8 @@ func updateDevicePluginResource(
 		resolvedPfNames := resolvePfNames(p.Spec.NicSelector.PfNames, nodeState)
 		netDeviceSelectors.PfNames = sriovnetworkv1.UniqueAppend(netDeviceSelectors.PfNames, resolvedPfNames...)
 	}
-	// vfio-pci device link type is not detectable
-	if p.Spec.DeviceType != constants.DeviceTypeVfioPci {
+	// the link type of the devices bound to a userspace driver is not detectable
+	if !drivers.IsUserspaceDeviceType(p.Spec.DeviceType) {
 		if p.Spec.LinkType != "" {
 			linkType := constants.LinkTypeEthernet
 			if strings.EqualFold(p.Spec.LinkType, constants.LinkTypeIB) {
@@ -678,6 +1257,13 @@ func updateDevicePluginResource(
 			}
 		}
 	}
+	// the resource selects the drivers only if all its policies have a driver selector,
+	// an empty list selects the devices whatever their driver is
+	if driver := getDriverSelector(p); driver != "" && len(netDeviceSelectors.Drivers) > 0 {
+		netDeviceSelectors.Drivers = sriovnetworkv1.UniqueAppend(netDeviceSelectors.Drivers, driver)
+	} else {
+		netDeviceSelectors.Drivers = nil
+	}
 	if len(p.Spec.NicSelector.RootDevices) > 0 {
 		netDeviceSelectors.RootDevices = sriovnetworkv1.UniqueAppend(netDeviceSelectors.RootDevices, p.Spec.NicSelector.RootDevices...)
 	}
@@ -704,3 +1290,65 @@ func updateDevicePluginResource(
 
 	return nil
 }
+
+// auxNetDeviceSelectors contains the selectors of the auxNetDevice resources of the device plugin,
+// they are not part of the device plugin types vendored by the operator
+type auxNetDeviceSelectors struct {
+	dptypes.DeviceSelectors
+	PfNames     []string `json:"pfNames,omitempty"`
+	RootDevices []string `json:"rootDevices,omitempty"`
+	LinkTypes   []string `json:"linkTypes,omitempty"`
+	IsRdma      bool     `json:"isRdma,omitempty"`
+	AuxTypes    []string `json:"auxTypes,omitempty"`
+}
+
+// updateSfDevicePluginResource adds the scalable functions selected by the policy to the auxNetDevice resource.
+// The SFs are selected by the attributes of their PF, the device ID is the one of the PF.
+func updateSfDevicePluginResource(
+	rc *dptypes.ResourceConfig,
+	p *sriovnetworkv1.SriovNetworkNodePolicy,
+	nodeState *sriovnetworkv1.SriovNetworkNodeState) error {
+	selectors := auxNetDeviceSelectors{}
+	if rc.Selectors != nil {
+		if err := json.Unmarshal(*rc.Selectors, &selectors); err != nil {
+			return err
+		}
+	}
+
+	selectors.AuxTypes = sriovnetworkv1.UniqueAppend(selectors.AuxTypes, constants.DevicePluginAuxTypeSf)
+	selectors.IsRdma = p.Spec.IsRdma
+	if p.Spec.NicSelector.Vendor != "" {
+		selectors.Vendors = sriovnetworkv1.UniqueAppend(selectors.Vendors, p.Spec.NicSelector.Vendor)
+	}
+	if p.Spec.NicSelector.DeviceID != "" {
+		selectors.Devices = sriovnetworkv1.UniqueAppend(selectors.Devices, p.Spec.NicSelector.DeviceID)
+	}
+	if len(p.Spec.NicSelector.PfNames) > 0 {
+		selectors.PfNames = sriovnetworkv1.UniqueAppend(selectors.PfNames, resolvePfNames(p.Spec.NicSelector.PfNames, nodeState)...)
+	}
+	if len(p.Spec.NicSelector.RootDevices) > 0 {
+		selectors.RootDevices = sriovnetworkv1.UniqueAppend(selectors.RootDevices, p.Spec.NicSelector.RootDevices...)
+	}
+	// SFs are created only in switchdev mode which requires ethernet links
+	selectors.LinkTypes = sriovnetworkv1.UniqueAppend(selectors.LinkTypes, constants.LinkTypeEthernet)
+
+	// Enable the selection of devices using NetFilter
+	if p.Spec.NicSelector.NetFilter != "" {
+		for _, intf := range nodeState.Status.Interfaces {
+			if sriovnetworkv1.NetFilterMatch(p.Spec.NicSelector.NetFilter, intf.NetFilter) {
+				selectors.PciAddresses = sriovnetworkv1.UniqueAppend(selectors.PciAddresses, intf.PciAddress)
+			}
+		}
+	}
+
+	selectorsMarshal, err := json.Marshal(selectors)
+	if err != nil {
+		return err
+	}
+	rawSelectors := json.RawMessage(selectorsMarshal)
+	rc.Selectors = &rawSelectors
+
+	rc.ExcludeTopology = p.Spec.ExcludeTopology
+
+	return nil
+}
@@ -31,6 +31,7 @@ import (
 	dptypes "github.com/k8snetworkplumbingwg/sriov-network-device-plugin/pkg/types"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -44,7 +45,7 @@ import (
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
-func mustMarshallSelector(t *testing.T, input *dptypes.NetDeviceSelectors) *json.RawMessage {
+func mustMarshallSelector(t *testing.T, input interface{}) *json.RawMessage {
 	out, err := json.Marshal(input)
 	if err != nil {
 		t.Error(err)
@@ -225,6 +226,37 @@ func TestRenderDevicePluginConfigData(t *testing.T) {
 				},
 			},
 		},
+		{
+			tname: "testScalableFunctions",
+			policy: sriovnetworkv1.SriovNetworkNodePolicy{
+				Spec: sriovnetworkv1.SriovNetworkNodePolicySpec{
+					ResourceName: "resourceName",
+					DeviceType:   consts.DeviceTypeSf,
+					NumSfs:       16,
+					IsRdma:       true,
+					NicSelector: sriovnetworkv1.SriovNetworkNicSelector{
+						Vendor:   "15b3",
+						DeviceID: "101d",
+						PfNames:  []string{"ens1f0"},
+					},
+				},
+			},
+			expResource: dptypes.ResourceConfList{
+				ResourceList: []dptypes.ResourceConfig{
+					{
+						ResourceName: "resourceName",
+						DeviceType:   consts.DevicePluginAuxNetDevice,
+						Selectors: mustMarshallSelector(t, &auxNetDeviceSelectors{
+							DeviceSelectors: dptypes.DeviceSelectors{Vendors: []string{"15b3"}, Devices: []string{"101d"}},
+							PfNames:         []string{"ens1f0"},
+							LinkTypes:       []string{consts.LinkTypeEthernet},
+							IsRdma:          true,
+							AuxTypes:        []string{consts.DevicePluginAuxTypeSf},
+						}),
+					},
+				},
+			},
+		},
 	}
 
 	reconciler := SriovNetworkNodePolicyReconciler{
@@ -279,9 +311,10 @@ var _ = Describe("SriovnetworkNodePolicy controller", Ordered, func() {
 		Expect(err).ToNot(HaveOccurred())
 
 		err = (&SriovNetworkNodePolicyReconciler{
-			Client:      k8sManager.GetClient(),
-			Scheme:      k8sManager.GetScheme(),
-			FeatureGate: featuregate.New(),
+			Client:            k8sManager.GetClient(),
+			Scheme:            k8sManager.GetScheme(),
+			FeatureGate:       featuregate.New(),
+			UncachedAPIReader: k8sManager.GetAPIReader(),
 		}).SetupWithManager(k8sManager)
 		Expect(err).ToNot(HaveOccurred())
 
@@ -789,5 +822,783 @@ var _ = Describe("SriovNetworkNodePolicyReconciler", Ordered, func() {
 			Expect(selectors).To(HaveKeyWithValue("resvfiopci", `{"vendors":["8086"],"pfNames":["ens0#0-9"],"IsRdma":false,"NeedVhostNet":false}`))
 			Expect(selectors).To(HaveKeyWithValue("resnetdevice", `{"vendors":["8086"],"pfNames":["ens0#10-19"],"IsRdma":false,"NeedVhostNet":false}`))
 		})
+
+		It("should render the driver selectors of the userspace drivers", func() {
+			node1 := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"node-role.kubernetes.io/worker": ""}}}
+			objs := []k8sclient.Object{
+				node1,
+				&sriovnetworkv1.SriovNetworkNodeState{ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: testNamespace}, Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+					Interfaces: sriovnetworkv1.InterfaceExts{
+						{Driver: "ice", DeviceID: "159b", Vendor: "8086", PciAddress: "0000:31:00.0", Name: "ens0"},
+					},
+				}},
+			}
+
+			r := &SriovNetworkNodePolicyReconciler{Client: fake.NewClientBuilder().WithObjects(objs...).Build()}
+
+			newPolicy := func(name, resourceName, deviceType, pfName string) sriovnetworkv1.SriovNetworkNodePolicy {
+				return sriovnetworkv1.SriovNetworkNodePolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: name},
+					Spec: sriovnetworkv1.SriovNetworkNodePolicySpec{
+						ResourceName: resourceName,
+						DeviceType:   deviceType,
+						LinkType:     "eth",
+						NicSelector: sriovnetworkv1.SriovNetworkNicSelector{
+							Vendor:  "8086",
+							PfNames: []string{pfName},
+						},
+						NumVfs:       128,
+						NodeSelector: map[string]string{"node-role.kubernetes.io/worker": ""},
+					},
+				}
+			}
+			pl := &sriovnetworkv1.SriovNetworkNodePolicyList{
+				Items: []sriovnetworkv1.SriovNetworkNodePolicy{
+					newPolicy("noiommu", "resnoiommu", "vfio-pci-noiommu", "ens0#0-9"),
+					newPolicy("uio", "resuio", "uio_pci_generic", "ens0#10-19"),
+					newPolicy("uio-mixed", "resmixed", "uio_pci_generic", "ens0#20-29"),
+					newPolicy("netdevice-mixed", "resmixed", "netdevice", "ens0#30-39"),
+				},
+			}
+			rcl, err := r.renderDevicePluginConfigData(context.Background(), pl, node1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(rcl.ResourceList)).To(Equal(3))
+			selectors := map[string]string{}
+			for _, rc := range rcl.ResourceList {
+				selectors[rc.ResourceName] = string(*rc.Selectors)
+			}
+			Expect(selectors).To(HaveKeyWithValue("resnoiommu", `{"vendors":["8086"],"drivers":["vfio-pci"],"pfNames":["ens0#0-9"],"IsRdma":false,"NeedVhostNet":false}`))
+			Expect(selectors).To(HaveKeyWithValue("resuio", `{"vendors":["8086"],"drivers":["uio_pci_generic"],"pfNames":["ens0#10-19"],"IsRdma":false,"NeedVhostNet":false}`))
+			// the netdevice VFs of the resource are bound to their kernel driver
+			Expect(selectors).To(HaveKeyWithValue("resmixed", `{"vendors":["8086"],"pfNames":["ens0#20-29","ens0#30-39"],"linkTypes":["ether"],"IsRdma":false,"NeedVhostNet":false}`))
+		})
+	})
+
+	Context("policyStatusInputChanged", func() {
+		DescribeTable("should only report the changes of the status fields used by the policy conditions",
+			func(update func(*sriovnetworkv1.SriovNetworkNodeState), expected bool) {
+				oldState := &sriovnetworkv1.SriovNetworkNodeState{
+					ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: testNamespace, Generation: 1},
+					Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+						SyncStatus:         consts.SyncStatusSucceeded,
+						ObservedGeneration: 1,
+						Interfaces: sriovnetworkv1.InterfaceExts{
+							{Driver: "ice", DeviceID: "159b", Vendor: "8086", PciAddress: "0000:31:00.0", Name: "ens0", NumVfs: 8, Mtu: 1500},
+						},
+					},
+				}
+				newState := oldState.DeepCopy()
+				update(newState)
+				Expect(policyStatusInputChanged(oldState, newState)).To(Equal(expected))
+			},
+			Entry("sync status", func(ns *sriovnetworkv1.SriovNetworkNodeState) {
+				ns.Status.SyncStatus = consts.SyncStatusInProgress
+			}, true),
+			Entry("observed generation", func(ns *sriovnetworkv1.SriovNetworkNodeState) {
+				ns.Status.ObservedGeneration = 2
+			}, true),
+			Entry("plan", func(ns *sriovnetworkv1.SriovNetworkNodeState) {
+				ns.Status.Plan = &sriovnetworkv1.NodeStatePlan{PlannedSpecHash: "hash"}
+			}, true),
+			Entry("number of VFs", func(ns *sriovnetworkv1.SriovNetworkNodeState) {
+				ns.Status.Interfaces[0].NumVfs = 4
+			}, true),
+			Entry("PF name", func(ns *sriovnetworkv1.SriovNetworkNodeState) {
+				ns.Status.Interfaces[0].Name = "ens1"
+			}, true),
+			Entry("last sync error", func(ns *sriovnetworkv1.SriovNetworkNodeState) {
+				ns.Status.LastSyncError = "error"
+			}, false),
+			Entry("PF MTU", func(ns *sriovnetworkv1.SriovNetworkNodeState) {
+				ns.Status.Interfaces[0].Mtu = 9000
+			}, false),
+			Entry("conditions", func(ns *sriovnetworkv1.SriovNetworkNodeState) {
+				ns.Status.Conditions = []metav1.Condition{{Type: sriovnetworkv1.ConditionReady, Status: metav1.ConditionTrue}}
+			}, false),
+		)
+	})
+
+	Context("syncPolicyStatuses", func() {
+		var (
+			ctx   context.Context
+			node1 *corev1.Node
+			node2 *corev1.Node
+		)
+
+		newNodeState := func(name, syncStatus string, numVfs int, vfGroups ...sriovnetworkv1.VfGroup) *sriovnetworkv1.SriovNetworkNodeState {
+			ns := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Generation: 1},
+				Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+					SyncStatus:         syncStatus,
+					ObservedGeneration: 1,
+					Interfaces: sriovnetworkv1.InterfaceExts{
+						{Driver: "ice", DeviceID: "159b", Vendor: "8086", PciAddress: "0000:31:00.0", Name: "ens0", NumVfs: numVfs},
+					},
+				},
+			}
+			if len(vfGroups) > 0 {
+				ns.Spec.Interfaces = sriovnetworkv1.Interfaces{{PciAddress: "0000:31:00.0", Name: "ens0", NumVfs: 8, VfGroups: vfGroups}}
+			}
+			return ns
+		}
+
+		newPolicy := func(name string, pfNames ...string) *sriovnetworkv1.SriovNetworkNodePolicy {
+			return &sriovnetworkv1.SriovNetworkNodePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+				Spec: sriovnetworkv1.SriovNetworkNodePolicySpec{
+					ResourceName: name,
+					NicSelector:  sriovnetworkv1.SriovNetworkNicSelector{PfNames: pfNames},
+					NumVfs:       8,
+					NodeSelector: map[string]string{"node-role.kubernetes.io/worker": ""},
+				},
+			}
+		}
+
+		syncStatuses := func(objs ...k8sclient.Object) *sriovnetworkv1.SriovNetworkNodePolicyList {
+			pl := &sriovnetworkv1.SriovNetworkNodePolicyList{}
+			for _, o := range objs {
+				if p, ok := o.(*sriovnetworkv1.SriovNetworkNodePolicy); ok {
+					pl.Items = append(pl.Items, *p)
+				}
+			}
+			objs = append(objs, node1, node2)
+			scheme := runtime.NewScheme()
+			utilruntime.Must(sriovnetworkv1.AddToScheme(scheme))
+			utilruntime.Must(corev1.AddToScheme(scheme))
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objs...).
+				WithStatusSubresource(&sriovnetworkv1.SriovNetworkNodePolicy{}).
+				Build()
+			r := &SriovNetworkNodePolicyReconciler{Client: c, UncachedAPIReader: c}
+			Expect(r.syncPolicyStatuses(ctx, pl, &corev1.NodeList{Items: []corev1.Node{*node1, *node2}})).To(Succeed())
+
+			result := &sriovnetworkv1.SriovNetworkNodePolicyList{}
+			Expect(r.List(ctx, result)).To(Succeed())
+			return result
+		}
+
+		getPolicy := func(pl *sriovnetworkv1.SriovNetworkNodePolicyList, name string) *sriovnetworkv1.SriovNetworkNodePolicy {
+			for i := range pl.Items {
+				if pl.Items[i].Name == name {
+					return &pl.Items[i]
+				}
+			}
+			Fail("policy not found " + name)
+			return nil
+		}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			node1 = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"node-role.kubernetes.io/worker": ""}}}
+			node2 = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node2", Labels: map[string]string{"node-role.kubernetes.io/worker": ""}}}
+		})
+
+		It("should report matched nodes, VF ranges and Ready condition", func() {
+			pl := syncStatuses(
+				newPolicy("p1", "ens0#0-3"),
+				newNodeState("node1", consts.SyncStatusSucceeded, 8, sriovnetworkv1.VfGroup{PolicyName: "p1", VfRange: "0-3"}),
+				newNodeState("node2", consts.SyncStatusInProgress, 0, sriovnetworkv1.VfGroup{PolicyName: "p1", VfRange: "0-3"}),
+			)
+			p := getPolicy(pl, "p1")
+			Expect(p.Status.MatchedNodeCount).To(Equal(2))
+			Expect(p.Status.ReadyNodeCount).To(Equal(1))
+			Expect(p.Status.Nodes).To(ConsistOf(
+				sriovnetworkv1.PolicyNodeStatus{Name: "node1", SyncStatus: consts.SyncStatusSucceeded, Synced: true,
+					Interfaces: []sriovnetworkv1.PolicyInterfaceStatus{{PciAddress: "0000:31:00.0", Name: "ens0", VfRange: "0-3"}}},
+				sriovnetworkv1.PolicyNodeStatus{Name: "node2", SyncStatus: consts.SyncStatusInProgress, Synced: false,
+					Interfaces: []sriovnetworkv1.PolicyInterfaceStatus{{PciAddress: "0000:31:00.0", Name: "ens0", VfRange: "0-3"}}},
+			))
+			ready := meta.FindStatusCondition(p.Status.Conditions, sriovnetworkv1.ConditionReady)
+			Expect(ready).ToNot(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Reason).To(Equal(sriovnetworkv1.PolicyReasonNodesSyncing))
+			Expect(meta.IsStatusConditionFalse(p.Status.Conditions, sriovnetworkv1.ConditionDegraded)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(p.Status.Conditions, sriovnetworkv1.ConditionConflicting)).To(BeTrue())
+		})
+
+		It("should report Degraded and Conflicting conditions", func() {
+			pl := syncStatuses(
+				newPolicy("p1", "ens0"),
+				newPolicy("p2", "ens0"),
+				newNodeState("node1", consts.SyncStatusFailed, 8, sriovnetworkv1.VfGroup{PolicyName: "p2", VfRange: "0-7"}),
+				newNodeState("node2", consts.SyncStatusSucceeded, 8, sriovnetworkv1.VfGroup{PolicyName: "p2", VfRange: "0-7"}),
+			)
+			p1 := getPolicy(pl, "p1")
+			Expect(meta.IsStatusConditionTrue(p1.Status.Conditions, sriovnetworkv1.ConditionConflicting)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(p1.Status.Conditions, sriovnetworkv1.ConditionDegraded)).To(BeTrue())
+
+			p2 := getPolicy(pl, "p2")
+			Expect(meta.IsStatusConditionFalse(p2.Status.Conditions, sriovnetworkv1.ConditionConflicting)).To(BeTrue())
+			degraded := meta.FindStatusCondition(p2.Status.Conditions, sriovnetworkv1.ConditionDegraded)
+			Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+			Expect(degraded.Message).To(ContainSubstring("node1"))
+			Expect(p2.Status.ReadyNodeCount).To(Equal(1))
+		})
+
+		It("should not report nodes that didn't apply the latest node state generation as synced", func() {
+			ns := newNodeState("node1", consts.SyncStatusSucceeded, 8, sriovnetworkv1.VfGroup{PolicyName: "p1", VfRange: "0-7"})
+			ns.Generation = 2
+			pl := syncStatuses(newPolicy("p1", "ens0"), ns)
+			p := getPolicy(pl, "p1")
+			Expect(p.Status.MatchedNodeCount).To(Equal(1))
+			Expect(p.Status.ReadyNodeCount).To(Equal(0))
+			Expect(meta.IsStatusConditionFalse(p.Status.Conditions, sriovnetworkv1.ConditionReady)).To(BeTrue())
+		})
+
+		It("should report policies that don't match any PF", func() {
+			pl := syncStatuses(
+				newPolicy("p1", "ens1"),
+				newNodeState("node1", consts.SyncStatusSucceeded, 0),
+			)
+			p := getPolicy(pl, "p1")
+			Expect(p.Status.MatchedNodeCount).To(Equal(0))
+			Expect(p.Status.Nodes).To(BeEmpty())
+			ready := meta.FindStatusCondition(p.Status.Conditions, sriovnetworkv1.ConditionReady)
+			Expect(ready.Reason).To(Equal(sriovnetworkv1.PolicyReasonNoMatchingNodes))
+		})
+
+		It("should report the planned changes of dry-run policies", func() {
+			p1 := newPolicy("p1", "ens0")
+			p1.Annotations = map[string]string{consts.PolicyDryRunAnnotation: "true"}
+			plannedSpec := `{"interfaces":[{"pciAddress":"0000:31:00.0","numVfs":8,"name":"ens0",` +
+				`"vfGroups":[{"resourceName":"p1","deviceType":"netdevice","vfRange":"0-7","policyName":"p1"}]}]}`
+
+			ns1 := newNodeState("node1", consts.SyncStatusSucceeded, 0)
+			ns1.Annotations = map[string]string{consts.NodeStatePlannedSpecAnnotation: plannedSpec}
+			ns1.Status.Plan = &sriovnetworkv1.NodeStatePlan{
+				PlannedSpecHash: sriovnetworkv1.PlannedSpecHash(plannedSpec),
+				DrainRequired:   true,
+			}
+			ns2 := newNodeState("node2", consts.SyncStatusSucceeded, 0)
+			ns2.Annotations = map[string]string{consts.NodeStatePlannedSpecAnnotation: plannedSpec}
+
+			pl := syncStatuses(p1, ns1, ns2)
+			p := getPolicy(pl, "p1")
+			Expect(p.Status.MatchedNodeCount).To(Equal(2))
+			Expect(p.Status.ReadyNodeCount).To(Equal(0))
+			changes := []string{"0000:31:00.0 numVfs 0 -> 8", "0000:31:00.0 vfGroups [] -> [p1:0-7:netdevice]"}
+			Expect(p.Status.Nodes).To(ConsistOf(
+				sriovnetworkv1.PolicyNodeStatus{Name: "node1", SyncStatus: consts.SyncStatusSucceeded,
+					Interfaces: []sriovnetworkv1.PolicyInterfaceStatus{{PciAddress: "0000:31:00.0", Name: "ens0", VfRange: "0-7"}},
+					Plan:       &sriovnetworkv1.PolicyNodePlan{Changes: changes, Evaluated: true, DrainRequired: true}},
+				sriovnetworkv1.PolicyNodeStatus{Name: "node2", SyncStatus: consts.SyncStatusSucceeded,
+					Interfaces: []sriovnetworkv1.PolicyInterfaceStatus{{PciAddress: "0000:31:00.0", Name: "ens0", VfRange: "0-7"}},
+					Plan:       &sriovnetworkv1.PolicyNodePlan{Changes: changes}},
+			))
+			ready := meta.FindStatusCondition(p.Status.Conditions, sriovnetworkv1.ConditionReady)
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Reason).To(Equal(sriovnetworkv1.PolicyReasonDryRun))
+			Expect(ready.Message).To(ContainSubstring("evaluated on 1 of 2 nodes"))
+			Expect(meta.IsStatusConditionFalse(p.Status.Conditions, sriovnetworkv1.ConditionConflicting)).To(BeTrue())
+		})
+	})
+
+	Context("paused pools", func() {
+		It("should mark the node states of paused pools", func() {
+			ctx := context.Background()
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"pool": "paused"}}}
+			pool := &sriovnetworkv1.SriovNetworkPoolConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "paused", Namespace: testNamespace},
+				Spec: sriovnetworkv1.SriovNetworkPoolConfigSpec{
+					NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "paused"}},
+					Paused:       true,
+				},
+			}
+			ns := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: testNamespace},
+				Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+					Interfaces: sriovnetworkv1.InterfaceExts{
+						{Driver: "ice", DeviceID: "159b", Vendor: "8086", PciAddress: "0000:31:00.0", Name: "ens0", TotalVfs: 64},
+					},
+				},
+			}
+			dc := &sriovnetworkv1.SriovOperatorConfig{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: testNamespace}}
+
+			scheme := runtime.NewScheme()
+			utilruntime.Must(sriovnetworkv1.AddToScheme(scheme))
+			utilruntime.Must(corev1.AddToScheme(scheme))
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(node, pool, ns, dc).Build()
+			r := &SriovNetworkNodePolicyReconciler{
+				Client:            c,
+				Scheme:            scheme,
+				FeatureGate:       featuregate.New(),
+				UncachedAPIReader: c,
+			}
+			npl := &sriovnetworkv1.SriovNetworkNodePolicyList{}
+			nl := &corev1.NodeList{Items: []corev1.Node{*node}}
+
+			_, err := r.syncAllSriovNetworkNodeStates(ctx, dc, npl, nl)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(r.Get(ctx, types.NamespacedName{Name: "node1", Namespace: testNamespace}, ns)).To(Succeed())
+			Expect(ns.Annotations).To(HaveKeyWithValue(consts.NodeStatePausedAnnotation, "true"))
+
+			pool.Spec.Paused = false
+			Expect(r.Update(ctx, pool)).To(Succeed())
+			_, err = r.syncAllSriovNetworkNodeStates(ctx, dc, npl, nl)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(r.Get(ctx, types.NamespacedName{Name: "node1", Namespace: testNamespace}, ns)).To(Succeed())
+			Expect(ns.Annotations).ToNot(HaveKey(consts.NodeStatePausedAnnotation))
+		})
+	})
+
+	Context("canary rollout", func() {
+		It("should hold the pool until the canary nodes are synced and soaked", func() {
+			ctx := context.Background()
+			nodes := []corev1.Node{}
+			objs := []k8sclient.Object{}
+			for _, name := range []string{"node1", "node2"} {
+				node := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"pool": "canary", "kubernetes.io/hostname": name}}}
+				nodes = append(nodes, node)
+				objs = append(objs, &node, &sriovnetworkv1.SriovNetworkNodeState{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+					Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+						Interfaces: sriovnetworkv1.InterfaceExts{
+							{Driver: "ice", DeviceID: "159b", Vendor: "8086", PciAddress: "0000:31:00.0", Name: "ens0", TotalVfs: 64},
+						},
+					},
+				})
+			}
+			pool := &sriovnetworkv1.SriovNetworkPoolConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "canary", Namespace: testNamespace},
+				Spec: sriovnetworkv1.SriovNetworkPoolConfigSpec{
+					NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "canary"}},
+					RolloutStrategy: &sriovnetworkv1.RolloutStrategy{
+						CanaryNodes: []string{"node2"},
+						SoakTime:    &metav1.Duration{Duration: time.Hour},
+					},
+				},
+			}
+			dc := &sriovnetworkv1.SriovOperatorConfig{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: testNamespace}}
+			objs = append(objs, pool, dc)
+
+			scheme := runtime.NewScheme()
+			utilruntime.Must(sriovnetworkv1.AddToScheme(scheme))
+			utilruntime.Must(corev1.AddToScheme(scheme))
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+				WithStatusSubresource(&sriovnetworkv1.SriovNetworkPoolConfig{}, &sriovnetworkv1.SriovNetworkNodeState{}).Build()
+			r := &SriovNetworkNodePolicyReconciler{
+				Client:            c,
+				Scheme:            scheme,
+				FeatureGate:       featuregate.New(),
+				UncachedAPIReader: c,
+			}
+			npl := &sriovnetworkv1.SriovNetworkNodePolicyList{}
+			nl := &corev1.NodeList{Items: nodes}
+
+			expectRollout := func(phase string, held bool) {
+				ns := &sriovnetworkv1.SriovNetworkNodeState{}
+				Expect(r.Get(ctx, types.NamespacedName{Name: "node2", Namespace: testNamespace}, ns)).To(Succeed())
+				Expect(ns.Annotations).ToNot(HaveKey(consts.NodeStateRolloutHoldAnnotation))
+				Expect(r.Get(ctx, types.NamespacedName{Name: "node1", Namespace: testNamespace}, ns)).To(Succeed())
+				if held {
+					Expect(ns.Annotations).To(HaveKey(consts.NodeStateRolloutHoldAnnotation))
+				} else {
+					Expect(ns.Annotations).ToNot(HaveKey(consts.NodeStateRolloutHoldAnnotation))
+				}
+				p := &sriovnetworkv1.SriovNetworkPoolConfig{}
+				Expect(r.Get(ctx, k8sclient.ObjectKeyFromObject(pool), p)).To(Succeed())
+				Expect(p.Status.Rollout).ToNot(BeNil())
+				Expect(p.Status.Rollout.CanaryNodes).To(Equal([]string{"node2"}))
+				Expect(p.Status.Rollout.Phase).To(Equal(phase))
+			}
+			setCanaryStatus := func(syncStatus string) {
+				ns := &sriovnetworkv1.SriovNetworkNodeState{}
+				Expect(r.Get(ctx, types.NamespacedName{Name: "node2", Namespace: testNamespace}, ns)).To(Succeed())
+				ns.Status.SyncStatus = syncStatus
+				ns.Status.ObservedGeneration = ns.Generation
+				Expect(r.Status().Update(ctx, ns)).To(Succeed())
+			}
+			// endSoakTime moves the start of the soak time of the canary before the soak time of the pool
+			endSoakTime := func() {
+				p := &sriovnetworkv1.SriovNetworkPoolConfig{}
+				Expect(r.Get(ctx, k8sclient.ObjectKeyFromObject(pool), p)).To(Succeed())
+				Expect(p.Status.Rollout.SoakStartTimes).To(HaveKey("node2"))
+				p.Status.Rollout.SoakStartTimes["node2"] = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+				Expect(r.Status().Update(ctx, p)).To(Succeed())
+			}
+
+			requeueAfter, err := r.syncAllSriovNetworkNodeStates(ctx, dc, npl, nl)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requeueAfter).To(BeZero())
+			expectRollout(RolloutPhaseCanary, true)
+
+			setCanaryStatus(consts.SyncStatusFailed)
+			_, err = r.syncAllSriovNetworkNodeStates(ctx, dc, npl, nl)
+			Expect(err).ToNot(HaveOccurred())
+			expectRollout(RolloutPhaseStopped, true)
+
+			By("starting the soak time when the canary is synced with its target generation")
+			setCanaryStatus(consts.SyncStatusSucceeded)
+			requeueAfter, err = r.syncAllSriovNetworkNodeStates(ctx, dc, npl, nl)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+			expectRollout(RolloutPhaseSoaking, true)
+
+			endSoakTime()
+			requeueAfter, err = r.syncAllSriovNetworkNodeStates(ctx, dc, npl, nl)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requeueAfter).To(BeZero())
+			expectRollout(RolloutPhaseCompleted, false)
+
+			By("soaking the canary again when a node of the pool gets a new configuration")
+			npl.Items = []sriovnetworkv1.SriovNetworkNodePolicy{{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1-only", Namespace: testNamespace},
+				Spec: sriovnetworkv1.SriovNetworkNodePolicySpec{
+					NodeSelector: map[string]string{"kubernetes.io/hostname": "node1"},
+					NicSelector:  sriovnetworkv1.SriovNetworkNicSelector{PfNames: []string{"ens0"}},
+					NumVfs:       4,
+					ResourceName: "p0",
+				},
+			}}
+			requeueAfter, err = r.syncAllSriovNetworkNodeStates(ctx, dc, npl, nl)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+			expectRollout(RolloutPhaseSoaking, true)
+			ns := &sriovnetworkv1.SriovNetworkNodeState{}
+			Expect(r.Get(ctx, types.NamespacedName{Name: "node1", Namespace: testNamespace}, ns)).To(Succeed())
+			Expect(ns.Spec.Interfaces).To(HaveLen(1))
+
+			// the held node keeps waiting once its spec is rendered
+			requeueAfter, err = r.syncAllSriovNetworkNodeStates(ctx, dc, npl, nl)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+			expectRollout(RolloutPhaseSoaking, true)
+
+			endSoakTime()
+			_, err = r.syncAllSriovNetworkNodeStates(ctx, dc, npl, nl)
+			Expect(err).ToNot(HaveOccurred())
+			expectRollout(RolloutPhaseCompleted, false)
+
+			By("not holding the pool when no node gets a new configuration")
+			requeueAfter, err = r.syncAllSriovNetworkNodeStates(ctx, dc, npl, nl)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requeueAfter).To(BeZero())
+			expectRollout(RolloutPhaseCompleted, false)
+		})
+
+		It("should wait for the canary node state holding the configuration being rolled out", func() {
+			ctx := context.Background()
+			canary := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: testNamespace, Generation: 2},
+				Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+					SyncStatus:         consts.SyncStatusSucceeded,
+					ObservedGeneration: 2,
+					Conditions: []metav1.Condition{{
+						Type:               sriovnetworkv1.ConditionSynced,
+						Status:             metav1.ConditionTrue,
+						Reason:             sriovnetworkv1.NodeStateReasonSyncSucceeded,
+						LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+					}},
+				},
+			}
+			pool := &sriovnetworkv1.SriovNetworkPoolConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "canary", Namespace: testNamespace},
+				Spec: sriovnetworkv1.SriovNetworkPoolConfigSpec{
+					RolloutStrategy: &sriovnetworkv1.RolloutStrategy{CanaryNodes: []string{"node1"}},
+				},
+				Status: sriovnetworkv1.SriovNetworkPoolConfigStatus{
+					Rollout: &sriovnetworkv1.PoolRolloutStatus{TargetGenerations: map[string]int64{"node1": 3}},
+				},
+			}
+			nodes := []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}, {ObjectMeta: metav1.ObjectMeta{Name: "node2"}}}
+			scheme := runtime.NewScheme()
+			utilruntime.Must(sriovnetworkv1.AddToScheme(scheme))
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(canary).Build()
+
+			// the node state read is older than the one updated for the rollout
+			rollout, err := newPoolRollout(pool, nodes)
+			Expect(err).ToNot(HaveOccurred())
+			hold, err := rollout.holdReason(ctx, c, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hold).To(ContainSubstring("waiting for canary nodes to apply the configuration: node1"))
+			Expect(rollout.status.TargetGenerations).To(Equal(map[string]int64{"node1": 3}))
+
+			// an older generation doesn't move the target backward
+			rollout, err = newPoolRollout(pool, nodes)
+			Expect(err).ToNot(HaveOccurred())
+			rollout.setTargetGeneration("node1", 1)
+			hold, err = rollout.holdReason(ctx, c, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hold).ToNot(BeEmpty())
+
+			pool.Status.Rollout.TargetGenerations["node1"] = 2
+			rollout, err = newPoolRollout(pool, nodes)
+			Expect(err).ToNot(HaveOccurred())
+			hold, err = rollout.holdReason(ctx, c, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hold).To(BeEmpty())
+			Expect(rollout.status.Phase).To(Equal(RolloutPhaseCompleted))
+		})
+	})
+
+	Context("ib guid pools", func() {
+		It("should allocate a block of GUIDs to the InfiniBand PFs", func() {
+			ctx := context.Background()
+			nodes := []corev1.Node{}
+			objs := []k8sclient.Object{}
+			for _, name := range []string{"node1", "node2", "node3"} {
+				node := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"ib": "true"}}}
+				nodes = append(nodes, node)
+				objs = append(objs, &node, &sriovnetworkv1.SriovNetworkNodeState{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+					Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+						Interfaces: sriovnetworkv1.InterfaceExts{
+							{Driver: "mlx5_core", DeviceID: "101b", Vendor: "15b3", PciAddress: "0000:31:00.0", Name: "ibs1",
+								LinkType: "IB", TotalVfs: 8},
+							{Driver: "ice", DeviceID: "159b", Vendor: "8086", PciAddress: "0000:41:00.0", Name: "ens1",
+								LinkType: "ETH", TotalVfs: 8},
+						},
+					},
+				})
+			}
+			guidPool := &sriovnetworkv1.SriovIBGUIDPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+				Spec: sriovnetworkv1.SriovIBGUIDPoolSpec{
+					Ranges: []sriovnetworkv1.IbGUIDRange{{Start: "02:00:00:00:00:00:00:00", End: "02:00:00:00:00:00:00:0f"}},
+				},
+			}
+			dc := &sriovnetworkv1.SriovOperatorConfig{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: testNamespace}}
+			objs = append(objs, guidPool, dc)
+
+			scheme := runtime.NewScheme()
+			utilruntime.Must(sriovnetworkv1.AddToScheme(scheme))
+			utilruntime.Must(corev1.AddToScheme(scheme))
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+				WithStatusSubresource(&sriovnetworkv1.SriovIBGUIDPool{}, &sriovnetworkv1.SriovNetworkNodeState{}).Build()
+			r := &SriovNetworkNodePolicyReconciler{
+				Client:            c,
+				Scheme:            scheme,
+				FeatureGate:       featuregate.New(),
+				UncachedAPIReader: c,
+			}
+			npl := &sriovnetworkv1.SriovNetworkNodePolicyList{Items: []sriovnetworkv1.SriovNetworkNodePolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "ib", Namespace: testNamespace},
+					Spec: sriovnetworkv1.SriovNetworkNodePolicySpec{
+						NodeSelector: map[string]string{"ib": "true"},
+						NicSelector:  sriovnetworkv1.SriovNetworkNicSelector{Vendor: "15b3"},
+						NumVfs:       4,
+						LinkType:     "ib",
+						ResourceName: "ib",
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "eth", Namespace: testNamespace},
+					Spec: sriovnetworkv1.SriovNetworkNodePolicySpec{
+						NodeSelector: map[string]string{"ib": "true"},
+						NicSelector:  sriovnetworkv1.SriovNetworkNicSelector{Vendor: "8086"},
+						NumVfs:       4,
+						ResourceName: "eth",
+					},
+				},
+			}}
+			nl := &corev1.NodeList{Items: nodes}
+
+			allocationOf := func(name string) *sriovnetworkv1.IbGUIDAllocation {
+				ns := &sriovnetworkv1.SriovNetworkNodeState{}
+				Expect(r.Get(ctx, types.NamespacedName{Name: name, Namespace: testNamespace}, ns)).To(Succeed())
+				Expect(ns.Spec.Interfaces).To(HaveLen(2))
+				var allocation *sriovnetworkv1.IbGUIDAllocation
+				for _, iface := range ns.Spec.Interfaces {
+					if iface.PciAddress == "0000:41:00.0" {
+						Expect(iface.IbGUIDAllocation).To(BeNil())
+					} else {
+						allocation = iface.IbGUIDAllocation
+					}
+				}
+				return allocation
+			}
+
+			_, err := r.syncAllSriovNetworkNodeStates(ctx, dc, npl, nl)
+			Expect(err).ToNot(HaveOccurred())
+			// a block of TotalVfs GUIDs for each PF, the pool is exhausted for node3
+			Expect(allocationOf("node1")).To(Equal(&sriovnetworkv1.IbGUIDAllocation{
+				Pool: "pool", Start: "02:00:00:00:00:00:00:00", End: "02:00:00:00:00:00:00:07"}))
+			Expect(allocationOf("node2")).To(Equal(&sriovnetworkv1.IbGUIDAllocation{
+				Pool: "pool", Start: "02:00:00:00:00:00:00:08", End: "02:00:00:00:00:00:00:0f"}))
+			Expect(allocationOf("node3")).To(BeNil())
+			p := &sriovnetworkv1.SriovIBGUIDPool{}
+			Expect(r.Get(ctx, k8sclient.ObjectKeyFromObject(guidPool), p)).To(Succeed())
+			Expect(p.Status).To(Equal(sriovnetworkv1.SriovIBGUIDPoolStatus{Allocated: 16, Unallocated: []string{"node3/0000:31:00.0"}}))
+
+			// node1 is replaced by node3, its block is released and the other allocations are kept
+			_, err = r.syncAllSriovNetworkNodeStates(ctx, dc, npl, &corev1.NodeList{Items: nodes[1:]})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(r.Delete(ctx, &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: testNamespace}})).To(Succeed())
+			_, err = r.syncAllSriovNetworkNodeStates(ctx, dc, npl, &corev1.NodeList{Items: nodes[1:]})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allocationOf("node2")).To(Equal(&sriovnetworkv1.IbGUIDAllocation{
+				Pool: "pool", Start: "02:00:00:00:00:00:00:08", End: "02:00:00:00:00:00:00:0f"}))
+			Expect(allocationOf("node3")).To(Equal(&sriovnetworkv1.IbGUIDAllocation{
+				Pool: "pool", Start: "02:00:00:00:00:00:00:00", End: "02:00:00:00:00:00:00:07"}))
+			Expect(r.Get(ctx, k8sclient.ObjectKeyFromObject(guidPool), p)).To(Succeed())
+			Expect(p.Status).To(Equal(sriovnetworkv1.SriovIBGUIDPoolStatus{Allocated: 16}))
+		})
+
+		It("should keep the pool of the allocated PFs when a pool is added", func() {
+			ctx := context.Background()
+			nodes := []corev1.Node{}
+			objs := []k8sclient.Object{}
+			for _, name := range []string{"node1", "node2", "node3"} {
+				node := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"ib": "true", "name": name}}}
+				nodes = append(nodes, node)
+				objs = append(objs, &node, &sriovnetworkv1.SriovNetworkNodeState{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+					Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+						Interfaces: sriovnetworkv1.InterfaceExts{
+							{Driver: "mlx5_core", DeviceID: "101b", Vendor: "15b3", PciAddress: "0000:31:00.0", Name: "ibs1",
+								LinkType: "IB", TotalVfs: 8},
+						},
+					},
+				})
+			}
+			guidPool := &sriovnetworkv1.SriovIBGUIDPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+				Spec: sriovnetworkv1.SriovIBGUIDPoolSpec{
+					Ranges: []sriovnetworkv1.IbGUIDRange{{Start: "02:00:00:00:00:00:00:00", End: "02:00:00:00:00:00:00:0f"}},
+				},
+			}
+			dc := &sriovnetworkv1.SriovOperatorConfig{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: testNamespace}}
+			objs = append(objs, guidPool, dc)
+
+			scheme := runtime.NewScheme()
+			utilruntime.Must(sriovnetworkv1.AddToScheme(scheme))
+			utilruntime.Must(corev1.AddToScheme(scheme))
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+				WithStatusSubresource(&sriovnetworkv1.SriovIBGUIDPool{}, &sriovnetworkv1.SriovNetworkNodeState{}).Build()
+			r := &SriovNetworkNodePolicyReconciler{
+				Client:            c,
+				Scheme:            scheme,
+				FeatureGate:       featuregate.New(),
+				UncachedAPIReader: c,
+			}
+			npl := &sriovnetworkv1.SriovNetworkNodePolicyList{Items: []sriovnetworkv1.SriovNetworkNodePolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "ib", Namespace: testNamespace},
+					Spec: sriovnetworkv1.SriovNetworkNodePolicySpec{
+						NodeSelector: map[string]string{"ib": "true"},
+						NicSelector:  sriovnetworkv1.SriovNetworkNicSelector{Vendor: "15b3"},
+						NumVfs:       4,
+						LinkType:     "ib",
+						ResourceName: "ib",
+					},
+				},
+			}}
+			nl := &corev1.NodeList{Items: nodes}
+
+			allocationOf := func(name string) *sriovnetworkv1.IbGUIDAllocation {
+				ns := &sriovnetworkv1.SriovNetworkNodeState{}
+				Expect(r.Get(ctx, types.NamespacedName{Name: name, Namespace: testNamespace}, ns)).To(Succeed())
+				Expect(ns.Spec.Interfaces).To(HaveLen(1))
+				return ns.Spec.Interfaces[0].IbGUIDAllocation
+			}
+
+			_, err := r.syncAllSriovNetworkNodeStates(ctx, dc, npl, nl)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allocationOf("node3")).To(BeNil())
+
+			// the new pool is the first one by name, only the PF without a block is allocated from it
+			Expect(r.Create(ctx, &sriovnetworkv1.SriovIBGUIDPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "a-pool"},
+				Spec: sriovnetworkv1.SriovIBGUIDPoolSpec{
+					Ranges: []sriovnetworkv1.IbGUIDRange{{Start: "03:00:00:00:00:00:00:00", End: "03:00:00:00:00:00:00:0f"}},
+				},
+			})).To(Succeed())
+			_, err = r.syncAllSriovNetworkNodeStates(ctx, dc, npl, nl)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allocationOf("node1")).To(Equal(&sriovnetworkv1.IbGUIDAllocation{
+				Pool: "pool", Start: "02:00:00:00:00:00:00:00", End: "02:00:00:00:00:00:00:07"}))
+			Expect(allocationOf("node2")).To(Equal(&sriovnetworkv1.IbGUIDAllocation{
+				Pool: "pool", Start: "02:00:00:00:00:00:00:08", End: "02:00:00:00:00:00:00:0f"}))
+			Expect(allocationOf("node3")).To(Equal(&sriovnetworkv1.IbGUIDAllocation{
+				Pool: "a-pool", Start: "03:00:00:00:00:00:00:00", End: "03:00:00:00:00:00:00:07"}))
+
+			// the PFs of the nodes the pool doesn't select anymore move to the first pool selecting them
+			Expect(r.Get(ctx, k8sclient.ObjectKeyFromObject(guidPool), guidPool)).To(Succeed())
+			guidPool.Spec.NodeSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"name": "node1"}}
+			Expect(r.Update(ctx, guidPool)).To(Succeed())
+			_, err = r.syncAllSriovNetworkNodeStates(ctx, dc, npl, nl)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allocationOf("node1")).To(Equal(&sriovnetworkv1.IbGUIDAllocation{
+				Pool: "pool", Start: "02:00:00:00:00:00:00:00", End: "02:00:00:00:00:00:00:07"}))
+			Expect(allocationOf("node2")).To(Equal(&sriovnetworkv1.IbGUIDAllocation{
+				Pool: "a-pool", Start: "03:00:00:00:00:00:00:08", End: "03:00:00:00:00:00:00:0f"}))
+			p := &sriovnetworkv1.SriovIBGUIDPool{}
+			Expect(r.Get(ctx, k8sclient.ObjectKeyFromObject(guidPool), p)).To(Succeed())
+			Expect(p.Status).To(Equal(sriovnetworkv1.SriovIBGUIDPoolStatus{Allocated: 8}))
+		})
+	})
+
+	Context("renderPlannedSpec", func() {
+		var (
+			node *corev1.Node
+			r    *SriovNetworkNodePolicyReconciler
+		)
+
+		newPolicy := func(name string, numVfs int, dryRun bool) sriovnetworkv1.SriovNetworkNodePolicy {
+			p := sriovnetworkv1.SriovNetworkNodePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+				Spec: sriovnetworkv1.SriovNetworkNodePolicySpec{
+					ResourceName: name,
+					NicSelector:  sriovnetworkv1.SriovNetworkNicSelector{PfNames: []string{"ens0"}},
+					NumVfs:       numVfs,
+					NodeSelector: map[string]string{"node-role.kubernetes.io/worker": ""},
+				},
+			}
+			if dryRun {
+				p.Annotations = map[string]string{consts.PolicyDryRunAnnotation: "true"}
+			}
+			return p
+		}
+
+		newNodeState := func() *sriovnetworkv1.SriovNetworkNodeState {
+			return &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: testNamespace},
+				Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+					Interfaces: sriovnetworkv1.InterfaceExts{
+						{Driver: "ice", DeviceID: "159b", Vendor: "8086", PciAddress: "0000:31:00.0", Name: "ens0", TotalVfs: 64},
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"node-role.kubernetes.io/worker": ""}}}
+			r = &SriovNetworkNodePolicyReconciler{FeatureGate: featuregate.New()}
+		})
+
+		It("should render dry-run policies only in the planned spec annotation", func() {
+			npl := &sriovnetworkv1.SriovNetworkNodePolicyList{Items: []sriovnetworkv1.SriovNetworkNodePolicy{
+				newPolicy("p1", 4, false),
+				newPolicy("p2", 8, true),
+			}}
+			ns := newNodeState()
+			Expect(r.applyPolicies(ns, npl, node, false)).To(Succeed())
+			Expect(r.renderPlannedSpec(ns, sriovnetworkv1.SriovNetworkNodeStateSpec{}, npl, node)).To(Succeed())
+
+			Expect(ns.Spec.Interfaces).To(HaveLen(1))
+			Expect(ns.Spec.Interfaces[0].NumVfs).To(Equal(4))
+			Expect(ns.Spec.Interfaces[0].VfGroups).To(HaveLen(1))
+			Expect(ns.Spec.Interfaces[0].VfGroups[0].PolicyName).To(Equal("p1"))
+
+			planned := sriovnetworkv1.SriovNetworkNodeStateSpec{}
+			Expect(json.Unmarshal([]byte(ns.Annotations[consts.NodeStatePlannedSpecAnnotation]), &planned)).To(Succeed())
+			Expect(planned.Interfaces).To(HaveLen(1))
+			Expect(planned.Interfaces[0].NumVfs).To(Equal(8))
+			Expect(planned.Interfaces[0].VfGroups[0].PolicyName).To(Equal("p2"))
+		})
+
+		It("should remove the planned spec annotation without dry-run policies", func() {
+			npl := &sriovnetworkv1.SriovNetworkNodePolicyList{Items: []sriovnetworkv1.SriovNetworkNodePolicy{
+				newPolicy("p1", 4, false),
+			}}
+			ns := newNodeState()
+			ns.Annotations = map[string]string{consts.NodeStatePlannedSpecAnnotation: "{}"}
+			Expect(r.applyPolicies(ns, npl, node, false)).To(Succeed())
+			Expect(r.renderPlannedSpec(ns, sriovnetworkv1.SriovNetworkNodeStateSpec{}, npl, node)).To(Succeed())
+			Expect(ns.Annotations).ToNot(HaveKey(consts.NodeStatePlannedSpecAnnotation))
+		})
 	})
 })
@@ -18,6 +18,9 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -196,6 +199,7 @@ func (r *SriovOperatorConfigReconciler) syncConfigDaemonSet(ctx context.Context,
 	data.Data["ReleaseVersion"] = os.Getenv("RELEASEVERSION")
 	data.Data["ClusterType"] = vars.ClusterType
 	data.Data["DevMode"] = os.Getenv("DEV_MODE")
+	data.Data["MetricsBindAddress"] = os.Getenv("CONFIG_DAEMON_METRICS_BIND_ADDRESS")
 	data.Data["UseExternalDrainer"] = vars.UseExternalDrainer
 	data.Data["ImagePullSecrets"] = GetImagePullSecrets()
 	if dc.Spec.ConfigurationMode == sriovnetworkv1.SystemdConfigurationMode {
@@ -221,6 +225,16 @@ func (r *SriovOperatorConfigReconciler) syncConfigDaemonSet(ctx context.Context,
 
 	data.Data["ConfigDaemonEnvVars"] = dc.Spec.ConfigDaemonEnvVars
 
+	// the config daemons load the external plugins at startup, the hash rolls the daemonset when they change
+	if len(dc.Spec.ExternalPlugins) > 0 {
+		hash, err := externalPluginsHash(dc.Spec.ExternalPlugins)
+		if err != nil {
+			logger.Error(err, "Fail to hash the external plugins")
+			return err
+		}
+		data.Data["ExternalPluginsHash"] = hash
+	}
+
 	objs, err := render.RenderDir(consts.ConfigDaemonPath, &data)
 	if err != nil {
 		logger.Error(err, "Fail to render config daemon manifests")
@@ -558,3 +572,13 @@ func (r SriovOperatorConfigReconciler) deleteAllWebhooks(ctx context.Context) er
 
 	return err
 }
+
+// externalPluginsHash returns a short hash identifying the external plugins configuration
+func externalPluginsHash(plugins []sriovnetworkv1.ExternalPlugin) (string, error) {
+	data, err := json.Marshal(plugins)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:8]), nil
+}
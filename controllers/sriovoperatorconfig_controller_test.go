@@ -464,6 +464,47 @@ var _ = Describe("SriovOperatorConfig controller", Ordered, func() {
 				return strings.Join(daemonSet.Spec.Template.Spec.Containers[0].Args, " ")
 			}, util.APITimeout*10, util.RetryInterval).Should(ContainSubstring("disable-plugins=mellanox"))
 		})
+
+		It("should render the metrics-bind-address cmdline flag of sriov-network-config-daemon if set in the operator environment", func() {
+			DeferCleanup(os.Setenv, "CONFIG_DAEMON_METRICS_BIND_ADDRESS", os.Getenv("CONFIG_DAEMON_METRICS_BIND_ADDRESS"))
+			os.Setenv("CONFIG_DAEMON_METRICS_BIND_ADDRESS", ":9111")
+
+			// update the config to trigger a reconcile
+			config := &sriovnetworkv1.SriovOperatorConfig{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "default"}, config)).NotTo(HaveOccurred())
+			config.Spec.LogLevel = 3
+			Expect(k8sClient.Update(ctx, config)).NotTo(HaveOccurred())
+
+			Eventually(func() string {
+				daemonSet := &appsv1.DaemonSet{}
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: "sriov-network-config-daemon", Namespace: testNamespace}, daemonSet)
+				if err != nil {
+					return ""
+				}
+				return strings.Join(daemonSet.Spec.Template.Spec.Containers[0].Args, " ")
+			}, util.APITimeout*10, util.RetryInterval).Should(ContainSubstring("--metrics-bind-address=:9111"))
+		})
+		It("should roll the sriov-network-config-daemon when the external plugins change", func() {
+			config := &sriovnetworkv1.SriovOperatorConfig{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "default"}, config)).NotTo(HaveOccurred())
+
+			config.Spec.ExternalPlugins = []sriovnetworkv1.ExternalPlugin{
+				{Name: "acme", Vendors: []string{"1d0f"}, Command: "/usr/local/bin/acme-sriov-plugin"}}
+			err := k8sClient.Update(ctx, config)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "default"}, config)).NotTo(HaveOccurred())
+				config.Spec.ExternalPlugins = nil
+				Expect(k8sClient.Update(ctx, config)).NotTo(HaveOccurred())
+			})
+
+			Eventually(func(g Gomega) {
+				daemonSet := &appsv1.DaemonSet{}
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: "sriov-network-config-daemon", Namespace: testNamespace}, daemonSet)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(daemonSet.Spec.Template.Annotations).To(HaveKey("sriovnetwork.openshift.io/external-plugins-hash"))
+			}, util.APITimeout*10, util.RetryInterval).Should(Succeed())
+		})
 		It("should render configDaemonEnvVars in sriov-network-config-daemon if provided in spec", func() {
 			config := &sriovnetworkv1.SriovOperatorConfig{}
 			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "default"}, config)).NotTo(HaveOccurred())
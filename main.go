@@ -20,6 +20,8 @@ import (
 	"context"
 	"flag"
 	"os"
+	// embed the time zone database used by the pool maintenance windows
+	_ "time/tzdata"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -182,9 +184,10 @@ func main() {
 		os.Exit(1)
 	}
 	if err = (&controllers.SriovNetworkNodePolicyReconciler{
-		Client:      mgr.GetClient(),
-		Scheme:      mgr.GetScheme(),
-		FeatureGate: featureGate,
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		FeatureGate:       featureGate,
+		UncachedAPIReader: mgr.GetAPIReader(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SriovNetworkNodePolicy")
 		os.Exit(1)
@@ -37,6 +37,9 @@ const (
 	ResyncPeriod               = 5 * time.Minute
 	DaemonRequeueTime          = 30 * time.Second
 	DrainControllerRequeueTime = 5 * time.Second
+	// MaintenanceWindowRequeueTime is the maximum time a node waiting for a maintenance window is requeued after,
+	// so changes of the pool maintenance windows are taken into account
+	MaintenanceWindowRequeueTime = time.Minute
 
 	DefaultConfigName                  = "default"
 	ConfigDaemonPath                   = "./bindata/manifests/daemon"
@@ -71,10 +74,45 @@ const (
 
 	UninitializedNodeGUID = "0000:0000:0000:0000"
 
-	DeviceTypeVfioPci   = "vfio-pci"
-	DeviceTypeNetDevice = "netdevice"
-	VdpaTypeVirtio      = "virtio"
-	VdpaTypeVhost       = "vhost"
+	DeviceTypeVfioPci        = "vfio-pci"
+	DeviceTypeVfioPciNoIommu = "vfio-pci-noiommu"
+	DeviceTypeUioPciGeneric  = "uio_pci_generic"
+	DeviceTypeIgbUio         = "igb_uio"
+	DeviceTypeNetDevice      = "netdevice"
+	DeviceTypeSf             = "sf"
+	VdpaTypeVirtio           = "virtio"
+	VdpaTypeVhost            = "vhost"
+
+	// device type and auxiliary type of the scalable functions in the device plugin config
+	DevicePluginAuxNetDevice = "auxNetDevice"
+	DevicePluginAuxTypeSf    = "sf"
+
+	SfStateActive   = "active"
+	SfStateInactive = "inactive"
+
+	BondModeActiveBackup     = "active-backup"
+	BondXmitHashPolicyLayer2 = "layer2"
+	// alias of the bonds of PFs created by the config daemon, used to discover the managed bonds
+	BondManagedAlias = "sriov-network-operator-bond"
+
+	DevlinkParamCmodeRuntime    = "runtime"
+	DevlinkParamCmodeDriverinit = "driverinit"
+	DevlinkParamCmodePermanent  = "permanent"
+
+	// only the mlx4 driver exposes the PKey table of the VFs on the host, the PKeys of the mlx5 VFs
+	// are assigned by the subnet manager to their GUID
+	Mlx4DriverName = "mlx4_core"
+
+	IntelVendorID = "8086"
+	IceDriverName = "ice"
+	// the ice driver loads the DDP package ice-<serial number>.pkg of this directory for the device
+	// with this serial number, and the ice.pkg of the parent directory for the other devices
+	IceDdpPackagePath = "/lib/firmware/updates/intel/ice/ddp"
+	// oldest NVM version of the E810 NICs supporting the switchdev mode
+	IceSwitchdevMinNvmVersion = "4.00"
+
+	// checkpoint of the kubelet device manager, it records the devices allocated to the pods by the device plugins
+	KubeletDeviceManagerCheckpoint = "/var/lib/kubelet/device-plugins/kubelet_internal_checkpoint"
 
 	RdmaSubsystemModeShared    = "shared"
 	RdmaSubsystemModeExclusive = "exclusive"
@@ -84,6 +122,18 @@ const (
 	SriovSwitchDevConfPath     = SriovConfBasePath + "/sriov_config.json"
 	SriovHostSwitchDevConfPath = Host + SriovSwitchDevConfPath
 	ManagedOVSBridgesPath      = SriovConfBasePath + "/managed-ovs-bridges.json"
+	LastAppliedNodeStatePath   = SriovConfBasePath + "/last-applied-node-state.json"
+	// reboots requested by the config daemon, used to count the completed reboots across the restarts
+	RebootsPath = SriovConfBasePath + "/reboots.json"
+	// random ID generated by the kernel on each boot
+	BootIDPath = "/proc/sys/kernel/random/boot_id"
+	// kernel arguments added by the config daemon to the boot configuration
+	ManagedKernelArgsPath = SriovConfBasePath + "/managed-kernel-args.json"
+	// SF numbers of the SFs created by the config daemon by PF PCI address
+	ManagedSfsPath = SriovConfBasePath + "/managed-sfs.json"
+
+	// DefaultAutoRollbackMaxFailures is the number of failures before a rollback when not set in the SriovOperatorConfig
+	DefaultAutoRollbackMaxFailures = 3
 
 	MachineConfigPoolPausedAnnotation       = "sriovnetwork.openshift.io/state"
 	MachineConfigPoolPausedAnnotationIdle   = "Idle"
@@ -123,6 +173,20 @@ const (
 
 	DevicePluginWaitConfigAnnotation = "sriovnetwork.openshift.io/device-plugin-wait-config"
 
+	// PolicyDryRunAnnotation marks a SriovNetworkNodePolicy as a preview, the policy is rendered in the planned
+	// configuration of the nodes but it is not applied
+	PolicyDryRunAnnotation = "sriovnetwork.openshift.io/dry-run"
+	// NodeStatePlannedSpecAnnotation contains the SriovNetworkNodeState spec rendered with the dry-run policies
+	NodeStatePlannedSpecAnnotation = "sriovnetwork.openshift.io/planned-spec"
+	// NodeStateMaintenanceWindowAnnotation contains the start time of the next maintenance window
+	// when the node waits for it to start the drain
+	NodeStateMaintenanceWindowAnnotation = "sriovnetwork.openshift.io/waiting-for-maintenance-window"
+	// NodeStatePausedAnnotation is set to "true" on the SriovNetworkNodeState when the node pool is paused
+	NodeStatePausedAnnotation = "sriovnetwork.openshift.io/paused"
+	// NodeStateRolloutHoldAnnotation contains the reason the node waits for the canary nodes of its pool
+	// before applying a new configuration
+	NodeStateRolloutHoldAnnotation = "sriovnetwork.openshift.io/rollout-hold"
+
 	// NodeStateKeepUntilAnnotation contains name of the "keep until time" annotation for SriovNetworkNodeState object.
 	// The "keep until time" specifies the earliest time at which the state object can be removed
 	// if the daemon's pod is not found on the node.
@@ -136,14 +200,18 @@ const (
 
 	SysBus                = "/sys/bus"
 	SysBusPciDevices      = SysBus + "/pci/devices"
+	SysBusPciSlots        = SysBus + "/pci/slots"
 	SysBusPciDrivers      = SysBus + "/pci/drivers"
+	SysKernelIommuGroups  = "/sys/kernel/iommu_groups"
 	SysBusPciDriversProbe = SysBus + "/pci/drivers_probe"
 	SysClassNet           = "/sys/class/net"
+	SysModule             = "/sys/module"
 	ProcKernelCmdLine     = "/proc/cmdline"
 	NetClass              = 0x02
 	NumVfsFile            = "sriov_numvfs"
 	BusPci                = "pci"
 	BusVdpa               = "vdpa"
+	BusAuxiliary          = "auxiliary"
 
 	UdevFolder          = "/etc/udev"
 	HostUdevFolder      = Host + UdevFolder
@@ -172,6 +240,14 @@ const (
 	KernelArgRdmaShared    = "ib_core.netns_mode=1"
 	KernelArgRdmaExclusive = "ib_core.netns_mode=0"
 
+	// bootloaders configuring the kernel arguments of the next boot
+	BootloaderOstree      = "rpm-ostree"
+	BootloaderGrubby      = "grubby"
+	BootloaderSystemdBoot = "systemd-boot"
+	BootloaderBLS         = "bls"
+	BootloaderFlatcarGrub = "flatcar-grub"
+	BootloaderGrub        = "grub"
+
 	// Systemd consts
 	SriovSystemdConfigPath        = SriovConfBasePath + "/sriov-interface-config.yaml"
 	SriovSystemdResultPath        = SriovConfBasePath + "/sriov-interface-result.yaml"
@@ -202,6 +278,9 @@ const (
 	// MellanoxFirmwareResetFeatureGate: enables the firmware reset via mstfwreset before a reboot
 	MellanoxFirmwareResetFeatureGate = "mellanoxFirmwareReset"
 
+	// HostEventWatcherFeatureGate: reconciles the node state on the netlink and uevent events of the managed PFs
+	HostEventWatcherFeatureGate = "hostEventWatcher"
+
 	// The path to the file on the host filesystem that contains the IB GUID distribution for IB VFs
 	InfinibandGUIDConfigFilePath = SriovConfBasePath + "/infiniband/guids"
 )
@@ -0,0 +1,153 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package daemon
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	mock_helper "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper/mock"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/store"
+	mock_platform "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/platform/mock"
+	plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins/generic"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins/intel"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+var _ = Describe("Apply", func() {
+	const (
+		applyNodeName = "apply-node"
+		ddpPackage    = "ice_comms-1.3.45.0.pkg"
+	)
+	var (
+		dn           *NodeReconciler
+		hostHelper   *mock_helper.MockHostHelpersInterface
+		platformMock *mock_platform.MockInterface
+		nodeState    *sriovnetworkv1.SriovNetworkNodeState
+	)
+
+	BeforeEach(func() {
+		testCtrl := gomock.NewController(GinkgoT())
+		hostHelper = mock_helper.NewMockHostHelpersInterface(testCtrl)
+		platformMock = mock_platform.NewMockInterface(testCtrl)
+
+		DeferCleanup(func(nodeName, namespace string, systemd, bridges bool) {
+			vars.NodeName = nodeName
+			vars.Namespace = namespace
+			vars.UsingSystemdMode = systemd
+			vars.ManageSoftwareBridges = bridges
+		}, vars.NodeName, vars.Namespace, vars.UsingSystemdMode, vars.ManageSoftwareBridges)
+		vars.NodeName = applyNodeName
+		vars.Namespace = "sriov-network-operator"
+		vars.UsingSystemdMode = false
+		vars.ManageSoftwareBridges = false
+
+		// the ports of an E810 adapter, the first one has 4 VFs
+		nodeState = &sriovnetworkv1.SriovNetworkNodeState{
+			ObjectMeta: metav1.ObjectMeta{Name: applyNodeName, Namespace: vars.Namespace, Generation: 2},
+			Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+				Interfaces: sriovnetworkv1.Interfaces{{PciAddress: "0000:d8:00.0", Name: "ens1f0", NumVfs: 4, DdpPackage: ddpPackage}},
+			},
+			Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+				Interfaces: sriovnetworkv1.InterfaceExts{
+					{PciAddress: "0000:d8:00.0", Name: "ens1f0", Vendor: "8086", Driver: "ice", TotalVfs: 64, NumVfs: 4,
+						VFs: []sriovnetworkv1.VirtualFunction{{PciAddress: "0000:d8:01.0"}, {PciAddress: "0000:d8:01.1"},
+							{PciAddress: "0000:d8:01.2"}, {PciAddress: "0000:d8:01.3"}}},
+					{PciAddress: "0000:d8:00.1", Name: "ens1f1", Vendor: "8086", Driver: "ice", TotalVfs: 64},
+				},
+			},
+		}
+
+		s := runtime.NewScheme()
+		Expect(sriovnetworkv1.AddToScheme(s)).To(Succeed())
+		c := fake.NewClientBuilder().WithScheme(s).WithObjects(nodeState.DeepCopy()).Build()
+		dn = New(c, hostHelper, platformMock, &EventRecorder{client: c, eventRecorder: record.NewFakeRecorder(100)}, vars.FeatureGate)
+
+		hostHelper.EXPECT().GetCurrentKernelArgs().Return("", nil).AnyTimes()
+		hostHelper.EXPECT().IsKernelArgsSet(gomock.Any(), gomock.Any()).Return(false).AnyTimes()
+		mainPlugin, err := generic.NewGenericPlugin(hostHelper)
+		Expect(err).NotTo(HaveOccurred())
+		intelPlugin, err := intel.NewIntelPlugin(hostHelper)
+		Expect(err).NotTo(HaveOccurred())
+		dn.mainPlugin = mainPlugin
+		dn.additionalPlugins = []plugin.VendorPlugin{intelPlugin}
+	})
+
+	It("should recreate the VFs of the PFs reloaded to load a DDP package", func() {
+		hostHelper.EXPECT().GetDevlinkDeviceInfo(gomock.Any()).Return(&netlink.DevlinkDeviceInfo{SerialNumber: "00-01-02-ff-ff-03-04-05"}, nil).AnyTimes()
+		hostHelper.EXPECT().GetInstalledDDPPackage("0000:d8:00.0").Return("", false, nil)
+		hostHelper.EXPECT().GetOperatorKernelArgs().Return(nil, nil)
+		hostHelper.EXPECT().SetRDMASubsystem("").Return(nil)
+		hostHelper.EXPECT().AddKernelArgs().Return(false, nil)
+		hostHelper.EXPECT().RemoveKernelArgs(gomock.Any()).Return(false, nil)
+		reqReboot, reqDrain, err := dn.checkOnNodeStateChange(context.Background(), nodeState)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reqReboot).To(BeFalse())
+		Expect(reqDrain).To(BeTrue())
+
+		By("reloading the PFs of the adapter")
+		hostHelper.EXPECT().InstallDDPPackage("0000:d8:00.0", ddpPackage).Return(nil)
+		hostHelper.EXPECT().ReloadIceDevices([]string{"0000:d8:00.0", "0000:d8:00.1"}).Return(nil)
+		// the reload removed the VFs of the PFs
+		platformMock.EXPECT().DiscoverSriovPfs([]string{"0000:d8:00.0", "0000:d8:00.1"}).Return(sriovnetworkv1.InterfaceExts{
+			{PciAddress: "0000:d8:00.0", Name: "ens1f0", Vendor: "8086", Driver: "ice", TotalVfs: 64, DdpPackage: "ICE COMMS Package 1.3.45.0"},
+			{PciAddress: "0000:d8:00.1", Name: "ens1f1", Vendor: "8086", Driver: "ice", TotalVfs: 64, DdpPackage: "ICE COMMS Package 1.3.45.0"},
+		}, nil)
+
+		By("recreating the VFs with the generic plugin")
+		hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+		hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), nodeState.Spec.Interfaces, gomock.Any(), false).DoAndReturn(
+			func(_ store.ManagerInterface, interfaces []sriovnetworkv1.Interface, ifaceStatuses []sriovnetworkv1.InterfaceExt, _ bool) error {
+				Expect(ifaceStatuses).To(HaveLen(2))
+				Expect(ifaceStatuses[0].PciAddress).To(Equal("0000:d8:00.0"))
+				Expect(ifaceStatuses[0].NumVfs).To(Equal(0))
+				// the PF isn't skipped, its VFs are created again
+				Expect(sriovnetworkv1.NeedToUpdateSriov(&interfaces[0], &ifaceStatuses[0])).To(BeTrue())
+				return nil
+			})
+		hostHelper.EXPECT().ConfigureBonds(gomock.Any(), gomock.Any()).Return(nil)
+		Expect(dn.applyPlugins(nodeState, reqReboot)).To(Succeed())
+	})
+
+	It("should not rediscover the PFs when no PF is reloaded", func() {
+		hostHelper.EXPECT().GetDevlinkDeviceInfo(gomock.Any()).Return(&netlink.DevlinkDeviceInfo{SerialNumber: "00-01-02-ff-ff-03-04-05"}, nil).AnyTimes()
+		nodeState.Spec.Interfaces[0].DdpPackage = ""
+		hostHelper.EXPECT().GetInstalledDDPPackage("0000:d8:00.0").Return("", false, nil)
+		hostHelper.EXPECT().GetOperatorKernelArgs().Return(nil, nil)
+		hostHelper.EXPECT().SetRDMASubsystem("").Return(nil)
+		hostHelper.EXPECT().AddKernelArgs().Return(false, nil)
+		hostHelper.EXPECT().RemoveKernelArgs(gomock.Any()).Return(false, nil)
+		_, _, err := dn.checkOnNodeStateChange(context.Background(), nodeState)
+		Expect(err).NotTo(HaveOccurred())
+
+		hostHelper.EXPECT().Chroot(consts.Host).Return(func() error { return nil }, nil)
+		hostHelper.EXPECT().ConfigSriovInterfaces(gomock.Any(), nodeState.Spec.Interfaces, nodeState.Status.Interfaces, false).Return(nil)
+		hostHelper.EXPECT().ConfigureBonds(gomock.Any(), gomock.Any()).Return(nil)
+		Expect(dn.applyPlugins(nodeState, false)).To(Succeed())
+	})
+})
@@ -26,6 +26,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	snolog "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/log"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
@@ -64,6 +65,18 @@ func (oc *OperatorConfigNodeReconcile) Reconcile(ctx context.Context, req ctrl.R
 		log.Log.Info("Set Disable Drain", "value", vars.DisableDrain)
 	}
 
+	newAutoRollbackMaxFailures := 0
+	if operatorConfig.Spec.AutoRollback != nil {
+		newAutoRollbackMaxFailures = operatorConfig.Spec.AutoRollback.MaxFailures
+		if newAutoRollbackMaxFailures < 1 {
+			newAutoRollbackMaxFailures = consts.DefaultAutoRollbackMaxFailures
+		}
+	}
+	if vars.AutoRollbackMaxFailures != newAutoRollbackMaxFailures {
+		vars.AutoRollbackMaxFailures = newAutoRollbackMaxFailures
+		log.Log.Info("Set Auto Rollback max failures", "value", vars.AutoRollbackMaxFailures)
+	}
+
 	if !equality.Semantic.DeepEqual(oc.latestFeatureGates, operatorConfig.Spec.FeatureGates) {
 		vars.FeatureGate.Init(operatorConfig.Spec.FeatureGates)
 		oc.latestFeatureGates = operatorConfig.Spec.FeatureGates
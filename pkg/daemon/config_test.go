@@ -137,6 +137,28 @@ var _ = Describe("Daemon OperatorConfig Controller", Ordered, func() {
 		})
 	})
 
+	Context("Auto Rollback", func() {
+		It("should update the auto rollback max failures", func() {
+			soc := &sriovnetworkv1.SriovOperatorConfig{ObjectMeta: metav1.ObjectMeta{
+				Name:      consts.DefaultConfigName,
+				Namespace: testNamespace,
+			},
+				Spec: sriovnetworkv1.SriovOperatorConfigSpec{
+					AutoRollback: &sriovnetworkv1.AutoRollbackConfig{MaxFailures: 5},
+				},
+			}
+
+			err := k8sClient.Create(ctx, soc)
+			Expect(err).ToNot(HaveOccurred())
+			validateExpectedAutoRollback(5)
+
+			soc.Spec.AutoRollback = nil
+			err = k8sClient.Update(ctx, soc)
+			Expect(err).ToNot(HaveOccurred())
+			validateExpectedAutoRollback(0)
+		})
+	})
+
 	Context("Feature gates", func() {
 		It("should update the feature gates struct", func() {
 			soc := &sriovnetworkv1.SriovOperatorConfig{ObjectMeta: metav1.ObjectMeta{
@@ -184,3 +206,9 @@ func validateExpectedDrain(disableDrain bool) {
 		g.Expect(vars.DisableDrain).To(Equal(disableDrain))
 	}, "15s", "3s").Should(Succeed())
 }
+
+func validateExpectedAutoRollback(maxFailures int) {
+	EventuallyWithOffset(1, func(g Gomega) {
+		g.Expect(vars.AutoRollbackMaxFailures).To(Equal(maxFailures))
+	}, "15s", "3s").Should(Succeed())
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	stdErrors "errors"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -29,8 +31,12 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
@@ -60,6 +66,19 @@ type NodeReconciler struct {
 	mainPlugin        plugin.VendorPlugin
 
 	lastAppliedGeneration int64
+	// time of the last drain request, used to report the drain duration
+	drainRequestTime time.Time
+	// last generation and drain phase reported with events, used to send each event once
+	reportedGeneration int64
+	reportedDrainPhase string
+
+	// host events of the managed PFs trigger a reconcile through this channel
+	hostEvents chan event.GenericEvent
+	// PF PCI address of the managed devices by PCI address or interface name, see host_events.go
+	hostEventDevices map[string]string
+	// PFs with host events since the last reconcile, only these PFs are rediscovered
+	hostEventPfs         map[string]struct{}
+	hostEventDevicesLock sync.Mutex
 }
 
 // New creates a new instance of NodeReconciler.
@@ -78,6 +97,7 @@ func New(
 		lastAppliedGeneration: 0,
 		eventRecorder:         er,
 		featureGate:           featureGates,
+		hostEvents:            make(chan event.GenericEvent, 1),
 	}
 }
 
@@ -109,6 +129,8 @@ func (dn *NodeReconciler) Init(disabledPlugins []string) error {
 		funcLog.Error(err, "failed to prepare udev files to rename VF representors for requested VFs")
 	}
 
+	recordCompletedReboots(dn.hostHelpers)
+
 	// init hypervisor info
 	err := dn.platformInterface.Init()
 	if err != nil {
@@ -142,14 +164,16 @@ func (dn *NodeReconciler) Init(disabledPlugins []string) error {
 // 1. Retrieves the latest NodeState from the API server.
 // 2. Checks if the object has the required drain controller annotations for the current generation.
 // 3. Updates the nodeState Status object with the existing network state (interfaces, bridges, and RDMA status).
-// 4. If running in systemd mode, checks the sriov result from the config-daemon that runs in systemd.
-// 5. Compares the latest generation with the last applied generation to determine if a refresh on NICs is needed.
-// 6. Checks for drift between the host state and the nodeState status.
-// 7. Updates the sync state of the nodeState object as per the current requirements.
-// 8. Determines if a drain is required based on the current state of the nodeState.
-// 9. Handles the drain if necessary, ensuring that it does not conflict with other drain requests.
-// 10. Applies the changes to the nodeState if there are no issues and updates the sync status accordingly.
-// 11. If a reboot is required after applying the changes, returns a result to trigger a reboot.
+// 4. Evaluates the configuration planned by the dry-run policies, if any.
+// 5. If running in systemd mode, checks the sriov result from the config-daemon that runs in systemd.
+// 6. Compares the latest generation with the last applied generation to determine if a refresh on NICs is needed.
+// 7. Checks for drift between the host state and the nodeState status.
+// 8. Updates the sync state of the nodeState object as per the current requirements.
+// 9. Determines if a drain is required based on the current state of the nodeState.
+// 10. Handles the drain if necessary, ensuring that it does not conflict with other drain requests.
+// 11. Applies the changes to the nodeState if there are no issues and updates the sync status accordingly.
+// 12. If applying the changes keeps failing, restores the last applied configuration when the automatic rollback is enabled.
+// 13. If a reboot is required after applying the changes, returns a result to trigger a reboot.
 //
 // Returns a Result indicating whether or not the controller should requeue the request for further processing.
 func (dn *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -189,12 +213,27 @@ func (dn *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	current := desiredNodeState.DeepCopy()
 	reqLogger.V(0).Info("new generation", "generation", latest)
 
-	// Update the nodeState Status object with the existing network state (interfaces bridges and rdma status)
-	err = dn.updateStatusFromHost(desiredNodeState)
+	// Update the nodeState Status object with the existing network state (interfaces bridges and rdma status).
+	// Once the generation is applied, a reconcile triggered by host events only rediscovers the PFs of the events,
+	// the status of the other devices is the one of the last discovery. A drift of these PFs is then reconfigured
+	// by the plugins while the other PFs are found in sync.
+	if hostEventPfs := dn.takeHostEventPfs(); len(hostEventPfs) > 0 && dn.lastAppliedGeneration == latest {
+		err = dn.updatePfsStatusFromHost(desiredNodeState, hostEventPfs)
+	} else {
+		err = dn.updateStatusFromHost(desiredNodeState)
+	}
 	if err != nil {
 		reqLogger.Error(err, "failed to get host network status")
 		return ctrl.Result{}, err
 	}
+	dn.updateHostEventDevices(desiredNodeState)
+
+	// Evaluate the configuration planned by the dry-run policies
+	err = dn.evaluatePlan(ctx, desiredNodeState)
+	if err != nil {
+		reqLogger.Error(err, "failed to evaluate planned spec")
+		return ctrl.Result{}, err
+	}
 
 	// if we are running in systemd mode we want to get the sriov result from the config-daemon that runs in systemd
 	sriovResult, sriovResultExists, err := dn.CheckSystemdStatus()
@@ -210,6 +249,19 @@ func (dn *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return ctrl.Result{}, nil
 	}
 
+	// keep the restored configuration until a new generation is rendered for the node
+	if isRolledBack(desiredNodeState) {
+		reqLogger.V(2).Info("generation was rolled back, waiting for a new generation", "generation", latest)
+		if dn.shouldUpdateStatus(current, desiredNodeState) {
+			err = dn.updateSyncState(ctx, desiredNodeState, desiredNodeState.Status.SyncStatus, desiredNodeState.Status.LastSyncError)
+			if err != nil {
+				reqLogger.Error(err, "failed to update nodeState new host status")
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{RequeueAfter: consts.DaemonRequeueTime}, nil
+	}
+
 	// if we are on the latest generation make a refresh on the nics
 	if dn.lastAppliedGeneration == latest {
 		isDrifted, err := dn.checkHostStateDrift(ctx, desiredNodeState)
@@ -249,6 +301,20 @@ func (dn *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		}
 	}
 
+	// keep the new generation pending while the node pool is paused or waits for its canary nodes,
+	// nodes that already requested a drain finish applying the configuration
+	if (utils.ObjectHasAnnotation(desiredNodeState, consts.NodeStatePausedAnnotation, "true") ||
+		utils.ObjectHasAnnotationKey(desiredNodeState, consts.NodeStateRolloutHoldAnnotation)) &&
+		utils.ObjectHasAnnotation(desiredNodeState, consts.NodeStateDrainAnnotation, consts.DrainIdle) {
+		reqLogger.Info("node pool is paused or waits for canary nodes, the new generation will be applied later", "generation", latest)
+		err = dn.updateSyncState(ctx, desiredNodeState, desiredNodeState.Status.SyncStatus, desiredNodeState.Status.LastSyncError)
+		if err != nil {
+			reqLogger.Error(err, "failed to update nodeState status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
 	// set sync state to inProgress, but we don't clear the failed status
 	err = dn.updateSyncState(ctx, desiredNodeState, consts.SyncStatusInProgress, desiredNodeState.Status.LastSyncError)
 	if err != nil {
@@ -256,8 +322,9 @@ func (dn *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return ctrl.Result{}, err
 	}
 
-	reqReboot, reqDrain, err := dn.checkOnNodeStateChange(desiredNodeState)
+	reqReboot, reqDrain, err := dn.checkOnNodeStateChange(ctx, desiredNodeState)
 	if err != nil {
+		syncFailuresTotal.WithLabelValues(syncFailureReasonPluginCheck).Inc()
 		return ctrl.Result{}, err
 	}
 
@@ -283,6 +350,7 @@ func (dn *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		!utils.ObjectHasAnnotation(desiredNodeState, consts.NodeStateDrainAnnotationCurrent, consts.DrainIdle) {
 		drainInProcess, err := dn.handleDrain(ctx, desiredNodeState, reqReboot)
 		if err != nil {
+			syncFailuresTotal.WithLabelValues(syncFailureReasonDrain).Inc()
 			reqLogger.Error(err, "failed to handle drain")
 			return ctrl.Result{}, err
 		}
@@ -298,7 +366,11 @@ func (dn *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 	// if we finish the drain we should run apply here
 	if dn.isDrainCompleted(reqDrain, desiredNodeState) {
-		return dn.apply(ctx, desiredNodeState, reqReboot, sriovResult)
+		result, err := dn.apply(ctx, desiredNodeState, reqReboot, sriovResult)
+		if err != nil {
+			return dn.handleApplyFailure(ctx, desiredNodeState, err)
+		}
+		return result, nil
 	}
 
 	return ctrl.Result{}, nil
@@ -307,37 +379,68 @@ func (dn *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 // checkOnNodeStateChange checks the state change required for the node based on the desired SriovNetworkNodeState.
 // The function iterates over all loaded plugins and calls their OnNodeStateChange method with the desired state.
 // It returns two boolean values indicating whether a reboot or drain operation is required.
-func (dn *NodeReconciler) checkOnNodeStateChange(desiredNodeState *sriovnetworkv1.SriovNetworkNodeState) (bool, bool, error) {
+// The decisions of the plugins are reported with events once per generation.
+func (dn *NodeReconciler) checkOnNodeStateChange(ctx context.Context, desiredNodeState *sriovnetworkv1.SriovNetworkNodeState) (bool, bool, error) {
 	funcLog := log.Log.WithName("checkOnNodeStateChange")
+	report := dn.reportedGeneration != desiredNodeState.Generation
 	// Check the main plugin for changes
 	reqDrain, reqReboot, err := dn.mainPlugin.OnNodeStateChange(desiredNodeState)
 	if err != nil {
 		funcLog.Error(err, "OnNodeStateChange plugin error", "mainPluginName", dn.mainPlugin.Name())
+		dn.eventRecorder.SendNodeEvent(ctx, corev1.EventTypeWarning, EventReasonNodeStateChange,
+			fmt.Sprintf("plugin %s failed to evaluate generation %d: %v", dn.mainPlugin.Name(), desiredNodeState.Generation, err))
 		return false, false, err
 	}
 	funcLog.V(0).Info("OnNodeStateChange result",
 		"main plugin name", dn.mainPlugin.Name(),
 		"drain-required", reqDrain,
 		"reboot-required", reqReboot)
+	if report {
+		dn.reportPluginDecision(ctx, desiredNodeState, dn.mainPlugin, reqDrain, reqReboot)
+	}
 
 	// check if any of the plugins required to drain or reboot the node
 	for _, p := range dn.additionalPlugins {
 		d, r, err := p.OnNodeStateChange(desiredNodeState)
 		if err != nil {
 			funcLog.Error(err, "OnNodeStateChange plugin error", "pluginName", p.Name())
+			dn.eventRecorder.SendNodeEvent(ctx, corev1.EventTypeWarning, EventReasonNodeStateChange,
+				fmt.Sprintf("plugin %s failed to evaluate generation %d: %v", p.Name(), desiredNodeState.Generation, err))
 			return false, false, err
 		}
 		funcLog.V(0).Info("OnNodeStateChange result",
 			"pluginName", p.Name(),
 			"drain-required", d,
 			"reboot-required", r)
+		if report {
+			dn.reportPluginDecision(ctx, desiredNodeState, p, d, r)
+		}
 		reqDrain = reqDrain || d
 		reqReboot = reqReboot || r
 	}
 
+	dn.reportedGeneration = desiredNodeState.Generation
 	return reqReboot, reqDrain, nil
 }
 
+// reportPluginDecision sends the OnNodeStateChange result of a plugin,
+// and the firmware changes queued by the plugin if it reports them
+func (dn *NodeReconciler) reportPluginDecision(ctx context.Context,
+	desiredNodeState *sriovnetworkv1.SriovNetworkNodeState, p plugin.VendorPlugin, reqDrain, reqReboot bool) {
+	dn.eventRecorder.SendNodeEvent(ctx, corev1.EventTypeNormal, EventReasonNodeStateChange,
+		fmt.Sprintf("plugin %s evaluated generation %d: drain required %t, reboot required %t",
+			p.Name(), desiredNodeState.Generation, reqDrain, reqReboot))
+
+	reporter, ok := p.(plugin.FirmwareChangeReporter)
+	if !ok {
+		return
+	}
+	for _, change := range reporter.PendingFirmwareChanges() {
+		dn.eventRecorder.SendNodeEvent(ctx, corev1.EventTypeNormal, EventReasonFirmwareChangeQueued,
+			fmt.Sprintf("plugin %s queued a firmware change %s", p.Name(), change))
+	}
+}
+
 // CheckSystemdStatus Checks the status of systemd services on the host node.
 // return the sriovResult struct a boolean if the result file exist on the node
 func (dn *NodeReconciler) CheckSystemdStatus() (*hosttypes.SriovResult, bool, error) {
@@ -384,7 +487,7 @@ func (dn *NodeReconciler) CheckSystemdStatus() (*hosttypes.SriovResult, bool, er
 // 4. Restarting the device plugin pod on the node.
 // 5. Requesting annotation updates for draining the idle state of the node.
 // 6. Synchronizing with the host network status and updating the sync status of the node in the nodeState object.
-// 7. Updating the lastAppliedGeneration to the current generation.
+// 7. Updating the lastAppliedGeneration to the current generation and saving it on the host.
 func (dn *NodeReconciler) apply(ctx context.Context, desiredNodeState *sriovnetworkv1.SriovNetworkNodeState, reqReboot bool, sriovResult *hosttypes.SriovResult) (ctrl.Result, error) {
 	reqLogger := log.FromContext(ctx).WithName("Apply")
 
@@ -399,30 +502,43 @@ func (dn *NodeReconciler) apply(ctx context.Context, desiredNodeState *sriovnetw
 			reqLogger.Error(err, "failed to restart device plugin on the node")
 			return ctrl.Result{}, err
 		}
-	}
-
-	// apply the additional plugins after we are done with drain if needed
-	for _, p := range dn.additionalPlugins {
-		err := p.Apply()
-		if err != nil {
-			reqLogger.Error(err, "plugin Apply failed", "plugin-name", p.Name())
+		blockedCondition := metav1.Condition{
+			Type:    sriovnetworkv1.ConditionDevicePluginBlocked,
+			Status:  metav1.ConditionTrue,
+			Reason:  sriovnetworkv1.NodeStateReasonWaitingForConfig,
+			Message: "device plugin is blocked until the node configuration is applied",
+		}
+		if len(desiredNodeState.Spec.Interfaces) == 0 {
+			blockedCondition.Status = metav1.ConditionFalse
+			blockedCondition.Reason = sriovnetworkv1.NodeStateReasonNoInterfacesConfigured
+			blockedCondition.Message = "device plugin is not deployed on the node"
+		}
+		if err := dn.updateConditions(ctx, desiredNodeState, blockedCondition); err != nil {
+			reqLogger.Error(err, "failed to update device plugin condition")
 			return ctrl.Result{}, err
 		}
 	}
 
-	// if we don't need to reboot, or we are not doing the configuration in systemd
-	// we apply the main plugin
-	if !reqReboot && !vars.UsingSystemdMode && dn.mainPlugin != nil {
-		err := dn.mainPlugin.Apply()
-		if err != nil {
-			reqLogger.Error(err, "plugin Apply failed", "plugin-name", dn.mainPlugin.Name())
-			return ctrl.Result{}, err
-		}
+	if err := dn.applyPlugins(desiredNodeState, reqReboot); err != nil {
+		return ctrl.Result{}, err
 	}
 
 	if reqReboot {
 		reqLogger.Info("reboot node")
-		dn.eventRecorder.SendEvent(ctx, "RebootNode", "Reboot node has been initiated")
+		if err := dn.updateConditions(ctx, desiredNodeState, metav1.Condition{
+			Type:    sriovnetworkv1.ConditionRebootRequired,
+			Status:  metav1.ConditionTrue,
+			Reason:  sriovnetworkv1.NodeStateReasonRebootInitiated,
+			Message: "node reboot initiated to apply the configuration",
+		}); err != nil {
+			reqLogger.Error(err, "failed to update reboot condition")
+			return ctrl.Result{}, err
+		}
+		dn.eventRecorder.SendNodeEvent(ctx, corev1.EventTypeNormal, EventReasonRebootScheduled, "Reboot node has been initiated")
+		// the reboot is counted by the next config daemon once the node started with a new boot ID
+		if err := dn.hostHelpers.SaveRebootRequest(); err != nil {
+			reqLogger.Error(err, "failed to record the reboot request")
+		}
 		return ctrl.Result{}, dn.rebootNode()
 	}
 
@@ -430,7 +546,11 @@ func (dn *NodeReconciler) apply(ctx context.Context, desiredNodeState *sriovnetw
 		if len(desiredNodeState.Spec.Interfaces) == 0 {
 			reqLogger.Info("no interfaces in desired state, skipping device plugin wait as device plugin won't be deployed")
 		} else {
-			if err := dn.waitForDevicePluginPodAndTryUnblock(ctx, desiredNodeState); err != nil {
+			waitStart := time.Now()
+			err := dn.waitForDevicePluginPodAndTryUnblock(ctx, desiredNodeState)
+			devicePluginWaitDuration.Observe(time.Since(waitStart).Seconds())
+			if err != nil {
+				syncFailuresTotal.WithLabelValues(syncFailureReasonDevicePlugin).Inc()
 				reqLogger.Error(err, "failed to wait for device plugin pod to start and try to unblock it")
 				return ctrl.Result{}, err
 			}
@@ -456,6 +576,9 @@ func (dn *NodeReconciler) apply(ctx context.Context, desiredNodeState *sriovnetw
 	if vars.UsingSystemdMode {
 		syncStatus = sriovResult.SyncStatus
 		lastSyncError = sriovResult.LastSyncError
+		if syncStatus == consts.SyncStatusFailed {
+			syncFailuresTotal.WithLabelValues(syncFailureReasonSystemd).Inc()
+		}
 	}
 
 	// Update the nodeState Status object with the existing network interfaces
@@ -464,7 +587,11 @@ func (dn *NodeReconciler) apply(ctx context.Context, desiredNodeState *sriovnetw
 		reqLogger.Error(err, "failed to get host network status")
 		return ctrl.Result{}, err
 	}
+	dn.updateHostEventDevices(desiredNodeState)
 
+	desiredNodeState.Status.ObservedGeneration = desiredNodeState.Generation
+	desiredNodeState.Status.Rollback = nil
+	desiredNodeState.Status.ApplyFailures = nil
 	err = dn.updateSyncState(ctx, desiredNodeState, syncStatus, lastSyncError)
 	if err != nil {
 		reqLogger.Error(err, "failed to update sync status")
@@ -474,9 +601,65 @@ func (dn *NodeReconciler) apply(ctx context.Context, desiredNodeState *sriovnetw
 	// update the lastAppliedGeneration
 	dn.lastAppliedGeneration = desiredNodeState.Generation
 
+	// save the configuration to restore it if a next generation fails to be applied
+	if syncStatus == consts.SyncStatusSucceeded {
+		if err := dn.hostHelpers.SaveLastAppliedNodeState(desiredNodeState); err != nil {
+			reqLogger.Error(err, "failed to save last applied node state")
+		}
+	}
+
 	return ctrl.Result{RequeueAfter: consts.DaemonRequeueTime}, nil
 }
 
+// applyPlugins applies the additional plugins and then the main plugin. The main plugin applies the status it
+// got in OnNodeStateChange, the status of the PFs reloaded by the additional plugins is rediscovered before.
+func (dn *NodeReconciler) applyPlugins(desiredNodeState *sriovnetworkv1.SriovNetworkNodeState, reqReboot bool) error {
+	funcLog := log.Log.WithName("applyPlugins")
+	// apply the additional plugins after we are done with drain if needed
+	reloaded := []string{}
+	for _, p := range dn.additionalPlugins {
+		err := applyPlugin(p)
+		if err != nil {
+			funcLog.Error(err, "plugin Apply failed", "plugin-name", p.Name())
+			return err
+		}
+		if reloader, ok := p.(plugin.DeviceReloader); ok {
+			reloaded = append(reloaded, reloader.ReloadedDevices()...)
+		}
+	}
+
+	// if we don't need to reboot, or we are not doing the configuration in systemd
+	// we apply the main plugin
+	if reqReboot || vars.UsingSystemdMode || dn.mainPlugin == nil {
+		return nil
+	}
+	// the reloaded PFs lost their VFs and their eSwitch mode, the main plugin must configure them again
+	if len(reloaded) > 0 {
+		funcLog.Info("PFs reloaded by the additional plugins, rediscover them", "devices", reloaded)
+		if err := dn.updatePfsStatusFromHost(desiredNodeState, reloaded); err != nil {
+			funcLog.Error(err, "failed to rediscover the reloaded PFs")
+			return err
+		}
+	}
+	err := applyPlugin(dn.mainPlugin)
+	if err != nil {
+		funcLog.Error(err, "plugin Apply failed", "plugin-name", dn.mainPlugin.Name())
+		return err
+	}
+	return nil
+}
+
+// applyPlugin applies the configuration of the plugin and reports the time spent
+func applyPlugin(p plugin.VendorPlugin) error {
+	start := time.Now()
+	err := p.Apply()
+	pluginApplyDuration.WithLabelValues(p.Name()).Observe(time.Since(start).Seconds())
+	if err != nil {
+		syncFailuresTotal.WithLabelValues(syncFailureReasonPluginApply).Inc()
+	}
+	return err
+}
+
 // tryUnblockDevicePlugin checks if the device plugin can be unblocked
 func (dn *NodeReconciler) tryUnblockDevicePlugin(ctx context.Context,
 	desiredNodeState *sriovnetworkv1.SriovNetworkNodeState, devicePluginPods []corev1.Pod) error {
@@ -487,13 +670,27 @@ func (dn *NodeReconciler) tryUnblockDevicePlugin(ctx context.Context,
 		funcLog.Info("desired node state has no interfaces, keep the wait-for-config annotation")
 		return nil
 	}
+	unblocked := []string{}
 	for _, pod := range devicePluginPods {
+		if !utils.ObjectHasAnnotationKey(&pod, consts.DevicePluginWaitConfigAnnotation) {
+			continue
+		}
 		if err := utils.RemoveAnnotationFromObject(ctx, &pod,
 			consts.DevicePluginWaitConfigAnnotation, dn.client); err != nil {
 			return fmt.Errorf("failed to remove %s annotation from pod: %w", consts.DevicePluginWaitConfigAnnotation, err)
 		}
-	}
-	return nil
+		unblocked = append(unblocked, pod.Name)
+	}
+	if len(unblocked) > 0 {
+		dn.eventRecorder.SendNodeEvent(ctx, corev1.EventTypeNormal, EventReasonDevicePluginUnblocked,
+			fmt.Sprintf("device plugin pods unblocked: %s", strings.Join(unblocked, ", ")))
+	}
+	return dn.updateConditions(ctx, desiredNodeState, metav1.Condition{
+		Type:    sriovnetworkv1.ConditionDevicePluginBlocked,
+		Status:  metav1.ConditionFalse,
+		Reason:  sriovnetworkv1.NodeStateReasonDevicePluginUnblocked,
+		Message: "device plugin started with the applied configuration",
+	})
 }
 
 // checkHostStateDrift returns true if the node state drifted from the nodeState policy
@@ -511,7 +708,9 @@ func (dn *NodeReconciler) checkHostStateDrift(ctx context.Context, desiredNodeSt
 		funcLog.V(0).Info("interface policy spec not yet set by controller for sriovNetworkNodeState",
 			"name", desiredNodeState.Name)
 		if desiredNodeState.Status.SyncStatus != consts.SyncStatusSucceeded ||
-			desiredNodeState.Status.LastSyncError != "" {
+			desiredNodeState.Status.LastSyncError != "" ||
+			desiredNodeState.Status.ObservedGeneration != desiredNodeState.Generation {
+			desiredNodeState.Status.ObservedGeneration = desiredNodeState.Generation
 			err = dn.updateSyncState(ctx, desiredNodeState, consts.SyncStatusSucceeded, "")
 		}
 		return false, err
@@ -527,6 +726,8 @@ func (dn *NodeReconciler) checkHostStateDrift(ctx context.Context, desiredNodeSt
 		}
 		if changed {
 			log.Log.V(0).Info("plugin require change", "pluginName", dn.mainPlugin.Name())
+			dn.eventRecorder.SendNodeEvent(ctx, corev1.EventTypeWarning, EventReasonHostStateDrift,
+				fmt.Sprintf("plugin %s detected a host state drift from generation %d", dn.mainPlugin.Name(), desiredNodeState.Generation))
 			return true, nil
 		}
 	}
@@ -540,6 +741,8 @@ func (dn *NodeReconciler) checkHostStateDrift(ctx context.Context, desiredNodeSt
 		}
 		if changed {
 			log.Log.V(0).Info("plugin require change", "pluginName", p.Name())
+			dn.eventRecorder.SendNodeEvent(ctx, corev1.EventTypeWarning, EventReasonHostStateDrift,
+				fmt.Sprintf("plugin %s detected a host state drift from generation %d", p.Name(), desiredNodeState.Generation))
 			return true, nil
 		}
 	}
@@ -590,12 +793,18 @@ func (dn *NodeReconciler) handleDrain(ctx context.Context, desiredNodeState *sri
 	// done with the drain we can continue with the configuration
 	if utils.ObjectHasAnnotation(desiredNodeState, consts.NodeStateDrainAnnotationCurrent, consts.DrainComplete) {
 		funcLog.Info("the node complete the draining")
+		dn.reportDrainPhase(ctx, EventReasonDrainCompleted, "node drain completed, applying the configuration")
+		if !dn.drainRequestTime.IsZero() {
+			drainDuration.Observe(time.Since(dn.drainRequestTime).Seconds())
+			dn.drainRequestTime = time.Time{}
+		}
 		return false, nil
 	}
 
 	// the operator is still draining the node so we reconcile
 	if utils.ObjectHasAnnotation(desiredNodeState, consts.NodeStateDrainAnnotationCurrent, consts.Draining) {
 		funcLog.Info("the node is still draining")
+		dn.reportDrainPhase(ctx, EventReasonDrainStarted, "node drain started by the drain controller")
 		return true, nil
 	}
 
@@ -610,7 +819,22 @@ func (dn *NodeReconciler) handleDrain(ctx context.Context, desiredNodeState *sri
 	if reqReboot {
 		annotation = consts.RebootRequired
 	}
-	return true, dn.annotate(ctx, desiredNodeState, annotation)
+	if err := dn.annotate(ctx, desiredNodeState, annotation); err != nil {
+		return true, err
+	}
+	dn.drainRequestTime = time.Now()
+	dn.reportDrainPhase(ctx, EventReasonDrainRequested, fmt.Sprintf("node drain requested with %s", annotation))
+	// refresh the status to report the drain request in the nodeState conditions
+	return true, dn.updateSyncState(ctx, desiredNodeState, desiredNodeState.Status.SyncStatus, desiredNodeState.Status.LastSyncError)
+}
+
+// reportDrainPhase sends an event the first time the drain reaches a phase
+func (dn *NodeReconciler) reportDrainPhase(ctx context.Context, reason, msg string) {
+	if dn.reportedDrainPhase == reason {
+		return
+	}
+	dn.reportedDrainPhase = reason
+	dn.eventRecorder.SendNodeEvent(ctx, corev1.EventTypeNormal, reason, msg)
 }
 
 // getDevicePluginPods returns the device plugin pods running on this node
@@ -686,6 +910,8 @@ func (dn *NodeReconciler) restartDevicePluginPod(ctx context.Context) error {
 			return err
 		}
 	}
+	dn.eventRecorder.SendNodeEvent(ctx, corev1.EventTypeNormal, EventReasonDevicePluginRestarted,
+		"device plugin pods restarted to advertise the new configuration")
 	return nil
 }
 
@@ -867,9 +1093,14 @@ func (dn *NodeReconciler) addRemoveExternalDrainerAnnotation(ctx context.Context
 
 // SetupWithManager sets up the controller with the Manager.
 func (dn *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// the host watcher runs while the hostEventWatcher feature gate is enabled
+	if err := mgr.Add(manager.RunnableFunc(dn.watchHostEvents)); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&sriovnetworkv1.SriovNetworkNodeState{}).
 		WithEventFilter(predicate.Or(predicate.AnnotationChangedPredicate{}, predicate.GenerationChangedPredicate{})).
+		WatchesRawSource(source.Channel(dn.hostEvents, &handler.EnqueueRequestForObject{})).
 		WithOptions(controller.Options{MaxConcurrentReconciles: 1}).
 		Complete(dn)
 }
@@ -28,6 +28,7 @@ import (
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
@@ -206,16 +207,23 @@ var _ = Describe("Daemon Controller", Ordered, func() {
 		hostHelper.EXPECT().PrepareNMUdevRule().Return(nil)
 		hostHelper.EXPECT().PrepareVFRepUdevRule().Return(nil)
 		hostHelper.EXPECT().WriteCheckpointFile(gomock.Any()).Return(nil)
+		hostHelper.EXPECT().GetCompletedReboots().Return(0, nil)
 
 		// general
 		hostHelper.EXPECT().Chroot(gomock.Any()).Return(func() error { return nil }, nil).AnyTimes()
-		hostHelper.EXPECT().RunCommand("/bin/sh", gomock.Any(), gomock.Any(), gomock.Any()).Return("", "", nil).AnyTimes()
+		hostHelper.EXPECT().AddKernelArgs(gomock.Any()).Return(false, nil).AnyTimes()
+		hostHelper.EXPECT().RemoveKernelArgs(gomock.Any()).Return(false, nil).AnyTimes()
+		hostHelper.EXPECT().GetOperatorKernelArgs().Return(nil, nil).AnyTimes()
+		hostHelper.EXPECT().GetKernelArgsStatus().Return(&sriovnetworkv1.KernelArgsStatus{}, nil).AnyTimes()
 
 		discoverSriovReturn = newSriovDiscoverReturn()
 
 		hostHelper.EXPECT().LoadPfsStatus("0000:16:00.0").Return(&sriovnetworkv1.Interface{ExternallyManaged: false}, true, nil).AnyTimes()
 		hostHelper.EXPECT().ClearPCIAddressFolder().Return(nil).AnyTimes()
+		hostHelper.EXPECT().SaveLastAppliedNodeState(gomock.Any()).Return(nil).AnyTimes()
 		hostHelper.EXPECT().DiscoverRDMASubsystem().Return("shared", nil).AnyTimes()
+		hostHelper.EXPECT().IsIommuEnabled().Return(true).AnyTimes()
+		hostHelper.EXPECT().DiscoverBonds().Return(nil, nil).AnyTimes()
 		hostHelper.EXPECT().GetCurrentKernelArgs().Return("", nil).AnyTimes()
 		hostHelper.EXPECT().IsKernelArgsSet("", constants.KernelArgPciRealloc).Return(true).AnyTimes()
 		hostHelper.EXPECT().IsKernelArgsSet("", constants.KernelArgIntelIommu).Return(true).AnyTimes()
@@ -347,6 +355,8 @@ var _ = Describe("Daemon Controller", Ordered, func() {
 					ToNot(HaveOccurred())
 
 				g.Expect(nodeState.Annotations[constants.NodeStateDrainAnnotation]).To(Equal(constants.DrainRequired))
+				g.Expect(meta.IsStatusConditionTrue(nodeState.Status.Conditions, sriovnetworkv1.ConditionDrainRequired)).To(BeTrue())
+				g.Expect(meta.IsStatusConditionFalse(nodeState.Status.Conditions, sriovnetworkv1.ConditionSynced)).To(BeTrue())
 				// verify that external drainer annotation doesn't exist
 				node := &corev1.Node{}
 				g.Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: nodeName}, node)).
@@ -370,9 +380,14 @@ var _ = Describe("Daemon Controller", Ordered, func() {
 					ToNot(HaveOccurred())
 
 				g.Expect(nodeState.Status.SyncStatus).To(Equal(constants.SyncStatusSucceeded))
+				g.Expect(nodeState.Status.ObservedGeneration).To(Equal(nodeState.Generation))
 			}, waitTime, retryTime).Should(Succeed())
 
 			Expect(nodeState.Status.LastSyncError).To(Equal(""))
+			Expect(meta.IsStatusConditionTrue(nodeState.Status.Conditions, sriovnetworkv1.ConditionSynced)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(nodeState.Status.Conditions, sriovnetworkv1.ConditionDegraded)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(nodeState.Status.Conditions, sriovnetworkv1.ConditionDrainRequired)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(nodeState.Status.Conditions, sriovnetworkv1.ConditionRebootRequired)).To(BeTrue())
 		})
 
 		It("Should apply external drainer annotation when useExternalDrainer is true", func(ctx context.Context) {
@@ -21,6 +21,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	typedv1core "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
@@ -50,6 +51,20 @@ func NewEventRecorder(c client.Client, kubeclient kubernetes.Interface, s *runti
 	}
 }
 
+// Reasons of the events sent by the config daemon for each configuration phase
+const (
+	EventReasonNodeStateChange       = "NodeStateChangeEvaluated"
+	EventReasonDrainRequested        = "DrainRequested"
+	EventReasonDrainStarted          = "DrainStarted"
+	EventReasonDrainCompleted        = "DrainCompleted"
+	EventReasonRebootScheduled       = "RebootNode"
+	EventReasonFirmwareChangeQueued  = "FirmwareChangeQueued"
+	EventReasonDevicePluginRestarted = "DevicePluginRestarted"
+	EventReasonDevicePluginUnblocked = "DevicePluginUnblocked"
+	EventReasonHostStateDrift        = "HostStateDriftDetected"
+	EventReasonRollback              = "RollbackNodeState"
+)
+
 // SendEvent Send an Event on the NodeState object
 func (e *EventRecorder) SendEvent(ctx context.Context, eventType string, msg string) {
 	nodeState := &sriovnetworkv1.SriovNetworkNodeState{}
@@ -61,6 +76,22 @@ func (e *EventRecorder) SendEvent(ctx context.Context, eventType string, msg str
 	e.eventRecorder.Event(nodeState, corev1.EventTypeNormal, eventType, msg)
 }
 
+// SendNodeEvent Send an Event on the NodeState and the Node objects,
+// the events of the Node are listed by kubectl describe node
+func (e *EventRecorder) SendNodeEvent(ctx context.Context, eventType, reason, msg string) {
+	nodeState := &sriovnetworkv1.SriovNetworkNodeState{}
+	err := e.client.Get(ctx, client.ObjectKey{Namespace: vars.Namespace, Name: vars.NodeName}, nodeState)
+	if err != nil {
+		log.Log.V(2).Error(err, "SendNodeEvent(): Failed to fetch node state, skip nodeState event", "name", vars.NodeName)
+	} else {
+		e.eventRecorder.Event(nodeState, eventType, reason, msg)
+	}
+
+	// the node events use the node name as UID, like the kubelet
+	nodeRef := &corev1.ObjectReference{Kind: "Node", Name: vars.NodeName, UID: types.UID(vars.NodeName)}
+	e.eventRecorder.Event(nodeRef, eventType, reason, msg)
+}
+
 // Shutdown Close the EventBroadcaster
 func (e *EventRecorder) Shutdown() {
 	e.eventBroadcaster.Shutdown()
@@ -0,0 +1,194 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package daemon
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	hosttypes "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+const hostEventsBufferSize = 256
+
+var (
+	// the events of a PF come in bursts, e.g. all its VFs are removed by a driver reset,
+	// they are grouped to trigger a single reconcile
+	hostEventsDebounceTime = 2 * time.Second
+	// interval between the checks of the feature gate and the restarts of a failed host watcher
+	hostWatcherCheckInterval = 30 * time.Second
+)
+
+// watchHostEvents runs the host watcher while the hostEventWatcher feature gate is enabled,
+// the watcher is restarted when it fails
+func (dn *NodeReconciler) watchHostEvents(ctx context.Context) error {
+	funcLog := log.Log.WithName("watchHostEvents")
+	for {
+		if vars.FeatureGate.IsEnabled(consts.HostEventWatcherFeatureGate) {
+			if err := dn.runHostWatcher(ctx); err != nil {
+				funcLog.Error(err, "host watcher failed, restarting it", "interval", hostWatcherCheckInterval)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(hostWatcherCheckInterval):
+		}
+	}
+}
+
+// runHostWatcher triggers a reconcile of the node state when the host watcher reports events of the
+// managed PFs, their VFs or SFs. It returns when the context is done, the feature gate is disabled
+// or the watcher fails.
+func (dn *NodeReconciler) runHostWatcher(ctx context.Context) error {
+	funcLog := log.Log.WithName("runHostWatcher")
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events := make(chan hosttypes.HostEvent, hostEventsBufferSize)
+	watchErr := make(chan error, 1)
+	go func() {
+		watchErr <- dn.hostHelpers.WatchHostEvents(ctx, events)
+	}()
+
+	gateCheck := time.NewTicker(hostWatcherCheckInterval)
+	defer gateCheck.Stop()
+	var debounce <-chan time.Time
+	affectedPfs := map[string][]string{}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-watchErr:
+			return err
+		case <-gateCheck.C:
+			if !vars.FeatureGate.IsEnabled(consts.HostEventWatcherFeatureGate) {
+				funcLog.Info("feature gate disabled, stop host watcher")
+				return nil
+			}
+		case e := <-events:
+			pf, ok := dn.getHostEventPf(e)
+			if !ok {
+				continue
+			}
+			affectedPfs[pf] = append(affectedPfs[pf], e.Reason)
+			if debounce == nil {
+				debounce = time.After(hostEventsDebounceTime)
+			}
+		case <-debounce:
+			pfs := make([]string, 0, len(affectedPfs))
+			for pf := range affectedPfs {
+				pfs = append(pfs, pf)
+			}
+			sort.Strings(pfs)
+			funcLog.Info("host events of managed PFs, reconcile node state", "pfs", pfs, "events", affectedPfs)
+			dn.addHostEventPfs(pfs)
+			dn.triggerHostEventReconcile()
+			affectedPfs = map[string][]string{}
+			debounce = nil
+		}
+	}
+}
+
+// addHostEventPfs records the PFs with host events for the next reconcile
+func (dn *NodeReconciler) addHostEventPfs(pfs []string) {
+	dn.hostEventDevicesLock.Lock()
+	defer dn.hostEventDevicesLock.Unlock()
+	if dn.hostEventPfs == nil {
+		dn.hostEventPfs = map[string]struct{}{}
+	}
+	for _, pf := range pfs {
+		dn.hostEventPfs[pf] = struct{}{}
+	}
+}
+
+// takeHostEventPfs returns the sorted PFs with host events since the last call
+func (dn *NodeReconciler) takeHostEventPfs() []string {
+	dn.hostEventDevicesLock.Lock()
+	defer dn.hostEventDevicesLock.Unlock()
+	pfs := make([]string, 0, len(dn.hostEventPfs))
+	for pf := range dn.hostEventPfs {
+		pfs = append(pfs, pf)
+	}
+	sort.Strings(pfs)
+	dn.hostEventPfs = nil
+	return pfs
+}
+
+// triggerHostEventReconcile enqueues a reconcile of the node state, a reconcile already waiting
+// in the channel covers the new events
+func (dn *NodeReconciler) triggerHostEventReconcile() {
+	select {
+	case dn.hostEvents <- event.GenericEvent{Object: &sriovnetworkv1.SriovNetworkNodeState{
+		ObjectMeta: metav1.ObjectMeta{Name: vars.NodeName, Namespace: vars.Namespace}}}:
+	default:
+	}
+}
+
+// getHostEventPf returns the PCI address of the managed PF of the device of the event
+func (dn *NodeReconciler) getHostEventPf(e hosttypes.HostEvent) (string, bool) {
+	dn.hostEventDevicesLock.Lock()
+	defer dn.hostEventDevicesLock.Unlock()
+	if pf, ok := dn.hostEventDevices[e.PciAddress]; ok && e.PciAddress != "" {
+		return pf, true
+	}
+	if pf, ok := dn.hostEventDevices[e.Interface]; ok && e.Interface != "" {
+		return pf, true
+	}
+	return "", false
+}
+
+// updateHostEventDevices updates the devices of the managed PFs with the discovered status,
+// the PCI addresses and interface names of the PFs, VFs, representors and SFs are mapped to the PF
+func (dn *NodeReconciler) updateHostEventDevices(nodeState *sriovnetworkv1.SriovNetworkNodeState) {
+	devices := map[string]string{}
+	add := func(device, pf string) {
+		if device != "" {
+			devices[device] = pf
+		}
+	}
+	for _, ifaceSpec := range nodeState.Spec.Interfaces {
+		pf := ifaceSpec.PciAddress
+		add(pf, pf)
+		for _, iface := range nodeState.Status.Interfaces {
+			if iface.PciAddress != pf {
+				continue
+			}
+			add(iface.Name, pf)
+			for _, vf := range iface.VFs {
+				add(vf.PciAddress, pf)
+				add(vf.Name, pf)
+				add(vf.RepresentorName, pf)
+			}
+			for _, sf := range iface.SFs {
+				add(sf.Name, pf)
+				add(sf.RepresentorName, pf)
+			}
+		}
+	}
+	dn.hostEventDevicesLock.Lock()
+	defer dn.hostEventDevicesLock.Unlock()
+	dn.hostEventDevices = devices
+}
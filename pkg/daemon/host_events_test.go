@@ -0,0 +1,300 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/featuregate"
+	mock_helper "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper/mock"
+	hosttypes "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
+	mock_platform "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/platform/mock"
+	mock_plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins/mock"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+var _ = Describe("Host events", func() {
+	var (
+		dn                 *NodeReconciler
+		hostHelper         *mock_helper.MockHostHelpersInterface
+		testCtrl           *gomock.Controller
+		ctx                context.Context
+		cancel             context.CancelFunc
+		savedFeatureGate   featuregate.FeatureGate
+		savedDebounceTime  time.Duration
+		savedCheckInterval time.Duration
+		managedPfNodeState *sriovnetworkv1.SriovNetworkNodeState
+	)
+	// the gate is updated in place, the watcher goroutines read it through vars.FeatureGate
+	enableHostWatcherGate := func(enabled bool) {
+		vars.FeatureGate.Init(map[string]bool{consts.HostEventWatcherFeatureGate: enabled})
+	}
+
+	BeforeEach(func() {
+		testCtrl = gomock.NewController(GinkgoT())
+		hostHelper = mock_helper.NewMockHostHelpersInterface(testCtrl)
+		dn = New(nil, hostHelper, nil, nil, nil)
+		ctx, cancel = context.WithCancel(context.Background())
+
+		savedFeatureGate = vars.FeatureGate
+		savedDebounceTime = hostEventsDebounceTime
+		savedCheckInterval = hostWatcherCheckInterval
+		hostEventsDebounceTime = 100 * time.Millisecond
+		hostWatcherCheckInterval = 100 * time.Millisecond
+		vars.FeatureGate = featuregate.New()
+		enableHostWatcherGate(true)
+
+		managedPfNodeState = &sriovnetworkv1.SriovNetworkNodeState{
+			Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+				Interfaces: sriovnetworkv1.Interfaces{{PciAddress: "0000:d8:00.0", Name: "ens1f0", NumVfs: 2}},
+			},
+			Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+				Interfaces: sriovnetworkv1.InterfaceExts{
+					{
+						PciAddress: "0000:d8:00.0",
+						Name:       "ens1f0",
+						VFs: []sriovnetworkv1.VirtualFunction{
+							{PciAddress: "0000:d8:00.2", Name: "ens1f0v0", RepresentorName: "pf0vf0"},
+							{PciAddress: "0000:d8:00.3"},
+						},
+						SFs: []sriovnetworkv1.ScalableFunction{{Name: "enp216s0f0s1", RepresentorName: "pf0sf1"}},
+					},
+					{PciAddress: "0000:d8:00.1", Name: "ens1f1"},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+		vars.FeatureGate = savedFeatureGate
+		hostEventsDebounceTime = savedDebounceTime
+		hostWatcherCheckInterval = savedCheckInterval
+		testCtrl.Finish()
+	})
+
+	// watchEvents returns the channel the host watcher sends the events to, the watcher returns watchErr
+	// when the test context is done
+	watchEvents := func(watchErr error) chan chan<- hosttypes.HostEvent {
+		started := make(chan chan<- hosttypes.HostEvent, 1)
+		hostHelper.EXPECT().WatchHostEvents(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(watchCtx context.Context, events chan<- hosttypes.HostEvent) error {
+				started <- events
+				<-watchCtx.Done()
+				return watchErr
+			})
+		return started
+	}
+
+	receiveReconcile := func() {
+		Eventually(dn.hostEvents).Should(Receive(WithTransform(func(e event.GenericEvent) string {
+			return e.Object.GetName()
+		}, Equal(vars.NodeName))))
+	}
+
+	Context("updateHostEventDevices", func() {
+		It("should map the devices of the managed PFs to the PF", func() {
+			dn.updateHostEventDevices(managedPfNodeState)
+			Expect(dn.hostEventDevices).To(Equal(map[string]string{
+				"0000:d8:00.0": "0000:d8:00.0",
+				"ens1f0":       "0000:d8:00.0",
+				"0000:d8:00.2": "0000:d8:00.0",
+				"ens1f0v0":     "0000:d8:00.0",
+				"pf0vf0":       "0000:d8:00.0",
+				"0000:d8:00.3": "0000:d8:00.0",
+				"enp216s0f0s1": "0000:d8:00.0",
+				"pf0sf1":       "0000:d8:00.0",
+			}))
+
+			pf, ok := dn.getHostEventPf(hosttypes.HostEvent{PciAddress: "0000:d8:00.3", Reason: "device removed"})
+			Expect(ok).To(BeTrue())
+			Expect(pf).To(Equal("0000:d8:00.0"))
+			pf, ok = dn.getHostEventPf(hosttypes.HostEvent{Interface: "pf0vf0", Reason: "link down"})
+			Expect(ok).To(BeTrue())
+			Expect(pf).To(Equal("0000:d8:00.0"))
+			_, ok = dn.getHostEventPf(hosttypes.HostEvent{PciAddress: "0000:d8:00.1", Interface: "ens1f1", Reason: "link down"})
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should forget the devices of the PFs removed from the spec", func() {
+			dn.updateHostEventDevices(managedPfNodeState)
+			managedPfNodeState.Spec.Interfaces = nil
+			dn.updateHostEventDevices(managedPfNodeState)
+			Expect(dn.hostEventDevices).To(BeEmpty())
+		})
+	})
+
+	Context("runHostWatcher", func() {
+		It("should trigger a single reconcile for a burst of events of the managed PFs", func() {
+			dn.updateHostEventDevices(managedPfNodeState)
+			started := watchEvents(nil)
+			result := make(chan error, 1)
+			go func() { result <- dn.runHostWatcher(ctx) }()
+
+			var events chan<- hosttypes.HostEvent
+			Eventually(started).Should(Receive(&events))
+			events <- hosttypes.HostEvent{PciAddress: "0000:d8:00.2", Reason: "device removed"}
+			events <- hosttypes.HostEvent{PciAddress: "0000:d8:00.3", Reason: "device removed"}
+			events <- hosttypes.HostEvent{Interface: "ens1f0", Reason: "link down"}
+			receiveReconcile()
+			Expect(dn.takeHostEventPfs()).To(Equal([]string{"0000:d8:00.0"}))
+			Consistently(dn.hostEvents, 3*hostEventsDebounceTime).ShouldNot(Receive())
+
+			// the events of the unmanaged devices are ignored
+			events <- hosttypes.HostEvent{PciAddress: "0000:d8:00.1", Interface: "ens1f1", Reason: "link down"}
+			Consistently(dn.hostEvents, 3*hostEventsDebounceTime).ShouldNot(Receive())
+
+			events <- hosttypes.HostEvent{Interface: "pf0sf1", Reason: "link removed"}
+			receiveReconcile()
+
+			cancel()
+			Eventually(result).Should(Receive(BeNil()))
+		})
+
+		It("should stop when the feature gate is disabled", func() {
+			watchEvents(nil)
+			result := make(chan error, 1)
+			go func() { result <- dn.runHostWatcher(ctx) }()
+			Consistently(result, 3*hostWatcherCheckInterval).ShouldNot(Receive())
+
+			enableHostWatcherGate(false)
+			Eventually(result).Should(Receive(BeNil()))
+		})
+
+		It("should return the error of the watcher", func() {
+			hostHelper.EXPECT().WatchHostEvents(gomock.Any(), gomock.Any()).Return(fmt.Errorf("test-error"))
+			Expect(dn.runHostWatcher(ctx)).To(MatchError("test-error"))
+		})
+	})
+
+	Context("Reconcile", func() {
+		var (
+			c            client.Client
+			platformMock *mock_platform.MockInterface
+			mainPlugin   *mock_plugin.MockVendorPlugin
+		)
+		BeforeEach(func() {
+			DeferCleanup(func(nodeName, namespace string) {
+				vars.NodeName = nodeName
+				vars.Namespace = namespace
+			}, vars.NodeName, vars.Namespace)
+			vars.NodeName = "host-events-node"
+			vars.Namespace = "sriov-network-operator"
+
+			managedPfNodeState.ObjectMeta = metav1.ObjectMeta{Name: vars.NodeName, Namespace: vars.Namespace, Generation: 2,
+				Annotations: map[string]string{
+					consts.NodeStateDrainAnnotation:        consts.DrainIdle,
+					consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle,
+				}}
+			s := runtime.NewScheme()
+			Expect(corev1.AddToScheme(s)).To(Succeed())
+			Expect(sriovnetworkv1.AddToScheme(s)).To(Succeed())
+			c = fake.NewClientBuilder().WithScheme(s).WithStatusSubresource(&sriovnetworkv1.SriovNetworkNodeState{}).
+				WithObjects(managedPfNodeState).Build()
+
+			platformMock = mock_platform.NewMockInterface(testCtrl)
+			mainPlugin = mock_plugin.NewMockVendorPlugin(testCtrl)
+			mainPlugin.EXPECT().Name().Return("generic").AnyTimes()
+			dn = New(c, hostHelper, platformMock, &EventRecorder{client: c, eventRecorder: record.NewFakeRecorder(100)}, vars.FeatureGate)
+			dn.mainPlugin = mainPlugin
+			dn.lastAppliedGeneration = 2
+		})
+
+		reconcile := func() {
+			_, err := dn.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(managedPfNodeState)})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		It("should rediscover only the PFs of the host events", func() {
+			dn.addHostEventPfs([]string{"0000:d8:00.0"})
+			// the VFs of the PF were removed by a driver reset
+			platformMock.EXPECT().DiscoverSriovPfs([]string{"0000:d8:00.0"}).Return(sriovnetworkv1.InterfaceExts{
+				{PciAddress: "0000:d8:00.0", Name: "ens1f0"}}, nil)
+			mainPlugin.EXPECT().CheckStatusChanges(gomock.Any()).DoAndReturn(func(nodeState *sriovnetworkv1.SriovNetworkNodeState) (bool, error) {
+				Expect(nodeState.Status.Interfaces).To(Equal(sriovnetworkv1.InterfaceExts{
+					{PciAddress: "0000:d8:00.0", Name: "ens1f0"},
+					{PciAddress: "0000:d8:00.1", Name: "ens1f1"},
+				}))
+				return false, nil
+			})
+			reconcile()
+			Expect(dn.takeHostEventPfs()).To(BeEmpty())
+
+			By("discovering all the devices on the periodic resync")
+			platformMock.EXPECT().DiscoverSriovDevices().Return(sriovnetworkv1.InterfaceExts{
+				{PciAddress: "0000:d8:00.0", Name: "ens1f0"}, {PciAddress: "0000:d8:00.1", Name: "ens1f1"}}, nil)
+			hostHelper.EXPECT().DiscoverBonds().Return(nil, nil)
+			hostHelper.EXPECT().DiscoverRDMASubsystem().Return("shared", nil)
+			hostHelper.EXPECT().IsIommuEnabled().Return(true)
+			hostHelper.EXPECT().GetKernelArgsStatus().Return(&sriovnetworkv1.KernelArgsStatus{}, nil)
+			mainPlugin.EXPECT().CheckStatusChanges(gomock.Any()).Return(false, nil)
+			reconcile()
+		})
+
+		It("should discover all the devices for a new generation", func() {
+			dn.addHostEventPfs([]string{"0000:d8:00.0"})
+			dn.lastAppliedGeneration = 1
+			platformMock.EXPECT().DiscoverSriovDevices().Return(nil, fmt.Errorf("test-error"))
+			_, err := dn.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(managedPfNodeState)})
+			Expect(err).To(MatchError("test-error"))
+			Expect(dn.takeHostEventPfs()).To(BeEmpty())
+		})
+	})
+
+	Context("watchHostEvents", func() {
+		It("should restart the watcher after a failure", func() {
+			hostHelper.EXPECT().WatchHostEvents(gomock.Any(), gomock.Any()).Return(fmt.Errorf("test-error"))
+			started := watchEvents(nil)
+			result := make(chan error, 1)
+			go func() { result <- dn.watchHostEvents(ctx) }()
+
+			Eventually(started, 10*hostWatcherCheckInterval).Should(Receive())
+			cancel()
+			Eventually(result).Should(Receive(BeNil()))
+		})
+
+		It("should start the watcher when the feature gate is enabled", func() {
+			enableHostWatcherGate(false)
+			result := make(chan error, 1)
+			go func() { result <- dn.watchHostEvents(ctx) }()
+			// the watcher isn't started, the mock fails on an unexpected call
+			Consistently(result, 3*hostWatcherCheckInterval).ShouldNot(Receive())
+
+			started := watchEvents(nil)
+			enableHostWatcherGate(true)
+			Eventually(started, 10*hostWatcherCheckInterval).Should(Receive())
+			cancel()
+			Eventually(result).Should(Receive(BeNil()))
+		})
+	})
+})
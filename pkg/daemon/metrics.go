@@ -0,0 +1,101 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package daemon
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/store"
+)
+
+// Reasons used to label the sync failures metric
+const (
+	syncFailureReasonPluginCheck  = "PluginCheckFailed"
+	syncFailureReasonPluginApply  = "PluginApplyFailed"
+	syncFailureReasonDrain        = "DrainFailed"
+	syncFailureReasonDevicePlugin = "DevicePluginFailed"
+	syncFailureReasonSystemd      = "SystemdSyncFailed"
+	syncFailureReasonRolledBack   = "RolledBack"
+)
+
+var (
+	pluginApplyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sriov_config_daemon_plugin_apply_duration_seconds",
+		Help:    "Time spent by each plugin to apply the node configuration",
+		Buckets: prometheus.ExponentialBuckets(0.5, 2, 12),
+	}, []string{"plugin"})
+
+	drainDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "sriov_config_daemon_drain_duration_seconds",
+		Help:    "Time between the drain request of the config daemon and the drain completion",
+		Buckets: prometheus.ExponentialBuckets(5, 2, 10),
+	})
+
+	rebootsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "sriov_config_daemon_reboots_total",
+		Help: "Number of node reboots requested by the config daemon and completed",
+	})
+
+	syncFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sriov_config_daemon_sync_failures_total",
+		Help: "Number of failures to apply the SriovNetworkNodeState by reason",
+	}, []string{"reason"})
+
+	vfsConfigured = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sriov_config_daemon_vfs_configured",
+		Help: "Number of VFs configured on each PF of the node",
+	}, []string{"pf", "pci_address"})
+
+	devicePluginWaitDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "sriov_config_daemon_device_plugin_wait_duration_seconds",
+		Help:    "Time spent waiting for the device plugin pod to start and be unblocked",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 8),
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		pluginApplyDuration,
+		drainDuration,
+		rebootsTotal,
+		syncFailuresTotal,
+		vfsConfigured,
+		devicePluginWaitDuration,
+	)
+}
+
+// recordVfsConfigured reports the number of VFs of every PF discovered on the host
+func recordVfsConfigured(ifaces sriovnetworkv1.InterfaceExts) {
+	vfsConfigured.Reset()
+	for _, iface := range ifaces {
+		vfsConfigured.WithLabelValues(iface.Name, iface.PciAddress).Set(float64(iface.NumVfs))
+	}
+}
+
+// recordCompletedReboots reports the reboots requested by the config daemon and completed on the node,
+// a reboot can't be counted before it happens as the counter of the rebooted daemon is lost
+func recordCompletedReboots(storeManager store.ManagerInterface) {
+	completed, err := storeManager.GetCompletedReboots()
+	if err != nil {
+		log.Log.Error(err, "recordCompletedReboots(): failed to count the completed reboots")
+		return
+	}
+	rebootsTotal.Add(float64(completed))
+}
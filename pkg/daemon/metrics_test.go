@@ -0,0 +1,87 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package daemon
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.uber.org/mock/gomock"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	mock_helper "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper/mock"
+)
+
+// metricValue returns the value of a counter or a gauge
+func metricValue(m prometheus.Metric) float64 {
+	metric := &dto.Metric{}
+	Expect(m.Write(metric)).To(Succeed())
+	if metric.Counter != nil {
+		return metric.GetCounter().GetValue()
+	}
+	return metric.GetGauge().GetValue()
+}
+
+var _ = Describe("Metrics", func() {
+	var hostHelper *mock_helper.MockHostHelpersInterface
+
+	BeforeEach(func() {
+		hostHelper = mock_helper.NewMockHostHelpersInterface(gomock.NewController(GinkgoT()))
+	})
+
+	Context("recordCompletedReboots", func() {
+		It("should count the reboots completed on the node", func() {
+			before := metricValue(rebootsTotal)
+			hostHelper.EXPECT().GetCompletedReboots().Return(2, nil)
+			recordCompletedReboots(hostHelper)
+			Expect(metricValue(rebootsTotal) - before).To(Equal(2.0))
+		})
+
+		It("should not count the reboots when the reboots record can't be read", func() {
+			before := metricValue(rebootsTotal)
+			hostHelper.EXPECT().GetCompletedReboots().Return(0, fmt.Errorf("test-error"))
+			recordCompletedReboots(hostHelper)
+			Expect(metricValue(rebootsTotal)).To(Equal(before))
+		})
+	})
+
+	Context("recordVfsConfigured", func() {
+		It("should report the VFs of the discovered PFs only", func() {
+			recordVfsConfigured(sriovnetworkv1.InterfaceExts{
+				{Name: "ens1f0", PciAddress: "0000:d8:00.0", NumVfs: 4},
+				{Name: "ens1f1", PciAddress: "0000:d8:00.1", NumVfs: 2},
+			})
+			Expect(metricValue(vfsConfigured.WithLabelValues("ens1f0", "0000:d8:00.0"))).To(Equal(4.0))
+			Expect(metricValue(vfsConfigured.WithLabelValues("ens1f1", "0000:d8:00.1"))).To(Equal(2.0))
+
+			recordVfsConfigured(sriovnetworkv1.InterfaceExts{{Name: "ens1f0", PciAddress: "0000:d8:00.0", NumVfs: 8}})
+			Expect(metricValue(vfsConfigured.WithLabelValues("ens1f0", "0000:d8:00.0"))).To(Equal(8.0))
+			Expect(testCollectorCount(vfsConfigured)).To(Equal(1))
+		})
+	})
+})
+
+// testCollectorCount returns the number of metrics of a collector
+func testCollectorCount(c prometheus.Collector) int {
+	ch := make(chan prometheus.Metric, 100)
+	c.Collect(ch)
+	close(ch)
+	return len(ch)
+}
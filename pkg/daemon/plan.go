@@ -0,0 +1,99 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins"
+)
+
+// evaluatePlan evaluates the planned spec rendered by the operator with the dry-run policies
+// and reports in the nodeState status if applying it would require to drain or reboot the node.
+// The planned spec is evaluated only once per content, the result is removed when the operator
+// removes the planned spec annotation.
+func (dn *NodeReconciler) evaluatePlan(ctx context.Context, desiredNodeState *sriovnetworkv1.SriovNetworkNodeState) error {
+	funcLog := log.Log.WithName("evaluatePlan")
+	plannedSpec, ok := desiredNodeState.GetAnnotations()[consts.NodeStatePlannedSpecAnnotation]
+	if !ok {
+		if desiredNodeState.Status.Plan == nil {
+			return nil
+		}
+		funcLog.Info("planned spec removed, clearing plan")
+		desiredNodeState.Status.Plan = nil
+		_, err := dn.patchStatus(ctx, desiredNodeState)
+		return err
+	}
+
+	hash := sriovnetworkv1.PlannedSpecHash(plannedSpec)
+	if desiredNodeState.Status.Plan != nil && desiredNodeState.Status.Plan.PlannedSpecHash == hash {
+		return nil
+	}
+
+	plan := &sriovnetworkv1.NodeStatePlan{PlannedSpecHash: hash}
+	planned := desiredNodeState.DeepCopy()
+	planned.Spec = sriovnetworkv1.SriovNetworkNodeStateSpec{}
+	if err := json.Unmarshal([]byte(plannedSpec), &planned.Spec); err != nil {
+		plan.Error = fmt.Sprintf("failed to parse planned spec: %v", err)
+	} else {
+		plan.RebootRequired, plan.DrainRequired, err = dn.checkPlannedSpec(planned)
+		if err != nil {
+			plan.Error = err.Error()
+		}
+	}
+	funcLog.Info("planned spec evaluated", "hash", hash,
+		"drain-required", plan.DrainRequired, "reboot-required", plan.RebootRequired, "error", plan.Error)
+
+	desiredNodeState.Status.Plan = plan
+	if _, err := dn.patchStatus(ctx, desiredNodeState); err != nil {
+		funcLog.Error(err, "failed to update node state plan")
+		return err
+	}
+	return nil
+}
+
+// checkPlannedSpec evaluates the planned spec with the loaded plugins implementing plugin.Planner, the
+// evaluation changes neither the host nor the state the plugins keep for Apply. The plugins without a
+// dry-run evaluation, like the external plugins, are skipped.
+func (dn *NodeReconciler) checkPlannedSpec(planned *sriovnetworkv1.SriovNetworkNodeState) (bool, bool, error) {
+	plugins := dn.additionalPlugins
+	if dn.mainPlugin != nil {
+		plugins = append([]plugin.VendorPlugin{dn.mainPlugin}, plugins...)
+	}
+
+	reqDrain, reqReboot := false, false
+	for _, p := range plugins {
+		planner, ok := p.(plugin.Planner)
+		if !ok {
+			log.Log.V(2).Info("plugin doesn't support the evaluation of a planned spec, skip", "plugin", p.Name())
+			continue
+		}
+		d, r, err := planner.Plan(planned)
+		if err != nil {
+			return false, false, fmt.Errorf("plugin %s failed to evaluate planned spec: %v", p.Name(), err)
+		}
+		reqDrain = reqDrain || d
+		reqReboot = reqReboot || r
+	}
+	return reqReboot, reqDrain, nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package daemon
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+// handleApplyFailure counts the consecutive failures to apply the generation of the nodeState in its status,
+// the count survives the restarts of the config daemon. When the automatic rollback is enabled in the
+// SriovOperatorConfig and the generation failed AutoRollbackMaxFailures times, the last configuration
+// successfully applied on the node is restored and the nodeState is marked as failed with the failed
+// generation recorded in the status.
+func (dn *NodeReconciler) handleApplyFailure(ctx context.Context, desiredNodeState *sriovnetworkv1.SriovNetworkNodeState, applyErr error) (ctrl.Result, error) {
+	funcLog := log.Log.WithName("handleApplyFailure")
+	// in systemd mode the configuration is applied by the sriov-config service
+	if vars.AutoRollbackMaxFailures == 0 || vars.UsingSystemdMode {
+		return ctrl.Result{}, applyErr
+	}
+
+	// the patch of the status refreshes the object meta, the generation may move forward
+	failedGeneration := desiredNodeState.Generation
+	failures := 1
+	if previous := desiredNodeState.Status.ApplyFailures; previous != nil && previous.Generation == failedGeneration {
+		failures = previous.Failures + 1
+	}
+	funcLog.Info("failed to apply generation", "generation", failedGeneration,
+		"failures", failures, "max-failures", vars.AutoRollbackMaxFailures)
+	desiredNodeState.Status.ApplyFailures = &sriovnetworkv1.NodeStateApplyFailures{
+		Generation: failedGeneration,
+		Failures:   failures,
+	}
+	if _, err := dn.patchStatus(ctx, desiredNodeState); err != nil {
+		funcLog.Error(err, "failed to record the apply failure")
+		return ctrl.Result{}, applyErr
+	}
+	if failures < vars.AutoRollbackMaxFailures {
+		return ctrl.Result{}, applyErr
+	}
+
+	lastApplied, exist, err := dn.hostHelpers.GetLastAppliedNodeState()
+	if err != nil {
+		funcLog.Error(err, "failed to load the last applied node state")
+		return ctrl.Result{}, applyErr
+	}
+	if !exist {
+		funcLog.Info("no configuration was successfully applied on the node, nothing to restore")
+		return ctrl.Result{}, applyErr
+	}
+
+	funcLog.Info("restoring the last applied configuration", "failed-generation", failedGeneration,
+		"restored-generation", lastApplied.Generation)
+	if err := dn.restoreLastApplied(ctx, desiredNodeState, lastApplied); err != nil {
+		funcLog.Error(err, "failed to restore the last applied configuration")
+		message := fmt.Sprintf("failed to apply generation %d: %v, failed to restore generation %d: %v",
+			failedGeneration, applyErr, lastApplied.Generation, err)
+		if err := dn.updateSyncState(ctx, desiredNodeState, consts.SyncStatusFailed, message); err != nil {
+			funcLog.Error(err, "failed to update sync status")
+		}
+		return ctrl.Result{}, applyErr
+	}
+
+	desiredNodeState.Status.Rollback = &sriovnetworkv1.NodeStateRollback{
+		FailedGeneration:   failedGeneration,
+		RestoredGeneration: lastApplied.Generation,
+		Failures:           failures,
+	}
+	desiredNodeState.Status.ApplyFailures = nil
+	message := fmt.Sprintf("rolled back to generation %d after %d failures to apply generation %d: %v",
+		lastApplied.Generation, failures, failedGeneration, applyErr)
+	if err := dn.updateSyncState(ctx, desiredNodeState, consts.SyncStatusFailed, message); err != nil {
+		funcLog.Error(err, "failed to update sync status")
+		return ctrl.Result{}, err
+	}
+	dn.eventRecorder.SendNodeEvent(ctx, corev1.EventTypeWarning, EventReasonRollback, message)
+	syncFailuresTotal.WithLabelValues(syncFailureReasonRolledBack).Inc()
+	return ctrl.Result{RequeueAfter: consts.DaemonRequeueTime}, nil
+}
+
+// isRolledBack returns true if the last applied configuration was restored after failing to apply
+// the generation of the nodeState, the restored configuration is kept until a new generation is rendered
+func isRolledBack(nodeState *sriovnetworkv1.SriovNetworkNodeState) bool {
+	return nodeState.Status.Rollback != nil && nodeState.Status.Rollback.FailedGeneration == nodeState.Generation
+}
+
+// restoreLastApplied applies the spec of the last applied nodeState with the loaded plugins,
+// the node is released from the drain once the configuration is restored.
+// The restore is given up before any plugin changes the host if the restored spec requires a reboot.
+func (dn *NodeReconciler) restoreLastApplied(ctx context.Context, desiredNodeState, lastApplied *sriovnetworkv1.SriovNetworkNodeState) error {
+	// only the status of the nodeState is patched, the failed spec is kept in the object
+	failedSpec := desiredNodeState.Spec
+	desiredNodeState.Spec = lastApplied.Spec
+	defer func() { desiredNodeState.Spec = failedSpec }()
+
+	// the plugins change the host when they are notified of the spec, e.g. the generic plugin
+	// edits the kernel arguments, so the restored spec is evaluated with their dry-run first
+	reqReboot, _, err := dn.checkPlannedSpec(desiredNodeState)
+	if err != nil {
+		return err
+	}
+	if reqReboot {
+		return fmt.Errorf("restoring generation %d requires a reboot", lastApplied.Generation)
+	}
+
+	// the plugins without dry-run evaluation may still require a reboot
+	reqReboot, _, err = dn.checkOnNodeStateChange(ctx, desiredNodeState)
+	if err != nil {
+		return err
+	}
+	if reqReboot {
+		return fmt.Errorf("restoring generation %d requires a reboot", lastApplied.Generation)
+	}
+
+	for _, p := range dn.additionalPlugins {
+		if err := p.Apply(); err != nil {
+			return fmt.Errorf("plugin %s failed to apply: %v", p.Name(), err)
+		}
+	}
+	if dn.mainPlugin != nil {
+		if err := dn.mainPlugin.Apply(); err != nil {
+			return fmt.Errorf("plugin %s failed to apply: %v", dn.mainPlugin.Name(), err)
+		}
+	}
+
+	if err := dn.restartDevicePluginPod(ctx); err != nil {
+		return fmt.Errorf("failed to restart device plugin on the node: %v", err)
+	}
+	if vars.FeatureGate.IsEnabled(consts.BlockDevicePluginUntilConfiguredFeatureGate) &&
+		len(desiredNodeState.Spec.Interfaces) > 0 {
+		if err := dn.waitForDevicePluginPodAndTryUnblock(ctx, desiredNodeState); err != nil {
+			return fmt.Errorf("failed to unblock device plugin: %v", err)
+		}
+	}
+	if err := dn.annotate(ctx, desiredNodeState, consts.DrainIdle); err != nil {
+		return fmt.Errorf("failed to request annotation update to idle: %v", err)
+	}
+	return dn.updateStatusFromHost(desiredNodeState)
+}
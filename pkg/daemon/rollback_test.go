@@ -0,0 +1,223 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package daemon
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/featuregate"
+	mock_helper "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper/mock"
+	mock_platform "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/platform/mock"
+	mock_plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins/mock"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+// plannerPlugin is a vendor plugin evaluating the specs without side effects, like the generic plugin
+type plannerPlugin struct {
+	*mock_plugin.MockVendorPlugin
+	*mock_plugin.MockPlanner
+}
+
+var _ = Describe("Automatic rollback", func() {
+	const rollbackNodeName = "rollback-node"
+	var (
+		ctx          context.Context
+		testCtrl     *gomock.Controller
+		c            client.Client
+		hostHelper   *mock_helper.MockHostHelpersInterface
+		platformMock *mock_platform.MockInterface
+		mainPlugin   *mock_plugin.MockVendorPlugin
+		planner      *mock_plugin.MockPlanner
+		applyErr     = fmt.Errorf("test-apply-error")
+
+		lastApplied *sriovnetworkv1.SriovNetworkNodeState
+	)
+
+	// newReconciler returns a new config daemon, like after a restart of the daemon
+	newReconciler := func() *NodeReconciler {
+		dn := New(c, hostHelper, platformMock,
+			&EventRecorder{client: c, eventRecorder: record.NewFakeRecorder(100)}, vars.FeatureGate)
+		dn.mainPlugin = &plannerPlugin{MockVendorPlugin: mainPlugin, MockPlanner: planner}
+		return dn
+	}
+	getNodeState := func() *sriovnetworkv1.SriovNetworkNodeState {
+		nodeState := &sriovnetworkv1.SriovNetworkNodeState{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: vars.Namespace, Name: rollbackNodeName}, nodeState)).To(Succeed())
+		return nodeState
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		testCtrl = gomock.NewController(GinkgoT())
+		hostHelper = mock_helper.NewMockHostHelpersInterface(testCtrl)
+		platformMock = mock_platform.NewMockInterface(testCtrl)
+		mainPlugin = mock_plugin.NewMockVendorPlugin(testCtrl)
+		mainPlugin.EXPECT().Name().Return("generic").AnyTimes()
+		planner = mock_plugin.NewMockPlanner(testCtrl)
+
+		DeferCleanup(func(nodeName, namespace string, maxFailures int, systemd bool, fg featuregate.FeatureGate) {
+			vars.NodeName = nodeName
+			vars.Namespace = namespace
+			vars.AutoRollbackMaxFailures = maxFailures
+			vars.UsingSystemdMode = systemd
+			vars.FeatureGate = fg
+		}, vars.NodeName, vars.Namespace, vars.AutoRollbackMaxFailures, vars.UsingSystemdMode, vars.FeatureGate)
+		vars.NodeName = rollbackNodeName
+		vars.Namespace = "sriov-network-operator"
+		vars.AutoRollbackMaxFailures = 2
+		vars.UsingSystemdMode = false
+		vars.FeatureGate = featuregate.New()
+
+		s := runtime.NewScheme()
+		Expect(corev1.AddToScheme(s)).To(Succeed())
+		Expect(sriovnetworkv1.AddToScheme(s)).To(Succeed())
+		c = fake.NewClientBuilder().WithScheme(s).
+			WithStatusSubresource(&sriovnetworkv1.SriovNetworkNodeState{}).
+			WithObjects(
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: rollbackNodeName}},
+				&sriovnetworkv1.SriovNetworkNodeState{
+					ObjectMeta: metav1.ObjectMeta{Name: rollbackNodeName, Namespace: vars.Namespace, Generation: 3},
+					Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+						Interfaces: sriovnetworkv1.Interfaces{{PciAddress: "0000:d8:00.0", NumVfs: 8}},
+					},
+				}).Build()
+
+		lastApplied = &sriovnetworkv1.SriovNetworkNodeState{
+			ObjectMeta: metav1.ObjectMeta{Name: rollbackNodeName, Namespace: vars.Namespace, Generation: 2},
+			Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+				Interfaces: sriovnetworkv1.Interfaces{{PciAddress: "0000:d8:00.0", NumVfs: 4}},
+			},
+		}
+	})
+
+	// expectRestore expects the restore of the last applied configuration with the main plugin
+	expectRestore := func() {
+		hostHelper.EXPECT().GetLastAppliedNodeState().Return(lastApplied, true, nil)
+		planner.EXPECT().Plan(gomock.Any()).DoAndReturn(
+			func(nodeState *sriovnetworkv1.SriovNetworkNodeState) (bool, bool, error) {
+				Expect(nodeState.Spec).To(Equal(lastApplied.Spec))
+				return true, false, nil
+			})
+		mainPlugin.EXPECT().OnNodeStateChange(gomock.Any()).DoAndReturn(
+			func(nodeState *sriovnetworkv1.SriovNetworkNodeState) (bool, bool, error) {
+				Expect(nodeState.Spec).To(Equal(lastApplied.Spec))
+				return true, false, nil
+			})
+		mainPlugin.EXPECT().Apply().Return(nil)
+		platformMock.EXPECT().DiscoverSriovDevices().Return(sriovnetworkv1.InterfaceExts{}, nil)
+		hostHelper.EXPECT().DiscoverBonds().Return(nil, nil)
+		hostHelper.EXPECT().DiscoverRDMASubsystem().Return("shared", nil)
+		hostHelper.EXPECT().IsIommuEnabled().Return(true)
+		hostHelper.EXPECT().GetKernelArgsStatus().Return(&sriovnetworkv1.KernelArgsStatus{}, nil)
+	}
+
+	It("should restore the last applied configuration and wait for a new generation", func() {
+		By("counting the first failure in the status")
+		_, err := newReconciler().handleApplyFailure(ctx, getNodeState(), applyErr)
+		Expect(err).To(MatchError(applyErr))
+		nodeState := getNodeState()
+		Expect(nodeState.Status.ApplyFailures).To(Equal(&sriovnetworkv1.NodeStateApplyFailures{Generation: 3, Failures: 1}))
+		Expect(nodeState.Status.Rollback).To(BeNil())
+		Expect(isRolledBack(nodeState)).To(BeFalse())
+
+		By("rolling back on the second failure after a restart of the daemon")
+		expectRestore()
+		rolledBackFailures := metricValue(syncFailuresTotal.WithLabelValues(syncFailureReasonRolledBack))
+		result, err := newReconciler().handleApplyFailure(ctx, getNodeState(), applyErr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{RequeueAfter: consts.DaemonRequeueTime}))
+		Expect(metricValue(syncFailuresTotal.WithLabelValues(syncFailureReasonRolledBack)) - rolledBackFailures).To(Equal(1.0))
+
+		nodeState = getNodeState()
+		Expect(nodeState.Status.Rollback).To(Equal(&sriovnetworkv1.NodeStateRollback{
+			FailedGeneration: 3, RestoredGeneration: 2, Failures: 2}))
+		Expect(nodeState.Status.ApplyFailures).To(BeNil())
+		Expect(nodeState.Status.SyncStatus).To(Equal(consts.SyncStatusFailed))
+		Expect(nodeState.Status.LastSyncError).To(ContainSubstring("rolled back to generation 2 after 2 failures"))
+		// only the status is updated, the failed spec is kept in the object
+		Expect(nodeState.Spec.Interfaces[0].NumVfs).To(Equal(8))
+		Expect(nodeState.Annotations[consts.NodeStateDrainAnnotation]).To(Equal(consts.DrainIdle))
+		node := &corev1.Node{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: rollbackNodeName}, node)).To(Succeed())
+		Expect(node.Annotations[consts.NodeDrainAnnotation]).To(Equal(consts.DrainIdle))
+
+		By("keeping the restored configuration until a new generation is rendered")
+		Expect(isRolledBack(nodeState)).To(BeTrue())
+		nodeState.Generation = 4
+		Expect(isRolledBack(nodeState)).To(BeFalse())
+	})
+
+	It("should not change the host when restoring the last applied configuration requires a reboot", func() {
+		nodeState := getNodeState()
+		nodeState.Status.ApplyFailures = &sriovnetworkv1.NodeStateApplyFailures{Generation: 3, Failures: 1}
+		Expect(c.Status().Update(ctx, nodeState)).To(Succeed())
+		hostHelper.EXPECT().GetLastAppliedNodeState().Return(lastApplied, true, nil)
+		// the restored spec isn't notified to the plugins, they would edit the kernel arguments
+		planner.EXPECT().Plan(gomock.Any()).Return(true, true, nil)
+
+		_, err := newReconciler().handleApplyFailure(ctx, getNodeState(), applyErr)
+		Expect(err).To(MatchError(applyErr))
+		nodeState = getNodeState()
+		Expect(nodeState.Status.Rollback).To(BeNil())
+		Expect(nodeState.Status.SyncStatus).To(Equal(consts.SyncStatusFailed))
+		Expect(nodeState.Status.LastSyncError).To(ContainSubstring("failed to restore generation 2: restoring generation 2 requires a reboot"))
+	})
+
+	It("should restart the count for a new generation", func() {
+		nodeState := getNodeState()
+		nodeState.Status.ApplyFailures = &sriovnetworkv1.NodeStateApplyFailures{Generation: 2, Failures: 1}
+		Expect(c.Status().Update(ctx, nodeState)).To(Succeed())
+
+		_, err := newReconciler().handleApplyFailure(ctx, getNodeState(), applyErr)
+		Expect(err).To(MatchError(applyErr))
+		Expect(getNodeState().Status.ApplyFailures).To(Equal(&sriovnetworkv1.NodeStateApplyFailures{Generation: 3, Failures: 1}))
+	})
+
+	It("should keep failing without a configuration to restore", func() {
+		nodeState := getNodeState()
+		nodeState.Status.ApplyFailures = &sriovnetworkv1.NodeStateApplyFailures{Generation: 3, Failures: 1}
+		Expect(c.Status().Update(ctx, nodeState)).To(Succeed())
+		hostHelper.EXPECT().GetLastAppliedNodeState().Return(nil, false, nil)
+
+		_, err := newReconciler().handleApplyFailure(ctx, getNodeState(), applyErr)
+		Expect(err).To(MatchError(applyErr))
+		nodeState = getNodeState()
+		Expect(nodeState.Status.ApplyFailures).To(Equal(&sriovnetworkv1.NodeStateApplyFailures{Generation: 3, Failures: 2}))
+		Expect(nodeState.Status.Rollback).To(BeNil())
+	})
+
+	It("should not count the failures when the automatic rollback is disabled", func() {
+		vars.AutoRollbackMaxFailures = 0
+		_, err := newReconciler().handleApplyFailure(ctx, getNodeState(), applyErr)
+		Expect(err).To(MatchError(applyErr))
+		Expect(getNodeState().Status.ApplyFailures).To(BeNil())
+	})
+})
@@ -19,13 +19,21 @@ package daemon
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/drivers"
+	plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
@@ -35,19 +43,64 @@ const (
 
 func (dn *NodeReconciler) updateSyncState(ctx context.Context, desiredNodeState *sriovnetworkv1.SriovNetworkNodeState, status, failedMessage string) error {
 	funcLog := log.Log.WithName("updateSyncState")
-	currentNodeState := &sriovnetworkv1.SriovNetworkNodeState{}
 	desiredNodeState.Status.SyncStatus = status
 	desiredNodeState.Status.LastSyncError = failedMessage
 
+	previousStatus, err := dn.patchStatus(ctx, desiredNodeState)
+	if err != nil {
+		funcLog.Error(err, "failed to update node state status",
+			"SyncStatus", status,
+			"LastSyncError", failedMessage)
+		return err
+	}
+
+	dn.recordStatusChangeEvent(ctx, previousStatus.SyncStatus, status, failedMessage)
+	return nil
+}
+
+// updateConditions sets the provided conditions on the nodeState status and
+// patches the status only if one of the conditions changed
+func (dn *NodeReconciler) updateConditions(ctx context.Context, desiredNodeState *sriovnetworkv1.SriovNetworkNodeState, conditions ...metav1.Condition) error {
+	funcLog := log.Log.WithName("updateConditions")
+	changed := false
+	currentConditions := slices.Clone(desiredNodeState.Status.Conditions)
+	for _, condition := range conditions {
+		condition.ObservedGeneration = desiredNodeState.Generation
+		if meta.SetStatusCondition(&currentConditions, condition) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	if _, err := dn.patchStatus(ctx, desiredNodeState, conditions...); err != nil {
+		funcLog.Error(err, "failed to update node state conditions")
+		return err
+	}
+	return nil
+}
+
+// patchStatus patches the status of the nodeState with the desired status and returns the status before the patch.
+// The sync and drain conditions are refreshed from the latest object to report the right generation and drain annotations,
+// the provided conditions are set after them.
+func (dn *NodeReconciler) patchStatus(ctx context.Context, desiredNodeState *sriovnetworkv1.SriovNetworkNodeState,
+	conditions ...metav1.Condition) (*sriovnetworkv1.SriovNetworkNodeStateStatus, error) {
+	funcLog := log.Log.WithName("patchStatus")
+	currentNodeState := &sriovnetworkv1.SriovNetworkNodeState{}
+
 	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		if err := dn.client.Get(ctx, client.ObjectKey{Namespace: desiredNodeState.Namespace, Name: desiredNodeState.Name}, currentNodeState); err != nil {
-			funcLog.Error(err, "failed to get latest node state",
-				"SyncStatus", status,
-				"LastSyncError", failedMessage)
+			funcLog.Error(err, "failed to get latest node state")
 			return err
 		}
 		// update the object meta if not the patch can fail if the object did change
 		desiredNodeState.ObjectMeta = currentNodeState.ObjectMeta
+		setSyncConditions(desiredNodeState)
+		for _, condition := range conditions {
+			condition.ObservedGeneration = desiredNodeState.Generation
+			meta.SetStatusCondition(&desiredNodeState.Status.Conditions, condition)
+		}
 
 		funcLog.V(2).Info("update nodeState status",
 			"CurrentSyncStatus", currentNodeState.Status.SyncStatus,
@@ -55,23 +108,146 @@ func (dn *NodeReconciler) updateSyncState(ctx context.Context, desiredNodeState
 			"NewSyncStatus", desiredNodeState.Status.SyncStatus,
 			"NewFailedMessage", desiredNodeState.Status.LastSyncError)
 
-		err := dn.client.Status().Patch(ctx, desiredNodeState, client.MergeFrom(currentNodeState))
-		if err != nil {
-			funcLog.Error(err, "failed to update node state status",
-				"SyncStatus", status,
-				"LastSyncError", failedMessage)
-			return err
-		}
-		return nil
+		return dn.client.Status().Patch(ctx, desiredNodeState, client.MergeFrom(currentNodeState))
 	})
-
 	if retryErr != nil {
-		funcLog.Error(retryErr, "failed to update node state status")
-		return retryErr
+		return nil, retryErr
 	}
+	return &currentNodeState.Status, nil
+}
 
-	dn.recordStatusChangeEvent(ctx, currentNodeState.Status.SyncStatus, status, failedMessage)
-	return nil
+// setSyncConditions computes the Synced, Degraded, DrainRequired and RebootRequired conditions
+// from the sync status, the observed generation and the desired drain annotation of the nodeState
+func setSyncConditions(nodeState *sriovnetworkv1.SriovNetworkNodeState) {
+	synced := metav1.Condition{
+		Type:    sriovnetworkv1.ConditionSynced,
+		Status:  metav1.ConditionFalse,
+		Reason:  sriovnetworkv1.NodeStateReasonSyncInProgress,
+		Message: fmt.Sprintf("generation %d is not applied yet", nodeState.Generation),
+	}
+	switch {
+	case nodeState.Status.SyncStatus == consts.SyncStatusFailed && isRolledBack(nodeState):
+		synced.Reason = sriovnetworkv1.NodeStateReasonRolledBack
+		synced.Message = nodeState.Status.LastSyncError
+	case nodeState.Status.SyncStatus == consts.SyncStatusFailed:
+		synced.Reason = sriovnetworkv1.NodeStateReasonSyncFailed
+		synced.Message = nodeState.Status.LastSyncError
+	case nodeState.Status.SyncStatus == consts.SyncStatusSucceeded &&
+		nodeState.Status.ObservedGeneration == nodeState.Generation:
+		synced.Status = metav1.ConditionTrue
+		synced.Reason = sriovnetworkv1.NodeStateReasonSyncSucceeded
+		synced.Message = fmt.Sprintf("generation %d applied", nodeState.Generation)
+	case nodeState.Status.SyncStatus != consts.SyncStatusInProgress &&
+		nodeState.GetAnnotations()[consts.NodeStatePausedAnnotation] == "true":
+		synced.Reason = sriovnetworkv1.NodeStateReasonPaused
+		synced.Message = fmt.Sprintf("generation %d is pending until the SriovNetworkPoolConfig is resumed", nodeState.Generation)
+	case nodeState.Status.SyncStatus != consts.SyncStatusInProgress &&
+		utils.ObjectHasAnnotationKey(nodeState, consts.NodeStateRolloutHoldAnnotation):
+		synced.Reason = sriovnetworkv1.NodeStateReasonWaitingForCanaries
+		synced.Message = nodeState.GetAnnotations()[consts.NodeStateRolloutHoldAnnotation]
+	}
+
+	degraded := metav1.Condition{
+		Type:   sriovnetworkv1.ConditionDegraded,
+		Status: metav1.ConditionFalse,
+		Reason: sriovnetworkv1.NodeStateReasonNoSyncFailures,
+	}
+	if nodeState.Status.SyncStatus == consts.SyncStatusFailed || nodeState.Status.LastSyncError != "" {
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = sriovnetworkv1.NodeStateReasonSyncFailed
+		degraded.Message = nodeState.Status.LastSyncError
+		if isRolledBack(nodeState) {
+			degraded.Reason = sriovnetworkv1.NodeStateReasonRolledBack
+		}
+	}
+
+	drainState := nodeState.GetAnnotations()[consts.NodeStateDrainAnnotation]
+	drainRequired := metav1.Condition{
+		Type:   sriovnetworkv1.ConditionDrainRequired,
+		Status: metav1.ConditionFalse,
+		Reason: sriovnetworkv1.NodeStateReasonDrainNotRequested,
+	}
+	rebootRequired := metav1.Condition{
+		Type:   sriovnetworkv1.ConditionRebootRequired,
+		Status: metav1.ConditionFalse,
+		Reason: sriovnetworkv1.NodeStateReasonRebootNotRequested,
+	}
+	if drainState == consts.DrainRequired || drainState == consts.RebootRequired {
+		drainRequired.Status = metav1.ConditionTrue
+		drainRequired.Reason = sriovnetworkv1.NodeStateReasonDrainRequested
+		drainRequired.Message = fmt.Sprintf("current drain state: %s",
+			nodeState.GetAnnotations()[consts.NodeStateDrainAnnotationCurrent])
+		if nextWindow, ok := nodeState.GetAnnotations()[consts.NodeStateMaintenanceWindowAnnotation]; ok {
+			drainRequired.Reason = sriovnetworkv1.NodeStateReasonWaitingForWindow
+			drainRequired.Message = fmt.Sprintf("waiting for the maintenance window starting at %s", nextWindow)
+		}
+	}
+	if drainState == consts.RebootRequired {
+		rebootRequired.Status = metav1.ConditionTrue
+		rebootRequired.Reason = sriovnetworkv1.NodeStateReasonRebootRequested
+	}
+
+	for _, condition := range []metav1.Condition{synced, degraded, drainRequired, rebootRequired} {
+		condition.ObservedGeneration = nodeState.Generation
+		meta.SetStatusCondition(&nodeState.Status.Conditions, condition)
+	}
+
+	// the virtual platforms use vfio-pci in the unsafe no-IOMMU mode on purpose
+	vfioUnsafe, ok := vfioUnsafeCondition(nodeState)
+	if !ok || vars.PlatformType != consts.Baremetal {
+		meta.RemoveStatusCondition(&nodeState.Status.Conditions, sriovnetworkv1.ConditionVfioUnsafe)
+		return
+	}
+	vfioUnsafe.ObservedGeneration = nodeState.Generation
+	meta.SetStatusCondition(&nodeState.Status.Conditions, vfioUnsafe)
+}
+
+// vfioUnsafeCondition computes the VfioUnsafe condition from the IOMMU state and the IOMMU groups of the VFs
+// bound to vfio-pci, it returns false when the spec has no VF group of a driver using the IOMMU
+func vfioUnsafeCondition(nodeState *sriovnetworkv1.SriovNetworkNodeState) (metav1.Condition, bool) {
+	vfioPfs := map[string]bool{}
+	for _, iface := range nodeState.Spec.Interfaces {
+		for _, group := range iface.VfGroups {
+			if driver, ok := drivers.Get(group.DeviceType); ok && driver.NeedIommu {
+				vfioPfs[iface.PciAddress] = true
+			}
+		}
+	}
+	if len(vfioPfs) == 0 {
+		return metav1.Condition{}, false
+	}
+
+	condition := metav1.Condition{
+		Type:   sriovnetworkv1.ConditionVfioUnsafe,
+		Status: metav1.ConditionFalse,
+		Reason: sriovnetworkv1.NodeStateReasonVfioIsolated,
+	}
+	if !nodeState.Status.System.IommuEnabled {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = sriovnetworkv1.NodeStateReasonIommuDisabled
+		condition.Message = "the IOMMU is disabled, check the IOMMU kernel arguments and the IOMMU support (VT-d or AMD-Vi) in the BIOS"
+		return condition, true
+	}
+	var shared []string
+	for _, iface := range nodeState.Status.Interfaces {
+		if !vfioPfs[iface.PciAddress] {
+			continue
+		}
+		for _, vf := range iface.VFs {
+			if vf.Driver != consts.DeviceTypeVfioPci || vf.IommuGroup == nil || len(vf.IommuGroupDevices) == 0 {
+				continue
+			}
+			shared = append(shared, fmt.Sprintf("%s (group %d with %s)",
+				vf.PciAddress, *vf.IommuGroup, strings.Join(vf.IommuGroupDevices, ", ")))
+		}
+	}
+	if len(shared) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = sriovnetworkv1.NodeStateReasonSharedIommuGroup
+		condition.Message = "VFs share their IOMMU group with other devices, check the ACS support of the PCIe ports: " +
+			strings.Join(shared, "; ")
+	}
+	return condition, true
 }
 
 func (dn *NodeReconciler) shouldUpdateStatus(current, desiredNodeState *sriovnetworkv1.SriovNetworkNodeState) bool {
@@ -85,11 +261,21 @@ func (dn *NodeReconciler) shouldUpdateStatus(current, desiredNodeState *sriovnet
 		return true
 	}
 
+	// check for bonds
+	if !equality.Semantic.DeepEqual(current.Status.Bonds, desiredNodeState.Status.Bonds) {
+		return true
+	}
+
 	// check for system
 	if !equality.Semantic.DeepEqual(current.Status.System, desiredNodeState.Status.System) {
 		return true
 	}
 
+	// check for external plugins
+	if !equality.Semantic.DeepEqual(current.Status.ExternalPlugins, desiredNodeState.Status.ExternalPlugins) {
+		return true
+	}
+
 	// check for interfaces
 	// we can't use deep equal here because if we have a vf inside a pod is name will not be available for example
 	// we use the index for both lists
@@ -130,16 +316,111 @@ func (dn *NodeReconciler) updateStatusFromHost(nodeState *sriovnetworkv1.SriovNe
 		}
 	}
 
+	bonds, err := dn.hostHelpers.DiscoverBonds()
+	if err != nil {
+		funcLog.Error(err, "failed to discover bonds")
+		return err
+	}
+
+	filterPfStatus(nodeState.Spec.Interfaces, ifaces)
 	nodeState.Status.Interfaces = ifaces
 	nodeState.Status.Bridges = bridges
+	nodeState.Status.Bonds = bonds
+	nodeState.Status.ExternalPlugins = dn.externalPluginsStatus()
+	recordVfsConfigured(ifaces)
 	nodeState.Status.System.RdmaMode, err = dn.hostHelpers.DiscoverRDMASubsystem()
 	if err != nil {
 		funcLog.Error(err, "failed to discover rdma subsystem")
 		return err
 	}
+	nodeState.Status.System.IommuEnabled = dn.hostHelpers.IsIommuEnabled()
+	// the kernel arguments are reported for information, the status is updated without them on failure
+	nodeState.Status.KernelArgs, err = dn.hostHelpers.GetKernelArgsStatus()
+	if err != nil {
+		funcLog.Error(err, "failed to get the status of the kernel arguments")
+	}
+	return nil
+}
+
+// updatePfsStatusFromHost rediscovers the given PFs and replaces their status in the nodeState,
+// the status of the other interfaces is kept. PFs not found on the host are removed from the status.
+func (dn *NodeReconciler) updatePfsStatusFromHost(nodeState *sriovnetworkv1.SriovNetworkNodeState, pciAddresses []string) error {
+	funcLog := log.Log.WithName("updatePfsStatusFromHost")
+	funcLog.Info("Getting the status of the PFs", "devices", pciAddresses)
+	ifaces, err := dn.platformInterface.DiscoverSriovPfs(pciAddresses)
+	if err != nil {
+		funcLog.Error(err, "failed to discover the PFs")
+		return err
+	}
+	filterPfStatus(nodeState.Spec.Interfaces, ifaces)
+
+	statuses := slices.DeleteFunc(slices.Clone(nodeState.Status.Interfaces), func(iface sriovnetworkv1.InterfaceExt) bool {
+		return slices.Contains(pciAddresses, iface.PciAddress)
+	})
+	statuses = append(statuses, ifaces...)
+	// keep the order of the discovery of all the devices
+	slices.SortFunc(statuses, func(a, b sriovnetworkv1.InterfaceExt) int { return strings.Compare(a.PciAddress, b.PciAddress) })
+	nodeState.Status.Interfaces = statuses
+	recordVfsConfigured(statuses)
 	return nil
 }
 
+// externalPluginsStatus returns the status of the loaded external plugins
+func (dn *NodeReconciler) externalPluginsStatus() []sriovnetworkv1.ExternalPluginStatus {
+	var statuses []sriovnetworkv1.ExternalPluginStatus
+	for _, p := range dn.additionalPlugins {
+		if reporter, ok := p.(plugin.ExternalPluginStatusReporter); ok {
+			statuses = append(statuses, reporter.Status())
+		}
+	}
+	return statuses
+}
+
+// filterPfStatus keeps in the status only the ethtool features and the devlink parameters managed
+// by the spec, a PF exposes dozens of them and reporting all of them would bloat the nodeState.
+// The devlink parameters of the spec the PF doesn't support are reported as unsupported.
+func filterPfStatus(specIfaces sriovnetworkv1.Interfaces, ifaces sriovnetworkv1.InterfaceExts) {
+	for i := range ifaces {
+		var specIface *sriovnetworkv1.Interface
+		for j := range specIfaces {
+			if specIfaces[j].PciAddress == ifaces[i].PciAddress {
+				specIface = &specIfaces[j]
+				break
+			}
+		}
+		ifaces[i].UnsupportedDevlinkParams = nil
+		if specIface != nil {
+			ifaces[i].UnsupportedDevlinkParams = sriovnetworkv1.GetUnsupportedDevlinkParams(specIface.DevlinkParams, ifaces[i].DevlinkParams)
+		}
+		ifaces[i].DevlinkParams = slices.DeleteFunc(ifaces[i].DevlinkParams, func(param sriovnetworkv1.DevlinkParamStatus) bool {
+			if specIface == nil {
+				return true
+			}
+			_, managed := specIface.DevlinkParams[param.Name]
+			return !managed
+		})
+		if len(ifaces[i].DevlinkParams) == 0 {
+			ifaces[i].DevlinkParams = nil
+		}
+		if ifaces[i].PfSettings == nil {
+			continue
+		}
+		features := ifaces[i].PfSettings.Features
+		ifaces[i].PfSettings.Features = nil
+		if specIface == nil || specIface.PfSettings == nil {
+			continue
+		}
+		for name := range specIface.PfSettings.Features {
+			if enabled, ok := features[name]; ok {
+				if ifaces[i].PfSettings.Features == nil {
+					ifaces[i].PfSettings.Features = map[string]bool{}
+				}
+				ifaces[i].PfSettings.Features[name] = enabled
+			}
+		}
+	}
+}
+
 func (dn *NodeReconciler) recordStatusChangeEvent(ctx context.Context, oldStatus, newStatus, lastError string) {
 	if oldStatus != newStatus {
 		if oldStatus == "" {
@@ -0,0 +1,54 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package daemon
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+)
+
+var _ = Describe("filterPfStatus", func() {
+	It("should report the managed devlink parameters and the unsupported ones", func() {
+		specIfaces := sriovnetworkv1.Interfaces{{PciAddress: "0000:d8:00.0", DevlinkParams: map[string]sriovnetworkv1.DevlinkParam{
+			"flow_steering_mode": {Value: "smfs"},
+			"max_macs":           {Value: "16", Cmode: consts.DevlinkParamCmodePermanent},
+			"foo":                {Value: "bar"},
+		}}}
+		ifaces := sriovnetworkv1.InterfaceExts{
+			{PciAddress: "0000:d8:00.0", DevlinkParams: []sriovnetworkv1.DevlinkParamStatus{
+				{Name: "enable_roce", Cmode: consts.DevlinkParamCmodeDriverinit, Value: "true"},
+				{Name: "flow_steering_mode", Cmode: consts.DevlinkParamCmodeRuntime, Value: "dmfs"},
+				{Name: "max_macs", Cmode: consts.DevlinkParamCmodeDriverinit, Value: "8"},
+			}},
+			{PciAddress: "0000:d8:00.1", DevlinkParams: []sriovnetworkv1.DevlinkParamStatus{
+				{Name: "enable_roce", Cmode: consts.DevlinkParamCmodeDriverinit, Value: "true"},
+			}},
+		}
+
+		filterPfStatus(specIfaces, ifaces)
+		Expect(ifaces[0].DevlinkParams).To(Equal([]sriovnetworkv1.DevlinkParamStatus{
+			{Name: "flow_steering_mode", Cmode: consts.DevlinkParamCmodeRuntime, Value: "dmfs"},
+			{Name: "max_macs", Cmode: consts.DevlinkParamCmodeDriverinit, Value: "8"},
+		}))
+		Expect(ifaces[0].UnsupportedDevlinkParams).To(Equal([]string{"foo", "max_macs"}))
+		Expect(ifaces[1].DevlinkParams).To(BeNil())
+		Expect(ifaces[1].UnsupportedDevlinkParams).To(BeNil())
+	})
+})
@@ -0,0 +1,137 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package drivers is the registry of the userspace drivers the VFs can be bound to with the deviceType of a policy
+package drivers
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+)
+
+// ModuleParam is a parameter of a kernel module, it is set once the module is loaded
+type ModuleParam struct {
+	// Module is the name of the kernel module of the parameter, e.g. vfio
+	Module string
+	// Name of the parameter, e.g. enable_unsafe_noiommu_mode
+	Name string
+	// Value of the parameter
+	Value string
+}
+
+// UserspaceDriver describes a driver binding the VFs to the userspace (DPDK) applications
+type UserspaceDriver struct {
+	// DeviceType is the deviceType of the policies using the driver
+	DeviceType string
+	// Driver is the name of the kernel driver the VFs are bound to, it is the driver selector of the device plugin
+	Driver string
+	// Module is the kernel module of the driver loaded on the nodes
+	Module string
+	// ModuleParams are the parameters of the kernel modules set once the module is loaded
+	ModuleParams []ModuleParam
+	// NeedIommu is true if the driver requires the IOMMU, the IOMMU kernel arguments are configured
+	// and the isolation of the devices by their IOMMU group is validated
+	NeedIommu bool
+}
+
+var (
+	lock     sync.RWMutex
+	registry = map[string]UserspaceDriver{}
+)
+
+func init() {
+	Register(UserspaceDriver{
+		DeviceType: consts.DeviceTypeVfioPci,
+		Driver:     "vfio-pci",
+		Module:     "vfio_pci",
+		NeedIommu:  true,
+	})
+	// vfio-pci without IOMMU, the devices are not isolated and the vfio module taints the kernel
+	Register(UserspaceDriver{
+		DeviceType:   consts.DeviceTypeVfioPciNoIommu,
+		Driver:       "vfio-pci",
+		Module:       "vfio_pci",
+		ModuleParams: []ModuleParam{{Module: "vfio", Name: "enable_unsafe_noiommu_mode", Value: "1"}},
+	})
+	Register(UserspaceDriver{
+		DeviceType: consts.DeviceTypeUioPciGeneric,
+		Driver:     "uio_pci_generic",
+		Module:     "uio_pci_generic",
+	})
+	// igb_uio is an out-of-tree module of dpdk-kmods, it must be installed on the nodes
+	Register(UserspaceDriver{
+		DeviceType: consts.DeviceTypeIgbUio,
+		Driver:     "igb_uio",
+		Module:     "igb_uio",
+	})
+}
+
+// Register adds a userspace driver to the registry, a driver registered with the same device type is replaced
+func Register(driver UserspaceDriver) {
+	lock.Lock()
+	defer lock.Unlock()
+	registry[driver.DeviceType] = driver
+}
+
+// Get returns the userspace driver of a device type
+func Get(deviceType string) (UserspaceDriver, bool) {
+	lock.RLock()
+	defer lock.RUnlock()
+	driver, ok := registry[deviceType]
+	return driver, ok
+}
+
+// List returns the registered userspace drivers sorted by device type
+func List() []UserspaceDriver {
+	lock.RLock()
+	defer lock.RUnlock()
+	drivers := make([]UserspaceDriver, 0, len(registry))
+	for _, driver := range registry {
+		drivers = append(drivers, driver)
+	}
+	sort.Slice(drivers, func(i, j int) bool {
+		return drivers[i].DeviceType < drivers[j].DeviceType
+	})
+	return drivers
+}
+
+// IsUserspaceDeviceType returns true if the VFs of the device type are bound to a userspace driver
+func IsUserspaceDeviceType(deviceType string) bool {
+	_, ok := Get(deviceType)
+	return ok
+}
+
+// IsUserspaceDriver returns true if the kernel driver is the driver of a registered userspace device type
+func IsUserspaceDriver(driver string) bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	for _, d := range registry {
+		if d.Driver == driver {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDriver returns the kernel driver of a device type, the device type is returned for the unknown ones
+func GetDriver(deviceType string) string {
+	if driver, ok := Get(deviceType); ok {
+		return driver.Driver
+	}
+	return deviceType
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package drivers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+)
+
+var _ = Describe("Drivers", func() {
+	Context("Get", func() {
+		It("return the built-in userspace drivers", func() {
+			d, ok := Get(consts.DeviceTypeVfioPci)
+			Expect(ok).To(BeTrue())
+			Expect(d.Driver).To(Equal("vfio-pci"))
+			Expect(d.NeedIommu).To(BeTrue())
+
+			d, ok = Get(consts.DeviceTypeVfioPciNoIommu)
+			Expect(ok).To(BeTrue())
+			Expect(d.Driver).To(Equal("vfio-pci"))
+			Expect(d.Module).To(Equal("vfio_pci"))
+			Expect(d.NeedIommu).To(BeFalse())
+			Expect(d.ModuleParams).To(ConsistOf(ModuleParam{Module: "vfio", Name: "enable_unsafe_noiommu_mode", Value: "1"}))
+		})
+		It("return false for the kernel device types", func() {
+			Expect(IsUserspaceDeviceType(consts.DeviceTypeNetDevice)).To(BeFalse())
+			Expect(IsUserspaceDeviceType(consts.DeviceTypeSf)).To(BeFalse())
+			Expect(IsUserspaceDeviceType("")).To(BeFalse())
+		})
+	})
+	Context("IsUserspaceDriver", func() {
+		It("match the kernel drivers of the device types", func() {
+			Expect(IsUserspaceDriver("vfio-pci")).To(BeTrue())
+			Expect(IsUserspaceDriver("uio_pci_generic")).To(BeTrue())
+			Expect(IsUserspaceDriver("igb_uio")).To(BeTrue())
+			Expect(IsUserspaceDriver("mlx5_core")).To(BeFalse())
+		})
+	})
+	Context("GetDriver", func() {
+		It("return the kernel driver of the device type", func() {
+			Expect(GetDriver(consts.DeviceTypeVfioPciNoIommu)).To(Equal("vfio-pci"))
+			Expect(GetDriver(consts.DeviceTypeUioPciGeneric)).To(Equal("uio_pci_generic"))
+			Expect(GetDriver(consts.DeviceTypeNetDevice)).To(Equal(consts.DeviceTypeNetDevice))
+		})
+	})
+	Context("Register", func() {
+		It("add a driver to the registry", func() {
+			DeferCleanup(func() {
+				lock.Lock()
+				defer lock.Unlock()
+				delete(registry, "test-driver")
+			})
+			Register(UserspaceDriver{DeviceType: "test-driver", Driver: "test_drv", Module: "test_drv"})
+			Expect(IsUserspaceDriver("test_drv")).To(BeTrue())
+			types := []string{}
+			for _, d := range List() {
+				types = append(types, d.DeviceType)
+			}
+			Expect(types).To(Equal([]string{consts.DeviceTypeIgbUio, "test-driver", consts.DeviceTypeUioPciGeneric,
+				consts.DeviceTypeVfioPci, consts.DeviceTypeVfioPciNoIommu}))
+		})
+	})
+})
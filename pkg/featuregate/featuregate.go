@@ -33,6 +33,7 @@ var DefaultFeatureStates = map[string]bool{
 	consts.ManageSoftwareBridgesFeatureGate:            false,
 	consts.BlockDevicePluginUntilConfiguredFeatureGate: true,
 	consts.MellanoxFirmwareResetFeatureGate:            false,
+	consts.HostEventWatcherFeatureGate:                 false,
 }
 
 // FeatureGate provides methods to check state of the feature
@@ -10,11 +10,13 @@
 package mock_helper
 
 import (
+	context "context"
 	reflect "reflect"
 
 	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	store "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/store"
 	types "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
+	intelutils "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vendors/intel"
 	mlxutils "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vendors/mellanox"
 	netlink "github.com/vishvananda/netlink"
 	gomock "go.uber.org/mock/gomock"
@@ -58,6 +60,25 @@ func (mr *MockHostHelpersInterfaceMockRecorder) AddDisableNMUdevRule(pfPciAddres
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDisableNMUdevRule", reflect.TypeOf((*MockHostHelpersInterface)(nil).AddDisableNMUdevRule), pfPciAddress)
 }
 
+// AddKernelArgs mocks base method.
+func (m *MockHostHelpersInterface) AddKernelArgs(kargs ...string) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range kargs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddKernelArgs", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddKernelArgs indicates an expected call of AddKernelArgs.
+func (mr *MockHostHelpersInterfaceMockRecorder) AddKernelArgs(kargs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddKernelArgs", reflect.TypeOf((*MockHostHelpersInterface)(nil).AddKernelArgs), kargs...)
+}
+
 // AddPersistPFNameUdevRule mocks base method.
 func (m *MockHostHelpersInterface) AddPersistPFNameUdevRule(pfPciAddress, pfName string) error {
 	m.ctrl.T.Helper()
@@ -229,6 +250,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) ConfigSriovInterfaces(storeManag
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigSriovInterfaces", reflect.TypeOf((*MockHostHelpersInterface)(nil).ConfigSriovInterfaces), storeManager, interfaces, ifaceStatuses, skipVFConfiguration)
 }
 
+// ConfigureBonds mocks base method.
+func (m *MockHostHelpersInterface) ConfigureBonds(bondsSpec v1.Bonds, bondsStatus []v1.BondStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigureBonds", bondsSpec, bondsStatus)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfigureBonds indicates an expected call of ConfigureBonds.
+func (mr *MockHostHelpersInterfaceMockRecorder) ConfigureBonds(bondsSpec, bondsStatus any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureBonds", reflect.TypeOf((*MockHostHelpersInterface)(nil).ConfigureBonds), bondsSpec, bondsStatus)
+}
+
 // ConfigureBridges mocks base method.
 func (m *MockHostHelpersInterface) ConfigureBridges(bridgesSpec, bridgesStatus v1.Bridges) error {
 	m.ctrl.T.Helper()
@@ -243,32 +278,74 @@ func (mr *MockHostHelpersInterfaceMockRecorder) ConfigureBridges(bridgesSpec, br
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureBridges", reflect.TypeOf((*MockHostHelpersInterface)(nil).ConfigureBridges), bridgesSpec, bridgesStatus)
 }
 
+// ConfigureSfs mocks base method.
+func (m *MockHostHelpersInterface) ConfigureSfs(pciAddr string, numSfs int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigureSfs", pciAddr, numSfs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfigureSfs indicates an expected call of ConfigureSfs.
+func (mr *MockHostHelpersInterfaceMockRecorder) ConfigureSfs(pciAddr, numSfs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureSfs", reflect.TypeOf((*MockHostHelpersInterface)(nil).ConfigureSfs), pciAddr, numSfs)
+}
+
 // ConfigureVfGUID mocks base method.
-func (m *MockHostHelpersInterface) ConfigureVfGUID(vfAddr, pfAddr string, vfID int, pfLink netlink.Link) error {
+func (m *MockHostHelpersInterface) ConfigureVfGUID(vfAddr, pfAddr string, vfID int, pfLink netlink.Link, allocation *v1.IbGUIDAllocation) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ConfigureVfGUID", vfAddr, pfAddr, vfID, pfLink)
+	ret := m.ctrl.Call(m, "ConfigureVfGUID", vfAddr, pfAddr, vfID, pfLink, allocation)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ConfigureVfGUID indicates an expected call of ConfigureVfGUID.
-func (mr *MockHostHelpersInterfaceMockRecorder) ConfigureVfGUID(vfAddr, pfAddr, vfID, pfLink any) *gomock.Call {
+func (mr *MockHostHelpersInterfaceMockRecorder) ConfigureVfGUID(vfAddr, pfAddr, vfID, pfLink, allocation any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureVfGUID", reflect.TypeOf((*MockHostHelpersInterface)(nil).ConfigureVfGUID), vfAddr, pfAddr, vfID, pfLink)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureVfGUID", reflect.TypeOf((*MockHostHelpersInterface)(nil).ConfigureVfGUID), vfAddr, pfAddr, vfID, pfLink, allocation)
+}
+
+// ConfigureVfPKeys mocks base method.
+func (m *MockHostHelpersInterface) ConfigureVfPKeys(vfAddr, pfAddr string, pkeys []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigureVfPKeys", vfAddr, pfAddr, pkeys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfigureVfPKeys indicates an expected call of ConfigureVfPKeys.
+func (mr *MockHostHelpersInterfaceMockRecorder) ConfigureVfPKeys(vfAddr, pfAddr, pkeys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureVfPKeys", reflect.TypeOf((*MockHostHelpersInterface)(nil).ConfigureVfPKeys), vfAddr, pfAddr, pkeys)
 }
 
 // CreateVDPADevice mocks base method.
-func (m *MockHostHelpersInterface) CreateVDPADevice(pciAddr, vdpaType string) error {
+func (m *MockHostHelpersInterface) CreateVDPADevice(pciAddr, vdpaType string, attrs *v1.VdpaAttributes) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateVDPADevice", pciAddr, vdpaType)
+	ret := m.ctrl.Call(m, "CreateVDPADevice", pciAddr, vdpaType, attrs)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateVDPADevice indicates an expected call of CreateVDPADevice.
-func (mr *MockHostHelpersInterfaceMockRecorder) CreateVDPADevice(pciAddr, vdpaType any) *gomock.Call {
+func (mr *MockHostHelpersInterfaceMockRecorder) CreateVDPADevice(pciAddr, vdpaType, attrs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVDPADevice", reflect.TypeOf((*MockHostHelpersInterface)(nil).CreateVDPADevice), pciAddr, vdpaType, attrs)
+}
+
+// DeleteSfs mocks base method.
+func (m *MockHostHelpersInterface) DeleteSfs(pciAddr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSfs", pciAddr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSfs indicates an expected call of DeleteSfs.
+func (mr *MockHostHelpersInterfaceMockRecorder) DeleteSfs(pciAddr any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVDPADevice", reflect.TypeOf((*MockHostHelpersInterface)(nil).CreateVDPADevice), pciAddr, vdpaType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSfs", reflect.TypeOf((*MockHostHelpersInterface)(nil).DeleteSfs), pciAddr)
 }
 
 // DeleteVDPADevice mocks base method.
@@ -299,6 +376,35 @@ func (mr *MockHostHelpersInterfaceMockRecorder) DetachInterfaceFromManagedBridge
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachInterfaceFromManagedBridge", reflect.TypeOf((*MockHostHelpersInterface)(nil).DetachInterfaceFromManagedBridge), pciAddr)
 }
 
+// DetachPfFromManagedBond mocks base method.
+func (m *MockHostHelpersInterface) DetachPfFromManagedBond(pciAddr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachPfFromManagedBond", pciAddr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachPfFromManagedBond indicates an expected call of DetachPfFromManagedBond.
+func (mr *MockHostHelpersInterfaceMockRecorder) DetachPfFromManagedBond(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachPfFromManagedBond", reflect.TypeOf((*MockHostHelpersInterface)(nil).DetachPfFromManagedBond), pciAddr)
+}
+
+// DiscoverBonds mocks base method.
+func (m *MockHostHelpersInterface) DiscoverBonds() ([]v1.BondStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscoverBonds")
+	ret0, _ := ret[0].([]v1.BondStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscoverBonds indicates an expected call of DiscoverBonds.
+func (mr *MockHostHelpersInterfaceMockRecorder) DiscoverBonds() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverBonds", reflect.TypeOf((*MockHostHelpersInterface)(nil).DiscoverBonds))
+}
+
 // DiscoverBridges mocks base method.
 func (m *MockHostHelpersInterface) DiscoverBridges() (v1.Bridges, error) {
 	m.ctrl.T.Helper()
@@ -329,6 +435,21 @@ func (mr *MockHostHelpersInterfaceMockRecorder) DiscoverRDMASubsystem() *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverRDMASubsystem", reflect.TypeOf((*MockHostHelpersInterface)(nil).DiscoverRDMASubsystem))
 }
 
+// DiscoverSfs mocks base method.
+func (m *MockHostHelpersInterface) DiscoverSfs(pciAddr string) ([]v1.ScalableFunction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscoverSfs", pciAddr)
+	ret0, _ := ret[0].([]v1.ScalableFunction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscoverSfs indicates an expected call of DiscoverSfs.
+func (mr *MockHostHelpersInterfaceMockRecorder) DiscoverSfs(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverSfs", reflect.TypeOf((*MockHostHelpersInterface)(nil).DiscoverSfs), pciAddr)
+}
+
 // DiscoverSriovDevices mocks base method.
 func (m *MockHostHelpersInterface) DiscoverSriovDevices(storeManager store.ManagerInterface) ([]v1.InterfaceExt, error) {
 	m.ctrl.T.Helper()
@@ -344,6 +465,21 @@ func (mr *MockHostHelpersInterfaceMockRecorder) DiscoverSriovDevices(storeManage
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverSriovDevices", reflect.TypeOf((*MockHostHelpersInterface)(nil).DiscoverSriovDevices), storeManager)
 }
 
+// DiscoverSriovPfs mocks base method.
+func (m *MockHostHelpersInterface) DiscoverSriovPfs(storeManager store.ManagerInterface, pciAddresses []string) ([]v1.InterfaceExt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscoverSriovPfs", storeManager, pciAddresses)
+	ret0, _ := ret[0].([]v1.InterfaceExt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscoverSriovPfs indicates an expected call of DiscoverSriovPfs.
+func (mr *MockHostHelpersInterfaceMockRecorder) DiscoverSriovPfs(storeManager, pciAddresses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverSriovPfs", reflect.TypeOf((*MockHostHelpersInterface)(nil).DiscoverSriovPfs), storeManager, pciAddresses)
+}
+
 // DiscoverSriovVirtualDevices mocks base method.
 func (m *MockHostHelpersInterface) DiscoverSriovVirtualDevices() ([]v1.InterfaceExt, error) {
 	m.ctrl.T.Helper()
@@ -359,6 +495,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) DiscoverSriovVirtualDevices() *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverSriovVirtualDevices", reflect.TypeOf((*MockHostHelpersInterface)(nil).DiscoverSriovVirtualDevices))
 }
 
+// DiscoverVDPAAttributes mocks base method.
+func (m *MockHostHelpersInterface) DiscoverVDPAAttributes(pciAddr string) *v1.VdpaAttributes {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscoverVDPAAttributes", pciAddr)
+	ret0, _ := ret[0].(*v1.VdpaAttributes)
+	return ret0
+}
+
+// DiscoverVDPAAttributes indicates an expected call of DiscoverVDPAAttributes.
+func (mr *MockHostHelpersInterfaceMockRecorder) DiscoverVDPAAttributes(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverVDPAAttributes", reflect.TypeOf((*MockHostHelpersInterface)(nil).DiscoverVDPAAttributes), pciAddr)
+}
+
 // DiscoverVDPAType mocks base method.
 func (m *MockHostHelpersInterface) DiscoverVDPAType(pciAddr string) string {
 	m.ctrl.T.Helper()
@@ -431,6 +581,21 @@ func (mr *MockHostHelpersInterfaceMockRecorder) GetCheckPointNodeState() *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckPointNodeState", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetCheckPointNodeState))
 }
 
+// GetCompletedReboots mocks base method.
+func (m *MockHostHelpersInterface) GetCompletedReboots() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompletedReboots")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompletedReboots indicates an expected call of GetCompletedReboots.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetCompletedReboots() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompletedReboots", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetCompletedReboots))
+}
+
 // GetCurrentKernelArgs mocks base method.
 func (m *MockHostHelpersInterface) GetCurrentKernelArgs() (string, error) {
 	m.ctrl.T.Helper()
@@ -446,6 +611,36 @@ func (mr *MockHostHelpersInterfaceMockRecorder) GetCurrentKernelArgs() *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentKernelArgs", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetCurrentKernelArgs))
 }
 
+// GetDDPPackage mocks base method.
+func (m *MockHostHelpersInterface) GetDDPPackage(pkgFile string) (*intelutils.DDPPackage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDDPPackage", pkgFile)
+	ret0, _ := ret[0].(*intelutils.DDPPackage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDDPPackage indicates an expected call of GetDDPPackage.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetDDPPackage(pkgFile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDDPPackage", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetDDPPackage), pkgFile)
+}
+
+// GetDevlinkDeviceInfo mocks base method.
+func (m *MockHostHelpersInterface) GetDevlinkDeviceInfo(pciAddr string) (*netlink.DevlinkDeviceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDevlinkDeviceInfo", pciAddr)
+	ret0, _ := ret[0].(*netlink.DevlinkDeviceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDevlinkDeviceInfo indicates an expected call of GetDevlinkDeviceInfo.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetDevlinkDeviceInfo(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevlinkDeviceInfo", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetDevlinkDeviceInfo), pciAddr)
+}
+
 // GetDevlinkDeviceParam mocks base method.
 func (m *MockHostHelpersInterface) GetDevlinkDeviceParam(pciAddr, paramName string) (string, error) {
 	m.ctrl.T.Helper()
@@ -461,6 +656,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) GetDevlinkDeviceParam(pciAddr, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevlinkDeviceParam", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetDevlinkDeviceParam), pciAddr, paramName)
 }
 
+// GetDevlinkParams mocks base method.
+func (m *MockHostHelpersInterface) GetDevlinkParams(pciAddr string) []v1.DevlinkParamStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDevlinkParams", pciAddr)
+	ret0, _ := ret[0].([]v1.DevlinkParamStatus)
+	return ret0
+}
+
+// GetDevlinkParams indicates an expected call of GetDevlinkParams.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetDevlinkParams(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevlinkParams", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetDevlinkParams), pciAddr)
+}
+
 // GetDriverByBusAndDevice mocks base method.
 func (m *MockHostHelpersInterface) GetDriverByBusAndDevice(bus, device string) (string, error) {
 	m.ctrl.T.Helper()
@@ -476,6 +685,22 @@ func (mr *MockHostHelpersInterfaceMockRecorder) GetDriverByBusAndDevice(bus, dev
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDriverByBusAndDevice", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetDriverByBusAndDevice), bus, device)
 }
 
+// GetInstalledDDPPackage mocks base method.
+func (m *MockHostHelpersInterface) GetInstalledDDPPackage(pciAddress string) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstalledDDPPackage", pciAddress)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetInstalledDDPPackage indicates an expected call of GetInstalledDDPPackage.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetInstalledDDPPackage(pciAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstalledDDPPackage", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetInstalledDDPPackage), pciAddress)
+}
+
 // GetInterfaceIndex mocks base method.
 func (m *MockHostHelpersInterface) GetInterfaceIndex(pciAddr string) (int, error) {
 	m.ctrl.T.Helper()
@@ -491,6 +716,53 @@ func (mr *MockHostHelpersInterfaceMockRecorder) GetInterfaceIndex(pciAddr any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterfaceIndex", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetInterfaceIndex), pciAddr)
 }
 
+// GetIommuGroup mocks base method.
+func (m *MockHostHelpersInterface) GetIommuGroup(pciAddr string) (int, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIommuGroup", pciAddr)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetIommuGroup indicates an expected call of GetIommuGroup.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetIommuGroup(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIommuGroup", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetIommuGroup), pciAddr)
+}
+
+// GetKernelArgsStatus mocks base method.
+func (m *MockHostHelpersInterface) GetKernelArgsStatus() (*v1.KernelArgsStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKernelArgsStatus")
+	ret0, _ := ret[0].(*v1.KernelArgsStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKernelArgsStatus indicates an expected call of GetKernelArgsStatus.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetKernelArgsStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKernelArgsStatus", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetKernelArgsStatus))
+}
+
+// GetLastAppliedNodeState mocks base method.
+func (m *MockHostHelpersInterface) GetLastAppliedNodeState() (*v1.SriovNetworkNodeState, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastAppliedNodeState")
+	ret0, _ := ret[0].(*v1.SriovNetworkNodeState)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLastAppliedNodeState indicates an expected call of GetLastAppliedNodeState.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetLastAppliedNodeState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastAppliedNodeState", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetLastAppliedNodeState))
+}
+
 // GetLinkType mocks base method.
 func (m *MockHostHelpersInterface) GetLinkType(name string) string {
 	m.ctrl.T.Helper()
@@ -536,6 +808,22 @@ func (mr *MockHostHelpersInterfaceMockRecorder) GetMlxNicFwData(pciAddress any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMlxNicFwData", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetMlxNicFwData), pciAddress)
 }
 
+// GetNetDevDriverInfo mocks base method.
+func (m *MockHostHelpersInterface) GetNetDevDriverInfo(ifaceName string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetDevDriverInfo", ifaceName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetNetDevDriverInfo indicates an expected call of GetNetDevDriverInfo.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetNetDevDriverInfo(ifaceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetDevDriverInfo", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetNetDevDriverInfo), ifaceName)
+}
+
 // GetNetDevLinkAdminState mocks base method.
 func (m *MockHostHelpersInterface) GetNetDevLinkAdminState(ifaceName string) string {
 	m.ctrl.T.Helper()
@@ -620,6 +908,21 @@ func (mr *MockHostHelpersInterfaceMockRecorder) GetNicSriovMode(pciAddr any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNicSriovMode", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetNicSriovMode), pciAddr)
 }
 
+// GetOperatorKernelArgs mocks base method.
+func (m *MockHostHelpersInterface) GetOperatorKernelArgs() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOperatorKernelArgs")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOperatorKernelArgs indicates an expected call of GetOperatorKernelArgs.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetOperatorKernelArgs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperatorKernelArgs", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetOperatorKernelArgs))
+}
+
 // GetPciAddressFromInterfaceName mocks base method.
 func (m *MockHostHelpersInterface) GetPciAddressFromInterfaceName(interfaceName string) (string, error) {
 	m.ctrl.T.Helper()
@@ -635,6 +938,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) GetPciAddressFromInterfaceName(i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPciAddressFromInterfaceName", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetPciAddressFromInterfaceName), interfaceName)
 }
 
+// GetPfSettings mocks base method.
+func (m *MockHostHelpersInterface) GetPfSettings(ifaceName string) *v1.PfSettings {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPfSettings", ifaceName)
+	ret0, _ := ret[0].(*v1.PfSettings)
+	return ret0
+}
+
+// GetPfSettings indicates an expected call of GetPfSettings.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetPfSettings(ifaceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPfSettings", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetPfSettings), ifaceName)
+}
+
 // GetPhysPortName mocks base method.
 func (m *MockHostHelpersInterface) GetPhysPortName(name string) (string, error) {
 	m.ctrl.T.Helper()
@@ -665,6 +982,35 @@ func (mr *MockHostHelpersInterfaceMockRecorder) GetPhysSwitchID(name any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPhysSwitchID", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetPhysSwitchID), name)
 }
 
+// GetPodAllocatedDevices mocks base method.
+func (m *MockHostHelpersInterface) GetPodAllocatedDevices() (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPodAllocatedDevices")
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPodAllocatedDevices indicates an expected call of GetPodAllocatedDevices.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetPodAllocatedDevices() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodAllocatedDevices", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetPodAllocatedDevices))
+}
+
+// GetVfPKeys mocks base method.
+func (m *MockHostHelpersInterface) GetVfPKeys(vfAddr string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVfPKeys", vfAddr)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetVfPKeys indicates an expected call of GetVfPKeys.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetVfPKeys(vfAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVfPKeys", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetVfPKeys), vfAddr)
+}
+
 // HTTPGetFetchData mocks base method.
 func (m *MockHostHelpersInterface) HTTPGetFetchData(arg0 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -695,6 +1041,34 @@ func (mr *MockHostHelpersInterfaceMockRecorder) HasDriver(pciAddr any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasDriver", reflect.TypeOf((*MockHostHelpersInterface)(nil).HasDriver), pciAddr)
 }
 
+// InstallDDPPackage mocks base method.
+func (m *MockHostHelpersInterface) InstallDDPPackage(pciAddress, pkgFile string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallDDPPackage", pciAddress, pkgFile)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallDDPPackage indicates an expected call of InstallDDPPackage.
+func (mr *MockHostHelpersInterfaceMockRecorder) InstallDDPPackage(pciAddress, pkgFile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallDDPPackage", reflect.TypeOf((*MockHostHelpersInterface)(nil).InstallDDPPackage), pciAddress, pkgFile)
+}
+
+// IsIommuEnabled mocks base method.
+func (m *MockHostHelpersInterface) IsIommuEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsIommuEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsIommuEnabled indicates an expected call of IsIommuEnabled.
+func (mr *MockHostHelpersInterfaceMockRecorder) IsIommuEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsIommuEnabled", reflect.TypeOf((*MockHostHelpersInterface)(nil).IsIommuEnabled))
+}
+
 // IsKernelArgsSet mocks base method.
 func (m *MockHostHelpersInterface) IsKernelArgsSet(cmdLine, karg string) bool {
 	m.ctrl.T.Helper()
@@ -1007,6 +1381,48 @@ func (mr *MockHostHelpersInterfaceMockRecorder) RebindVfToDefaultDriver(pciAddr
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebindVfToDefaultDriver", reflect.TypeOf((*MockHostHelpersInterface)(nil).RebindVfToDefaultDriver), pciAddr)
 }
 
+// ReloadDevlinkDevice mocks base method.
+func (m *MockHostHelpersInterface) ReloadDevlinkDevice(pciAddr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReloadDevlinkDevice", pciAddr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReloadDevlinkDevice indicates an expected call of ReloadDevlinkDevice.
+func (mr *MockHostHelpersInterfaceMockRecorder) ReloadDevlinkDevice(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadDevlinkDevice", reflect.TypeOf((*MockHostHelpersInterface)(nil).ReloadDevlinkDevice), pciAddr)
+}
+
+// ReloadIceDevices mocks base method.
+func (m *MockHostHelpersInterface) ReloadIceDevices(pciAddresses []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReloadIceDevices", pciAddresses)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReloadIceDevices indicates an expected call of ReloadIceDevices.
+func (mr *MockHostHelpersInterfaceMockRecorder) ReloadIceDevices(pciAddresses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadIceDevices", reflect.TypeOf((*MockHostHelpersInterface)(nil).ReloadIceDevices), pciAddresses)
+}
+
+// RemoveDDPPackage mocks base method.
+func (m *MockHostHelpersInterface) RemoveDDPPackage(pciAddress string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDDPPackage", pciAddress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDDPPackage indicates an expected call of RemoveDDPPackage.
+func (mr *MockHostHelpersInterfaceMockRecorder) RemoveDDPPackage(pciAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDDPPackage", reflect.TypeOf((*MockHostHelpersInterface)(nil).RemoveDDPPackage), pciAddress)
+}
+
 // RemoveDisableNMUdevRule mocks base method.
 func (m *MockHostHelpersInterface) RemoveDisableNMUdevRule(pfPciAddress string) error {
 	m.ctrl.T.Helper()
@@ -1021,6 +1437,25 @@ func (mr *MockHostHelpersInterfaceMockRecorder) RemoveDisableNMUdevRule(pfPciAdd
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDisableNMUdevRule", reflect.TypeOf((*MockHostHelpersInterface)(nil).RemoveDisableNMUdevRule), pfPciAddress)
 }
 
+// RemoveKernelArgs mocks base method.
+func (m *MockHostHelpersInterface) RemoveKernelArgs(kargs ...string) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range kargs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveKernelArgs", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveKernelArgs indicates an expected call of RemoveKernelArgs.
+func (mr *MockHostHelpersInterfaceMockRecorder) RemoveKernelArgs(kargs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveKernelArgs", reflect.TypeOf((*MockHostHelpersInterface)(nil).RemoveKernelArgs), kargs...)
+}
+
 // RemovePersistPFNameUdevRule mocks base method.
 func (m *MockHostHelpersInterface) RemovePersistPFNameUdevRule(pfPciAddress string) error {
 	m.ctrl.T.Helper()
@@ -1091,6 +1526,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) ResetSriovDevice(ifaceStatus any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetSriovDevice", reflect.TypeOf((*MockHostHelpersInterface)(nil).ResetSriovDevice), ifaceStatus)
 }
 
+// ResetVfPKeys mocks base method.
+func (m *MockHostHelpersInterface) ResetVfPKeys(vfAddr, pfAddr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetVfPKeys", vfAddr, pfAddr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetVfPKeys indicates an expected call of ResetVfPKeys.
+func (mr *MockHostHelpersInterfaceMockRecorder) ResetVfPKeys(vfAddr, pfAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetVfPKeys", reflect.TypeOf((*MockHostHelpersInterface)(nil).ResetVfPKeys), vfAddr, pfAddr)
+}
+
 // RunCommand mocks base method.
 func (m *MockHostHelpersInterface) RunCommand(arg0 string, arg1 ...string) (string, string, error) {
 	m.ctrl.T.Helper()
@@ -1112,6 +1561,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) RunCommand(arg0 any, arg1 ...any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunCommand", reflect.TypeOf((*MockHostHelpersInterface)(nil).RunCommand), varargs...)
 }
 
+// SaveLastAppliedNodeState mocks base method.
+func (m *MockHostHelpersInterface) SaveLastAppliedNodeState(arg0 *v1.SriovNetworkNodeState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLastAppliedNodeState", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveLastAppliedNodeState indicates an expected call of SaveLastAppliedNodeState.
+func (mr *MockHostHelpersInterfaceMockRecorder) SaveLastAppliedNodeState(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLastAppliedNodeState", reflect.TypeOf((*MockHostHelpersInterface)(nil).SaveLastAppliedNodeState), arg0)
+}
+
 // SaveLastPfAppliedStatus mocks base method.
 func (m *MockHostHelpersInterface) SaveLastPfAppliedStatus(PfInfo *v1.Interface) error {
 	m.ctrl.T.Helper()
@@ -1126,6 +1589,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) SaveLastPfAppliedStatus(PfInfo a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLastPfAppliedStatus", reflect.TypeOf((*MockHostHelpersInterface)(nil).SaveLastPfAppliedStatus), PfInfo)
 }
 
+// SaveRebootRequest mocks base method.
+func (m *MockHostHelpersInterface) SaveRebootRequest() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRebootRequest")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRebootRequest indicates an expected call of SaveRebootRequest.
+func (mr *MockHostHelpersInterfaceMockRecorder) SaveRebootRequest() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRebootRequest", reflect.TypeOf((*MockHostHelpersInterface)(nil).SaveRebootRequest))
+}
+
 // SetDevlinkDeviceParam mocks base method.
 func (m *MockHostHelpersInterface) SetDevlinkDeviceParam(pciAddr, paramName, value string) error {
 	m.ctrl.T.Helper()
@@ -1140,6 +1617,35 @@ func (mr *MockHostHelpersInterfaceMockRecorder) SetDevlinkDeviceParam(pciAddr, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDevlinkDeviceParam", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetDevlinkDeviceParam), pciAddr, paramName, value)
 }
 
+// SetDevlinkParams mocks base method.
+func (m *MockHostHelpersInterface) SetDevlinkParams(pciAddr string, params map[string]v1.DevlinkParam) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDevlinkParams", pciAddr, params)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetDevlinkParams indicates an expected call of SetDevlinkParams.
+func (mr *MockHostHelpersInterfaceMockRecorder) SetDevlinkParams(pciAddr, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDevlinkParams", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetDevlinkParams), pciAddr, params)
+}
+
+// SetKernelModuleParam mocks base method.
+func (m *MockHostHelpersInterface) SetKernelModuleParam(module, param, value string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKernelModuleParam", module, param, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetKernelModuleParam indicates an expected call of SetKernelModuleParam.
+func (mr *MockHostHelpersInterfaceMockRecorder) SetKernelModuleParam(module, param, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKernelModuleParam", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetKernelModuleParam), module, param, value)
+}
+
 // SetNetdevMTU mocks base method.
 func (m *MockHostHelpersInterface) SetNetdevMTU(pciAddr string, mtu int) error {
 	m.ctrl.T.Helper()
@@ -1168,6 +1674,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) SetNicSriovMode(pciAddr, mode an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNicSriovMode", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetNicSriovMode), pciAddr, mode)
 }
 
+// SetPfSettings mocks base method.
+func (m *MockHostHelpersInterface) SetPfSettings(ifaceName string, settings *v1.PfSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPfSettings", ifaceName, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPfSettings indicates an expected call of SetPfSettings.
+func (mr *MockHostHelpersInterfaceMockRecorder) SetPfSettings(ifaceName, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPfSettings", reflect.TypeOf((*MockHostHelpersInterface)(nil).SetPfSettings), ifaceName, settings)
+}
+
 // SetRDMASubsystem mocks base method.
 func (m *MockHostHelpersInterface) SetRDMASubsystem(mode string) error {
 	m.ctrl.T.Helper()
@@ -1333,6 +1853,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) VFIsReady(pciAddr any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VFIsReady", reflect.TypeOf((*MockHostHelpersInterface)(nil).VFIsReady), pciAddr)
 }
 
+// ValidateIceSwitchdevSupport mocks base method.
+func (m *MockHostHelpersInterface) ValidateIceSwitchdevSupport(pciAddr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateIceSwitchdevSupport", pciAddr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateIceSwitchdevSupport indicates an expected call of ValidateIceSwitchdevSupport.
+func (mr *MockHostHelpersInterfaceMockRecorder) ValidateIceSwitchdevSupport(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateIceSwitchdevSupport", reflect.TypeOf((*MockHostHelpersInterface)(nil).ValidateIceSwitchdevSupport), pciAddr)
+}
+
 // WaitUdevEventsProcessed mocks base method.
 func (m *MockHostHelpersInterface) WaitUdevEventsProcessed(timeout int) error {
 	m.ctrl.T.Helper()
@@ -1347,6 +1881,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) WaitUdevEventsProcessed(timeout
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUdevEventsProcessed", reflect.TypeOf((*MockHostHelpersInterface)(nil).WaitUdevEventsProcessed), timeout)
 }
 
+// WatchHostEvents mocks base method.
+func (m *MockHostHelpersInterface) WatchHostEvents(ctx context.Context, events chan<- types.HostEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchHostEvents", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchHostEvents indicates an expected call of WatchHostEvents.
+func (mr *MockHostHelpersInterfaceMockRecorder) WatchHostEvents(ctx, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchHostEvents", reflect.TypeOf((*MockHostHelpersInterface)(nil).WatchHostEvents), ctx, events)
+}
+
 // WriteCheckpointFile mocks base method.
 func (m *MockHostHelpersInterface) WriteCheckpointFile(arg0 *v1.SriovNetworkNodeState) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,252 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bond
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/vishvananda/netlink"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	netlinkLibPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
+)
+
+// link monitoring interval in milliseconds, required to fail over between the PFs
+const bondMiimon = 100
+
+type bond struct {
+	networkHelper types.NetworkInterface
+	netlinkLib    netlinkLibPkg.NetlinkLib
+}
+
+// New return default implementation of the BondInterface
+func New(networkHelper types.NetworkInterface, netlinkLib netlinkLibPkg.NetlinkLib) types.BondInterface {
+	return &bond{networkHelper: networkHelper, netlinkLib: netlinkLib}
+}
+
+// DiscoverBonds returns the bonds of PFs created by the operator on the host sorted by name
+func (b *bond) DiscoverBonds() ([]sriovnetworkv1.BondStatus, error) {
+	log.Log.V(2).Info("DiscoverBonds(): discover managed bonds")
+	links, err := b.netlinkLib.LinkList()
+	if err != nil {
+		log.Log.Error(err, "DiscoverBonds(): failed to list links")
+		return nil, err
+	}
+	var bonds []sriovnetworkv1.BondStatus
+	for _, link := range links {
+		bondLink, ok := link.(*netlink.Bond)
+		if !ok || bondLink.Alias != consts.BondManagedAlias {
+			continue
+		}
+		status := sriovnetworkv1.BondStatus{
+			BondConfigExt: sriovnetworkv1.BondConfigExt{
+				Name:   bondLink.Name,
+				Mode:   bondLink.Mode.String(),
+				Mtu:    bondLink.MTU,
+				Slaves: []sriovnetworkv1.BondSlave{},
+			},
+			LinkState: consts.LinkAdminStateDown,
+		}
+		if bondLink.Mode != netlink.BOND_MODE_ACTIVE_BACKUP {
+			status.XmitHashPolicy = bondLink.XmitHashPolicy.String()
+		}
+		if bondLink.OperState == netlink.OperUp {
+			status.LinkState = consts.LinkAdminStateUp
+		}
+		for _, slave := range links {
+			if slave.Attrs().MasterIndex != bondLink.Index {
+				continue
+			}
+			pciAddr, err := b.networkHelper.GetPciAddressFromInterfaceName(slave.Attrs().Name)
+			if err != nil {
+				log.Log.Error(err, "DiscoverBonds(): failed to get pci address of the bond slave", "bond", bondLink.Name, "slave", slave.Attrs().Name)
+				return nil, err
+			}
+			status.Slaves = append(status.Slaves, sriovnetworkv1.BondSlave{PciAddress: pciAddr, Name: slave.Attrs().Name})
+			if bondLink.ActiveSlave == slave.Attrs().Index {
+				status.ActiveSlave = slave.Attrs().Name
+			}
+		}
+		sort.Slice(status.Slaves, func(i, j int) bool { return status.Slaves[i].PciAddress < status.Slaves[j].PciAddress })
+		bonds = append(bonds, status)
+	}
+	sort.Slice(bonds, func(i, j int) bool { return bonds[i].Name < bonds[j].Name })
+	return bonds, nil
+}
+
+// ConfigureBonds creates the bonds of the spec and enslaves their PFs, the managed bonds
+// which are not in the spec are removed. The eSwitch of the PFs must be in switchdev mode.
+func (b *bond) ConfigureBonds(bondsSpec sriovnetworkv1.Bonds, bondsStatus []sriovnetworkv1.BondStatus) error {
+	log.Log.V(1).Info("ConfigureBonds(): configure bonds")
+	if len(bondsSpec) == 0 && len(bondsStatus) == 0 {
+		log.Log.V(2).Info("ConfigureBonds(): configuration is not required")
+		return nil
+	}
+	for _, current := range bondsStatus {
+		idx := slices.IndexFunc(bondsSpec, func(desired sriovnetworkv1.BondConfigExt) bool { return desired.Name == current.Name })
+		if idx != -1 && bondsSpec[idx].Mode == current.Mode && bondsSpec[idx].XmitHashPolicy == current.XmitHashPolicy {
+			continue
+		}
+		// the mode of a bond can't be changed while it has slaves, the bond is created again by configureBond
+		if err := b.deleteBond(current.Name); err != nil {
+			log.Log.Error(err, "ConfigureBonds(): failed to remove bond", "bond", current.Name)
+			return err
+		}
+	}
+	for i := range bondsSpec {
+		if err := b.configureBond(&bondsSpec[i], bondsStatus); err != nil {
+			log.Log.Error(err, "ConfigureBonds(): failed to configure bond", "bond", bondsSpec[i].Name)
+			return err
+		}
+	}
+	return nil
+}
+
+// DetachPfFromManagedBond releases the PF from the managed bond it is enslaved to,
+// this step is required before changing the eSwitch mode of the PF.
+func (b *bond) DetachPfFromManagedBond(pciAddr string) error {
+	name := b.networkHelper.TryGetInterfaceName(pciAddr)
+	if name == "" {
+		log.Log.V(2).Info("DetachPfFromManagedBond(): PF has no netdevice, skip", "device", pciAddr)
+		return nil
+	}
+	link, err := b.netlinkLib.LinkByName(name)
+	if err != nil {
+		return err
+	}
+	if link.Attrs().MasterIndex == 0 {
+		return nil
+	}
+	master, err := b.netlinkLib.LinkByIndex(link.Attrs().MasterIndex)
+	if err != nil {
+		return err
+	}
+	if !isManagedBond(master) {
+		return nil
+	}
+	log.Log.V(1).Info("DetachPfFromManagedBond(): detach PF", "device", pciAddr, "bond", master.Attrs().Name)
+	return b.netlinkLib.LinkSetNoMaster(link)
+}
+
+// configureBond creates the bond if it doesn't exist and enslaves the PFs,
+// the slaves reported in the status which are not in the spec are released
+func (b *bond) configureBond(conf *sriovnetworkv1.BondConfigExt, bondsStatus []sriovnetworkv1.BondStatus) error {
+	funcLog := log.Log.WithValues("bond", conf.Name)
+	bondLink, err := b.netlinkLib.LinkByName(conf.Name)
+	if err != nil {
+		var notFound netlink.LinkNotFoundError
+		if !errors.As(err, &notFound) {
+			return err
+		}
+		funcLog.V(2).Info("configureBond(): create bond", "mode", conf.Mode, "xmitHashPolicy", conf.XmitHashPolicy)
+		newBond := netlink.NewLinkBond(netlink.LinkAttrs{Name: conf.Name, Alias: consts.BondManagedAlias})
+		newBond.Mode = netlink.StringToBondMode(conf.Mode)
+		if conf.XmitHashPolicy != "" {
+			newBond.XmitHashPolicy = netlink.StringToBondXmitHashPolicy(conf.XmitHashPolicy)
+		}
+		newBond.Miimon = bondMiimon
+		if err := b.netlinkLib.LinkAdd(newBond); err != nil {
+			return err
+		}
+		if bondLink, err = b.netlinkLib.LinkByName(conf.Name); err != nil {
+			return err
+		}
+	} else if !isManagedBond(bondLink) {
+		return fmt.Errorf("interface %s already exists and is not a bond managed by the operator", conf.Name)
+	}
+
+	for _, status := range bondsStatus {
+		if status.Name != conf.Name {
+			continue
+		}
+		for _, slave := range status.Slaves {
+			if slices.ContainsFunc(conf.Slaves, func(s sriovnetworkv1.BondSlave) bool { return s.PciAddress == slave.PciAddress }) {
+				continue
+			}
+			funcLog.V(2).Info("configureBond(): release PF", "device", slave.PciAddress)
+			if err := b.DetachPfFromManagedBond(slave.PciAddress); err != nil {
+				return err
+			}
+		}
+	}
+
+	// the bond propagates its MTU to the slaves
+	if conf.Mtu > 0 && bondLink.Attrs().MTU != conf.Mtu {
+		funcLog.V(2).Info("configureBond(): set MTU", "mtu", conf.Mtu)
+		if err := b.netlinkLib.LinkSetMTU(bondLink, conf.Mtu); err != nil {
+			return err
+		}
+	}
+	for _, slave := range conf.Slaves {
+		if err := b.enslave(bondLink, slave.PciAddress); err != nil {
+			funcLog.Error(err, "configureBond(): failed to enslave PF", "device", slave.PciAddress)
+			return err
+		}
+	}
+	return b.netlinkLib.LinkSetUp(bondLink)
+}
+
+// enslave adds the PF to the bond, the PF is released first if it belongs to another bond
+func (b *bond) enslave(bondLink netlinkLibPkg.Link, pciAddr string) error {
+	name := b.networkHelper.TryGetInterfaceName(pciAddr)
+	if name == "" {
+		return fmt.Errorf("failed to get interface name for PF %s", pciAddr)
+	}
+	link, err := b.netlinkLib.LinkByName(name)
+	if err != nil {
+		return err
+	}
+	if link.Attrs().MasterIndex == bondLink.Attrs().Index {
+		return nil
+	}
+	log.Log.V(2).Info("enslave(): add PF to the bond", "device", pciAddr, "bond", bondLink.Attrs().Name)
+	if link.Attrs().MasterIndex != 0 {
+		if err := b.netlinkLib.LinkSetNoMaster(link); err != nil {
+			return err
+		}
+	}
+	// old kernels refuse to enslave an interface which is up, the bond brings the slave up
+	if err := b.netlinkLib.LinkSetDown(link); err != nil {
+		return err
+	}
+	return b.netlinkLib.LinkSetMaster(link, bondLink)
+}
+
+// deleteBond removes the bond, the kernel releases its slaves
+func (b *bond) deleteBond(name string) error {
+	link, err := b.netlinkLib.LinkByName(name)
+	if err != nil {
+		var notFound netlink.LinkNotFoundError
+		if errors.As(err, &notFound) {
+			return nil
+		}
+		return err
+	}
+	log.Log.V(2).Info("deleteBond(): remove bond", "bond", name)
+	return b.netlinkLib.LinkDel(link)
+}
+
+func isManagedBond(link netlinkLibPkg.Link) bool {
+	_, ok := link.(*netlink.Bond)
+	return ok && link.Attrs().Alias == consts.BondManagedAlias
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bond
+
+import (
+	"fmt"
+
+	"github.com/vishvananda/netlink"
+	"go.uber.org/mock/gomock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	netlinkLibPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink"
+	netlinkMock "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink/mock"
+	hostMock "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/mock"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
+)
+
+var _ = Describe("Bond", func() {
+	var (
+		b           types.BondInterface
+		libMock     *netlinkMock.MockNetlinkLib
+		networkMock *hostMock.MockHostManagerInterface
+		testCtrl    *gomock.Controller
+		testErr     = fmt.Errorf("test-error")
+	)
+	newBond := func(index int, mode netlink.BondMode) *netlink.Bond {
+		bond := netlink.NewLinkBond(netlink.LinkAttrs{Name: "bond0", Index: index, Alias: consts.BondManagedAlias, MTU: 9000, OperState: netlink.OperUp})
+		bond.Mode = mode
+		bond.XmitHashPolicy = netlink.BOND_XMIT_HASH_POLICY_LAYER3_4
+		return bond
+	}
+	newPf := func(name string, index, masterIndex int) *netlink.Device {
+		return &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: name, Index: index, MasterIndex: masterIndex}}
+	}
+	bondSpec := sriovnetworkv1.BondConfigExt{
+		Name:   "bond0",
+		Mode:   "active-backup",
+		Mtu:    9000,
+		Slaves: []sriovnetworkv1.BondSlave{{PciAddress: "0000:d8:00.0"}, {PciAddress: "0000:d8:00.1"}},
+	}
+	BeforeEach(func() {
+		testCtrl = gomock.NewController(GinkgoT())
+		libMock = netlinkMock.NewMockNetlinkLib(testCtrl)
+		networkMock = hostMock.NewMockHostManagerInterface(testCtrl)
+		b = New(networkMock, libMock)
+	})
+	AfterEach(func() {
+		testCtrl.Finish()
+	})
+	Context("DiscoverBonds", func() {
+		It("Discovered", func() {
+			bond := newBond(10, netlink.BOND_MODE_ACTIVE_BACKUP)
+			bond.ActiveSlave = 3
+			unmanaged := netlink.NewLinkBond(netlink.LinkAttrs{Name: "bond1", Index: 11})
+			libMock.EXPECT().LinkList().Return([]netlinkLibPkg.Link{
+				newPf("enp216s0f1np1", 3, 10), newPf("enp216s0f0np0", 2, 10), newPf("eno1", 4, 11), bond, unmanaged,
+			}, nil)
+			networkMock.EXPECT().GetPciAddressFromInterfaceName("enp216s0f0np0").Return("0000:d8:00.0", nil)
+			networkMock.EXPECT().GetPciAddressFromInterfaceName("enp216s0f1np1").Return("0000:d8:00.1", nil)
+			bonds, err := b.DiscoverBonds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bonds).To(Equal([]sriovnetworkv1.BondStatus{{
+				BondConfigExt: sriovnetworkv1.BondConfigExt{
+					Name: "bond0",
+					Mode: "active-backup",
+					Mtu:  9000,
+					Slaves: []sriovnetworkv1.BondSlave{
+						{PciAddress: "0000:d8:00.0", Name: "enp216s0f0np0"},
+						{PciAddress: "0000:d8:00.1", Name: "enp216s0f1np1"},
+					},
+				},
+				LinkState:   "up",
+				ActiveSlave: "enp216s0f1np1",
+			}}))
+		})
+		It("Hash policy of 802.3ad bond", func() {
+			libMock.EXPECT().LinkList().Return([]netlinkLibPkg.Link{newBond(10, netlink.BOND_MODE_802_3AD)}, nil)
+			bonds, err := b.DiscoverBonds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bonds).To(HaveLen(1))
+			Expect(bonds[0].Mode).To(Equal("802.3ad"))
+			Expect(bonds[0].XmitHashPolicy).To(Equal("layer3+4"))
+		})
+		It("No managed bonds", func() {
+			libMock.EXPECT().LinkList().Return([]netlinkLibPkg.Link{newPf("eno1", 4, 0)}, nil)
+			bonds, err := b.DiscoverBonds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bonds).To(BeNil())
+		})
+	})
+	Context("ConfigureBonds", func() {
+		It("Created and PFs enslaved", func() {
+			bond := newBond(10, netlink.BOND_MODE_ACTIVE_BACKUP)
+			bond.MTU = 1500
+			libMock.EXPECT().LinkByName("bond0").Return(nil, netlink.LinkNotFoundError{})
+			libMock.EXPECT().LinkAdd(gomock.Any()).DoAndReturn(func(link netlinkLibPkg.Link) error {
+				created := link.(*netlink.Bond)
+				Expect(created.Alias).To(Equal(consts.BondManagedAlias))
+				Expect(created.Mode).To(Equal(netlink.BOND_MODE_ACTIVE_BACKUP))
+				Expect(created.Miimon).To(Equal(bondMiimon))
+				return nil
+			})
+			libMock.EXPECT().LinkByName("bond0").Return(bond, nil)
+			libMock.EXPECT().LinkSetMTU(bond, 9000).Return(nil)
+			pf0 := newPf("enp216s0f0np0", 2, 0)
+			pf1 := newPf("enp216s0f1np1", 3, 0)
+			networkMock.EXPECT().TryGetInterfaceName("0000:d8:00.0").Return("enp216s0f0np0")
+			networkMock.EXPECT().TryGetInterfaceName("0000:d8:00.1").Return("enp216s0f1np1")
+			libMock.EXPECT().LinkByName("enp216s0f0np0").Return(pf0, nil)
+			libMock.EXPECT().LinkByName("enp216s0f1np1").Return(pf1, nil)
+			libMock.EXPECT().LinkSetDown(pf0).Return(nil)
+			libMock.EXPECT().LinkSetDown(pf1).Return(nil)
+			libMock.EXPECT().LinkSetMaster(pf0, bond).Return(nil)
+			libMock.EXPECT().LinkSetMaster(pf1, bond).Return(nil)
+			libMock.EXPECT().LinkSetUp(bond).Return(nil)
+			Expect(b.ConfigureBonds(sriovnetworkv1.Bonds{bondSpec}, nil)).NotTo(HaveOccurred())
+		})
+		It("Already configured", func() {
+			bond := newBond(10, netlink.BOND_MODE_ACTIVE_BACKUP)
+			libMock.EXPECT().LinkByName("bond0").Return(bond, nil)
+			networkMock.EXPECT().TryGetInterfaceName("0000:d8:00.0").Return("enp216s0f0np0")
+			networkMock.EXPECT().TryGetInterfaceName("0000:d8:00.1").Return("enp216s0f1np1")
+			libMock.EXPECT().LinkByName("enp216s0f0np0").Return(newPf("enp216s0f0np0", 2, 10), nil)
+			libMock.EXPECT().LinkByName("enp216s0f1np1").Return(newPf("enp216s0f1np1", 3, 10), nil)
+			libMock.EXPECT().LinkSetUp(bond).Return(nil)
+			status := sriovnetworkv1.BondStatus{BondConfigExt: bondSpec}
+			Expect(b.ConfigureBonds(sriovnetworkv1.Bonds{bondSpec}, []sriovnetworkv1.BondStatus{status})).NotTo(HaveOccurred())
+		})
+		It("Remove bond not in the spec", func() {
+			bond := newBond(10, netlink.BOND_MODE_ACTIVE_BACKUP)
+			libMock.EXPECT().LinkByName("bond0").Return(bond, nil)
+			libMock.EXPECT().LinkDel(bond).Return(nil)
+			status := sriovnetworkv1.BondStatus{BondConfigExt: bondSpec}
+			Expect(b.ConfigureBonds(nil, []sriovnetworkv1.BondStatus{status})).NotTo(HaveOccurred())
+		})
+		It("Interface with the bond name is not managed", func() {
+			libMock.EXPECT().LinkByName("bond0").Return(newPf("bond0", 10, 0), nil)
+			Expect(b.ConfigureBonds(sriovnetworkv1.Bonds{bondSpec}, nil)).To(HaveOccurred())
+		})
+		It("Fail to create bond", func() {
+			libMock.EXPECT().LinkByName("bond0").Return(nil, netlink.LinkNotFoundError{})
+			libMock.EXPECT().LinkAdd(gomock.Any()).Return(testErr)
+			Expect(b.ConfigureBonds(sriovnetworkv1.Bonds{bondSpec}, nil)).To(MatchError(testErr))
+		})
+	})
+	Context("DetachPfFromManagedBond", func() {
+		It("Detached", func() {
+			pf0 := newPf("enp216s0f0np0", 2, 10)
+			networkMock.EXPECT().TryGetInterfaceName("0000:d8:00.0").Return("enp216s0f0np0")
+			libMock.EXPECT().LinkByName("enp216s0f0np0").Return(pf0, nil)
+			libMock.EXPECT().LinkByIndex(10).Return(newBond(10, netlink.BOND_MODE_ACTIVE_BACKUP), nil)
+			libMock.EXPECT().LinkSetNoMaster(pf0).Return(nil)
+			Expect(b.DetachPfFromManagedBond("0000:d8:00.0")).NotTo(HaveOccurred())
+		})
+		It("Not a managed bond", func() {
+			networkMock.EXPECT().TryGetInterfaceName("0000:d8:00.0").Return("enp216s0f0np0")
+			libMock.EXPECT().LinkByName("enp216s0f0np0").Return(newPf("enp216s0f0np0", 2, 11), nil)
+			libMock.EXPECT().LinkByIndex(11).Return(netlink.NewLinkBond(netlink.LinkAttrs{Name: "bond1", Index: 11}), nil)
+			Expect(b.DetachPfFromManagedBond("0000:d8:00.0")).NotTo(HaveOccurred())
+		})
+		It("No master", func() {
+			networkMock.EXPECT().TryGetInterfaceName("0000:d8:00.0").Return("enp216s0f0np0")
+			libMock.EXPECT().LinkByName("enp216s0f0np0").Return(newPf("enp216s0f0np0", 2, 0), nil)
+			Expect(b.DetachPfFromManagedBond("0000:d8:00.0")).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -17,50 +17,36 @@
 package infiniband
 
 import (
-	"fmt"
+	"encoding/binary"
 	"math/rand"
 	"net"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 )
 
 // GUID address is an uint64 encapsulation for network hardware address
 type GUID uint64
 
-const (
-	guidLength = 8
-	byteBitLen = 8
-	byteMask   = 0xff
-)
+const guidLength = 8
 
 // ParseGUID parses string only as GUID 64 bit
 func ParseGUID(s string) (GUID, error) {
-	ha, err := net.ParseMAC(s)
+	guid, err := sriovnetworkv1.ParseIbGUID(s)
 	if err != nil {
 		return 0, err
 	}
-	if len(ha) != guidLength {
-		return 0, fmt.Errorf("invalid GUID address %s", s)
-	}
-	var guid uint64
-	for idx, octet := range ha {
-		guid |= uint64(octet) << uint(byteBitLen*(guidLength-1-idx))
-	}
 	return GUID(guid), nil
 }
 
 // String returns the string representation of GUID
 func (g GUID) String() string {
-	return g.HardwareAddr().String()
+	return sriovnetworkv1.FormatIbGUID(uint64(g))
 }
 
 // HardwareAddr returns GUID representation as net.HardwareAddr
 func (g GUID) HardwareAddr() net.HardwareAddr {
-	value := uint64(g)
 	ha := make(net.HardwareAddr, guidLength)
-	for idx := guidLength - 1; idx >= 0; idx-- {
-		ha[idx] = byte(value & byteMask)
-		value >>= byteBitLen
-	}
-
+	binary.BigEndian.PutUint64(ha, uint64(g))
 	return ha
 }
 
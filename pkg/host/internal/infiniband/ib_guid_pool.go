@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net"
 
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
 )
@@ -47,6 +48,25 @@ func newIbGUIDPool(configPath string, netlinkLib netlink.NetlinkLib, networkHelp
 	return &ibGUIDPoolImpl{guidConfigs: configs}, nil
 }
 
+// newIbGUIDPoolFromAllocation returns an instance of ibGUIDPool for the block of GUIDs allocated to the PF
+// from a SriovIBGUIDPool
+func newIbGUIDPoolFromAllocation(pfPciAddr string, allocation *sriovnetworkv1.IbGUIDAllocation) (ibGUIDPool, error) {
+	rangeStart, err := ParseGUID(allocation.Start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ib guid allocation start: %w", err)
+	}
+	rangeEnd, err := ParseGUID(allocation.End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ib guid allocation end: %w", err)
+	}
+	if rangeEnd < rangeStart {
+		return nil, fmt.Errorf("range end cannot be less then range start")
+	}
+	return &ibGUIDPoolImpl{guidConfigs: map[string]ibPfGUIDConfig{
+		pfPciAddr: {GUIDRange: &GUIDRange{Start: rangeStart, End: rangeEnd}},
+	}}, nil
+}
+
 // GetVFGUID returns the GUID, allocated for a specific VF id of the specific PF
 // If no guid pool exists for the given pfPciAddr, returns an error
 // If no guids are available for the given VF id, returns an error
@@ -25,6 +25,7 @@ import (
 	"github.com/vishvananda/netlink"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	netlinkLibPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
@@ -42,6 +43,7 @@ func New(netlinkLib netlinkLibPkg.NetlinkLib, kernelHelper types.KernelInterface
 
 		return nil, fmt.Errorf("failed to create the ib guid pool: %w", err)
 	}
+	log.Log.Info("infiniband.New(): the ib guid config is deprecated, use a SriovIBGUIDPool instead", "config path", consts.InfinibandGUIDConfigFilePath)
 
 	return &infiniband{guidPool: guidPool, netlinkLib: netlinkLib, kernelHelper: kernelHelper}, nil
 }
@@ -52,14 +54,26 @@ type infiniband struct {
 	kernelHelper types.KernelInterface
 }
 
-// ConfigureVfGUID configures and sets a GUID for an IB VF device
-func (i *infiniband) ConfigureVfGUID(vfAddr string, pfAddr string, vfID int, pfLink netlink.Link) error {
+// ConfigureVfGUID configures and sets a GUID for an IB VF device.
+// The GUID is taken from the block allocated to the PF by a SriovIBGUIDPool, then from the ib guid config
+// file of the node, a random GUID is generated otherwise
+func (i *infiniband) ConfigureVfGUID(vfAddr string, pfAddr string, vfID int, pfLink netlink.Link, allocation *sriovnetworkv1.IbGUIDAllocation) error {
 	log.Log.Info("ConfigureVfGUID(): configure vf guid", "vfAddr", vfAddr, "pfAddr", pfAddr, "vfID", vfID)
 
 	guid := generateRandomGUID()
 
-	if i.guidPool != nil {
-		guidFromPool, err := i.guidPool.GetVFGUID(pfAddr, vfID)
+	guidPool := i.guidPool
+	if allocation != nil {
+		allocationPool, err := newIbGUIDPoolFromAllocation(pfAddr, allocation)
+		if err != nil {
+			log.Log.Error(err, "ConfigureVfGUID(): invalid GUID allocation", "address", vfAddr, "pool", allocation.Pool)
+			return err
+		}
+		guidPool = allocationPool
+	}
+
+	if guidPool != nil {
+		guidFromPool, err := guidPool.GetVFGUID(pfAddr, vfID)
 		if err != nil {
 			log.Log.Info("ConfigureVfGUID(): failed to get GUID from IB GUID pool", "address", vfAddr, "error", err)
 			return err
@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	netlinkLibPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink"
 	netlinkMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink/mock"
@@ -63,7 +64,7 @@ var _ = Describe("infiniband interface implementation", func() {
 		netlinkLibMock.EXPECT().LinkSetVfPortGUID(pfLinkMock, 0, gomock.Any()).Return(nil)
 		ib, err := New(netlinkLibMock, hostMock, hostMock)
 		Expect(err).NotTo(HaveOccurred())
-		err = ib.ConfigureVfGUID("0000:d8:00.2", "0000:d8:00.0", 0, pfLinkMock)
+		err = ib.ConfigureVfGUID("0000:d8:00.2", "0000:d8:00.0", 0, pfLinkMock, nil)
 		Expect(err).NotTo(HaveOccurred())
 		// validate that generated GUID is valid
 		_, err = ParseGUID(generatedGUID)
@@ -85,13 +86,40 @@ var _ = Describe("infiniband interface implementation", func() {
 
 		ib := &infiniband{guidPool: pool, netlinkLib: netlinkLibMock, kernelHelper: hostMock}
 
-		err := ib.ConfigureVfGUID("0000:d8:00.2", "0000:d8:00.0", 0, pfLinkMock)
+		err := ib.ConfigureVfGUID("0000:d8:00.2", "0000:d8:00.0", 0, pfLinkMock, nil)
 		Expect(err).NotTo(HaveOccurred())
 		// validate that generated GUID is valid
 		resultGUID, err := ParseGUID(assignedGUID)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(resultGUID).To(Equal(guid))
 	})
+	It("should assign guids from the allocation of the PF", func() {
+		var assignedGUID string
+		pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+		netlinkLibMock.EXPECT().LinkSetVfNodeGUID(pfLinkMock, 3, gomock.Any()).DoAndReturn(
+			func(link netlinkLibPkg.Link, vf int, nodeguid net.HardwareAddr) error {
+				assignedGUID = nodeguid.String()
+				return nil
+			})
+		netlinkLibMock.EXPECT().LinkSetVfPortGUID(pfLinkMock, 3, gomock.Any()).Return(nil)
+
+		// the allocation takes precedence over the guid config file
+		guid, _ := ParseGUID("00:00:00:00:00:00:00:01")
+		pool := &ibGUIDPoolImpl{guidConfigs: map[string]ibPfGUIDConfig{"0000:d8:00.0": {GUIDs: []GUID{guid}}}}
+		ib := &infiniband{guidPool: pool, netlinkLib: netlinkLibMock, kernelHelper: hostMock}
+
+		err := ib.ConfigureVfGUID("0000:d8:00.5", "0000:d8:00.0", 3, pfLinkMock, &sriovnetworkv1.IbGUIDAllocation{
+			Pool: "pool", Start: "02:00:00:00:00:00:00:f0", End: "02:00:00:00:00:00:00:ff"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(assignedGUID).To(Equal("02:00:00:00:00:00:00:f3"))
+	})
+	It("should fail if the VF is out of the allocation of the PF", func() {
+		pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+		ib := &infiniband{netlinkLib: netlinkLibMock, kernelHelper: hostMock}
+		err := ib.ConfigureVfGUID("0000:d8:00.5", "0000:d8:00.0", 3, pfLinkMock, &sriovnetworkv1.IbGUIDAllocation{
+			Pool: "pool", Start: "02:00:00:00:00:00:00:f0", End: "02:00:00:00:00:00:00:f1"})
+		Expect(err).To(MatchError(ContainSubstring("no guid allocation found for VF id: 3")))
+	})
 	It("should read guids from the file", func() {
 		var assignedGUID string
 		netlinkLibMock.EXPECT().LinkList().Return([]netlinkLibPkg.Link{}, nil)
@@ -112,7 +140,7 @@ var _ = Describe("infiniband interface implementation", func() {
 
 		ib, err := New(netlinkLibMock, hostMock, hostMock)
 		Expect(err).NotTo(HaveOccurred())
-		err = ib.ConfigureVfGUID("0000:d8:00.2", "0000:d8:00.0", 0, pfLinkMock)
+		err = ib.ConfigureVfGUID("0000:d8:00.2", "0000:d8:00.0", 0, pfLinkMock, nil)
 		Expect(err).NotTo(HaveOccurred())
 		// validate that generated GUID is valid
 		resultGUID, err := ParseGUID(assignedGUID)
@@ -0,0 +1,218 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infiniband
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+const (
+	// the VFs have a single IB port
+	ibPort = "1"
+	// value of an unmapped entry of the PKey table of a VF
+	pkeyIdxNone = "none"
+)
+
+// ConfigureVfPKeys maps the PKeys to the PKey table of the VF through the SR-IOV PKey virtualization sysfs of the PF,
+// /sys/class/infiniband/<pf rdma device>/iov/<vf pci address>/ports/<port>/pkey_idx/<vf index>, each entry of the
+// VF table is the index of the PKey in the PKey table of the PF.
+// Only the mlx4 driver exposes this table, the VFs of the other drivers are skipped as their PKeys
+// are assigned by the subnet manager
+func (i *infiniband) ConfigureVfPKeys(vfAddr string, pfAddr string, pkeys []string) error {
+	log.Log.Info("ConfigureVfPKeys(): configure vf pkeys", "vfAddr", vfAddr, "pfAddr", pfAddr, "pkeys", pkeys)
+	pfDevPath, err := getRdmaDevicePath(pfAddr)
+	if err != nil {
+		return err
+	}
+	pfTable, err := readPKeyTable(filepath.Join(pfDevPath, "ports", ibPort, "pkeys"))
+	if err != nil {
+		return fmt.Errorf("failed to read the pkey table of the PF %s: %w", pfAddr, err)
+	}
+	pfIndexes := map[uint16]int{}
+	for idx, pkey := range pfTable {
+		if _, exist := pfIndexes[pkey]; !exist && pkey != 0 {
+			pfIndexes[pkey] = idx
+		}
+	}
+
+	vfTablePath, size, err := getVfPKeyTable(pfDevPath, vfAddr, pfAddr)
+	if err != nil || vfTablePath == "" {
+		return err
+	}
+	if len(pkeys) > size {
+		return fmt.Errorf("the pkey table of the VF %s has %d entries, %d pkeys requested", vfAddr, size, len(pkeys))
+	}
+
+	desired := make([]string, size)
+	for idx := range desired {
+		desired[idx] = pkeyIdxNone
+		if idx >= len(pkeys) {
+			continue
+		}
+		pkey, err := parsePKey(pkeys[idx])
+		if err != nil {
+			return err
+		}
+		pfIdx, exist := pfIndexes[pkey]
+		if !exist {
+			return fmt.Errorf("pkey %s is not in the pkey table of the PF %s, it must be configured by the subnet manager",
+				formatPKey(pkey), pfAddr)
+		}
+		desired[idx] = strconv.Itoa(pfIdx)
+	}
+	return writeVfPKeyTable(vfTablePath, vfAddr, desired)
+}
+
+// ResetVfPKeys restores the default PKey table of the VF, the first entry maps the default PKey
+// at the index 0 of the PKey table of the PF and the other entries are unmapped
+func (i *infiniband) ResetVfPKeys(vfAddr string, pfAddr string) error {
+	log.Log.Info("ResetVfPKeys(): reset vf pkeys", "vfAddr", vfAddr, "pfAddr", pfAddr)
+	pfDevPath, err := getRdmaDevicePath(pfAddr)
+	if err != nil {
+		return err
+	}
+	vfTablePath, size, err := getVfPKeyTable(pfDevPath, vfAddr, pfAddr)
+	if err != nil || vfTablePath == "" {
+		return err
+	}
+	desired := make([]string, size)
+	for idx := range desired {
+		desired[idx] = pkeyIdxNone
+	}
+	if size > 0 {
+		desired[0] = "0"
+	}
+	return writeVfPKeyTable(vfTablePath, vfAddr, desired)
+}
+
+// getVfPKeyTable returns the path of the PKey table of the VF in the SR-IOV sysfs of the PF and its number of entries,
+// the path is empty if the driver of the PF doesn't expose the table
+func getVfPKeyTable(pfDevPath, vfAddr, pfAddr string) (string, int, error) {
+	vfTablePath := filepath.Join(pfDevPath, "iov", vfAddr, "ports", ibPort, "pkey_idx")
+	entries, err := os.ReadDir(vfTablePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			log.Log.Info("getVfPKeyTable(): the driver of the PF doesn't expose the pkey table of the VF, skipping",
+				"pfAddr", pfAddr, "vfAddr", vfAddr)
+			return "", 0, nil
+		}
+		return "", 0, fmt.Errorf("failed to read the pkey table of the VF %s: %w", vfAddr, err)
+	}
+	return vfTablePath, len(entries), nil
+}
+
+// writeVfPKeyTable writes the entries of the PKey table of the VF which differ from the desired PF indexes
+func writeVfPKeyTable(vfTablePath, vfAddr string, desired []string) error {
+	for idx, value := range desired {
+		entryPath := filepath.Join(vfTablePath, strconv.Itoa(idx))
+		current, err := os.ReadFile(entryPath)
+		if err != nil {
+			return fmt.Errorf("failed to read the pkey table entry %d of the VF %s: %w", idx, vfAddr, err)
+		}
+		if strings.TrimSpace(string(current)) == value {
+			continue
+		}
+		log.Log.V(2).Info("writeVfPKeyTable(): set vf pkey table entry", "vfAddr", vfAddr, "index", idx, "pfIndex", value)
+		if err := os.WriteFile(entryPath, []byte(value), os.ModeAppend); err != nil {
+			return fmt.Errorf("failed to set the pkey table entry %d of the VF %s: %w", idx, vfAddr, err)
+		}
+	}
+	return nil
+}
+
+// GetVfPKeys returns the PKeys of the PKey table of the VF read from its RDMA device, the empty entries are skipped
+func (i *infiniband) GetVfPKeys(vfAddr string) []string {
+	vfDevPath, err := getRdmaDevicePath(vfAddr)
+	if err != nil {
+		log.Log.V(2).Info("GetVfPKeys(): VF has no RDMA device", "vfAddr", vfAddr, "reason", err.Error())
+		return nil
+	}
+	table, err := readPKeyTable(filepath.Join(vfDevPath, "ports", ibPort, "pkeys"))
+	if err != nil {
+		log.Log.Error(err, "GetVfPKeys(): failed to read the pkey table of the VF", "vfAddr", vfAddr)
+		return nil
+	}
+	var pkeys []string
+	for _, pkey := range table {
+		if pkey != 0 {
+			pkeys = append(pkeys, formatPKey(pkey))
+		}
+	}
+	return pkeys
+}
+
+// getRdmaDevicePath returns the sysfs path of the RDMA device of the PCI device
+func getRdmaDevicePath(pciAddr string) (string, error) {
+	rdmaDevicesPath := filepath.Join(vars.FilesystemRoot, consts.SysBusPciDevices, pciAddr, "infiniband")
+	rdmaDevices, err := os.ReadDir(rdmaDevicesPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the RDMA devices of %s: %w", pciAddr, err)
+	}
+	if len(rdmaDevices) != 1 {
+		return "", fmt.Errorf("expected just one RDMA device for %s, found %d", pciAddr, len(rdmaDevices))
+	}
+	return filepath.Join(rdmaDevicesPath, rdmaDevices[0].Name()), nil
+}
+
+// readPKeyTable returns the PKeys of the pkeys directory of an IB port by their index
+func readPKeyTable(pkeysPath string) ([]uint16, error) {
+	entries, err := os.ReadDir(pkeysPath)
+	if err != nil {
+		return nil, err
+	}
+	table := make([]uint16, len(entries))
+	for _, entry := range entries {
+		idx, err := strconv.Atoi(entry.Name())
+		if err != nil || idx < 0 || idx >= len(entries) {
+			return nil, fmt.Errorf("unexpected pkey table entry %s", entry.Name())
+		}
+		data, err := os.ReadFile(filepath.Join(pkeysPath, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		pkey, err := parsePKey(strings.TrimSpace(string(data)))
+		if err != nil {
+			return nil, err
+		}
+		table[idx] = pkey
+	}
+	return table, nil
+}
+
+// parsePKey parses a PKey in the hexadecimal form of the sysfs, e.g. 0xffff
+func parsePKey(s string) (uint16, error) {
+	pkey, err := strconv.ParseUint(s, 0, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid pkey %s: %w", s, err)
+	}
+	return uint16(pkey), nil
+}
+
+func formatPKey(pkey uint16) string {
+	return fmt.Sprintf("0x%04x", pkey)
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infiniband
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/fakefilesystem"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/helpers"
+)
+
+var _ = Describe("infiniband pkeys", func() {
+	const (
+		pfDev = "/sys/bus/pci/devices/0000:d8:00.0/infiniband/mlx4_0"
+		vfDev = "/sys/bus/pci/devices/0000:d8:00.2/infiniband/mlx4_2"
+		vfIov = pfDev + "/iov/0000:d8:00.2/ports/1/pkey_idx"
+		// the mlx5 driver doesn't expose the pkey table of the VFs
+		mlx5PfDev = "/sys/bus/pci/devices/0000:3b:00.0/infiniband/mlx5_0"
+	)
+	var ib *infiniband
+	BeforeEach(func() {
+		ib = &infiniband{}
+		helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+			Dirs: []string{pfDev + "/ports/1/pkeys", vfIov, vfDev + "/ports/1/pkeys", mlx5PfDev + "/ports/1/pkeys"},
+			Files: map[string][]byte{
+				pfDev + "/ports/1/pkeys/0":     []byte("0xffff\n"),
+				pfDev + "/ports/1/pkeys/1":     []byte("0x8001\n"),
+				pfDev + "/ports/1/pkeys/2":     []byte("0x0002\n"),
+				pfDev + "/ports/1/pkeys/3":     []byte("0x0000\n"),
+				vfIov + "/0":                   []byte("0\n"),
+				vfIov + "/1":                   []byte("none\n"),
+				vfIov + "/2":                   []byte("2\n"),
+				vfDev + "/ports/1/pkeys/0":     []byte("0xffff\n"),
+				vfDev + "/ports/1/pkeys/1":     []byte("0x0000\n"),
+				vfDev + "/ports/1/pkeys/2":     []byte("0x0002\n"),
+				mlx5PfDev + "/ports/1/pkeys/0": []byte("0xffff\n"),
+			},
+		})
+	})
+	Context("ConfigureVfPKeys", func() {
+		It("should map the pkeys of the PF to the VF table", func() {
+			Expect(ib.ConfigureVfPKeys("0000:d8:00.2", "0000:d8:00.0", []string{"0x8001", "0xFFFF"})).NotTo(HaveOccurred())
+			helpers.GinkgoAssertFileContentsEquals(vfIov+"/0", "1")
+			helpers.GinkgoAssertFileContentsEquals(vfIov+"/1", "0")
+			helpers.GinkgoAssertFileContentsEquals(vfIov+"/2", "none")
+		})
+		It("should skip the entries already mapped", func() {
+			Expect(ib.ConfigureVfPKeys("0000:d8:00.2", "0000:d8:00.0", []string{"0xffff"})).NotTo(HaveOccurred())
+			helpers.GinkgoAssertFileContentsEquals(vfIov+"/0", "0\n")
+			helpers.GinkgoAssertFileContentsEquals(vfIov+"/1", "none\n")
+			helpers.GinkgoAssertFileContentsEquals(vfIov+"/2", "none")
+		})
+		It("should fail if the pkey is not in the PF table", func() {
+			Expect(ib.ConfigureVfPKeys("0000:d8:00.2", "0000:d8:00.0", []string{"0x8003"})).To(
+				MatchError(ContainSubstring("pkey 0x8003 is not in the pkey table of the PF 0000:d8:00.0")))
+		})
+		It("should fail if the VF table is too small", func() {
+			Expect(ib.ConfigureVfPKeys("0000:d8:00.2", "0000:d8:00.0", []string{"0xffff", "0x8001", "0x0002", "0xffff"})).To(
+				MatchError(ContainSubstring("the pkey table of the VF 0000:d8:00.2 has 3 entries, 4 pkeys requested")))
+		})
+		It("should skip the VF if the driver doesn't expose its table", func() {
+			Expect(ib.ConfigureVfPKeys("0000:3b:00.2", "0000:3b:00.0", []string{"0xffff"})).To(Succeed())
+		})
+	})
+	Context("ResetVfPKeys", func() {
+		It("should restore the default VF table", func() {
+			Expect(ib.ResetVfPKeys("0000:d8:00.2", "0000:d8:00.0")).NotTo(HaveOccurred())
+			helpers.GinkgoAssertFileContentsEquals(vfIov+"/0", "0\n")
+			helpers.GinkgoAssertFileContentsEquals(vfIov+"/1", "none\n")
+			helpers.GinkgoAssertFileContentsEquals(vfIov+"/2", "none")
+		})
+		It("should skip the VF if the driver doesn't expose its table", func() {
+			Expect(ib.ResetVfPKeys("0000:3b:00.2", "0000:3b:00.0")).To(Succeed())
+		})
+	})
+	Context("GetVfPKeys", func() {
+		It("should return the pkeys of the VF table", func() {
+			Expect(ib.GetVfPKeys("0000:d8:00.2")).To(Equal([]string{"0xffff", "0x0002"}))
+		})
+		It("should return nil if the VF has no RDMA device", func() {
+			Expect(ib.GetVfPKeys("0000:d8:00.3")).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,674 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kernel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/renameio/v2"
+	"golang.org/x/sys/unix"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
+)
+
+const (
+	ostreeBootedPath = "/run/ostree-booted"
+	osReleasePath    = "/etc/os-release"
+	// kernel-install uses the arguments of this file for the entries of the new kernels
+	kernelInstallCmdlinePath = "/etc/kernel/cmdline"
+	blsEntriesPath           = "/loader/entries"
+
+	grubDefaultPath = "/etc/default/grub"
+	// the drop-in directory of grub-mkconfig on Debian and Ubuntu, its files are read after /etc/default/grub
+	grubDefaultDropInDir  = "/etc/default/grub.d"
+	grubDefaultDropInPath = grubDefaultDropInDir + "/99-sriov-network-operator.cfg"
+	grubCmdlineVar        = "GRUB_CMDLINE_LINUX_DEFAULT"
+	grubCmdlineAllVar     = "GRUB_CMDLINE_LINUX"
+
+	// the OEM grub configuration of Flatcar appends its linux_append variable to the kernel command line
+	flatcarOsID          = "flatcar"
+	flatcarOemDir        = "/oem"
+	flatcarOemGrubPath   = flatcarOemDir + "/grub.cfg"
+	flatcarOldOemGrubCfg = "/usr/share/oem/grub.cfg"
+	flatcarCmdlineVar    = "linux_append"
+)
+
+var (
+	grubbyPaths = []string{"/usr/sbin/grubby", "/sbin/grubby", "/usr/bin/grubby"}
+	// EFI system partition mount points where systemd-boot reads its entries
+	espPaths = []string{"/efi", "/boot/efi", "/boot"}
+
+	// kernel arguments configured by the generic plugin, they are reported in the node state
+	// with the arguments recorded as added by the config daemon
+	managedKernelArgs = []string{
+		consts.KernelArgPciRealloc,
+		consts.KernelArgIntelIommu,
+		consts.KernelArgIommuPt,
+		consts.KernelArgRdmaShared,
+		consts.KernelArgRdmaExclusive,
+	}
+
+	errUnsupportedBootloader = errors.New(
+		"unsupported bootloader: none of rpm-ostree, grubby, BootLoaderSpec entries, systemd-boot or grub found on the host")
+)
+
+// getKernelRelease returns the release of the running kernel, it is a variable to be replaced in the tests
+var getKernelRelease = func() (string, error) {
+	var uts unix.Utsname
+	if err := unix.Uname(&uts); err != nil {
+		return "", err
+	}
+	return unix.ByteSliceToString(uts.Release[:]), nil
+}
+
+// bootloader configures the kernel arguments of the next boot
+type bootloader interface {
+	// name of the bootloader reported in the node state
+	name() string
+	// getKernelArgs returns the kernel arguments configured for the next boot
+	getKernelArgs() ([]string, error)
+	// editKernelArgs adds and removes kernel arguments, the added arguments are not configured
+	// and the removed ones are configured
+	editKernelArgs(add, remove []string) error
+}
+
+// AddKernelArgs adds the kernel arguments to the boot configuration, the arguments added by the operator are recorded
+func (k *kernel) AddKernelArgs(kargs ...string) (bool, error) {
+	return k.editKernelArgs(kargs, nil)
+}
+
+// RemoveKernelArgs removes the kernel arguments from the boot configuration
+func (k *kernel) RemoveKernelArgs(kargs ...string) (bool, error) {
+	return k.editKernelArgs(nil, kargs)
+}
+
+func (k *kernel) editKernelArgs(add, remove []string) (bool, error) {
+	funcLog := log.Log.WithName("editKernelArgs()")
+	if len(add) == 0 && len(remove) == 0 {
+		return false, nil
+	}
+	k.kargsLock.Lock()
+	defer k.kargsLock.Unlock()
+
+	bl, err := k.detectBootloader()
+	if err != nil {
+		// the kernel arguments of the nodes with an unsupported bootloader are managed by the administrator,
+		// there is nothing to do if the running kernel already has the desired arguments
+		cmdLine, cmdErr := k.GetCurrentKernelArgs()
+		if cmdErr == nil && k.hasKernelArgs(cmdLine, add, remove) {
+			funcLog.Info("unsupported bootloader, the running kernel has the desired arguments", "add", add, "remove", remove)
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to configure kernel arguments add %v remove %v: %w", add, remove, err)
+	}
+
+	bootArgs, err := bl.getKernelArgs()
+	if err != nil {
+		return false, fmt.Errorf("failed to read the kernel arguments of %s: %w", bl.name(), err)
+	}
+	toAdd := []string{}
+	for _, karg := range add {
+		if !slices.Contains(bootArgs, karg) && !slices.Contains(toAdd, karg) {
+			toAdd = append(toAdd, karg)
+		}
+	}
+	toRemove := []string{}
+	for _, karg := range remove {
+		if slices.Contains(bootArgs, karg) && !slices.Contains(toRemove, karg) {
+			toRemove = append(toRemove, karg)
+		}
+	}
+
+	changed := len(toAdd) > 0 || len(toRemove) > 0
+	if changed {
+		funcLog.Info("configure kernel arguments", "bootloader", bl.name(), "add", toAdd, "remove", toRemove)
+		if err := bl.editKernelArgs(toAdd, toRemove); err != nil {
+			return false, fmt.Errorf("failed to configure kernel arguments with %s: %w", bl.name(), err)
+		}
+		k.bootKernelArgs = nil
+	}
+
+	// the arguments configured by the administrator before the operator are not recorded,
+	// the generic plugin only removes the arguments it added
+	operatorArgs, err := k.GetOperatorKernelArgs()
+	if err != nil {
+		return changed, err
+	}
+	updated := slices.DeleteFunc(slices.Clone(operatorArgs), func(karg string) bool {
+		return slices.Contains(remove, karg)
+	})
+	for _, karg := range toAdd {
+		if !slices.Contains(updated, karg) {
+			updated = append(updated, karg)
+		}
+	}
+	if !slices.Equal(updated, operatorArgs) {
+		if err := writeOperatorKernelArgs(updated); err != nil {
+			return changed, err
+		}
+	}
+	return changed, nil
+}
+
+// hasKernelArgs returns true if the command line has the added arguments and not the removed ones
+func (k *kernel) hasKernelArgs(cmdLine string, add, remove []string) bool {
+	for _, karg := range add {
+		if !k.IsKernelArgsSet(cmdLine, karg) {
+			return false
+		}
+	}
+	for _, karg := range remove {
+		if k.IsKernelArgsSet(cmdLine, karg) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetOperatorKernelArgs returns the kernel arguments recorded as added by the config daemon
+func (k *kernel) GetOperatorKernelArgs() ([]string, error) {
+	path := utils.GetHostExtensionPath(consts.ManagedKernelArgsPath)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	kargs := []string{}
+	if err := json.Unmarshal(data, &kargs); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return kargs, nil
+}
+
+func writeOperatorKernelArgs(kargs []string) error {
+	path := utils.GetHostExtensionPath(consts.ManagedKernelArgsPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	data, err := json.Marshal(kargs)
+	if err != nil {
+		return err
+	}
+	if err := renameio.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// GetKernelArgsStatus returns the managed kernel arguments of the running kernel and of the next boot.
+// The arguments of the next boot are read once and after each change by the config daemon.
+func (k *kernel) GetKernelArgsStatus() (*sriovnetworkv1.KernelArgsStatus, error) {
+	cmdLine, err := k.GetCurrentKernelArgs()
+	if err != nil {
+		return nil, err
+	}
+	operatorArgs, err := k.GetOperatorKernelArgs()
+	if err != nil {
+		return nil, err
+	}
+	managed := slices.Clone(managedKernelArgs)
+	for _, karg := range operatorArgs {
+		if !slices.Contains(managed, karg) {
+			managed = append(managed, karg)
+		}
+	}
+	sort.Strings(managed)
+
+	status := &sriovnetworkv1.KernelArgsStatus{OperatorAdded: operatorArgs}
+	for _, karg := range managed {
+		if k.IsKernelArgsSet(cmdLine, karg) {
+			status.Active = append(status.Active, karg)
+		}
+	}
+
+	k.kargsLock.Lock()
+	defer k.kargsLock.Unlock()
+	bl, err := k.detectBootloader()
+	if err != nil {
+		log.Log.V(2).Info("GetKernelArgsStatus(): can't report the kernel arguments of the next boot", "reason", err.Error())
+		return status, nil
+	}
+	status.Bootloader = bl.name()
+	if k.bootKernelArgs == nil {
+		bootArgs, err := bl.getKernelArgs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the kernel arguments of %s: %w", bl.name(), err)
+		}
+		k.bootKernelArgs = bootArgs
+	}
+	for _, karg := range managed {
+		active := k.IsKernelArgsSet(cmdLine, karg)
+		configured := slices.Contains(k.bootKernelArgs, karg)
+		if configured && !active {
+			status.PendingAdd = append(status.PendingAdd, karg)
+		}
+		if active && !configured {
+			status.PendingRemove = append(status.PendingRemove, karg)
+		}
+	}
+	return status, nil
+}
+
+// detectBootloader returns the bootloader configuring the kernel arguments of the host
+func (k *kernel) detectBootloader() (bootloader, error) {
+	if hostPathExists(ostreeBootedPath) {
+		return &ostreeBootloader{k: k}, nil
+	}
+	for _, path := range grubbyPaths {
+		if hostPathExists(path) {
+			return &grubbyBootloader{k: k}, nil
+		}
+	}
+	if getOsID() == flatcarOsID {
+		configFile := flatcarOldOemGrubCfg
+		if hostPathExists(flatcarOemDir) {
+			configFile = flatcarOemGrubPath
+		}
+		return &grubBootloader{k: k, bootloaderName: consts.BootloaderFlatcarGrub, configFiles: []string{configFile},
+			variable: flatcarCmdlineVar, assignPrefix: "set "}, nil
+	}
+	for _, esp := range espPaths {
+		if hostPathExists(filepath.Join(esp, "EFI/systemd")) && hasBLSEntries(esp) {
+			return &blsBootloader{bootloaderName: consts.BootloaderSystemdBoot, entriesDir: filepath.Join(esp, blsEntriesPath)}, nil
+		}
+	}
+	if hasBLSEntries("/boot") {
+		return &blsBootloader{bootloaderName: consts.BootloaderBLS, entriesDir: filepath.Join("/boot", blsEntriesPath)}, nil
+	}
+	if hostPathExists(grubDefaultPath) {
+		configFiles := []string{grubDefaultPath}
+		if hostPathExists(grubDefaultDropInDir) {
+			configFiles = append(configFiles, grubDefaultDropInPath)
+		}
+		return &grubBootloader{k: k, bootloaderName: consts.BootloaderGrub, configFiles: configFiles,
+			variable: grubCmdlineVar, readVariables: []string{grubCmdlineAllVar}, regenerate: true}, nil
+	}
+	return nil, errUnsupportedBootloader
+}
+
+func hostPathExists(path string) bool {
+	_, err := os.Stat(utils.GetHostExtensionPath(path))
+	return err == nil
+}
+
+// getOsID returns the ID of the os-release file of the host
+func getOsID() string {
+	data, err := os.ReadFile(utils.GetHostExtensionPath(osReleasePath))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if value, found := strings.CutPrefix(strings.TrimSpace(line), "ID="); found {
+			return strings.Trim(value, `"'`)
+		}
+	}
+	return ""
+}
+
+func hasBLSEntries(bootDir string) bool {
+	entries, _ := filepath.Glob(utils.GetHostExtensionPath(filepath.Join(bootDir, blsEntriesPath, "*.conf")))
+	return len(entries) > 0
+}
+
+// runHostCommand runs a command on the host, the kernel arguments are passed without quoting
+func (k *kernel) runHostCommand(command string) (string, error) {
+	stdout, stderr, err := k.utilsHelper.RunCommand("/bin/sh", "-c", fmt.Sprintf("%s %s", utils.GetChrootExtension(), command))
+	if err != nil {
+		return "", fmt.Errorf("%q failed: %w: %s", command, err, strings.TrimSpace(stderr))
+	}
+	return stdout, nil
+}
+
+// editFields adds the missing arguments to the fields and removes the others
+func editFields(fields, add, remove []string) []string {
+	result := slices.DeleteFunc(slices.Clone(fields), func(field string) bool {
+		return slices.Contains(remove, field)
+	})
+	for _, karg := range add {
+		if !slices.Contains(result, karg) {
+			result = append(result, karg)
+		}
+	}
+	return result
+}
+
+// ostreeBootloader configures the kernel arguments of the next deployment of rpm-ostree based systems
+type ostreeBootloader struct {
+	k *kernel
+}
+
+func (b *ostreeBootloader) name() string {
+	return consts.BootloaderOstree
+}
+
+func (b *ostreeBootloader) getKernelArgs() ([]string, error) {
+	stdout, err := b.k.runHostCommand("rpm-ostree kargs")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(stdout), nil
+}
+
+func (b *ostreeBootloader) editKernelArgs(add, remove []string) error {
+	command := "rpm-ostree kargs"
+	for _, karg := range add {
+		command += " --append=" + karg
+	}
+	for _, karg := range remove {
+		command += " --delete=" + karg
+	}
+	_, err := b.k.runHostCommand(command)
+	return err
+}
+
+// grubbyBootloader configures the kernel arguments of all the kernels with grubby
+type grubbyBootloader struct {
+	k *kernel
+}
+
+func (b *grubbyBootloader) name() string {
+	return consts.BootloaderGrubby
+}
+
+func (b *grubbyBootloader) getKernelArgs() ([]string, error) {
+	stdout, err := b.k.runHostCommand("grubby --info=DEFAULT")
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(stdout, "\n") {
+		if args, found := strings.CutPrefix(strings.TrimSpace(line), "args="); found {
+			return strings.Fields(strings.Trim(args, `"`)), nil
+		}
+	}
+	return nil, fmt.Errorf("no kernel arguments in the grubby info of the default kernel")
+}
+
+func (b *grubbyBootloader) editKernelArgs(add, remove []string) error {
+	command := "grubby --update-kernel=ALL"
+	if len(add) > 0 {
+		command += fmt.Sprintf(" --args=%q", strings.Join(add, " "))
+	}
+	if len(remove) > 0 {
+		command += fmt.Sprintf(" --remove-args=%q", strings.Join(remove, " "))
+	}
+	_, err := b.k.runHostCommand(command)
+	return err
+}
+
+// blsBootloader configures the kernel arguments of the BootLoaderSpec entries read by grub or systemd-boot.
+// The arguments of /etc/kernel/cmdline are also configured for the entries of the kernels installed later.
+type blsBootloader struct {
+	bootloaderName string
+	entriesDir     string
+}
+
+func (b *blsBootloader) name() string {
+	return b.bootloaderName
+}
+
+func (b *blsBootloader) entries() ([]string, error) {
+	entries, err := filepath.Glob(utils.GetHostExtensionPath(filepath.Join(b.entriesDir, "*.conf")))
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no entries in %s", b.entriesDir)
+	}
+	sort.Strings(entries)
+	return entries, nil
+}
+
+// getKernelArgs returns the options of the entry of the running kernel, or of the last entry
+func (b *blsBootloader) getKernelArgs() ([]string, error) {
+	entries, err := b.entries()
+	if err != nil {
+		return nil, err
+	}
+	entry := entries[len(entries)-1]
+	if release, err := getKernelRelease(); err == nil {
+		for _, e := range entries {
+			if strings.Contains(filepath.Base(e), release) {
+				entry = e
+				break
+			}
+		}
+	}
+	data, err := os.ReadFile(entry)
+	if err != nil {
+		return nil, err
+	}
+	kargs := []string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if key, value, _ := strings.Cut(strings.TrimSpace(line), " "); key == "options" {
+			kargs = append(kargs, strings.Fields(value)...)
+		}
+	}
+	return kargs, nil
+}
+
+func (b *blsBootloader) editKernelArgs(add, remove []string) error {
+	entries, err := b.entries()
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := editFile(entry, func(content string) string {
+			return editBLSEntry(content, add, remove)
+		}); err != nil {
+			return err
+		}
+	}
+	cmdlinePath := utils.GetHostExtensionPath(kernelInstallCmdlinePath)
+	if _, err := os.Stat(cmdlinePath); err != nil {
+		return nil
+	}
+	return editFile(cmdlinePath, func(content string) string {
+		return strings.Join(editFields(strings.Fields(content), add, remove), " ") + "\n"
+	})
+}
+
+// editBLSEntry edits the options of an entry, the arguments are added to the last options line
+func editBLSEntry(content string, add, remove []string) string {
+	lines := strings.Split(content, "\n")
+	last := -1
+	for i, line := range lines {
+		key, value, _ := strings.Cut(strings.TrimSpace(line), " ")
+		if key != "options" {
+			continue
+		}
+		last = i
+		kargs := strings.Fields(value)
+		if edited := editFields(kargs, nil, remove); !slices.Equal(edited, kargs) {
+			lines[i] = strings.TrimSpace("options " + strings.Join(edited, " "))
+		}
+	}
+	if len(add) == 0 {
+		return strings.Join(lines, "\n")
+	}
+	if last == -1 {
+		lines, last = appendLine(lines, "options")
+	}
+	_, value, _ := strings.Cut(strings.TrimSpace(lines[last]), " ")
+	lines[last] = strings.TrimSpace("options " + strings.Join(editFields(strings.Fields(value), add, nil), " "))
+	return strings.Join(lines, "\n")
+}
+
+// appendLine appends a line before the trailing newline of the lines of a file and returns its index
+func appendLine(lines []string, line string) ([]string, int) {
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = slices.Insert(lines, len(lines)-1, line)
+		return lines, len(lines) - 2
+	}
+	return append(lines, line, ""), len(lines)
+}
+
+// grubBootloader configures the kernel arguments of a shell variable of the grub configuration files,
+// e.g. GRUB_CMDLINE_LINUX_DEFAULT of /etc/default/grub
+type grubBootloader struct {
+	k              *kernel
+	bootloaderName string
+	// the arguments are removed from all the files and added to the last one
+	configFiles []string
+	// variable of the configured arguments, the arguments of readVariables are only read
+	variable      string
+	readVariables []string
+	// prefix of the assignment of the variable, e.g. "set " in grub.cfg
+	assignPrefix string
+	// the grub configuration is generated from the configuration files
+	regenerate bool
+}
+
+func (b *grubBootloader) name() string {
+	return b.bootloaderName
+}
+
+// parseVariableLine returns the variable and the arguments assigned by a line, the references
+// to the variable itself are not arguments
+func (b *grubBootloader) parseVariableLine(line string) (string, []string, bool) {
+	assignment, found := strings.CutPrefix(strings.TrimSpace(line), b.assignPrefix)
+	if !found {
+		return "", nil, false
+	}
+	variable, value, found := strings.Cut(assignment, "=")
+	if !found || (variable != b.variable && !slices.Contains(b.readVariables, variable)) {
+		return "", nil, false
+	}
+	kargs := slices.DeleteFunc(strings.Fields(strings.Trim(value, `"'`)), func(field string) bool {
+		return field == "$"+variable || field == "${"+variable+"}"
+	})
+	return variable, kargs, true
+}
+
+func (b *grubBootloader) getKernelArgs() ([]string, error) {
+	kargs := []string{}
+	for _, configFile := range b.configFiles {
+		data, err := os.ReadFile(utils.GetHostExtensionPath(configFile))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if _, lineArgs, ok := b.parseVariableLine(line); ok {
+				kargs = append(kargs, lineArgs...)
+			}
+		}
+	}
+	return kargs, nil
+}
+
+func (b *grubBootloader) editKernelArgs(add, remove []string) error {
+	for i, configFile := range b.configFiles {
+		fileAdd := []string{}
+		if i == len(b.configFiles)-1 {
+			fileAdd = add
+		}
+		if err := editFile(utils.GetHostExtensionPath(configFile), func(content string) string {
+			return b.editConfig(content, fileAdd, remove)
+		}); err != nil {
+			return err
+		}
+	}
+	if !b.regenerate {
+		return nil
+	}
+	for _, tool := range []struct{ path, command string }{
+		{"/usr/sbin/update-grub", "update-grub"},
+		{"/usr/sbin/grub2-mkconfig", "grub2-mkconfig -o /boot/grub2/grub.cfg"},
+		{"/usr/sbin/grub-mkconfig", "grub-mkconfig -o /boot/grub/grub.cfg"},
+	} {
+		if hostPathExists(tool.path) {
+			_, err := b.k.runHostCommand(tool.command)
+			return err
+		}
+	}
+	return fmt.Errorf("no tool found to generate the grub configuration")
+}
+
+// editConfig edits the lines assigning the variable, the arguments are added to the last line.
+// A line referencing the variable itself is added if there is no line.
+func (b *grubBootloader) editConfig(content string, add, remove []string) string {
+	lines := strings.Split(content, "\n")
+	last := -1
+	for i, line := range lines {
+		variable, kargs, ok := b.parseVariableLine(line)
+		if !ok || variable != b.variable {
+			continue
+		}
+		last = i
+		if edited := editFields(kargs, nil, remove); !slices.Equal(edited, kargs) {
+			lines[i] = b.variableLine(line, edited)
+		}
+	}
+	if len(add) == 0 {
+		return strings.Join(lines, "\n")
+	}
+	if last == -1 {
+		lines, last = appendLine(lines, b.assignPrefix+b.variable+"=")
+	}
+	_, kargs, _ := b.parseVariableLine(lines[last])
+	lines[last] = b.variableLine(lines[last], editFields(kargs, add, nil))
+	return strings.Join(lines, "\n")
+}
+
+// variableLine returns the line assigning the arguments to the variable, the reference to the variable
+// itself and the indentation of the original line are kept
+func (b *grubBootloader) variableLine(line string, kargs []string) string {
+	indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+	_, value, _ := strings.Cut(line, "=")
+	fields := strings.Fields(strings.Trim(value, `"'`))
+	if slices.Contains(fields, "$"+b.variable) || slices.Contains(fields, "${"+b.variable+"}") || value == "" {
+		kargs = append([]string{"$" + b.variable}, kargs...)
+	}
+	return fmt.Sprintf("%s%s%s=%q", indent, b.assignPrefix, b.variable, strings.Join(kargs, " "))
+}
+
+// editFile writes the edited content of the file if it changed, the missing file is created
+func editFile(path string, edit func(string) string) error {
+	mode := os.FileMode(0o644)
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	content := edit(string(data))
+	if content == string(data) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := renameio.WriteFile(path, []byte(content), mode); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,289 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kernel
+
+import (
+	"fmt"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"go.uber.org/mock/gomock"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
+	mock_utils "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils/mock"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/fakefilesystem"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/helpers"
+)
+
+const testManagedKernelArgsPath = "/host" + consts.ManagedKernelArgsPath
+
+// withParentDirs adds the parent directories of the files to the fake filesystem
+func withParentDirs(fs *fakefilesystem.FS) *fakefilesystem.FS {
+	for file := range fs.Files {
+		fs.Dirs = append(fs.Dirs, filepath.Dir(file))
+	}
+	return fs
+}
+
+var _ = Describe("Kernel arguments", func() {
+	var (
+		k        types.KernelInterface
+		u        *mock_utils.MockCmdInterface
+		mockCtrl *gomock.Controller
+	)
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		u = mock_utils.NewMockCmdInterface(mockCtrl)
+		k = New(u)
+	})
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	// expectHostCommand expects a command run in the chroot of the host
+	expectHostCommand := func(command, stdout string) {
+		u.EXPECT().RunCommand("/bin/sh", "-c", fmt.Sprintf("%s %s", utils.GetChrootExtension(), command)).Return(stdout, "", nil)
+	}
+
+	Context("grub", func() {
+		BeforeEach(func() {
+			helpers.GinkgoConfigureFakeFS(withParentDirs(&fakefilesystem.FS{
+				Dirs: []string{"/host/etc/default/grub.d", "/host/usr/sbin"},
+				Files: map[string][]byte{
+					"/host/proc/cmdline":         []byte("BOOT_IMAGE=/vmlinuz root=/dev/sda1 ib_core.netns_mode=0\n"),
+					"/host/etc/os-release":       []byte("NAME=\"Ubuntu\"\nID=ubuntu\n"),
+					"/host/usr/sbin/update-grub": {},
+					"/host/etc/default/grub": []byte("GRUB_DEFAULT=0\n" +
+						"GRUB_CMDLINE_LINUX_DEFAULT=\"quiet splash ib_core.netns_mode=0\"\n" +
+						"GRUB_CMDLINE_LINUX=\"\"\n"),
+				},
+			}))
+		})
+		It("should add the kernel args to the drop-in file", func() {
+			expectHostCommand("update-grub", "")
+			changed, err := k.AddKernelArgs(consts.KernelArgIntelIommu, consts.KernelArgIommuPt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			helpers.GinkgoAssertFileContentsEquals("/host/etc/default/grub.d/99-sriov-network-operator.cfg",
+				"GRUB_CMDLINE_LINUX_DEFAULT=\"$GRUB_CMDLINE_LINUX_DEFAULT intel_iommu=on iommu=pt\"\n")
+			helpers.GinkgoAssertFileContentsEquals(testManagedKernelArgsPath, `["intel_iommu=on","iommu=pt"]`)
+
+			// the args are already configured
+			changed, err = k.AddKernelArgs(consts.KernelArgIommuPt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeFalse())
+		})
+		It("should remove the kernel args from all the files", func() {
+			expectHostCommand("update-grub", "")
+			_, err := k.AddKernelArgs(consts.KernelArgIommuPt)
+			Expect(err).NotTo(HaveOccurred())
+
+			expectHostCommand("update-grub", "")
+			changed, err := k.RemoveKernelArgs(consts.KernelArgIommuPt, consts.KernelArgRdmaExclusive)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			helpers.GinkgoAssertFileContentsEquals("/host/etc/default/grub",
+				"GRUB_DEFAULT=0\nGRUB_CMDLINE_LINUX_DEFAULT=\"quiet splash\"\nGRUB_CMDLINE_LINUX=\"\"\n")
+			helpers.GinkgoAssertFileContentsEquals("/host/etc/default/grub.d/99-sriov-network-operator.cfg",
+				"GRUB_CMDLINE_LINUX_DEFAULT=\"$GRUB_CMDLINE_LINUX_DEFAULT\"\n")
+			helpers.GinkgoAssertFileContentsEquals(testManagedKernelArgsPath, `[]`)
+
+			changed, err = k.RemoveKernelArgs(consts.KernelArgIommuPt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeFalse())
+		})
+		It("should report the pending kernel args", func() {
+			expectHostCommand("update-grub", "")
+			_, err := k.AddKernelArgs(consts.KernelArgIommuPt)
+			Expect(err).NotTo(HaveOccurred())
+			expectHostCommand("update-grub", "")
+			_, err = k.RemoveKernelArgs(consts.KernelArgRdmaExclusive)
+			Expect(err).NotTo(HaveOccurred())
+
+			status, err := k.GetKernelArgsStatus()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal(&sriovnetworkv1.KernelArgsStatus{
+				Bootloader:    consts.BootloaderGrub,
+				Active:        []string{consts.KernelArgRdmaExclusive},
+				PendingAdd:    []string{consts.KernelArgIommuPt},
+				PendingRemove: []string{consts.KernelArgRdmaExclusive},
+				OperatorAdded: []string{consts.KernelArgIommuPt},
+			}))
+		})
+		It("should fail when the grub configuration can't be generated", func() {
+			u.EXPECT().RunCommand("/bin/sh", "-c", gomock.Any()).Return("", "error: out of space", fmt.Errorf("exit status 1"))
+			_, err := k.AddKernelArgs(consts.KernelArgIommuPt)
+			Expect(err).To(MatchError(ContainSubstring("out of space")))
+		})
+	})
+
+	Context("Flatcar", func() {
+		It("should add the kernel args to the OEM grub configuration", func() {
+			helpers.GinkgoConfigureFakeFS(withParentDirs(&fakefilesystem.FS{
+				Dirs: []string{"/host/oem"},
+				Files: map[string][]byte{
+					"/host/proc/cmdline":   []byte("BOOT_IMAGE=/flatcar/vmlinuz-a flatcar.autologin\n"),
+					"/host/etc/os-release": []byte("NAME=\"Flatcar Container Linux by Kinvolk\"\nID=flatcar\n"),
+				},
+			}))
+			changed, err := k.AddKernelArgs(consts.KernelArgRdmaExclusive)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			helpers.GinkgoAssertFileContentsEquals("/host/oem/grub.cfg", "set linux_append=\"$linux_append ib_core.netns_mode=0\"\n")
+
+			status, err := k.GetKernelArgsStatus()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Bootloader).To(Equal(consts.BootloaderFlatcarGrub))
+			Expect(status.PendingAdd).To(Equal([]string{consts.KernelArgRdmaExclusive}))
+		})
+	})
+
+	Context("BootLoaderSpec entries", func() {
+		BeforeEach(func() {
+			origGetKernelRelease := getKernelRelease
+			DeferCleanup(func() { getKernelRelease = origGetKernelRelease })
+			getKernelRelease = func() (string, error) { return "6.8.0-40-generic", nil }
+		})
+		It("should edit the entries of systemd-boot and the kernel-install command line", func() {
+			helpers.GinkgoConfigureFakeFS(withParentDirs(&fakefilesystem.FS{
+				Dirs: []string{"/host/boot/efi/EFI/systemd"},
+				Files: map[string][]byte{
+					"/host/proc/cmdline":       []byte("root=/dev/nvme0n1p2 rw\n"),
+					"/host/etc/kernel/cmdline": []byte("root=/dev/nvme0n1p2 rw\n"),
+					"/host/boot/efi/loader/entries/arch-6.8.0-40-generic.conf": []byte(
+						"title Arch Linux\nlinux /vmlinuz-linux\noptions root=/dev/nvme0n1p2 rw\n"),
+					"/host/boot/efi/loader/entries/arch-6.9.1-fallback.conf": []byte(
+						"title Arch Linux fallback\nlinux /vmlinuz-linux\n"),
+				},
+			}))
+			changed, err := k.AddKernelArgs(consts.KernelArgIommuPt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			helpers.GinkgoAssertFileContentsEquals("/host/boot/efi/loader/entries/arch-6.8.0-40-generic.conf",
+				"title Arch Linux\nlinux /vmlinuz-linux\noptions root=/dev/nvme0n1p2 rw iommu=pt\n")
+			helpers.GinkgoAssertFileContentsEquals("/host/boot/efi/loader/entries/arch-6.9.1-fallback.conf",
+				"title Arch Linux fallback\nlinux /vmlinuz-linux\noptions iommu=pt\n")
+			helpers.GinkgoAssertFileContentsEquals("/host/etc/kernel/cmdline", "root=/dev/nvme0n1p2 rw iommu=pt\n")
+
+			status, err := k.GetKernelArgsStatus()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Bootloader).To(Equal(consts.BootloaderSystemdBoot))
+			Expect(status.PendingAdd).To(Equal([]string{consts.KernelArgIommuPt}))
+
+			changed, err = k.RemoveKernelArgs(consts.KernelArgIommuPt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			helpers.GinkgoAssertFileContentsEquals("/host/boot/efi/loader/entries/arch-6.8.0-40-generic.conf",
+				"title Arch Linux\nlinux /vmlinuz-linux\noptions root=/dev/nvme0n1p2 rw\n")
+			helpers.GinkgoAssertFileContentsEquals("/host/etc/kernel/cmdline", "root=/dev/nvme0n1p2 rw\n")
+		})
+		It("should read the options of the entry of the running kernel", func() {
+			helpers.GinkgoConfigureFakeFS(withParentDirs(&fakefilesystem.FS{
+				Files: map[string][]byte{
+					"/host/proc/cmdline": []byte("root=/dev/sda1 intel_iommu=on\n"),
+					"/host/boot/loader/entries/fedora-6.8.0-40-generic.conf": []byte("options root=/dev/sda1 intel_iommu=on\n"),
+					"/host/boot/loader/entries/fedora-6.9.0-1.conf":          []byte("options root=/dev/sda1\n"),
+				},
+			}))
+			status, err := k.GetKernelArgsStatus()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Bootloader).To(Equal(consts.BootloaderBLS))
+			Expect(status.Active).To(Equal([]string{consts.KernelArgIntelIommu}))
+			Expect(status.PendingRemove).To(BeEmpty())
+		})
+	})
+
+	Context("grubby", func() {
+		BeforeEach(func() {
+			helpers.GinkgoConfigureFakeFS(withParentDirs(&fakefilesystem.FS{
+				Dirs: []string{"/host/usr/sbin"},
+				Files: map[string][]byte{
+					"/host/proc/cmdline":    []byte("BOOT_IMAGE=(hd0,gpt2)/vmlinuz ro\n"),
+					"/host/usr/sbin/grubby": {},
+				},
+			}))
+		})
+		It("should edit the kernel args of all the kernels", func() {
+			expectHostCommand("grubby --info=DEFAULT", "index=0\nkernel=\"/boot/vmlinuz\"\nargs=\"ro crashkernel=auto iommu=pt\"\n")
+			expectHostCommand(`grubby --update-kernel=ALL --args="intel_iommu=on"`, "")
+			changed, err := k.AddKernelArgs(consts.KernelArgIntelIommu, consts.KernelArgIommuPt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			// iommu=pt was configured by the administrator
+			helpers.GinkgoAssertFileContentsEquals(testManagedKernelArgsPath, `["intel_iommu=on"]`)
+
+			expectHostCommand("grubby --info=DEFAULT", "index=0\nargs=\"ro crashkernel=auto iommu=pt intel_iommu=on\"\n")
+			expectHostCommand(`grubby --update-kernel=ALL --remove-args="intel_iommu=on"`, "")
+			changed, err = k.RemoveKernelArgs(consts.KernelArgIntelIommu, consts.KernelArgRdmaShared)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+		})
+	})
+
+	Context("rpm-ostree", func() {
+		It("should edit the kernel args of the next deployment", func() {
+			helpers.GinkgoConfigureFakeFS(withParentDirs(&fakefilesystem.FS{
+				Dirs: []string{"/host/run"},
+				Files: map[string][]byte{
+					"/host/proc/cmdline":       []byte("ostree=/ostree/boot.1/rhcos/0 ib_core.netns_mode=1\n"),
+					"/host/run/ostree-booted":  {},
+					"/host/usr/sbin/grubby":    {},
+					"/host/etc/default/grub":   {},
+					"/host/etc/os-release":     []byte("ID=\"rhcos\"\n"),
+					"/host/boot/loader/README": {},
+				},
+			}))
+			expectHostCommand("rpm-ostree kargs", "ostree=/ostree/boot.1/rhcos/0 ib_core.netns_mode=1\n")
+			expectHostCommand("rpm-ostree kargs --append=ib_core.netns_mode=0 --delete=ib_core.netns_mode=1", "")
+			changed, err := k.(*kernel).editKernelArgs([]string{consts.KernelArgRdmaExclusive}, []string{consts.KernelArgRdmaShared})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+		})
+	})
+
+	Context("unsupported bootloader", func() {
+		BeforeEach(func() {
+			helpers.GinkgoConfigureFakeFS(withParentDirs(&fakefilesystem.FS{
+				Files: map[string][]byte{
+					"/host/proc/cmdline": []byte("root=/dev/sda1 iommu=pt\n"),
+				},
+			}))
+		})
+		It("should succeed when the running kernel has the desired args", func() {
+			changed, err := k.AddKernelArgs(consts.KernelArgIommuPt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeFalse())
+			changed, err = k.RemoveKernelArgs(consts.KernelArgRdmaShared)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeFalse())
+		})
+		It("should fail when the running kernel misses the desired args", func() {
+			_, err := k.AddKernelArgs(consts.KernelArgIntelIommu)
+			Expect(err).To(MatchError(errUnsupportedBootloader))
+		})
+		It("should report the active kernel args only", func() {
+			status, err := k.GetKernelArgsStatus()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal(&sriovnetworkv1.KernelArgsStatus{Active: []string{consts.KernelArgIommuPt}}))
+		})
+	})
+})
@@ -21,12 +21,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
-	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/drivers"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
@@ -34,6 +36,10 @@ import (
 
 type kernel struct {
 	utilsHelper utils.CmdInterface
+	// kargsLock protects the boot configuration and bootKernelArgs, the kernel arguments
+	// configured for the next boot read by GetKernelArgsStatus
+	kargsLock      sync.Mutex
+	bootKernelArgs []string
 }
 
 func New(utilsHelper utils.CmdInterface) types.KernelInterface {
@@ -87,6 +93,25 @@ func (k *kernel) IsKernelModuleLoaded(kernelModuleName string) (bool, error) {
 	return false, nil
 }
 
+// SetKernelModuleParam sets a parameter of a loaded kernel module, the parameter must be writable
+func (k *kernel) SetKernelModuleParam(module, param, value string) error {
+	path := filepath.Join(vars.FilesystemRoot, consts.SysModule, module, "parameters", param)
+	current, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read parameter %s of kernel module %s: %w", param, module, err)
+	}
+	// the boolean parameters are read as Y/N and can be written as 1/0
+	currentValue := strings.TrimSpace(string(current))
+	if currentValue == value || (value == "1" && currentValue == "Y") || (value == "0" && currentValue == "N") {
+		return nil
+	}
+	log.Log.Info("SetKernelModuleParam(): set kernel module parameter", "module", module, "param", param, "value", value)
+	if err := os.WriteFile(path, []byte(value), os.ModeAppend); err != nil {
+		return fmt.Errorf("failed to set parameter %s of kernel module %s: %w", param, module, err)
+	}
+	return nil
+}
+
 func (k *kernel) TryEnableTun() {
 	if err := k.LoadKernelModule("tun"); err != nil {
 		log.Log.Error(err, "tryEnableTun(): TUN kernel module not loaded")
@@ -138,6 +163,10 @@ func (k *kernel) BindDpdkDriver(pciAddr, driver string) error {
 	log.Log.V(2).Info("BindDpdkDriver(): bind device to driver",
 		"device", pciAddr, "driver", driver)
 	if err := k.BindDriverByBusAndDevice(consts.BusPci, pciAddr, driver); err != nil {
+		// the uio drivers don't use the IOMMU
+		if d, ok := drivers.Get(consts.DeviceTypeVfioPci); !ok || d.Driver != driver {
+			return err
+		}
 		_, innerErr := os.Readlink(filepath.Join(vars.FilesystemRoot, consts.SysBusPciDevices, pciAddr, "iommu_group"))
 		if innerErr != nil {
 			log.Log.Error(err, "Could not read IOMMU group for device", "device", pciAddr)
@@ -159,7 +188,7 @@ func (k *kernel) BindDefaultDriver(pciAddr string) error {
 		return err
 	}
 	if curDriver != "" {
-		if !sriovnetworkv1.StringInArray(curDriver, vars.DpdkDrivers) {
+		if !drivers.IsUserspaceDriver(curDriver) {
 			log.Log.V(2).Info("BindDefaultDriver(): device already bound to default driver",
 				"device", pciAddr, "driver", curDriver)
 			return nil
@@ -326,6 +355,45 @@ func (k *kernel) IsKernelLockdownMode() bool {
 	return strings.Contains(stdout, "[integrity]") || strings.Contains(stdout, "[confidentiality]")
 }
 
+func (k *kernel) IsIommuEnabled() bool {
+	groups, err := os.ReadDir(filepath.Join(vars.FilesystemRoot, consts.SysKernelIommuGroups))
+	if err != nil {
+		log.Log.V(2).Info("IsIommuEnabled(): failed to read IOMMU groups", "error", err)
+		return false
+	}
+	return len(groups) > 0
+}
+
+func (k *kernel) GetIommuGroup(pciAddr string) (int, []string, error) {
+	groupLink, err := os.Readlink(filepath.Join(vars.FilesystemRoot, consts.SysBusPciDevices, pciAddr, "iommu_group"))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read IOMMU group of device %s: %w", pciAddr, err)
+	}
+	groupName := filepath.Base(groupLink)
+	group, err := strconv.Atoi(groupName)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid IOMMU group %s of device %s: %w", groupName, pciAddr, err)
+	}
+	entries, err := os.ReadDir(filepath.Join(vars.FilesystemRoot, consts.SysKernelIommuGroups, groupName, "devices"))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read devices of IOMMU group %d: %w", group, err)
+	}
+	devices := []string{}
+	for _, entry := range entries {
+		if entry.Name() == pciAddr || isPciBridge(entry.Name()) {
+			continue
+		}
+		devices = append(devices, entry.Name())
+	}
+	return group, devices, nil
+}
+
+// isPciBridge returns true if the class of the PCI device is PCI-to-PCI bridge (0x0604)
+func isPciBridge(pciAddr string) bool {
+	class, err := os.ReadFile(filepath.Join(vars.FilesystemRoot, consts.SysBusPciDevices, pciAddr, "class"))
+	return err == nil && strings.HasPrefix(strings.TrimSpace(string(class)), "0x0604")
+}
+
 // returns driver for device on the bus
 func getDriverByBusAndDevice(bus, device string) (string, error) {
 	driverLink := filepath.Join(vars.FilesystemRoot, consts.SysBus, bus, "devices", device, "driver")
@@ -376,6 +376,43 @@ var _ = Describe("Kernel", func() {
 				})
 				Expect(k.BindDpdkDriver("0000:d8:00.0", "vfio-pci")).To(HaveOccurred())
 			})
+			It("bind to uio driver", func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+					Dirs: []string{
+						"/sys/bus/pci/devices/0000:d8:00.0",
+						"/sys/bus/pci/drivers/uio_pci_generic"},
+					Files: map[string][]byte{
+						"/sys/bus/pci/drivers/uio_pci_generic/bind":         {},
+						"/sys/bus/pci/devices/0000:d8:00.0/driver_override": {}},
+				})
+				Expect(k.BindDpdkDriver("0000:d8:00.0", "uio_pci_generic")).NotTo(HaveOccurred())
+				helpers.GinkgoAssertFileContentsEquals("/sys/bus/pci/drivers/uio_pci_generic/bind", "0000:d8:00.0")
+			})
+		})
+
+		Context("SetKernelModuleParam", func() {
+			It("set the parameter", func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+					Dirs: []string{"/sys/module/vfio/parameters"},
+					Files: map[string][]byte{
+						"/sys/module/vfio/parameters/enable_unsafe_noiommu_mode": []byte("N\n")},
+				})
+				Expect(k.SetKernelModuleParam("vfio", "enable_unsafe_noiommu_mode", "1")).NotTo(HaveOccurred())
+				helpers.GinkgoAssertFileContentsEquals("/sys/module/vfio/parameters/enable_unsafe_noiommu_mode", "1")
+			})
+			It("skip the parameter already set", func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+					Dirs: []string{"/sys/module/vfio/parameters"},
+					Files: map[string][]byte{
+						"/sys/module/vfio/parameters/enable_unsafe_noiommu_mode": []byte("Y\n")},
+				})
+				Expect(k.SetKernelModuleParam("vfio", "enable_unsafe_noiommu_mode", "1")).NotTo(HaveOccurred())
+				helpers.GinkgoAssertFileContentsEquals("/sys/module/vfio/parameters/enable_unsafe_noiommu_mode", "Y\n")
+			})
+			It("module not loaded", func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{Dirs: []string{"/sys/module"}})
+				Expect(k.SetKernelModuleParam("vfio", "enable_unsafe_noiommu_mode", "1")).To(HaveOccurred())
+			})
 		})
 
 		Context("BindDriverByBusAndDevice", func() {
@@ -451,6 +488,61 @@ var _ = Describe("Kernel", func() {
 			})
 		})
 
+		Context("IsIommuEnabled", func() {
+			It("should return true when the IOMMU groups exist", func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+					Dirs: []string{"/sys/kernel/iommu_groups/0"},
+				})
+				Expect(k.IsIommuEnabled()).To(BeTrue())
+			})
+			It("should return false without IOMMU groups", func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+					Dirs: []string{"/sys/kernel/iommu_groups"},
+				})
+				Expect(k.IsIommuEnabled()).To(BeFalse())
+			})
+		})
+
+		Context("GetIommuGroup", func() {
+			BeforeEach(func() {
+				helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+					Dirs: []string{
+						"/sys/bus/pci/devices/0000:d8:02.0",
+						"/sys/bus/pci/devices/0000:d8:02.1",
+						"/sys/bus/pci/devices/0000:d7:00.0",
+						"/sys/bus/pci/devices/0000:d8:00.0",
+						"/sys/kernel/iommu_groups/42/devices",
+						"/sys/kernel/iommu_groups/43/devices"},
+					Files: map[string][]byte{
+						"/sys/bus/pci/devices/0000:d7:00.0/class": []byte("0x060400\n"),
+						"/sys/bus/pci/devices/0000:d8:00.0/class": []byte("0x020000\n")},
+					Symlinks: map[string]string{
+						"/sys/bus/pci/devices/0000:d8:02.0/iommu_group":    "../../../../kernel/iommu_groups/42",
+						"/sys/bus/pci/devices/0000:d8:02.1/iommu_group":    "../../../../kernel/iommu_groups/43",
+						"/sys/kernel/iommu_groups/42/devices/0000:d8:02.0": "../../../../devices/pci0000:d7/0000:d7:00.0/0000:d8:02.0",
+						"/sys/kernel/iommu_groups/42/devices/0000:d7:00.0": "../../../../devices/pci0000:d7/0000:d7:00.0",
+						"/sys/kernel/iommu_groups/43/devices/0000:d8:02.1": "../../../../devices/pci0000:d7/0000:d7:00.0/0000:d8:02.1",
+						"/sys/kernel/iommu_groups/43/devices/0000:d8:00.0": "../../../../devices/pci0000:d7/0000:d7:00.0/0000:d8:00.0"},
+				})
+			})
+			It("should ignore the PCI bridges of the group", func() {
+				group, devices, err := k.GetIommuGroup("0000:d8:02.0")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(group).To(Equal(42))
+				Expect(devices).To(BeEmpty())
+			})
+			It("should return the other devices of the group", func() {
+				group, devices, err := k.GetIommuGroup("0000:d8:02.1")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(group).To(Equal(43))
+				Expect(devices).To(Equal([]string{"0000:d8:00.0"}))
+			})
+			It("should fail when the device has no IOMMU group", func() {
+				_, _, err := k.GetIommuGroup("0000:d8:00.0")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
 		Context("TryEnableTun", func() {
 			It("should load tun kernel module", func() {
 				u.EXPECT().RunCommand("/bin/sh", "-c", fmt.Sprintf("chroot %s lsmod | grep \"^tun\"", getHost())).Return("", "", nil)
@@ -17,9 +17,24 @@
 package ethtool
 
 import (
+	"fmt"
+	"runtime"
+	"unsafe"
+
 	"github.com/safchain/ethtool"
+	"golang.org/x/sys/unix"
+)
+
+// ethtool commands and FEC mode bits not covered by the ethtool library,
+// defined in include/uapi/linux/ethtool.h
+const (
+	ethtoolGFecParam = 0x00000050
+	ethtoolSFecParam = 0x00000051
 )
 
+// FecModes lists the FEC modes by their ethtool bit, ETHTOOL_FEC_*_BIT
+var FecModes = []string{"none", "auto", "off", "rs", "baser", "llrs"}
+
 func New() EthtoolLib {
 	return &libWrapper{}
 }
@@ -32,6 +47,33 @@ type EthtoolLib interface {
 	FeatureNames(ifaceName string) (map[string]uint, error)
 	// Change requests a change in the given device's features.
 	Change(ifaceName string, config map[string]bool) error
+	// GetRing retrieves ring parameters of the given interface name.
+	// Equivalent to: `ethtool -g $ifaceName`
+	GetRing(ifaceName string) (ethtool.Ring, error)
+	// SetRing sets ring parameters of the given interface name.
+	// Equivalent to: `ethtool -G $ifaceName rx $rx tx $tx`
+	SetRing(ifaceName string, ring ethtool.Ring) error
+	// GetChannels retrieves the number of channels of the given interface name.
+	// Equivalent to: `ethtool -l $ifaceName`
+	GetChannels(ifaceName string) (ethtool.Channels, error)
+	// SetChannels sets the number of channels of the given interface name.
+	// Equivalent to: `ethtool -L $ifaceName combined $combined`
+	SetChannels(ifaceName string, channels ethtool.Channels) error
+	// GetPause retrieves the pause parameters of the given interface name.
+	// Equivalent to: `ethtool -a $ifaceName`
+	GetPause(ifaceName string) (ethtool.Pause, error)
+	// SetPause sets the pause parameters of the given interface name.
+	// Equivalent to: `ethtool -A $ifaceName autoneg $autoneg rx $rx tx $tx`
+	SetPause(ifaceName string, pause ethtool.Pause) error
+	// DriverInfo retrieves the driver and firmware information of the given interface name.
+	// Equivalent to: `ethtool -i $ifaceName`
+	DriverInfo(ifaceName string) (ethtool.DrvInfo, error)
+	// GetFecMode retrieves the configured FEC mode of the given interface name.
+	// Equivalent to: `ethtool --show-fec $ifaceName`
+	GetFecMode(ifaceName string) (string, error)
+	// SetFecMode sets the FEC mode of the given interface name.
+	// Equivalent to: `ethtool --set-fec $ifaceName encoding $mode`
+	SetFecMode(ifaceName string, mode string) error
 }
 
 type libWrapper struct{}
@@ -65,3 +107,145 @@ func (w *libWrapper) Change(ifaceName string, config map[string]bool) error {
 	defer e.Close()
 	return e.Change(ifaceName, config)
 }
+
+// GetRing retrieves ring parameters of the given interface name.
+// Equivalent to: `ethtool -g $ifaceName`
+func (w *libWrapper) GetRing(ifaceName string) (ethtool.Ring, error) {
+	e, err := ethtool.NewEthtool()
+	if err != nil {
+		return ethtool.Ring{}, err
+	}
+	defer e.Close()
+	return e.GetRing(ifaceName)
+}
+
+// SetRing sets ring parameters of the given interface name.
+// Equivalent to: `ethtool -G $ifaceName rx $rx tx $tx`
+func (w *libWrapper) SetRing(ifaceName string, ring ethtool.Ring) error {
+	e, err := ethtool.NewEthtool()
+	if err != nil {
+		return err
+	}
+	defer e.Close()
+	_, err = e.SetRing(ifaceName, ring)
+	return err
+}
+
+// GetChannels retrieves the number of channels of the given interface name.
+// Equivalent to: `ethtool -l $ifaceName`
+func (w *libWrapper) GetChannels(ifaceName string) (ethtool.Channels, error) {
+	e, err := ethtool.NewEthtool()
+	if err != nil {
+		return ethtool.Channels{}, err
+	}
+	defer e.Close()
+	return e.GetChannels(ifaceName)
+}
+
+// SetChannels sets the number of channels of the given interface name.
+// Equivalent to: `ethtool -L $ifaceName combined $combined`
+func (w *libWrapper) SetChannels(ifaceName string, channels ethtool.Channels) error {
+	e, err := ethtool.NewEthtool()
+	if err != nil {
+		return err
+	}
+	defer e.Close()
+	_, err = e.SetChannels(ifaceName, channels)
+	return err
+}
+
+// GetPause retrieves the pause parameters of the given interface name.
+// Equivalent to: `ethtool -a $ifaceName`
+func (w *libWrapper) GetPause(ifaceName string) (ethtool.Pause, error) {
+	e, err := ethtool.NewEthtool()
+	if err != nil {
+		return ethtool.Pause{}, err
+	}
+	defer e.Close()
+	return e.GetPause(ifaceName)
+}
+
+// SetPause sets the pause parameters of the given interface name.
+// Equivalent to: `ethtool -A $ifaceName autoneg $autoneg rx $rx tx $tx`
+func (w *libWrapper) SetPause(ifaceName string, pause ethtool.Pause) error {
+	e, err := ethtool.NewEthtool()
+	if err != nil {
+		return err
+	}
+	defer e.Close()
+	_, err = e.SetPause(ifaceName, pause)
+	return err
+}
+
+// DriverInfo retrieves the driver and firmware information of the given interface name.
+// Equivalent to: `ethtool -i $ifaceName`
+func (w *libWrapper) DriverInfo(ifaceName string) (ethtool.DrvInfo, error) {
+	e, err := ethtool.NewEthtool()
+	if err != nil {
+		return ethtool.DrvInfo{}, err
+	}
+	defer e.Close()
+	return e.DriverInfo(ifaceName)
+}
+
+// ethtoolFecParam is struct ethtool_fecparam
+type ethtoolFecParam struct {
+	cmd       uint32
+	activeFec uint32
+	fec       uint32
+	reserved  uint32
+}
+
+// ifreq is the struct ifreq used by the SIOCETHTOOL ioctl
+type ifreq struct {
+	name [unix.IFNAMSIZ]byte
+	data uintptr
+}
+
+// fecIoctl runs the ETHTOOL_GFECPARAM and ETHTOOL_SFECPARAM commands,
+// they are not supported by the ethtool library
+func fecIoctl(ifaceName string, param *ethtoolFecParam) error {
+	if len(ifaceName) >= unix.IFNAMSIZ {
+		return fmt.Errorf("interface name %s is too long", ifaceName)
+	}
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, unix.IPPROTO_IP)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	req := ifreq{data: uintptr(unsafe.Pointer(param))}
+	copy(req.name[:], ifaceName)
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), unix.SIOCETHTOOL, uintptr(unsafe.Pointer(&req)))
+	runtime.KeepAlive(param)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// GetFecMode retrieves the configured FEC mode of the given interface name.
+// Equivalent to: `ethtool --show-fec $ifaceName`
+func (w *libWrapper) GetFecMode(ifaceName string) (string, error) {
+	param := &ethtoolFecParam{cmd: ethtoolGFecParam}
+	if err := fecIoctl(ifaceName, param); err != nil {
+		return "", err
+	}
+	for bit, mode := range FecModes {
+		if param.fec&(1<<bit) != 0 {
+			return mode, nil
+		}
+	}
+	return "", nil
+}
+
+// SetFecMode sets the FEC mode of the given interface name.
+// Equivalent to: `ethtool --set-fec $ifaceName encoding $mode`
+func (w *libWrapper) SetFecMode(ifaceName string, mode string) error {
+	for bit, name := range FecModes {
+		if name == mode {
+			return fecIoctl(ifaceName, &ethtoolFecParam{cmd: ethtoolSFecParam, fec: 1 << bit})
+		}
+	}
+	return fmt.Errorf("unknown FEC mode %s", mode)
+}
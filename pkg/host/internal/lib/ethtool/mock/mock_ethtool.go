@@ -12,6 +12,7 @@ package mock_ethtool
 import (
 	reflect "reflect"
 
+	ethtool "github.com/safchain/ethtool"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -53,6 +54,21 @@ func (mr *MockEthtoolLibMockRecorder) Change(ifaceName, config any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Change", reflect.TypeOf((*MockEthtoolLib)(nil).Change), ifaceName, config)
 }
 
+// DriverInfo mocks base method.
+func (m *MockEthtoolLib) DriverInfo(ifaceName string) (ethtool.DrvInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DriverInfo", ifaceName)
+	ret0, _ := ret[0].(ethtool.DrvInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DriverInfo indicates an expected call of DriverInfo.
+func (mr *MockEthtoolLibMockRecorder) DriverInfo(ifaceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DriverInfo", reflect.TypeOf((*MockEthtoolLib)(nil).DriverInfo), ifaceName)
+}
+
 // FeatureNames mocks base method.
 func (m *MockEthtoolLib) FeatureNames(ifaceName string) (map[string]uint, error) {
 	m.ctrl.T.Helper()
@@ -82,3 +98,119 @@ func (mr *MockEthtoolLibMockRecorder) Features(ifaceName any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Features", reflect.TypeOf((*MockEthtoolLib)(nil).Features), ifaceName)
 }
+
+// GetChannels mocks base method.
+func (m *MockEthtoolLib) GetChannels(ifaceName string) (ethtool.Channels, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannels", ifaceName)
+	ret0, _ := ret[0].(ethtool.Channels)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannels indicates an expected call of GetChannels.
+func (mr *MockEthtoolLibMockRecorder) GetChannels(ifaceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannels", reflect.TypeOf((*MockEthtoolLib)(nil).GetChannels), ifaceName)
+}
+
+// GetFecMode mocks base method.
+func (m *MockEthtoolLib) GetFecMode(ifaceName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFecMode", ifaceName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFecMode indicates an expected call of GetFecMode.
+func (mr *MockEthtoolLibMockRecorder) GetFecMode(ifaceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFecMode", reflect.TypeOf((*MockEthtoolLib)(nil).GetFecMode), ifaceName)
+}
+
+// GetPause mocks base method.
+func (m *MockEthtoolLib) GetPause(ifaceName string) (ethtool.Pause, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPause", ifaceName)
+	ret0, _ := ret[0].(ethtool.Pause)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPause indicates an expected call of GetPause.
+func (mr *MockEthtoolLibMockRecorder) GetPause(ifaceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPause", reflect.TypeOf((*MockEthtoolLib)(nil).GetPause), ifaceName)
+}
+
+// GetRing mocks base method.
+func (m *MockEthtoolLib) GetRing(ifaceName string) (ethtool.Ring, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRing", ifaceName)
+	ret0, _ := ret[0].(ethtool.Ring)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRing indicates an expected call of GetRing.
+func (mr *MockEthtoolLibMockRecorder) GetRing(ifaceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRing", reflect.TypeOf((*MockEthtoolLib)(nil).GetRing), ifaceName)
+}
+
+// SetChannels mocks base method.
+func (m *MockEthtoolLib) SetChannels(ifaceName string, channels ethtool.Channels) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChannels", ifaceName, channels)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetChannels indicates an expected call of SetChannels.
+func (mr *MockEthtoolLibMockRecorder) SetChannels(ifaceName, channels any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChannels", reflect.TypeOf((*MockEthtoolLib)(nil).SetChannels), ifaceName, channels)
+}
+
+// SetFecMode mocks base method.
+func (m *MockEthtoolLib) SetFecMode(ifaceName, mode string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFecMode", ifaceName, mode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFecMode indicates an expected call of SetFecMode.
+func (mr *MockEthtoolLibMockRecorder) SetFecMode(ifaceName, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFecMode", reflect.TypeOf((*MockEthtoolLib)(nil).SetFecMode), ifaceName, mode)
+}
+
+// SetPause mocks base method.
+func (m *MockEthtoolLib) SetPause(ifaceName string, pause ethtool.Pause) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPause", ifaceName, pause)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPause indicates an expected call of SetPause.
+func (mr *MockEthtoolLibMockRecorder) SetPause(ifaceName, pause any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPause", reflect.TypeOf((*MockEthtoolLib)(nil).SetPause), ifaceName, pause)
+}
+
+// SetRing mocks base method.
+func (m *MockEthtoolLib) SetRing(ifaceName string, ring ethtool.Ring) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRing", ifaceName, ring)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRing indicates an expected call of SetRing.
+func (mr *MockEthtoolLibMockRecorder) SetRing(ifaceName, ring any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRing", reflect.TypeOf((*MockEthtoolLib)(nil).SetRing), ifaceName, ring)
+}
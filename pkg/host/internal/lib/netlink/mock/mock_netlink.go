@@ -94,6 +94,21 @@ func (m *MockNetlinkLib) EXPECT() *MockNetlinkLibMockRecorder {
 	return m.recorder
 }
 
+// DevLinkGetAllPortList mocks base method.
+func (m *MockNetlinkLib) DevLinkGetAllPortList() ([]*netlink0.DevlinkPort, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DevLinkGetAllPortList")
+	ret0, _ := ret[0].([]*netlink0.DevlinkPort)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DevLinkGetAllPortList indicates an expected call of DevLinkGetAllPortList.
+func (mr *MockNetlinkLibMockRecorder) DevLinkGetAllPortList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DevLinkGetAllPortList", reflect.TypeOf((*MockNetlinkLib)(nil).DevLinkGetAllPortList))
+}
+
 // DevLinkGetDeviceByName mocks base method.
 func (m *MockNetlinkLib) DevLinkGetDeviceByName(bus, device string) (*netlink0.DevlinkDevice, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +124,35 @@ func (mr *MockNetlinkLibMockRecorder) DevLinkGetDeviceByName(bus, device any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DevLinkGetDeviceByName", reflect.TypeOf((*MockNetlinkLib)(nil).DevLinkGetDeviceByName), bus, device)
 }
 
+// DevLinkPortAdd mocks base method.
+func (m *MockNetlinkLib) DevLinkPortAdd(bus, device string, flavour uint16, attrs netlink0.DevLinkPortAddAttrs) (*netlink0.DevlinkPort, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DevLinkPortAdd", bus, device, flavour, attrs)
+	ret0, _ := ret[0].(*netlink0.DevlinkPort)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DevLinkPortAdd indicates an expected call of DevLinkPortAdd.
+func (mr *MockNetlinkLibMockRecorder) DevLinkPortAdd(bus, device, flavour, attrs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DevLinkPortAdd", reflect.TypeOf((*MockNetlinkLib)(nil).DevLinkPortAdd), bus, device, flavour, attrs)
+}
+
+// DevLinkPortDel mocks base method.
+func (m *MockNetlinkLib) DevLinkPortDel(bus, device string, portIndex uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DevLinkPortDel", bus, device, portIndex)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DevLinkPortDel indicates an expected call of DevLinkPortDel.
+func (mr *MockNetlinkLibMockRecorder) DevLinkPortDel(bus, device, portIndex any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DevLinkPortDel", reflect.TypeOf((*MockNetlinkLib)(nil).DevLinkPortDel), bus, device, portIndex)
+}
+
 // DevLinkSetEswitchMode mocks base method.
 func (m *MockNetlinkLib) DevLinkSetEswitchMode(dev *netlink0.DevlinkDevice, newMode string) error {
 	m.ctrl.T.Helper()
@@ -123,6 +167,21 @@ func (mr *MockNetlinkLibMockRecorder) DevLinkSetEswitchMode(dev, newMode any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DevLinkSetEswitchMode", reflect.TypeOf((*MockNetlinkLib)(nil).DevLinkSetEswitchMode), dev, newMode)
 }
 
+// DevlinkGetDeviceInfoByName mocks base method.
+func (m *MockNetlinkLib) DevlinkGetDeviceInfoByName(bus, device string) (*netlink0.DevlinkDeviceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DevlinkGetDeviceInfoByName", bus, device)
+	ret0, _ := ret[0].(*netlink0.DevlinkDeviceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DevlinkGetDeviceInfoByName indicates an expected call of DevlinkGetDeviceInfoByName.
+func (mr *MockNetlinkLibMockRecorder) DevlinkGetDeviceInfoByName(bus, device any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DevlinkGetDeviceInfoByName", reflect.TypeOf((*MockNetlinkLib)(nil).DevlinkGetDeviceInfoByName), bus, device)
+}
+
 // DevlinkGetDeviceParamByName mocks base method.
 func (m *MockNetlinkLib) DevlinkGetDeviceParamByName(bus, device, param string) (*netlink0.DevlinkParam, error) {
 	m.ctrl.T.Helper()
@@ -138,6 +197,49 @@ func (mr *MockNetlinkLibMockRecorder) DevlinkGetDeviceParamByName(bus, device, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DevlinkGetDeviceParamByName", reflect.TypeOf((*MockNetlinkLib)(nil).DevlinkGetDeviceParamByName), bus, device, param)
 }
 
+// DevlinkGetDeviceParams mocks base method.
+func (m *MockNetlinkLib) DevlinkGetDeviceParams(bus, device string) ([]*netlink0.DevlinkParam, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DevlinkGetDeviceParams", bus, device)
+	ret0, _ := ret[0].([]*netlink0.DevlinkParam)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DevlinkGetDeviceParams indicates an expected call of DevlinkGetDeviceParams.
+func (mr *MockNetlinkLibMockRecorder) DevlinkGetDeviceParams(bus, device any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DevlinkGetDeviceParams", reflect.TypeOf((*MockNetlinkLib)(nil).DevlinkGetDeviceParams), bus, device)
+}
+
+// DevlinkPortFnSet mocks base method.
+func (m *MockNetlinkLib) DevlinkPortFnSet(bus, device string, portIndex uint32, fnAttrs netlink0.DevlinkPortFnSetAttrs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DevlinkPortFnSet", bus, device, portIndex, fnAttrs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DevlinkPortFnSet indicates an expected call of DevlinkPortFnSet.
+func (mr *MockNetlinkLibMockRecorder) DevlinkPortFnSet(bus, device, portIndex, fnAttrs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DevlinkPortFnSet", reflect.TypeOf((*MockNetlinkLib)(nil).DevlinkPortFnSet), bus, device, portIndex, fnAttrs)
+}
+
+// DevlinkReload mocks base method.
+func (m *MockNetlinkLib) DevlinkReload(bus, device string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DevlinkReload", bus, device)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DevlinkReload indicates an expected call of DevlinkReload.
+func (mr *MockNetlinkLibMockRecorder) DevlinkReload(bus, device any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DevlinkReload", reflect.TypeOf((*MockNetlinkLib)(nil).DevlinkReload), bus, device)
+}
+
 // DevlinkSetDeviceParam mocks base method.
 func (m *MockNetlinkLib) DevlinkSetDeviceParam(bus, device, param string, cmode uint8, value any) error {
 	m.ctrl.T.Helper()
@@ -181,6 +283,20 @@ func (mr *MockNetlinkLibMockRecorder) IsLinkAdminStateUp(link any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsLinkAdminStateUp", reflect.TypeOf((*MockNetlinkLib)(nil).IsLinkAdminStateUp), link)
 }
 
+// LinkAdd mocks base method.
+func (m *MockNetlinkLib) LinkAdd(link netlink.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkAdd", link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkAdd indicates an expected call of LinkAdd.
+func (mr *MockNetlinkLibMockRecorder) LinkAdd(link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkAdd", reflect.TypeOf((*MockNetlinkLib)(nil).LinkAdd), link)
+}
+
 // LinkByIndex mocks base method.
 func (m *MockNetlinkLib) LinkByIndex(index int) (netlink.Link, error) {
 	m.ctrl.T.Helper()
@@ -211,6 +327,20 @@ func (mr *MockNetlinkLibMockRecorder) LinkByName(name any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkByName", reflect.TypeOf((*MockNetlinkLib)(nil).LinkByName), name)
 }
 
+// LinkDel mocks base method.
+func (m *MockNetlinkLib) LinkDel(link netlink.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkDel", link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkDel indicates an expected call of LinkDel.
+func (mr *MockNetlinkLibMockRecorder) LinkDel(link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkDel", reflect.TypeOf((*MockNetlinkLib)(nil).LinkDel), link)
+}
+
 // LinkList mocks base method.
 func (m *MockNetlinkLib) LinkList() ([]netlink.Link, error) {
 	m.ctrl.T.Helper()
@@ -226,6 +356,20 @@ func (mr *MockNetlinkLibMockRecorder) LinkList() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkList", reflect.TypeOf((*MockNetlinkLib)(nil).LinkList))
 }
 
+// LinkSetDown mocks base method.
+func (m *MockNetlinkLib) LinkSetDown(link netlink.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetDown", link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetDown indicates an expected call of LinkSetDown.
+func (mr *MockNetlinkLibMockRecorder) LinkSetDown(link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetDown", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetDown), link)
+}
+
 // LinkSetMTU mocks base method.
 func (m *MockNetlinkLib) LinkSetMTU(link netlink.Link, mtu int) error {
 	m.ctrl.T.Helper()
@@ -240,6 +384,34 @@ func (mr *MockNetlinkLibMockRecorder) LinkSetMTU(link, mtu any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetMTU", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetMTU), link, mtu)
 }
 
+// LinkSetMaster mocks base method.
+func (m *MockNetlinkLib) LinkSetMaster(link, master netlink.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetMaster", link, master)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetMaster indicates an expected call of LinkSetMaster.
+func (mr *MockNetlinkLibMockRecorder) LinkSetMaster(link, master any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetMaster", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetMaster), link, master)
+}
+
+// LinkSetNoMaster mocks base method.
+func (m *MockNetlinkLib) LinkSetNoMaster(link netlink.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetNoMaster", link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetNoMaster indicates an expected call of LinkSetNoMaster.
+func (mr *MockNetlinkLibMockRecorder) LinkSetNoMaster(link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetNoMaster", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetNoMaster), link)
+}
+
 // LinkSetUp mocks base method.
 func (m *MockNetlinkLib) LinkSetUp(link netlink.Link) error {
 	m.ctrl.T.Helper()
@@ -296,6 +468,90 @@ func (mr *MockNetlinkLibMockRecorder) LinkSetVfPortGUID(link, vf, portguid any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetVfPortGUID", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetVfPortGUID), link, vf, portguid)
 }
 
+// LinkSetVfRate mocks base method.
+func (m *MockNetlinkLib) LinkSetVfRate(link netlink.Link, vf, minRate, maxRate int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetVfRate", link, vf, minRate, maxRate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetVfRate indicates an expected call of LinkSetVfRate.
+func (mr *MockNetlinkLibMockRecorder) LinkSetVfRate(link, vf, minRate, maxRate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetVfRate", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetVfRate), link, vf, minRate, maxRate)
+}
+
+// LinkSetVfSpoofchk mocks base method.
+func (m *MockNetlinkLib) LinkSetVfSpoofchk(link netlink.Link, vf int, check bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetVfSpoofchk", link, vf, check)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetVfSpoofchk indicates an expected call of LinkSetVfSpoofchk.
+func (mr *MockNetlinkLibMockRecorder) LinkSetVfSpoofchk(link, vf, check any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetVfSpoofchk", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetVfSpoofchk), link, vf, check)
+}
+
+// LinkSetVfState mocks base method.
+func (m *MockNetlinkLib) LinkSetVfState(link netlink.Link, vf int, state uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetVfState", link, vf, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetVfState indicates an expected call of LinkSetVfState.
+func (mr *MockNetlinkLibMockRecorder) LinkSetVfState(link, vf, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetVfState", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetVfState), link, vf, state)
+}
+
+// LinkSetVfTrust mocks base method.
+func (m *MockNetlinkLib) LinkSetVfTrust(link netlink.Link, vf int, state bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetVfTrust", link, vf, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetVfTrust indicates an expected call of LinkSetVfTrust.
+func (mr *MockNetlinkLibMockRecorder) LinkSetVfTrust(link, vf, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetVfTrust", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetVfTrust), link, vf, state)
+}
+
+// LinkSetVfVlanQos mocks base method.
+func (m *MockNetlinkLib) LinkSetVfVlanQos(link netlink.Link, vf, vlan, qos int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetVfVlanQos", link, vf, vlan, qos)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetVfVlanQos indicates an expected call of LinkSetVfVlanQos.
+func (mr *MockNetlinkLibMockRecorder) LinkSetVfVlanQos(link, vf, vlan, qos any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetVfVlanQos", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetVfVlanQos), link, vf, vlan, qos)
+}
+
+// LinkSubscribeWithOptions mocks base method.
+func (m *MockNetlinkLib) LinkSubscribeWithOptions(ch chan<- netlink0.LinkUpdate, done <-chan struct{}, options netlink0.LinkSubscribeOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSubscribeWithOptions", ch, done, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSubscribeWithOptions indicates an expected call of LinkSubscribeWithOptions.
+func (mr *MockNetlinkLibMockRecorder) LinkSubscribeWithOptions(ch, done, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSubscribeWithOptions", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSubscribeWithOptions), ch, done, options)
+}
+
 // RdmaLinkByName mocks base method.
 func (m *MockNetlinkLib) RdmaLinkByName(name string) (*netlink0.RdmaLink, error) {
 	m.ctrl.T.Helper()
@@ -355,6 +611,21 @@ func (mr *MockNetlinkLibMockRecorder) VDPAGetDevByName(name any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VDPAGetDevByName", reflect.TypeOf((*MockNetlinkLib)(nil).VDPAGetDevByName), name)
 }
 
+// VDPAGetDevConfigByName mocks base method.
+func (m *MockNetlinkLib) VDPAGetDevConfigByName(name string) (*netlink0.VDPADevConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VDPAGetDevConfigByName", name)
+	ret0, _ := ret[0].(*netlink0.VDPADevConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VDPAGetDevConfigByName indicates an expected call of VDPAGetDevConfigByName.
+func (mr *MockNetlinkLibMockRecorder) VDPAGetDevConfigByName(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VDPAGetDevConfigByName", reflect.TypeOf((*MockNetlinkLib)(nil).VDPAGetDevConfigByName), name)
+}
+
 // VDPANewDev mocks base method.
 func (m *MockNetlinkLib) VDPANewDev(name, mgmtBus, mgmtName string, params netlink0.VDPANewDevParams) error {
 	m.ctrl.T.Helper()
@@ -20,8 +20,13 @@ import (
 	"net"
 
 	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
 )
 
+// devlinkCmdReload is the DEVLINK_CMD_RELOAD command, not defined by the netlink library
+const devlinkCmdReload = 37
+
 func New() NetlinkLib {
 	return &libWrapper{}
 }
@@ -48,12 +53,42 @@ type NetlinkLib interface {
 	// LinkSetVfHardwareAddr sets the hardware address of a vf for the link.
 	// Equivalent to: `ip link set $link vf $vf mac $hwaddr`
 	LinkSetVfHardwareAddr(link Link, vf int, hwaddr net.HardwareAddr) error
+	// LinkSetVfVlanQos sets the vlan and qos priority of a vf for the link.
+	// Equivalent to: `ip link set $link vf $vf vlan $vlan qos $qos`
+	LinkSetVfVlanQos(link Link, vf, vlan, qos int) error
+	// LinkSetVfSpoofchk enables/disables spoof check on a vf for the link.
+	// Equivalent to: `ip link set $link vf $vf spoofchk $check`
+	LinkSetVfSpoofchk(link Link, vf int, check bool) error
+	// LinkSetVfTrust enables/disables trust state on a vf for the link.
+	// Equivalent to: `ip link set $link vf $vf trust $state`
+	LinkSetVfTrust(link Link, vf int, state bool) error
+	// LinkSetVfState enables/disables virtual link state on a vf.
+	// Equivalent to: `ip link set $link vf $vf state $state`
+	LinkSetVfState(link Link, vf int, state uint32) error
+	// LinkSetVfRate sets the min and max tx rate of a vf for the link.
+	// Equivalent to: `ip link set $link vf $vf min_tx_rate $min max_tx_rate $max`
+	LinkSetVfRate(link Link, vf, minRate, maxRate int) error
 	// LinkSetUp enables the link device.
 	// Equivalent to: `ip link set $link up`
 	LinkSetUp(link Link) error
 	// LinkSetMTU sets the mtu of the link device.
 	// Equivalent to: `ip link set $link mtu $mtu`
 	LinkSetMTU(link Link, mtu int) error
+	// LinkSetDown disables the link device.
+	// Equivalent to: `ip link set $link down`
+	LinkSetDown(link Link) error
+	// LinkAdd adds a new link device, the type and features of the device are taken from the parameters.
+	// Equivalent to: `ip link add $link`
+	LinkAdd(link Link) error
+	// LinkDel deletes the link device.
+	// Equivalent to: `ip link del $link`
+	LinkDel(link Link) error
+	// LinkSetMaster sets the master of the link device.
+	// Equivalent to: `ip link set $link master $master`
+	LinkSetMaster(link Link, master Link) error
+	// LinkSetNoMaster removes the master of the link device.
+	// Equivalent to: `ip link set $link nomaster`
+	LinkSetNoMaster(link Link) error
 	// DevlinkGetDeviceByName provides a pointer to devlink device and nil error,
 	// otherwise returns an error code.
 	DevLinkGetDeviceByName(bus string, device string) (*netlink.DevlinkDevice, error)
@@ -65,6 +100,9 @@ type NetlinkLib interface {
 	// VDPAGetDevByName returns VDPA device selected by name
 	// Equivalent to: `vdpa dev show <name>`
 	VDPAGetDevByName(name string) (*netlink.VDPADev, error)
+	// VDPAGetDevConfigByName returns VDPA device configuration selected by name
+	// Equivalent to: `vdpa dev config show <name>`
+	VDPAGetDevConfigByName(name string) (*netlink.VDPADevConfig, error)
 	// VDPADelDev removes VDPA device
 	// Equivalent to: `vdpa dev del <name>`
 	VDPADelDev(name string) error
@@ -79,6 +117,27 @@ type NetlinkLib interface {
 	// cmode argument should contain valid cmode value as uint8, modes are define in nl.DEVLINK_PARAM_CMODE_* constants
 	// value argument should have one of the following types: uint8, uint16, uint32, string, bool
 	DevlinkSetDeviceParam(bus string, device string, param string, cmode uint8, value interface{}) error
+	// DevlinkGetDeviceParams returns the parameters of the devlink device
+	// Equivalent to: `devlink dev param show <bus>/<device>`
+	DevlinkGetDeviceParams(bus string, device string) ([]*netlink.DevlinkParam, error)
+	// DevlinkReload reinitializes the driver of the devlink device
+	// Equivalent to: `devlink dev reload <bus>/<device> action driver_reinit`
+	DevlinkReload(bus string, device string) error
+	// DevlinkGetDeviceInfoByName returns the driver, serial number and firmware versions of the devlink device
+	// Equivalent to: `devlink dev info <bus>/<device>`
+	DevlinkGetDeviceInfoByName(bus string, device string) (*netlink.DevlinkDeviceInfo, error)
+	// DevLinkGetAllPortList provides a list of devlink ports of all the devlink devices
+	// Equivalent to: `devlink port show`
+	DevLinkGetAllPortList() ([]*netlink.DevlinkPort, error)
+	// DevLinkPortAdd adds a devlink port with the requested flavour and returns it
+	// Equivalent to: `devlink port add <bus>/<device> flavour <flavour> pfnum <pfnum> sfnum <sfnum>`
+	DevLinkPortAdd(bus string, device string, flavour uint16, attrs netlink.DevLinkPortAddAttrs) (*netlink.DevlinkPort, error)
+	// DevLinkPortDel deletes a devlink port
+	// Equivalent to: `devlink port del <bus>/<device>/<port index>`
+	DevLinkPortDel(bus string, device string, portIndex uint32) error
+	// DevlinkPortFnSet sets the attributes of the function of a devlink port
+	// Equivalent to: `devlink port function set <bus>/<device>/<port index> state <state>`
+	DevlinkPortFnSet(bus string, device string, portIndex uint32, fnAttrs netlink.DevlinkPortFnSetAttrs) error
 	// RdmaLinkByName finds a link by name and returns a pointer to the object if
 	// found and nil error, otherwise returns error code.
 	RdmaLinkByName(name string) (*netlink.RdmaLink, error)
@@ -88,6 +147,10 @@ type NetlinkLib interface {
 	RdmaSystemGetNetnsMode() (string, error)
 	// GetAltNames returns a list of alternative names for a link
 	GetAltNames(name string) ([]string, error)
+	// LinkSubscribeWithOptions sends the link updates of the host to the channel until done is closed,
+	// the channel is closed when the subscription ends.
+	// Equivalent to: `ip monitor link`
+	LinkSubscribeWithOptions(ch chan<- netlink.LinkUpdate, done <-chan struct{}, options netlink.LinkSubscribeOptions) error
 }
 
 type libWrapper struct{}
@@ -137,6 +200,36 @@ func (w *libWrapper) LinkSetVfHardwareAddr(link Link, vf int, hwaddr net.Hardwar
 	return netlink.LinkSetVfHardwareAddr(link, vf, hwaddr)
 }
 
+// LinkSetVfVlanQos sets the vlan and qos priority of a vf for the link.
+// Equivalent to: `ip link set $link vf $vf vlan $vlan qos $qos`
+func (w *libWrapper) LinkSetVfVlanQos(link Link, vf, vlan, qos int) error {
+	return netlink.LinkSetVfVlanQos(link, vf, vlan, qos)
+}
+
+// LinkSetVfSpoofchk enables/disables spoof check on a vf for the link.
+// Equivalent to: `ip link set $link vf $vf spoofchk $check`
+func (w *libWrapper) LinkSetVfSpoofchk(link Link, vf int, check bool) error {
+	return netlink.LinkSetVfSpoofchk(link, vf, check)
+}
+
+// LinkSetVfTrust enables/disables trust state on a vf for the link.
+// Equivalent to: `ip link set $link vf $vf trust $state`
+func (w *libWrapper) LinkSetVfTrust(link Link, vf int, state bool) error {
+	return netlink.LinkSetVfTrust(link, vf, state)
+}
+
+// LinkSetVfState enables/disables virtual link state on a vf.
+// Equivalent to: `ip link set $link vf $vf state $state`
+func (w *libWrapper) LinkSetVfState(link Link, vf int, state uint32) error {
+	return netlink.LinkSetVfState(link, vf, state)
+}
+
+// LinkSetVfRate sets the min and max tx rate of a vf for the link.
+// Equivalent to: `ip link set $link vf $vf min_tx_rate $min max_tx_rate $max`
+func (w *libWrapper) LinkSetVfRate(link Link, vf, minRate, maxRate int) error {
+	return netlink.LinkSetVfRate(link, vf, minRate, maxRate)
+}
+
 // LinkSetUp enables the link device.
 // Equivalent to: `ip link set $link up`
 func (w *libWrapper) LinkSetUp(link Link) error {
@@ -149,6 +242,36 @@ func (w *libWrapper) LinkSetMTU(link Link, mtu int) error {
 	return netlink.LinkSetMTU(link, mtu)
 }
 
+// LinkSetDown disables the link device.
+// Equivalent to: `ip link set $link down`
+func (w *libWrapper) LinkSetDown(link Link) error {
+	return netlink.LinkSetDown(link)
+}
+
+// LinkAdd adds a new link device, the type and features of the device are taken from the parameters.
+// Equivalent to: `ip link add $link`
+func (w *libWrapper) LinkAdd(link Link) error {
+	return netlink.LinkAdd(link)
+}
+
+// LinkDel deletes the link device.
+// Equivalent to: `ip link del $link`
+func (w *libWrapper) LinkDel(link Link) error {
+	return netlink.LinkDel(link)
+}
+
+// LinkSetMaster sets the master of the link device.
+// Equivalent to: `ip link set $link master $master`
+func (w *libWrapper) LinkSetMaster(link Link, master Link) error {
+	return netlink.LinkSetMaster(link, master)
+}
+
+// LinkSetNoMaster removes the master of the link device.
+// Equivalent to: `ip link set $link nomaster`
+func (w *libWrapper) LinkSetNoMaster(link Link) error {
+	return netlink.LinkSetNoMaster(link)
+}
+
 // DevlinkGetDeviceByName provides a pointer to devlink device and nil error,
 // otherwise returns an error code.
 func (w *libWrapper) DevLinkGetDeviceByName(bus string, device string) (*netlink.DevlinkDevice, error) {
@@ -169,6 +292,12 @@ func (w *libWrapper) VDPAGetDevByName(name string) (*netlink.VDPADev, error) {
 	return netlink.VDPAGetDevByName(name)
 }
 
+// VDPAGetDevConfigByName returns VDPA device configuration selected by name
+// Equivalent to: `vdpa dev config show <name>`
+func (w *libWrapper) VDPAGetDevConfigByName(name string) (*netlink.VDPADevConfig, error) {
+	return netlink.VDPAGetDevConfigByName(name)
+}
+
 // VDPADelDev removes VDPA device
 // Equivalent to: `vdpa dev del <name>`
 func (w *libWrapper) VDPADelDev(name string) error {
@@ -195,6 +324,58 @@ func (w *libWrapper) DevlinkSetDeviceParam(bus string, device string, param stri
 	return netlink.DevlinkSetDeviceParam(bus, device, param, cmode, value)
 }
 
+// DevlinkGetDeviceParams returns the parameters of the devlink device
+// Equivalent to: `devlink dev param show <bus>/<device>`
+func (w *libWrapper) DevlinkGetDeviceParams(bus string, device string) ([]*netlink.DevlinkParam, error) {
+	return netlink.DevlinkGetDeviceParams(bus, device)
+}
+
+// DevlinkGetDeviceInfoByName returns the driver, serial number and firmware versions of the devlink device
+// Equivalent to: `devlink dev info <bus>/<device>`
+func (w *libWrapper) DevlinkGetDeviceInfoByName(bus string, device string) (*netlink.DevlinkDeviceInfo, error) {
+	return netlink.DevlinkGetDeviceInfoByName(bus, device)
+}
+
+// DevlinkReload reinitializes the driver of the devlink device
+// Equivalent to: `devlink dev reload <bus>/<device> action driver_reinit`
+func (w *libWrapper) DevlinkReload(bus string, device string) error {
+	family, err := netlink.GenlFamilyGet(nl.GENL_DEVLINK_NAME)
+	if err != nil {
+		return err
+	}
+	req := nl.NewNetlinkRequest(int(family.ID), unix.NLM_F_REQUEST|unix.NLM_F_ACK)
+	req.AddData(&nl.Genlmsg{Command: devlinkCmdReload, Version: nl.GENL_DEVLINK_VERSION})
+	req.AddData(nl.NewRtAttr(nl.DEVLINK_ATTR_BUS_NAME, nl.ZeroTerminated(bus)))
+	req.AddData(nl.NewRtAttr(nl.DEVLINK_ATTR_DEV_NAME, nl.ZeroTerminated(device)))
+	// the kernel uses the driver_reinit action when no action is requested
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevLinkGetAllPortList provides a list of devlink ports of all the devlink devices
+// Equivalent to: `devlink port show`
+func (w *libWrapper) DevLinkGetAllPortList() ([]*netlink.DevlinkPort, error) {
+	return netlink.DevLinkGetAllPortList()
+}
+
+// DevLinkPortAdd adds a devlink port with the requested flavour and returns it
+// Equivalent to: `devlink port add <bus>/<device> flavour <flavour> pfnum <pfnum> sfnum <sfnum>`
+func (w *libWrapper) DevLinkPortAdd(bus string, device string, flavour uint16, attrs netlink.DevLinkPortAddAttrs) (*netlink.DevlinkPort, error) {
+	return netlink.DevLinkPortAdd(bus, device, flavour, attrs)
+}
+
+// DevLinkPortDel deletes a devlink port
+// Equivalent to: `devlink port del <bus>/<device>/<port index>`
+func (w *libWrapper) DevLinkPortDel(bus string, device string, portIndex uint32) error {
+	return netlink.DevLinkPortDel(bus, device, portIndex)
+}
+
+// DevlinkPortFnSet sets the attributes of the function of a devlink port
+// Equivalent to: `devlink port function set <bus>/<device>/<port index> state <state>`
+func (w *libWrapper) DevlinkPortFnSet(bus string, device string, portIndex uint32, fnAttrs netlink.DevlinkPortFnSetAttrs) error {
+	return netlink.DevlinkPortFnSet(bus, device, portIndex, fnAttrs)
+}
+
 // RdmaLinkByName finds a link by name and returns a pointer to the object if
 // found and nil error, otherwise returns error code.
 func (w *libWrapper) RdmaLinkByName(name string) (*netlink.RdmaLink, error) {
@@ -224,3 +405,8 @@ func (w *libWrapper) GetAltNames(name string) ([]string, error) {
 	}
 	return attrs.AltNames, nil
 }
+
+// LinkSubscribeWithOptions sends the link updates of the host to the channel until done is closed
+func (w *libWrapper) LinkSubscribeWithOptions(ch chan<- netlink.LinkUpdate, done <-chan struct{}, options netlink.LinkSubscribeOptions) error {
+	return netlink.LinkSubscribeWithOptions(ch, done, options)
+}
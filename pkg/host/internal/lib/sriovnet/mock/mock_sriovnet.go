@@ -39,6 +39,51 @@ func (m *MockSriovnetLib) EXPECT() *MockSriovnetLibMockRecorder {
 	return m.recorder
 }
 
+// GetAuxNetDevicesFromPci mocks base method.
+func (m *MockSriovnetLib) GetAuxNetDevicesFromPci(pciAddr string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuxNetDevicesFromPci", pciAddr)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuxNetDevicesFromPci indicates an expected call of GetAuxNetDevicesFromPci.
+func (mr *MockSriovnetLibMockRecorder) GetAuxNetDevicesFromPci(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuxNetDevicesFromPci", reflect.TypeOf((*MockSriovnetLib)(nil).GetAuxNetDevicesFromPci), pciAddr)
+}
+
+// GetNetDevicesFromAux mocks base method.
+func (m *MockSriovnetLib) GetNetDevicesFromAux(auxDev string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetDevicesFromAux", auxDev)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetDevicesFromAux indicates an expected call of GetNetDevicesFromAux.
+func (mr *MockSriovnetLibMockRecorder) GetNetDevicesFromAux(auxDev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetDevicesFromAux", reflect.TypeOf((*MockSriovnetLib)(nil).GetNetDevicesFromAux), auxDev)
+}
+
+// GetSfIndexByAuxDev mocks base method.
+func (m *MockSriovnetLib) GetSfIndexByAuxDev(auxDev string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSfIndexByAuxDev", auxDev)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSfIndexByAuxDev indicates an expected call of GetSfIndexByAuxDev.
+func (mr *MockSriovnetLibMockRecorder) GetSfIndexByAuxDev(auxDev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSfIndexByAuxDev", reflect.TypeOf((*MockSriovnetLib)(nil).GetSfIndexByAuxDev), auxDev)
+}
+
 // GetVfRepresentor mocks base method.
 func (m *MockSriovnetLib) GetVfRepresentor(uplink string, vfIndex int) (string, error) {
 	m.ctrl.T.Helper()
@@ -28,6 +28,12 @@ func New() SriovnetLib {
 type SriovnetLib interface {
 	// GetVfRepresentor returns representor name for VF device
 	GetVfRepresentor(uplink string, vfIndex int) (string, error)
+	// GetAuxNetDevicesFromPci returns the auxiliary network devices (e.g. SFs) created on the PCI device
+	GetAuxNetDevicesFromPci(pciAddr string) ([]string, error)
+	// GetSfIndexByAuxDev returns the SF number of the auxiliary device
+	GetSfIndexByAuxDev(auxDev string) (int, error)
+	// GetNetDevicesFromAux returns the netdevices of the auxiliary device
+	GetNetDevicesFromAux(auxDev string) ([]string, error)
 }
 
 type libWrapper struct{}
@@ -36,3 +42,18 @@ type libWrapper struct{}
 func (w *libWrapper) GetVfRepresentor(pfName string, vfIndex int) (string, error) {
 	return sriovnet.GetVfRepresentor(pfName, vfIndex)
 }
+
+// GetAuxNetDevicesFromPci returns the auxiliary network devices (e.g. SFs) created on the PCI device
+func (w *libWrapper) GetAuxNetDevicesFromPci(pciAddr string) ([]string, error) {
+	return sriovnet.GetAuxNetDevicesFromPci(pciAddr)
+}
+
+// GetSfIndexByAuxDev returns the SF number of the auxiliary device
+func (w *libWrapper) GetSfIndexByAuxDev(auxDev string) (int, error) {
+	return sriovnet.GetSfIndexByAuxDev(auxDev)
+}
+
+// GetNetDevicesFromAux returns the netdevices of the auxiliary device
+func (w *libWrapper) GetNetDevicesFromAux(auxDev string) ([]string, error) {
+	return sriovnet.GetNetDevicesFromAux(auxDev)
+}
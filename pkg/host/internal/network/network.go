@@ -17,19 +17,25 @@
 package network
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff"
+	"github.com/vishvananda/netlink"
 	"github.com/vishvananda/netlink/nl"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	dputilsPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/dputils"
 	ethtoolPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/ethtool"
@@ -282,12 +288,158 @@ func (n *network) GetDevlinkDeviceParam(pciAddr, paramName string) (string, erro
 		funcLog.Info("GetDevlinkDeviceParam(): WARNING: can't read devlink parameter from the device, an empty value received")
 		return "", nil
 	}
-	var value string
-	var ok bool
-	switch param.Type {
+	value, err := devlinkParamValueToString(param.Type, param.Values[0].Data)
+	if err != nil {
+		return "", err
+	}
+	funcLog.V(2).Info("GetDevlinkDeviceParam(): result", "value", value)
+	return value, nil
+}
+
+// SetDevlinkDeviceParam set devlink parameter for the device, accepts paramName and value
+// as a string. Automatically set CMODE for the parameter and converts the value to the right
+// type before submitting it.
+func (n *network) SetDevlinkDeviceParam(pciAddr, paramName, value string) error {
+	funcLog := log.Log.WithValues("device", pciAddr, "param", paramName, "value", value)
+	funcLog.V(2).Info("SetDevlinkDeviceParam(): set device parameter")
+	param, err := n.netlinkLib.DevlinkGetDeviceParamByName(consts.BusPci, pciAddr, paramName)
+	if err != nil {
+		funcLog.Error(err, "SetDevlinkDeviceParam(): can't get existing param data")
+		return err
+	}
+	if len(param.Values) == 0 {
+		err = fmt.Errorf("param %s has no value", paramName)
+		funcLog.Error(err, "SetDevlinkDeviceParam(): error")
+		return err
+	}
+	targetCMOD := param.Values[0].CMODE
+	typedValue, err := devlinkParamValueFromString(param.Type, value)
+	if err != nil {
+		funcLog.Error(err, "SetDevlinkDeviceParam(): error")
+		return err
+	}
+	if err := n.netlinkLib.DevlinkSetDeviceParam(consts.BusPci, pciAddr, paramName, targetCMOD, typedValue); err != nil {
+		funcLog.Error(err, "SetDevlinkDeviceParam(): failed to set parameter")
+		return err
+	}
+	return nil
+}
+
+// GetDevlinkParams returns the values of all the devlink parameters of the device in each
+// configuration mode they support, the parameters that can't be read are skipped
+func (n *network) GetDevlinkParams(pciAddr string) []sriovnetworkv1.DevlinkParamStatus {
+	funcLog := log.Log.WithValues("device", pciAddr)
+	funcLog.V(2).Info("GetDevlinkParams(): get device parameters")
+	params, err := n.netlinkLib.DevlinkGetDeviceParams(consts.BusPci, pciAddr)
+	if err != nil {
+		funcLog.V(2).Info("GetDevlinkParams(): can't read devlink parameters", "reason", err.Error())
+		return nil
+	}
+	var result []sriovnetworkv1.DevlinkParamStatus
+	for _, param := range params {
+		for _, paramValue := range param.Values {
+			cmode, ok := devlinkCmodeNames[paramValue.CMODE]
+			if !ok || paramValue.Data == nil {
+				continue
+			}
+			value, err := devlinkParamValueToString(param.Type, paramValue.Data)
+			if err != nil {
+				funcLog.V(2).Info("GetDevlinkParams(): can't read devlink parameter", "param", param.Name, "reason", err.Error())
+				continue
+			}
+			result = append(result, sriovnetworkv1.DevlinkParamStatus{Name: param.Name, Cmode: cmode, Value: value})
+		}
+	}
+	slices.SortFunc(result, func(a, b sriovnetworkv1.DevlinkParamStatus) int {
+		return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(a.Cmode, b.Cmode))
+	})
+	return result
+}
+
+// SetDevlinkParams sets the devlink parameters of the device that differ from the current values,
+// returns true if a parameter was set in driverinit mode and the driver must be reloaded to apply it.
+// The parameters the device doesn't support in the desired mode are skipped, the config daemon
+// reports them as unsupported in the status of the PF.
+func (n *network) SetDevlinkParams(pciAddr string, params map[string]sriovnetworkv1.DevlinkParam) (bool, error) {
+	funcLog := log.Log.WithValues("device", pciAddr)
+	funcLog.V(2).Info("SetDevlinkParams(): set device parameters", "params", params)
+	deviceParams, err := n.netlinkLib.DevlinkGetDeviceParams(consts.BusPci, pciAddr)
+	if err != nil {
+		return false, fmt.Errorf("failed to read devlink parameters of %s: %w", pciAddr, err)
+	}
+	needReload := false
+	for _, name := range slices.Sorted(maps.Keys(params)) {
+		desired := params[name]
+		idx := slices.IndexFunc(deviceParams, func(p *netlink.DevlinkParam) bool { return p.Name == name })
+		if idx < 0 {
+			funcLog.Info("SetDevlinkParams(): devlink parameter is not supported by the device, skipping", "param", name)
+			continue
+		}
+		param := deviceParams[idx]
+		var current *netlink.DevlinkParamValue
+		for _, cmode := range sriovnetworkv1.DevlinkParamCmodes {
+			for i := range param.Values {
+				if devlinkCmodeNames[param.Values[i].CMODE] == cmode && (desired.Cmode == "" || desired.Cmode == cmode) {
+					current = &param.Values[i]
+					break
+				}
+			}
+			if current != nil {
+				break
+			}
+		}
+		if current == nil {
+			funcLog.Info("SetDevlinkParams(): devlink parameter doesn't support the cmode, skipping",
+				"param", name, "cmode", desired.Cmode)
+			continue
+		}
+		typedValue, err := devlinkParamValueFromString(param.Type, desired.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid value for devlink parameter %s of %s: %w", name, pciAddr, err)
+		}
+		if current.Data == typedValue {
+			continue
+		}
+		funcLog.Info("SetDevlinkParams(): set devlink parameter", "param", name,
+			"cmode", devlinkCmodeNames[current.CMODE], "value", desired.Value)
+		if err := n.netlinkLib.DevlinkSetDeviceParam(consts.BusPci, pciAddr, name, current.CMODE, typedValue); err != nil {
+			return false, fmt.Errorf("failed to set devlink parameter %s of %s: %w", name, pciAddr, err)
+		}
+		if current.CMODE == nl.DEVLINK_PARAM_CMODE_DRIVERINIT {
+			needReload = true
+		}
+	}
+	return needReload, nil
+}
+
+// ReloadDevlinkDevice reloads the driver of the device to apply the driverinit devlink parameters
+func (n *network) ReloadDevlinkDevice(pciAddr string) error {
+	log.Log.Info("ReloadDevlinkDevice(): reload device driver", "device", pciAddr)
+	if err := n.netlinkLib.DevlinkReload(consts.BusPci, pciAddr); err != nil {
+		log.Log.Error(err, "ReloadDevlinkDevice(): failed to reload device", "device", pciAddr)
+		return err
+	}
+	return nil
+}
+
+// GetDevlinkDeviceInfo returns the driver, serial number and firmware versions reported by devlink for the device
+func (n *network) GetDevlinkDeviceInfo(pciAddr string) (*netlink.DevlinkDeviceInfo, error) {
+	log.Log.V(2).Info("GetDevlinkDeviceInfo(): get device info", "device", pciAddr)
+	return n.netlinkLib.DevlinkGetDeviceInfoByName(consts.BusPci, pciAddr)
+}
+
+var devlinkCmodeNames = map[uint8]string{
+	nl.DEVLINK_PARAM_CMODE_RUNTIME:    consts.DevlinkParamCmodeRuntime,
+	nl.DEVLINK_PARAM_CMODE_DRIVERINIT: consts.DevlinkParamCmodeDriverinit,
+	nl.DEVLINK_PARAM_CMODE_PERMANENT:  consts.DevlinkParamCmodePermanent,
+}
+
+// devlinkParamValueToString converts the value of a devlink parameter to a string
+func devlinkParamValueToString(paramType uint8, data interface{}) (string, error) {
+	switch paramType {
 	case nl.DEVLINK_PARAM_TYPE_U8, nl.DEVLINK_PARAM_TYPE_U16, nl.DEVLINK_PARAM_TYPE_U32:
 		var valData uint64
-		switch v := param.Values[0].Data.(type) {
+		switch v := data.(type) {
 		case uint8:
 			valData = uint64(v)
 		case uint16:
@@ -297,47 +449,30 @@ func (n *network) GetDevlinkDeviceParam(pciAddr, paramName string) (string, erro
 		default:
 			return "", fmt.Errorf("value is not uint")
 		}
-		value = strconv.FormatUint(valData, 10)
-
+		return strconv.FormatUint(valData, 10), nil
 	case nl.DEVLINK_PARAM_TYPE_STRING:
-		value, ok = param.Values[0].Data.(string)
+		value, ok := data.(string)
 		if !ok {
 			return "", fmt.Errorf("value is not a string")
 		}
+		return value, nil
 	case nl.DEVLINK_PARAM_TYPE_BOOL:
-		var boolValue bool
-		boolValue, ok = param.Values[0].Data.(bool)
+		boolValue, ok := data.(bool)
 		if !ok {
 			return "", fmt.Errorf("value is not a bool")
 		}
-		value = strconv.FormatBool(boolValue)
+		return strconv.FormatBool(boolValue), nil
 	default:
-		return "", fmt.Errorf("unknown value type: %d", param.Type)
+		return "", fmt.Errorf("unknown value type: %d", paramType)
 	}
-	funcLog.V(2).Info("GetDevlinkDeviceParam(): result", "value", value)
-	return value, nil
 }
 
-// SetDevlinkDeviceParam set devlink parameter for the device, accepts paramName and value
-// as a string. Automatically set CMODE for the parameter and converts the value to the right
-// type before submitting it.
-func (n *network) SetDevlinkDeviceParam(pciAddr, paramName, value string) error {
-	funcLog := log.Log.WithValues("device", pciAddr, "param", paramName, "value", value)
-	funcLog.V(2).Info("SetDevlinkDeviceParam(): set device parameter")
-	param, err := n.netlinkLib.DevlinkGetDeviceParamByName(consts.BusPci, pciAddr, paramName)
-	if err != nil {
-		funcLog.Error(err, "SetDevlinkDeviceParam(): can't get existing param data")
-		return err
-	}
-	if len(param.Values) == 0 {
-		err = fmt.Errorf("param %s has no value", paramName)
-		funcLog.Error(err, "SetDevlinkDeviceParam(): error")
-		return err
-	}
-	targetCMOD := param.Values[0].CMODE
+// devlinkParamValueFromString converts the string to the type of the devlink parameter
+func devlinkParamValueFromString(paramType uint8, value string) (interface{}, error) {
 	var typedValue interface{}
 	var v uint64
-	switch param.Type {
+	var err error
+	switch paramType {
 	case nl.DEVLINK_PARAM_TYPE_U8:
 		v, err = strconv.ParseUint(value, 10, 8)
 		typedValue = uint8(v)
@@ -348,23 +483,16 @@ func (n *network) SetDevlinkDeviceParam(pciAddr, paramName, value string) error
 		v, err = strconv.ParseUint(value, 10, 32)
 		typedValue = uint32(v)
 	case nl.DEVLINK_PARAM_TYPE_STRING:
-		err = nil
 		typedValue = value
 	case nl.DEVLINK_PARAM_TYPE_BOOL:
 		typedValue, err = strconv.ParseBool(value)
 	default:
-		return fmt.Errorf("parameter has unknown value type: %d", param.Type)
+		return nil, fmt.Errorf("parameter has unknown value type: %d", paramType)
 	}
 	if err != nil {
-		err = fmt.Errorf("failed to convert value %s to the required type: %T, devlink paramType is: %d", value, typedValue, param.Type)
-		funcLog.Error(err, "SetDevlinkDeviceParam(): error")
-		return err
+		return nil, fmt.Errorf("failed to convert value %s to the required type: %T, devlink paramType is: %d", value, typedValue, paramType)
 	}
-	if err := n.netlinkLib.DevlinkSetDeviceParam(consts.BusPci, pciAddr, paramName, targetCMOD, typedValue); err != nil {
-		funcLog.Error(err, "SetDevlinkDeviceParam(): failed to set parameter")
-		return err
-	}
-	return nil
+	return typedValue, nil
 }
 
 // EnableHwTcOffload makes sure that hw-tc-offload feature is enabled if device supports it
@@ -407,6 +535,139 @@ func (n *network) EnableHwTcOffload(ifaceName string) error {
 	return nil
 }
 
+// GetPfSettings returns the ethtool settings of the interface, the settings
+// that can't be read from the device are not reported
+func (n *network) GetPfSettings(ifaceName string) *sriovnetworkv1.PfSettings {
+	log.Log.V(2).Info("GetPfSettings(): get ethtool settings", "device", ifaceName)
+	settings := &sriovnetworkv1.PfSettings{}
+	if ring, err := n.ethtoolLib.GetRing(ifaceName); err != nil {
+		log.Log.V(2).Info("GetPfSettings(): can't read ring parameters", "device", ifaceName, "reason", err.Error())
+	} else {
+		settings.RxRingSize = ptr.To(int(ring.RxPending))
+		settings.TxRingSize = ptr.To(int(ring.TxPending))
+	}
+	if channels, err := n.ethtoolLib.GetChannels(ifaceName); err != nil {
+		log.Log.V(2).Info("GetPfSettings(): can't read channels", "device", ifaceName, "reason", err.Error())
+	} else {
+		settings.CombinedChannels = ptr.To(int(channels.CombinedCount))
+	}
+	if features, err := n.ethtoolLib.Features(ifaceName); err != nil {
+		log.Log.V(2).Info("GetPfSettings(): can't read features", "device", ifaceName, "reason", err.Error())
+	} else {
+		settings.Features = features
+	}
+	if pause, err := n.ethtoolLib.GetPause(ifaceName); err != nil {
+		log.Log.V(2).Info("GetPfSettings(): can't read pause parameters", "device", ifaceName, "reason", err.Error())
+	} else {
+		settings.Pause = &sriovnetworkv1.PauseSettings{
+			Autoneg: ptr.To(pause.Autoneg != 0),
+			Rx:      ptr.To(pause.RxPause != 0),
+			Tx:      ptr.To(pause.TxPause != 0),
+		}
+	}
+	if fecMode, err := n.ethtoolLib.GetFecMode(ifaceName); err != nil {
+		log.Log.V(2).Info("GetPfSettings(): can't read FEC mode", "device", ifaceName, "reason", err.Error())
+	} else {
+		settings.FecMode = fecMode
+	}
+	return settings
+}
+
+// SetPfSettings configures the ethtool settings of the interface, only the settings
+// that differ from the current ones are changed
+func (n *network) SetPfSettings(ifaceName string, settings *sriovnetworkv1.PfSettings) error {
+	log.Log.V(2).Info("SetPfSettings(): configure ethtool settings", "device", ifaceName, "settings", settings)
+	if settings.RxRingSize != nil || settings.TxRingSize != nil {
+		ring, err := n.ethtoolLib.GetRing(ifaceName)
+		if err != nil {
+			return fmt.Errorf("failed to read ring parameters of %s: %v", ifaceName, err)
+		}
+		desired := ring
+		if settings.RxRingSize != nil {
+			desired.RxPending = uint32(*settings.RxRingSize)
+		}
+		if settings.TxRingSize != nil {
+			desired.TxPending = uint32(*settings.TxRingSize)
+		}
+		if desired != ring {
+			if err := n.ethtoolLib.SetRing(ifaceName, desired); err != nil {
+				return fmt.Errorf("failed to set ring parameters of %s: %v", ifaceName, err)
+			}
+		}
+	}
+	if settings.CombinedChannels != nil {
+		channels, err := n.ethtoolLib.GetChannels(ifaceName)
+		if err != nil {
+			return fmt.Errorf("failed to read channels of %s: %v", ifaceName, err)
+		}
+		if channels.CombinedCount != uint32(*settings.CombinedChannels) {
+			channels.CombinedCount = uint32(*settings.CombinedChannels)
+			if err := n.ethtoolLib.SetChannels(ifaceName, channels); err != nil {
+				return fmt.Errorf("failed to set combined channels of %s: %v", ifaceName, err)
+			}
+		}
+	}
+	if len(settings.Features) > 0 {
+		knownFeatures, err := n.ethtoolLib.FeatureNames(ifaceName)
+		if err != nil {
+			return fmt.Errorf("failed to list features of %s: %v", ifaceName, err)
+		}
+		currentFeatures, err := n.ethtoolLib.Features(ifaceName)
+		if err != nil {
+			return fmt.Errorf("failed to read features of %s: %v", ifaceName, err)
+		}
+		changes := map[string]bool{}
+		for name, enabled := range settings.Features {
+			if _, isKnown := knownFeatures[name]; !isKnown {
+				return fmt.Errorf("feature %s is not supported by %s", name, ifaceName)
+			}
+			if currentFeatures[name] != enabled {
+				changes[name] = enabled
+			}
+		}
+		if len(changes) > 0 {
+			if err := n.ethtoolLib.Change(ifaceName, changes); err != nil {
+				return fmt.Errorf("failed to change features of %s: %v", ifaceName, err)
+			}
+		}
+	}
+	if settings.Pause != nil {
+		pause, err := n.ethtoolLib.GetPause(ifaceName)
+		if err != nil {
+			return fmt.Errorf("failed to read pause parameters of %s: %v", ifaceName, err)
+		}
+		desired := pause
+		setFlag := func(flag *uint32, value *bool) {
+			if value != nil {
+				*flag = 0
+				if *value {
+					*flag = 1
+				}
+			}
+		}
+		setFlag(&desired.Autoneg, settings.Pause.Autoneg)
+		setFlag(&desired.RxPause, settings.Pause.Rx)
+		setFlag(&desired.TxPause, settings.Pause.Tx)
+		if desired != pause {
+			if err := n.ethtoolLib.SetPause(ifaceName, desired); err != nil {
+				return fmt.Errorf("failed to set pause parameters of %s: %v", ifaceName, err)
+			}
+		}
+	}
+	if settings.FecMode != "" {
+		fecMode, err := n.ethtoolLib.GetFecMode(ifaceName)
+		if err != nil {
+			return fmt.Errorf("failed to read FEC mode of %s: %v", ifaceName, err)
+		}
+		if fecMode != settings.FecMode {
+			if err := n.ethtoolLib.SetFecMode(ifaceName, settings.FecMode); err != nil {
+				return fmt.Errorf("failed to set FEC mode of %s: %v", ifaceName, err)
+			}
+		}
+	}
+	return nil
+}
+
 // GetNetDevLinkAdminState returns the admin state of the interface.
 func (n *network) GetNetDevLinkAdminState(ifaceName string) string {
 	log.Log.V(2).Info("GetNetDevLinkAdminState(): get LinkAdminState", "device", ifaceName)
@@ -427,6 +688,15 @@ func (n *network) GetNetDevLinkAdminState(ifaceName string) string {
 	return consts.LinkAdminStateDown
 }
 
+// GetNetDevDriverInfo returns the driver and firmware versions of the interface reported by ethtool
+func (n *network) GetNetDevDriverInfo(ifaceName string) (string, string, error) {
+	info, err := n.ethtoolLib.DriverInfo(ifaceName)
+	if err != nil {
+		return "", "", err
+	}
+	return info.Version, info.FwVersion, nil
+}
+
 // GetPciAddressFromInterfaceName parses sysfs to get pci address of an interface by name
 func (n *network) GetPciAddressFromInterfaceName(interfaceName string) (string, error) {
 	log.Log.V(2).Info("GetPciAddressFromInterfaceName(): get pci address", "interface", interfaceName)
@@ -23,9 +23,13 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/safchain/ethtool"
 	"github.com/vishvananda/netlink"
 	"github.com/vishvananda/netlink/nl"
 	"go.uber.org/mock/gomock"
+	"k8s.io/utils/ptr"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	dputilsMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/dputils/mock"
@@ -196,6 +200,74 @@ var _ = Describe("Network", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+	Context("GetDevlinkParams", func() {
+		It("read all", func() {
+			netlinkLibMock.EXPECT().DevlinkGetDeviceParams("pci", "0000:d8:00.1").Return([]*netlink.DevlinkParam{
+				{Name: "flow_steering_mode", Type: nl.DEVLINK_PARAM_TYPE_STRING, Values: []netlink.DevlinkParamValue{
+					{Data: "dmfs", CMODE: nl.DEVLINK_PARAM_CMODE_RUNTIME}}},
+				{Name: "enable_roce", Type: nl.DEVLINK_PARAM_TYPE_BOOL, Values: []netlink.DevlinkParamValue{
+					{Data: true, CMODE: nl.DEVLINK_PARAM_CMODE_DRIVERINIT}}},
+				{Name: "unreadable", Type: nl.DEVLINK_PARAM_TYPE_U8, Values: []netlink.DevlinkParamValue{
+					{Data: "foo", CMODE: nl.DEVLINK_PARAM_CMODE_RUNTIME}}},
+			}, nil)
+			Expect(n.GetDevlinkParams("0000:d8:00.1")).To(Equal([]sriovnetworkv1.DevlinkParamStatus{
+				{Name: "enable_roce", Cmode: "driverinit", Value: "true"},
+				{Name: "flow_steering_mode", Cmode: "runtime", Value: "dmfs"},
+			}))
+		})
+		It("no devlink support", func() {
+			netlinkLibMock.EXPECT().DevlinkGetDeviceParams("pci", "0000:d8:00.1").Return(nil, testErr)
+			Expect(n.GetDevlinkParams("0000:d8:00.1")).To(BeNil())
+		})
+	})
+	Context("SetDevlinkParams", func() {
+		It("set runtime and driverinit parameters", func() {
+			netlinkLibMock.EXPECT().DevlinkGetDeviceParams("pci", "0000:d8:00.1").Return([]*netlink.DevlinkParam{
+				{Name: "enable_roce", Type: nl.DEVLINK_PARAM_TYPE_BOOL, Values: []netlink.DevlinkParamValue{
+					{Data: true, CMODE: nl.DEVLINK_PARAM_CMODE_DRIVERINIT}}},
+				{Name: "flow_steering_mode", Type: nl.DEVLINK_PARAM_TYPE_STRING, Values: []netlink.DevlinkParamValue{
+					{Data: "dmfs", CMODE: nl.DEVLINK_PARAM_CMODE_RUNTIME}}},
+			}, nil)
+			netlinkLibMock.EXPECT().DevlinkSetDeviceParam("pci", "0000:d8:00.1", "enable_roce",
+				uint8(nl.DEVLINK_PARAM_CMODE_DRIVERINIT), false).Return(nil)
+			netlinkLibMock.EXPECT().DevlinkSetDeviceParam("pci", "0000:d8:00.1", "flow_steering_mode",
+				uint8(nl.DEVLINK_PARAM_CMODE_RUNTIME), "smfs").Return(nil)
+			needReload, err := n.SetDevlinkParams("0000:d8:00.1", map[string]sriovnetworkv1.DevlinkParam{
+				"flow_steering_mode": {Value: "smfs"},
+				"enable_roce":        {Value: "false"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(needReload).To(BeTrue())
+		})
+		It("skip parameters with the desired value", func() {
+			netlinkLibMock.EXPECT().DevlinkGetDeviceParams("pci", "0000:d8:00.1").Return([]*netlink.DevlinkParam{
+				{Name: "max_macs", Type: nl.DEVLINK_PARAM_TYPE_U32, Values: []netlink.DevlinkParamValue{
+					{Data: uint32(8), CMODE: nl.DEVLINK_PARAM_CMODE_DRIVERINIT}}},
+			}, nil)
+			needReload, err := n.SetDevlinkParams("0000:d8:00.1", map[string]sriovnetworkv1.DevlinkParam{
+				"max_macs": {Value: "8", Cmode: "driverinit"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(needReload).To(BeFalse())
+		})
+		It("skip unsupported parameters and cmodes", func() {
+			netlinkLibMock.EXPECT().DevlinkGetDeviceParams("pci", "0000:d8:00.1").Return([]*netlink.DevlinkParam{
+				{Name: "max_macs", Type: nl.DEVLINK_PARAM_TYPE_U32, Values: []netlink.DevlinkParamValue{
+					{Data: uint32(8), CMODE: nl.DEVLINK_PARAM_CMODE_DRIVERINIT}}},
+			}, nil)
+			needReload, err := n.SetDevlinkParams("0000:d8:00.1", map[string]sriovnetworkv1.DevlinkParam{
+				"max_macs": {Value: "16", Cmode: "permanent"},
+				"foo":      {Value: "1"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(needReload).To(BeFalse())
+		})
+		It("fail - can't read the parameters", func() {
+			netlinkLibMock.EXPECT().DevlinkGetDeviceParams("pci", "0000:d8:00.1").Return(nil, testErr)
+			_, err := n.SetDevlinkParams("0000:d8:00.1", map[string]sriovnetworkv1.DevlinkParam{"foo": {Value: "1"}})
+			Expect(err).To(MatchError(testErr))
+		})
+	})
 	Context("EnableHwTcOffload", func() {
 		It("Enabled", func() {
 			ethtoolLibMock.EXPECT().FeatureNames("enp216s0f0np0").Return(map[string]uint{"hw-tc-offload": 42}, nil)
@@ -243,6 +315,91 @@ var _ = Describe("Network", func() {
 			Expect(n.EnableHwTcOffload("enp216s0f0np0")).To(MatchError(testErr))
 		})
 	})
+	Context("GetNetDevDriverInfo", func() {
+		It("Returned", func() {
+			ethtoolLibMock.EXPECT().DriverInfo("enp216s0f0np0").Return(ethtool.DrvInfo{
+				Driver: "ice", Version: "1.14.9", FwVersion: "4.40 0x8001c967 1.3534.0"}, nil)
+			driverVersion, firmwareVersion, err := n.GetNetDevDriverInfo("enp216s0f0np0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(driverVersion).To(Equal("1.14.9"))
+			Expect(firmwareVersion).To(Equal("4.40 0x8001c967 1.3534.0"))
+		})
+		It("Failed", func() {
+			ethtoolLibMock.EXPECT().DriverInfo("enp216s0f0np0").Return(ethtool.DrvInfo{}, testErr)
+			_, _, err := n.GetNetDevDriverInfo("enp216s0f0np0")
+			Expect(err).To(MatchError(testErr))
+		})
+	})
+	Context("SetPfSettings", func() {
+		It("Changed", func() {
+			ethtoolLibMock.EXPECT().GetRing("enp216s0f0np0").Return(ethtool.Ring{RxPending: 1024, TxPending: 1024}, nil)
+			ethtoolLibMock.EXPECT().SetRing("enp216s0f0np0", ethtool.Ring{RxPending: 4096, TxPending: 1024}).Return(nil)
+			ethtoolLibMock.EXPECT().GetChannels("enp216s0f0np0").Return(ethtool.Channels{CombinedCount: 8}, nil)
+			ethtoolLibMock.EXPECT().SetChannels("enp216s0f0np0", ethtool.Channels{CombinedCount: 16}).Return(nil)
+			ethtoolLibMock.EXPECT().FeatureNames("enp216s0f0np0").Return(map[string]uint{"rx-gro-hw": 1, "tx-checksumming": 2}, nil)
+			ethtoolLibMock.EXPECT().Features("enp216s0f0np0").Return(map[string]bool{"rx-gro-hw": true, "tx-checksumming": true}, nil)
+			ethtoolLibMock.EXPECT().Change("enp216s0f0np0", map[string]bool{"rx-gro-hw": false}).Return(nil)
+			ethtoolLibMock.EXPECT().GetPause("enp216s0f0np0").Return(ethtool.Pause{Autoneg: 1, RxPause: 1, TxPause: 1}, nil)
+			ethtoolLibMock.EXPECT().SetPause("enp216s0f0np0", ethtool.Pause{Autoneg: 0, RxPause: 1, TxPause: 1}).Return(nil)
+			ethtoolLibMock.EXPECT().GetFecMode("enp216s0f0np0").Return("auto", nil)
+			ethtoolLibMock.EXPECT().SetFecMode("enp216s0f0np0", "rs").Return(nil)
+			Expect(n.SetPfSettings("enp216s0f0np0", &sriovnetworkv1.PfSettings{
+				RxRingSize:       ptr.To(4096),
+				CombinedChannels: ptr.To(16),
+				Features:         map[string]bool{"rx-gro-hw": false, "tx-checksumming": true},
+				Pause:            &sriovnetworkv1.PauseSettings{Autoneg: ptr.To(false)},
+				FecMode:          "rs",
+			})).NotTo(HaveOccurred())
+		})
+		It("Already configured", func() {
+			ethtoolLibMock.EXPECT().GetRing("enp216s0f0np0").Return(ethtool.Ring{RxPending: 4096, TxPending: 4096}, nil)
+			ethtoolLibMock.EXPECT().GetFecMode("enp216s0f0np0").Return("rs", nil)
+			Expect(n.SetPfSettings("enp216s0f0np0", &sriovnetworkv1.PfSettings{
+				RxRingSize: ptr.To(4096),
+				TxRingSize: ptr.To(4096),
+				FecMode:    "rs",
+			})).NotTo(HaveOccurred())
+		})
+		It("fail - unknown feature", func() {
+			ethtoolLibMock.EXPECT().FeatureNames("enp216s0f0np0").Return(map[string]uint{"rx-gro-hw": 1}, nil)
+			ethtoolLibMock.EXPECT().Features("enp216s0f0np0").Return(map[string]bool{"rx-gro-hw": true}, nil)
+			Expect(n.SetPfSettings("enp216s0f0np0", &sriovnetworkv1.PfSettings{
+				Features: map[string]bool{"foo": true},
+			})).To(MatchError(ContainSubstring("feature foo is not supported")))
+		})
+		It("fail - can't set ring", func() {
+			ethtoolLibMock.EXPECT().GetRing("enp216s0f0np0").Return(ethtool.Ring{RxPending: 1024}, nil)
+			ethtoolLibMock.EXPECT().SetRing("enp216s0f0np0", ethtool.Ring{RxPending: 2048}).Return(testErr)
+			Expect(n.SetPfSettings("enp216s0f0np0", &sriovnetworkv1.PfSettings{
+				RxRingSize: ptr.To(2048),
+			})).To(MatchError(ContainSubstring(testErr.Error())))
+		})
+	})
+	Context("GetPfSettings", func() {
+		It("Read all", func() {
+			ethtoolLibMock.EXPECT().GetRing("enp216s0f0np0").Return(ethtool.Ring{RxPending: 1024, TxPending: 2048}, nil)
+			ethtoolLibMock.EXPECT().GetChannels("enp216s0f0np0").Return(ethtool.Channels{CombinedCount: 8}, nil)
+			ethtoolLibMock.EXPECT().Features("enp216s0f0np0").Return(map[string]bool{"rx-gro-hw": true}, nil)
+			ethtoolLibMock.EXPECT().GetPause("enp216s0f0np0").Return(ethtool.Pause{Autoneg: 1, RxPause: 0, TxPause: 1}, nil)
+			ethtoolLibMock.EXPECT().GetFecMode("enp216s0f0np0").Return("rs", nil)
+			Expect(n.GetPfSettings("enp216s0f0np0")).To(Equal(&sriovnetworkv1.PfSettings{
+				RxRingSize:       ptr.To(1024),
+				TxRingSize:       ptr.To(2048),
+				CombinedChannels: ptr.To(8),
+				Features:         map[string]bool{"rx-gro-hw": true},
+				Pause:            &sriovnetworkv1.PauseSettings{Autoneg: ptr.To(true), Rx: ptr.To(false), Tx: ptr.To(true)},
+				FecMode:          "rs",
+			}))
+		})
+		It("Not supported by the driver", func() {
+			ethtoolLibMock.EXPECT().GetRing("enp216s0f0np0").Return(ethtool.Ring{}, testErr)
+			ethtoolLibMock.EXPECT().GetChannels("enp216s0f0np0").Return(ethtool.Channels{}, testErr)
+			ethtoolLibMock.EXPECT().Features("enp216s0f0np0").Return(nil, testErr)
+			ethtoolLibMock.EXPECT().GetPause("enp216s0f0np0").Return(ethtool.Pause{}, testErr)
+			ethtoolLibMock.EXPECT().GetFecMode("enp216s0f0np0").Return("", testErr)
+			Expect(n.GetPfSettings("enp216s0f0np0")).To(Equal(&sriovnetworkv1.PfSettings{}))
+		})
+	})
 	Context("GetNetDevNodeGUID", func() {
 		It("Returns empty when pciAddr is empty", func() {
 			Expect(n.GetNetDevNodeGUID("")).To(Equal(""))
@@ -0,0 +1,320 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sf
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/renameio/v2"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	netlinkLibPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink"
+	sriovnetPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/sriovnet"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
+)
+
+type sf struct {
+	networkHelper types.NetworkInterface
+	netlinkLib    netlinkLibPkg.NetlinkLib
+	sriovnetLib   sriovnetPkg.SriovnetLib
+}
+
+func New(networkHelper types.NetworkInterface, netlinkLib netlinkLibPkg.NetlinkLib, sriovnetLib sriovnetPkg.SriovnetLib) types.SfInterface {
+	return &sf{networkHelper: networkHelper, netlinkLib: netlinkLib, sriovnetLib: sriovnetLib}
+}
+
+// sfPort is a devlink port of the PCI_SF flavour with the SF number read from its representor
+type sfPort struct {
+	port     *netlink.DevlinkPort
+	sfNumber int
+}
+
+// ConfigureSfs creates and activates the SFs 0 to numSfs-1 on the PF, the SFs created by the config daemon
+// with a higher number are deleted. The eSwitch of the PF must be in switchdev mode.
+func (s *sf) ConfigureSfs(pciAddr string, numSfs int) error {
+	funcLog := log.Log.WithValues("device", pciAddr, "numSfs", numSfs)
+	funcLog.V(2).Info("ConfigureSfs(): configure SFs")
+	ports, err := s.getSfPorts(pciAddr)
+	if err != nil {
+		funcLog.Error(err, "ConfigureSfs(): failed to get SF ports")
+		return err
+	}
+	managed, err := loadManagedSfs()
+	if err != nil {
+		funcLog.Error(err, "ConfigureSfs(): failed to load the SFs created by the operator")
+		return err
+	}
+	existing := map[int]*netlink.DevlinkPort{}
+	for _, p := range ports {
+		if p.sfNumber < numSfs {
+			existing[p.sfNumber] = p.port
+			continue
+		}
+		if !slices.Contains(managed[pciAddr], p.sfNumber) {
+			funcLog.V(2).Info("ConfigureSfs(): skip SF not created by the operator", "sfnum", p.sfNumber)
+			continue
+		}
+		if err := s.deletePort(pciAddr, p.port); err != nil {
+			funcLog.Error(err, "ConfigureSfs(): failed to delete SF", "sfnum", p.sfNumber)
+			return err
+		}
+		if err := setManagedSf(managed, pciAddr, p.sfNumber, false); err != nil {
+			return err
+		}
+	}
+
+	pfNumber, err := getPfNumber(pciAddr)
+	if err != nil {
+		return err
+	}
+	for sfNumber := 0; sfNumber < numSfs; sfNumber++ {
+		port, ok := existing[sfNumber]
+		if !ok {
+			funcLog.V(2).Info("ConfigureSfs(): add SF", "sfnum", sfNumber)
+			port, err = s.netlinkLib.DevLinkPortAdd(constants.BusPci, pciAddr, nl.DEVLINK_PORT_FLAVOUR_PCI_SF,
+				netlink.DevLinkPortAddAttrs{PfNumber: pfNumber, SfNumber: uint32(sfNumber), SfNumberValid: true})
+			if err != nil {
+				funcLog.Error(err, "ConfigureSfs(): failed to add SF", "sfnum", sfNumber)
+				return err
+			}
+			if err := setManagedSf(managed, pciAddr, sfNumber, true); err != nil {
+				return err
+			}
+		}
+		if isPortActive(port) {
+			continue
+		}
+		funcLog.V(2).Info("ConfigureSfs(): activate SF", "sfnum", sfNumber, "port", port.PortIndex)
+		err = s.netlinkLib.DevlinkPortFnSet(constants.BusPci, pciAddr, port.PortIndex, netlink.DevlinkPortFnSetAttrs{
+			FnAttrs:    netlink.DevlinkPortFn{State: nl.DEVLINK_PORT_FN_STATE_ACTIVE},
+			StateValid: true,
+		})
+		if err != nil {
+			funcLog.Error(err, "ConfigureSfs(): failed to activate SF", "sfnum", sfNumber)
+			return err
+		}
+	}
+	return nil
+}
+
+// DiscoverSfs returns the SFs created on the PF sorted by SF number
+func (s *sf) DiscoverSfs(pciAddr string) ([]sriovnetworkv1.ScalableFunction, error) {
+	ports, err := s.getSfPorts(pciAddr)
+	if err != nil {
+		return nil, err
+	}
+	if len(ports) == 0 {
+		return nil, nil
+	}
+	netdevs := s.getSfNetdevs(pciAddr)
+
+	sfs := make([]sriovnetworkv1.ScalableFunction, 0, len(ports))
+	for _, p := range ports {
+		sf := sriovnetworkv1.ScalableFunction{
+			Name:            netdevs[p.sfNumber],
+			SfNumber:        p.sfNumber,
+			PortIndex:       int(p.port.PortIndex),
+			RepresentorName: p.port.NetdeviceName,
+			State:           constants.SfStateInactive,
+		}
+		if p.port.Fn != nil {
+			sf.Mac = p.port.Fn.HwAddr.String()
+		}
+		if isPortActive(p.port) {
+			sf.State = constants.SfStateActive
+		}
+		sfs = append(sfs, sf)
+	}
+	return sfs, nil
+}
+
+// DeleteSfs deactivates and deletes the SFs created by the config daemon on the PF
+func (s *sf) DeleteSfs(pciAddr string) error {
+	ports, err := s.getSfPorts(pciAddr)
+	if err != nil {
+		log.Log.Error(err, "DeleteSfs(): failed to get SF ports", "device", pciAddr)
+		return err
+	}
+	managed, err := loadManagedSfs()
+	if err != nil {
+		log.Log.Error(err, "DeleteSfs(): failed to load the SFs created by the operator", "device", pciAddr)
+		return err
+	}
+	for _, p := range ports {
+		if !slices.Contains(managed[pciAddr], p.sfNumber) {
+			log.Log.V(2).Info("DeleteSfs(): skip SF not created by the operator", "device", pciAddr, "sfnum", p.sfNumber)
+			continue
+		}
+		if err := s.deletePort(pciAddr, p.port); err != nil {
+			log.Log.Error(err, "DeleteSfs(): failed to delete SF", "device", pciAddr, "sfnum", p.sfNumber)
+			return err
+		}
+		if err := setManagedSf(managed, pciAddr, p.sfNumber, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadManagedSfs returns the SF numbers of the SFs created by the config daemon by PF PCI address
+func loadManagedSfs() (map[string][]int, error) {
+	path := utils.GetHostExtensionPath(constants.ManagedSfsPath)
+	managed := map[string][]int{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return managed, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &managed); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return managed, nil
+}
+
+// setManagedSf records the SF as created or deleted by the config daemon, the record is written on each change
+// so that the SFs created before a failure are still known as managed
+func setManagedSf(managed map[string][]int, pciAddr string, sfNumber int, created bool) error {
+	if created {
+		managed[pciAddr] = append(managed[pciAddr], sfNumber)
+		slices.Sort(managed[pciAddr])
+	} else {
+		managed[pciAddr] = slices.DeleteFunc(managed[pciAddr], func(n int) bool { return n == sfNumber })
+		if len(managed[pciAddr]) == 0 {
+			delete(managed, pciAddr)
+		}
+	}
+	path := utils.GetHostExtensionPath(constants.ManagedSfsPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	data, err := json.Marshal(managed)
+	if err != nil {
+		return err
+	}
+	if err := renameio.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// deletePort deactivates the SF before deleting its port, the driver refuses to delete an active SF
+func (s *sf) deletePort(pciAddr string, port *netlink.DevlinkPort) error {
+	log.Log.V(2).Info("deletePort(): delete SF port", "device", pciAddr, "port", port.PortIndex)
+	if isPortActive(port) {
+		err := s.netlinkLib.DevlinkPortFnSet(constants.BusPci, pciAddr, port.PortIndex, netlink.DevlinkPortFnSetAttrs{
+			FnAttrs:    netlink.DevlinkPortFn{State: nl.DEVLINK_PORT_FN_STATE_INACTIVE},
+			StateValid: true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return s.netlinkLib.DevLinkPortDel(constants.BusPci, pciAddr, port.PortIndex)
+}
+
+// getSfPorts returns the devlink ports of the SFs of the PF sorted by SF number.
+// The SF number is not reported by the devlink port so it is read from the phys_port_name of the representor (pf<N>sf<M>).
+func (s *sf) getSfPorts(pciAddr string) ([]sfPort, error) {
+	ports, err := s.netlinkLib.DevLinkGetAllPortList()
+	if err != nil {
+		return nil, err
+	}
+	sfPorts := []sfPort{}
+	for _, port := range ports {
+		if port.BusName != constants.BusPci || port.DeviceName != pciAddr || port.PortFlavour != nl.DEVLINK_PORT_FLAVOUR_PCI_SF {
+			continue
+		}
+		if port.NetdeviceName == "" {
+			return nil, fmt.Errorf("SF port %d of device %s has no representor", port.PortIndex, pciAddr)
+		}
+		physPortName, err := s.networkHelper.GetPhysPortName(port.NetdeviceName)
+		if err != nil {
+			return nil, err
+		}
+		sfNumber, err := parseSfNumber(physPortName)
+		if err != nil {
+			return nil, err
+		}
+		sfPorts = append(sfPorts, sfPort{port: port, sfNumber: sfNumber})
+	}
+	sort.Slice(sfPorts, func(i, j int) bool { return sfPorts[i].sfNumber < sfPorts[j].sfNumber })
+	return sfPorts, nil
+}
+
+// getSfNetdevs returns the netdevice names of the active SFs of the PF by SF number
+func (s *sf) getSfNetdevs(pciAddr string) map[int]string {
+	netdevs := map[int]string{}
+	auxDevs, err := s.sriovnetLib.GetAuxNetDevicesFromPci(pciAddr)
+	if err != nil {
+		log.Log.V(2).Info("getSfNetdevs(): failed to get auxiliary devices", "device", pciAddr, "error", err)
+		return netdevs
+	}
+	for _, auxDev := range auxDevs {
+		sfNumber, err := s.sriovnetLib.GetSfIndexByAuxDev(auxDev)
+		if err != nil {
+			log.Log.V(2).Info("getSfNetdevs(): failed to get SF number", "auxDev", auxDev, "error", err)
+			continue
+		}
+		names, err := s.sriovnetLib.GetNetDevicesFromAux(auxDev)
+		if err != nil || len(names) == 0 {
+			log.Log.V(2).Info("getSfNetdevs(): no netdevice for SF", "auxDev", auxDev, "error", err)
+			continue
+		}
+		netdevs[sfNumber] = names[0]
+	}
+	return netdevs
+}
+
+func isPortActive(port *netlink.DevlinkPort) bool {
+	return port.Fn != nil && port.Fn.State == nl.DEVLINK_PORT_FN_STATE_ACTIVE
+}
+
+// parseSfNumber returns the SF number from the phys_port_name of a SF representor, e.g. pf0sf3
+func parseSfNumber(physPortName string) (int, error) {
+	_, sfNumber, found := strings.Cut(physPortName, "sf")
+	if !found || !strings.HasPrefix(physPortName, "pf") {
+		return 0, fmt.Errorf("unexpected phys_port_name %q for a SF representor", physPortName)
+	}
+	return strconv.Atoi(sfNumber)
+}
+
+// getPfNumber returns the PF number used to add the SFs, it is the function number of the PCI address
+func getPfNumber(pciAddr string) (uint16, error) {
+	idx := strings.LastIndex(pciAddr, ".")
+	if idx == -1 {
+		return 0, fmt.Errorf("invalid PCI address %s", pciAddr)
+	}
+	pfNumber, err := strconv.ParseUint(pciAddr[idx+1:], 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid PCI address %s: %v", pciAddr, err)
+	}
+	return uint16(pfNumber), nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sf
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"go.uber.org/mock/gomock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	netlinkMock "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink/mock"
+	sriovnetMock "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/sriovnet/mock"
+	hostMock "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/mock"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/fakefilesystem"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/helpers"
+)
+
+const testManagedSfsPath = "/host" + consts.ManagedSfsPath
+
+var _ = Describe("SF", func() {
+	var (
+		s            types.SfInterface
+		libMock      *netlinkMock.MockNetlinkLib
+		sriovnetLib  *sriovnetMock.MockSriovnetLib
+		networkMock  *hostMock.MockHostManagerInterface
+		testCtrl     *gomock.Controller
+		testErr      = fmt.Errorf("test-error")
+		activeFn     = &netlink.DevlinkPortFn{State: nl.DEVLINK_PORT_FN_STATE_ACTIVE}
+		inactiveFn   = &netlink.DevlinkPortFn{State: nl.DEVLINK_PORT_FN_STATE_INACTIVE}
+		activateFn   = netlink.DevlinkPortFnSetAttrs{FnAttrs: netlink.DevlinkPortFn{State: nl.DEVLINK_PORT_FN_STATE_ACTIVE}, StateValid: true}
+		deactivateFn = netlink.DevlinkPortFnSetAttrs{FnAttrs: netlink.DevlinkPortFn{State: nl.DEVLINK_PORT_FN_STATE_INACTIVE}, StateValid: true}
+	)
+	sfPort := func(index uint32, representor string, fn *netlink.DevlinkPortFn) *netlink.DevlinkPort {
+		return &netlink.DevlinkPort{BusName: "pci", DeviceName: "0000:d8:00.1", PortIndex: index,
+			NetdeviceName: representor, PortFlavour: nl.DEVLINK_PORT_FLAVOUR_PCI_SF, Fn: fn}
+	}
+	BeforeEach(func() {
+		testCtrl = gomock.NewController(GinkgoT())
+		libMock = netlinkMock.NewMockNetlinkLib(testCtrl)
+		sriovnetLib = sriovnetMock.NewMockSriovnetLib(testCtrl)
+		networkMock = hostMock.NewMockHostManagerInterface(testCtrl)
+		s = New(networkMock, libMock, sriovnetLib)
+		helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{})
+	})
+	// recordManagedSfs records the SFs of the PF 0000:d8:00.1 as created by the config daemon
+	recordManagedSfs := func(record string) {
+		helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+			Dirs:  []string{"/host/etc/sriov-operator"},
+			Files: map[string][]byte{testManagedSfsPath: []byte(record)},
+		})
+	}
+	AfterEach(func() {
+		testCtrl.Finish()
+	})
+	Context("ConfigureSfs", func() {
+		It("Created and activated", func() {
+			libMock.EXPECT().DevLinkGetAllPortList().Return([]*netlink.DevlinkPort{
+				// uplink and SF of another PF are ignored
+				{BusName: "pci", DeviceName: "0000:d8:00.1", PortIndex: 65535, NetdeviceName: "enp216s0f1np1", PortFlavour: nl.DEVLINK_PORT_FLAVOUR_PHYSICAL},
+				{BusName: "pci", DeviceName: "0000:d8:00.0", PortIndex: 32768, NetdeviceName: "en3f0pf0sf0", PortFlavour: nl.DEVLINK_PORT_FLAVOUR_PCI_SF},
+				sfPort(98304, "en3f1pf1sf0", activeFn),
+			}, nil)
+			networkMock.EXPECT().GetPhysPortName("en3f1pf1sf0").Return("pf1sf0", nil)
+			libMock.EXPECT().DevLinkPortAdd("pci", "0000:d8:00.1", uint16(nl.DEVLINK_PORT_FLAVOUR_PCI_SF),
+				netlink.DevLinkPortAddAttrs{PfNumber: 1, SfNumber: 1, SfNumberValid: true}).Return(sfPort(98305, "en3f1pf1sf1", inactiveFn), nil)
+			libMock.EXPECT().DevlinkPortFnSet("pci", "0000:d8:00.1", uint32(98305), activateFn).Return(nil)
+			Expect(s.ConfigureSfs("0000:d8:00.1", 2)).NotTo(HaveOccurred())
+			// the SF 0 already existed, only the SF 1 is recorded as created by the operator
+			helpers.GinkgoAssertFileContentsEquals(testManagedSfsPath, `{"0000:d8:00.1":[1]}`)
+		})
+		It("Delete the extra SFs", func() {
+			recordManagedSfs(`{"0000:d8:00.0":[0],"0000:d8:00.1":[0,1]}`)
+			libMock.EXPECT().DevLinkGetAllPortList().Return([]*netlink.DevlinkPort{
+				sfPort(98304, "en3f1pf1sf0", activeFn),
+				sfPort(98305, "en3f1pf1sf1", inactiveFn),
+			}, nil)
+			networkMock.EXPECT().GetPhysPortName("en3f1pf1sf0").Return("pf1sf0", nil)
+			networkMock.EXPECT().GetPhysPortName("en3f1pf1sf1").Return("pf1sf1", nil)
+			libMock.EXPECT().DevLinkPortDel("pci", "0000:d8:00.1", uint32(98305)).Return(nil)
+			Expect(s.ConfigureSfs("0000:d8:00.1", 1)).NotTo(HaveOccurred())
+			helpers.GinkgoAssertFileContentsEquals(testManagedSfsPath, `{"0000:d8:00.0":[0],"0000:d8:00.1":[0]}`)
+		})
+		It("Keep the extra SFs not created by the operator", func() {
+			recordManagedSfs(`{"0000:d8:00.1":[0]}`)
+			libMock.EXPECT().DevLinkGetAllPortList().Return([]*netlink.DevlinkPort{
+				sfPort(98304, "en3f1pf1sf0", activeFn),
+				sfPort(98305, "en3f1pf1sf7", activeFn),
+			}, nil)
+			networkMock.EXPECT().GetPhysPortName("en3f1pf1sf0").Return("pf1sf0", nil)
+			networkMock.EXPECT().GetPhysPortName("en3f1pf1sf7").Return("pf1sf7", nil)
+			Expect(s.ConfigureSfs("0000:d8:00.1", 1)).NotTo(HaveOccurred())
+			helpers.GinkgoAssertFileContentsEquals(testManagedSfsPath, `{"0000:d8:00.1":[0]}`)
+		})
+		It("Fail to add SF", func() {
+			libMock.EXPECT().DevLinkGetAllPortList().Return(nil, nil)
+			libMock.EXPECT().DevLinkPortAdd("pci", "0000:d8:00.1", uint16(nl.DEVLINK_PORT_FLAVOUR_PCI_SF),
+				netlink.DevLinkPortAddAttrs{PfNumber: 1, SfNumber: 0, SfNumberValid: true}).Return(nil, testErr)
+			Expect(s.ConfigureSfs("0000:d8:00.1", 1)).To(MatchError(testErr))
+		})
+		It("Fail on unexpected representor name", func() {
+			libMock.EXPECT().DevLinkGetAllPortList().Return([]*netlink.DevlinkPort{
+				sfPort(98304, "en3f1pf1sf0", activeFn),
+			}, nil)
+			networkMock.EXPECT().GetPhysPortName("en3f1pf1sf0").Return("p1", nil)
+			Expect(s.ConfigureSfs("0000:d8:00.1", 1)).To(HaveOccurred())
+		})
+	})
+	Context("DiscoverSfs", func() {
+		It("Discovered", func() {
+			mac, _ := net.ParseMAC("02:42:19:51:2f:af")
+			libMock.EXPECT().DevLinkGetAllPortList().Return([]*netlink.DevlinkPort{
+				sfPort(98305, "en3f1pf1sf1", inactiveFn),
+				sfPort(98304, "en3f1pf1sf0", &netlink.DevlinkPortFn{State: nl.DEVLINK_PORT_FN_STATE_ACTIVE, HwAddr: mac}),
+			}, nil)
+			networkMock.EXPECT().GetPhysPortName("en3f1pf1sf0").Return("pf1sf0", nil)
+			networkMock.EXPECT().GetPhysPortName("en3f1pf1sf1").Return("pf1sf1", nil)
+			sriovnetLib.EXPECT().GetAuxNetDevicesFromPci("0000:d8:00.1").Return([]string{"mlx5_core.sf.2"}, nil)
+			sriovnetLib.EXPECT().GetSfIndexByAuxDev("mlx5_core.sf.2").Return(0, nil)
+			sriovnetLib.EXPECT().GetNetDevicesFromAux("mlx5_core.sf.2").Return([]string{"enp216s0f1s0"}, nil)
+			sfs, err := s.DiscoverSfs("0000:d8:00.1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sfs).To(Equal([]sriovnetworkv1.ScalableFunction{
+				{Name: "enp216s0f1s0", Mac: "02:42:19:51:2f:af", SfNumber: 0, PortIndex: 98304, RepresentorName: "en3f1pf1sf0", State: "active"},
+				{SfNumber: 1, PortIndex: 98305, RepresentorName: "en3f1pf1sf1", State: "inactive"},
+			}))
+		})
+		It("No SFs", func() {
+			libMock.EXPECT().DevLinkGetAllPortList().Return(nil, nil)
+			sfs, err := s.DiscoverSfs("0000:d8:00.1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sfs).To(BeEmpty())
+		})
+	})
+	Context("DeleteSfs", func() {
+		It("Deactivated and deleted", func() {
+			recordManagedSfs(`{"0000:d8:00.1":[0]}`)
+			libMock.EXPECT().DevLinkGetAllPortList().Return([]*netlink.DevlinkPort{
+				sfPort(98304, "en3f1pf1sf0", activeFn),
+				sfPort(98305, "en3f1pf1sf7", activeFn),
+			}, nil)
+			networkMock.EXPECT().GetPhysPortName("en3f1pf1sf0").Return("pf1sf0", nil)
+			networkMock.EXPECT().GetPhysPortName("en3f1pf1sf7").Return("pf1sf7", nil)
+			// the SF 7 wasn't created by the operator
+			libMock.EXPECT().DevlinkPortFnSet("pci", "0000:d8:00.1", uint32(98304), deactivateFn).Return(nil)
+			libMock.EXPECT().DevLinkPortDel("pci", "0000:d8:00.1", uint32(98304)).Return(nil)
+			Expect(s.DeleteSfs("0000:d8:00.1")).NotTo(HaveOccurred())
+			helpers.GinkgoAssertFileContentsEquals(testManagedSfsPath, `{}`)
+		})
+		It("Fail to deactivate", func() {
+			recordManagedSfs(`{"0000:d8:00.1":[0]}`)
+			libMock.EXPECT().DevLinkGetAllPortList().Return([]*netlink.DevlinkPort{
+				sfPort(98304, "en3f1pf1sf0", activeFn),
+			}, nil)
+			networkMock.EXPECT().GetPhysPortName("en3f1pf1sf0").Return("pf1sf0", nil)
+			libMock.EXPECT().DevlinkPortFnSet("pci", "0000:d8:00.1", uint32(98304), deactivateFn).Return(testErr)
+			Expect(s.DeleteSfs("0000:d8:00.1")).To(MatchError(testErr))
+		})
+	})
+})
@@ -0,0 +1,80 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sriov
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
+)
+
+// kubeletCheckpoint is the part of the checkpoint of the kubelet device manager listing the allocated devices
+type kubeletCheckpoint struct {
+	Data struct {
+		PodDeviceEntries []struct {
+			ResourceName string `json:"ResourceName"`
+			// a list of device IDs, or the device IDs by NUMA node since Kubernetes 1.20
+			DeviceIDs json.RawMessage `json:"DeviceIDs"`
+		} `json:"PodDeviceEntries"`
+	} `json:"Data"`
+}
+
+// GetPodAllocatedDevices returns the devices allocated to the pods by the device plugins, read from the checkpoint
+// of the kubelet device manager. The SR-IOV device plugin uses the PCI addresses of the VFs as device IDs.
+func (s *sriov) GetPodAllocatedDevices() (map[string]bool, error) {
+	data, err := os.ReadFile(utils.GetHostExtensionPath(consts.KubeletDeviceManagerCheckpoint))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			log.Log.V(2).Info("GetPodAllocatedDevices(): no kubelet device manager checkpoint")
+			return map[string]bool{}, nil
+		}
+		return nil, fmt.Errorf("failed to read the kubelet device manager checkpoint: %v", err)
+	}
+	checkpoint := &kubeletCheckpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse the kubelet device manager checkpoint: %v", err)
+	}
+
+	allocated := map[string]bool{}
+	for _, entry := range checkpoint.Data.PodDeviceEntries {
+		if len(entry.DeviceIDs) == 0 {
+			continue
+		}
+		var deviceIDs []string
+		if err := json.Unmarshal(entry.DeviceIDs, &deviceIDs); err != nil {
+			byNuma := map[string][]string{}
+			if err := json.Unmarshal(entry.DeviceIDs, &byNuma); err != nil {
+				return nil, fmt.Errorf("unexpected device IDs of the resource %s in the kubelet device manager checkpoint: %v",
+					entry.ResourceName, err)
+			}
+			for _, ids := range byNuma {
+				deviceIDs = append(deviceIDs, ids...)
+			}
+		}
+		for _, id := range deviceIDs {
+			allocated[id] = true
+		}
+	}
+	return allocated, nil
+}
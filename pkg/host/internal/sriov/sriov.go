@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
@@ -29,11 +30,14 @@ import (
 
 	"github.com/jaypipes/ghw"
 	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/drivers"
 	dputilsPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/dputils"
 	ghwPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/ghw"
 	netlinkPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink"
@@ -48,11 +52,22 @@ const (
 	// sysfsWriteTimeout is the timeout for writing to sysfs files (e.g. sriov_numvfs).
 	// Kernel drivers can block indefinitely on these writes if the device is in a bad state.
 	sysfsWriteTimeout = 2 * time.Minute
+	// devlinkReloadTimeout is the timeout to wait for the PF netdevice after a driver reload
+	devlinkReloadTimeout = time.Minute
 )
 
+// vfLinkStates maps the VF link states of the vfAttributes to the netlink values
+var vfLinkStates = map[string]uint32{
+	sriovnetworkv1.SriovCniStateAuto:    nl.IFLA_VF_LINK_STATE_AUTO,
+	sriovnetworkv1.SriovCniStateEnable:  nl.IFLA_VF_LINK_STATE_ENABLE,
+	sriovnetworkv1.SriovCniStateDisable: nl.IFLA_VF_LINK_STATE_DISABLE,
+}
+
 type interfaceToConfigure struct {
 	Iface       sriovnetworkv1.Interface
 	IfaceStatus sriovnetworkv1.InterfaceExt
+	// IDs of the VFs whose PKey table programmed for the last applied configuration must be reset
+	VfPKeysToReset []int
 }
 
 type sriov struct {
@@ -67,6 +82,8 @@ type sriov struct {
 	sriovnetLib      sriovnetPkg.SriovnetLib
 	ghwLib           ghwPkg.GHWLib
 	bridgeHelper     types.BridgeInterface
+	sfHelper         types.SfInterface
+	bondHelper       types.BondInterface
 }
 
 func New(utilsHelper utils.CmdInterface,
@@ -79,7 +96,9 @@ func New(utilsHelper utils.CmdInterface,
 	dputilsLib dputilsPkg.DPUtilsLib,
 	sriovnetLib sriovnetPkg.SriovnetLib,
 	ghwLib ghwPkg.GHWLib,
-	bridgeHelper types.BridgeInterface) types.SriovInterface {
+	bridgeHelper types.BridgeInterface,
+	sfHelper types.SfInterface,
+	bondHelper types.BondInterface) types.SriovInterface {
 	return &sriov{utilsHelper: utilsHelper,
 		kernelHelper:     kernelHelper,
 		networkHelper:    networkHelper,
@@ -91,6 +110,8 @@ func New(utilsHelper utils.CmdInterface,
 		sriovnetLib:      sriovnetLib,
 		ghwLib:           ghwLib,
 		bridgeHelper:     bridgeHelper,
+		sfHelper:         sfHelper,
+		bondHelper:       bondHelper,
 	}
 }
 
@@ -139,6 +160,12 @@ func (s *sriov) ResetSriovDevice(ifaceStatus sriovnetworkv1.InterfaceExt) error
 		if err := s.networkHelper.SetNetdevMTU(ifaceStatus.PciAddress, mtu); err != nil {
 			return err
 		}
+		if len(ifaceStatus.SFs) > 0 {
+			log.Log.V(2).Info("ResetSriovDevice(): delete SFs", "count", len(ifaceStatus.SFs))
+			if err := s.sfHelper.DeleteSfs(ifaceStatus.PciAddress); err != nil {
+				return err
+			}
+		}
 		log.Log.V(2).Info("ResetSriovDevice(): reset eswitch mode and number of VFs", "mode", eswitchMode)
 		if err := s.setEswitchModeAndNumVFs(ifaceStatus.PciAddress, eswitchMode, 0); err != nil {
 			return err
@@ -169,6 +196,14 @@ func (s *sriov) getVfInfo(vfAddr string, pfName string, eswitchMode string, devi
 		VfID:       id,
 		VdpaType:   s.vdpaHelper.DiscoverVDPAType(vfAddr),
 	}
+	if vf.VdpaType != "" {
+		if attrs := s.vdpaHelper.DiscoverVDPAAttributes(vfAddr); attrs != nil {
+			vf.VdpaMac = attrs.Mac
+			vf.VdpaMtu = ptr.Deref(attrs.Mtu, 0)
+			vf.VdpaMaxVqp = ptr.Deref(attrs.MaxVqp, 0)
+			vf.VdpaMgmtDevice = attrs.MgmtDevice
+		}
+	}
 
 	if eswitchMode == sriovnetworkv1.ESwithModeSwitchDev {
 		repName, err := s.sriovnetLib.GetVfRepresentor(pfName, id)
@@ -198,6 +233,13 @@ func (s *sriov) getVfInfo(vfAddr string, pfName string, eswitchMode string, devi
 			break
 		}
 	}
+	// the VFs have no IOMMU group when the IOMMU is disabled
+	if group, devices, err := s.kernelHelper.GetIommuGroup(vfAddr); err == nil {
+		vf.IommuGroup = &group
+		if len(devices) > 0 {
+			vf.IommuGroupDevices = devices
+		}
+	}
 	return vf
 }
 
@@ -240,6 +282,101 @@ func (s *sriov) SetVfAdminMac(vfAddr string, pfLink, vfLink netlink.Link) error
 	return nil
 }
 
+// setVfAdminAttributes configures the administrative attributes of the VF group through the PF
+func (s *sriov) setVfAdminAttributes(vfAddr string, vfID int, group *sriovnetworkv1.VfGroup, pfLink netlink.Link) error {
+	attrs := group.VfAttributes
+	if attrs == nil {
+		return nil
+	}
+	log.Log.V(2).Info("setVfAdminAttributes()", "vf", vfAddr, "attributes", attrs)
+
+	mac, err := group.VfAdminMac(vfID)
+	if err != nil {
+		return fmt.Errorf("invalid admin MAC %s: %v", attrs.Mac, err)
+	}
+	if mac != nil {
+		if err := s.netlinkLib.LinkSetVfHardwareAddr(pfLink, vfID, mac); err != nil {
+			return fmt.Errorf("failed to set admin MAC %s: %v", mac, err)
+		}
+	}
+	if attrs.Vlan != nil {
+		qos := attrs.VlanQoS
+		if *attrs.Vlan == 0 {
+			qos = 0
+		}
+		if err := s.netlinkLib.LinkSetVfVlanQos(pfLink, vfID, *attrs.Vlan, qos); err != nil {
+			return fmt.Errorf("failed to set vlan %d qos %d: %v", *attrs.Vlan, qos, err)
+		}
+	}
+	if attrs.SpoofChk != "" {
+		if err := s.netlinkLib.LinkSetVfSpoofchk(pfLink, vfID, attrs.SpoofChk == sriovnetworkv1.SriovCniStateOn); err != nil {
+			return fmt.Errorf("failed to set spoofchk %s: %v", attrs.SpoofChk, err)
+		}
+	}
+	if attrs.Trust != "" {
+		if err := s.netlinkLib.LinkSetVfTrust(pfLink, vfID, attrs.Trust == sriovnetworkv1.SriovCniStateOn); err != nil {
+			return fmt.Errorf("failed to set trust %s: %v", attrs.Trust, err)
+		}
+	}
+	if attrs.LinkState != "" {
+		state, ok := vfLinkStates[attrs.LinkState]
+		if !ok {
+			return fmt.Errorf("unknown link state %s", attrs.LinkState)
+		}
+		if err := s.netlinkLib.LinkSetVfState(pfLink, vfID, state); err != nil {
+			return fmt.Errorf("failed to set link state %s: %v", attrs.LinkState, err)
+		}
+	}
+	if attrs.MinTxRate != nil || attrs.MaxTxRate != nil {
+		// keep the current value of the rate that is not managed
+		var minRate, maxRate int
+		for _, vf := range pfLink.Attrs().Vfs {
+			if vf.ID == vfID {
+				minRate, maxRate = int(vf.MinTxRate), int(vf.MaxTxRate)
+				break
+			}
+		}
+		if attrs.MinTxRate != nil {
+			minRate = *attrs.MinTxRate
+		}
+		if attrs.MaxTxRate != nil {
+			maxRate = *attrs.MaxTxRate
+		}
+		if err := s.netlinkLib.LinkSetVfRate(pfLink, vfID, minRate, maxRate); err != nil {
+			return fmt.Errorf("failed to set tx rate min %d max %d: %v", minRate, maxRate, err)
+		}
+	}
+	return nil
+}
+
+// setVfAdminStatus reports the administrative attributes of the VF configured on the PF
+func setVfAdminStatus(vf *sriovnetworkv1.VirtualFunction, pfVfs []netlink.VfInfo) {
+	for _, info := range pfVfs {
+		if info.ID != vf.VfID {
+			continue
+		}
+		vf.AdminMac = info.Mac.String()
+		vf.Vlan = info.Vlan
+		vf.VlanQoS = info.Qos
+		vf.SpoofChk = sriovnetworkv1.SriovCniStateOff
+		if info.Spoofchk {
+			vf.SpoofChk = sriovnetworkv1.SriovCniStateOn
+		}
+		vf.Trust = sriovnetworkv1.SriovCniStateOff
+		if info.Trust == 1 {
+			vf.Trust = sriovnetworkv1.SriovCniStateOn
+		}
+		for name, state := range vfLinkStates {
+			if state == info.LinkState {
+				vf.LinkState = name
+			}
+		}
+		vf.MinTxRate = int(info.MinTxRate)
+		vf.MaxTxRate = int(info.MaxTxRate)
+		return
+	}
+}
+
 // getDeviceClass parses the device class from the device
 func getDeviceClass(device *ghw.PCIDevice) (int64, error) {
 	devClass, err := strconv.ParseInt(device.Class.ID, 16, 64)
@@ -251,6 +388,22 @@ func getDeviceClass(device *ghw.PCIDevice) (int64, error) {
 
 func (s *sriov) DiscoverSriovDevices(storeManager store.ManagerInterface) ([]sriovnetworkv1.InterfaceExt, error) {
 	log.Log.V(2).Info("DiscoverSriovDevices")
+	return s.discoverSriovDevices(storeManager, nil)
+}
+
+// DiscoverSriovPfs returns the status of the SR-IOV capable network interfaces with the given PCI addresses,
+// the other devices of the system are not discovered
+func (s *sriov) DiscoverSriovPfs(storeManager store.ManagerInterface, pciAddresses []string) ([]sriovnetworkv1.InterfaceExt, error) {
+	log.Log.V(2).Info("DiscoverSriovPfs", "devices", pciAddresses)
+	if len(pciAddresses) == 0 {
+		return []sriovnetworkv1.InterfaceExt{}, nil
+	}
+	return s.discoverSriovDevices(storeManager, pciAddresses)
+}
+
+// discoverSriovDevices returns the SR-IOV capable network interfaces of the system,
+// only the devices with the given PCI addresses are discovered if the list isn't nil
+func (s *sriov) discoverSriovDevices(storeManager store.ManagerInterface, pciAddresses []string) ([]sriovnetworkv1.InterfaceExt, error) {
 	pfList := []sriovnetworkv1.InterfaceExt{}
 
 	pci, err := s.ghwLib.PCI()
@@ -264,6 +417,9 @@ func (s *sriov) DiscoverSriovDevices(storeManager store.ManagerInterface) ([]sri
 	}
 
 	for _, device := range devices {
+		if pciAddresses != nil && !slices.Contains(pciAddresses, device.Address) {
+			continue
+		}
 		devClass, err := getDeviceClass(device)
 		if err != nil {
 			log.Log.Error(err, "DiscoverSriovDevices(): unable to parse device class, skipping",
@@ -326,7 +482,10 @@ func (s *sriov) DiscoverSriovDevices(storeManager store.ManagerInterface) ([]sri
 			LinkSpeed:      s.networkHelper.GetNetDevLinkSpeed(pfNetName),
 			LinkAdminState: s.networkHelper.GetNetDevLinkAdminState(pfNetName),
 			AltNames:       altNames,
+			PfSettings:     s.networkHelper.GetPfSettings(pfNetName),
+			DevlinkParams:  s.networkHelper.GetDevlinkParams(device.Address),
 		}
+		s.setHardwareInfo(&iface)
 
 		pfStatus, exist, err := storeManager.LoadPfsStatus(iface.PciAddress)
 		if err != nil {
@@ -350,9 +509,21 @@ func (s *sriov) DiscoverSriovDevices(storeManager store.ManagerInterface) ([]sri
 				}
 				for _, vf := range vfs {
 					instance := s.getVfInfo(vf, pfNetName, iface.EswitchMode, devices)
+					setVfAdminStatus(&instance, link.Attrs().Vfs)
+					if strings.EqualFold(iface.LinkType, consts.LinkTypeIB) {
+						instance.PKeys = s.infinibandHelper.GetVfPKeys(vf)
+					}
 					iface.VFs = append(iface.VFs, instance)
 				}
 			}
+			if iface.EswitchMode == sriovnetworkv1.ESwithModeSwitchDev {
+				sfs, err := s.sfHelper.DiscoverSfs(device.Address)
+				if err != nil {
+					log.Log.Error(err, "DiscoverSriovDevices(): unable to discover SFs for device", "device", device.Address)
+				}
+				iface.SFs = sfs
+				iface.NumSfs = len(sfs)
+			}
 		}
 		pfList = append(pfList, iface)
 	}
@@ -429,6 +600,9 @@ func (s *sriov) configSriovPFDevice(iface *sriovnetworkv1.Interface) error {
 	if err := s.configureHWOptionsForSwitchdev(iface); err != nil {
 		return err
 	}
+	if err := s.configDevlinkParams(iface); err != nil {
+		return err
+	}
 	// remove all UDEV rules for the PF before adding new rules to
 	// make sure that rules are always in a consistent state, e.g. there is no
 	// switchdev-related rules for PF in legacy mode
@@ -450,6 +624,13 @@ func (s *sriov) configSriovPFDevice(iface *sriovnetworkv1.Interface) error {
 		log.Log.Error(err, "configSriovPFDevice(): fail to add VR representor udev rule", "device", iface.PciAddress)
 		return err
 	}
+	// SFs can be created only in switchdev mode, ConfigureSfs also removes the SFs not requested anymore
+	if sriovnetworkv1.GetEswitchModeFromSpec(iface) == sriovnetworkv1.ESwithModeSwitchDev {
+		if err := s.sfHelper.ConfigureSfs(iface.PciAddress, iface.NumSfs); err != nil {
+			log.Log.Error(err, "configSriovPFDevice(): fail to configure SFs", "device", iface.PciAddress)
+			return err
+		}
+	}
 	// set PF mtu
 	if iface.Mtu > 0 && iface.Mtu > s.networkHelper.GetNetdevMTU(iface.PciAddress) {
 		err = s.networkHelper.SetNetdevMTU(iface.PciAddress, iface.Mtu)
@@ -458,6 +639,12 @@ func (s *sriov) configSriovPFDevice(iface *sriovnetworkv1.Interface) error {
 			return err
 		}
 	}
+	if iface.PfSettings != nil {
+		if err := s.networkHelper.SetPfSettings(iface.Name, iface.PfSettings); err != nil {
+			log.Log.Error(err, "configSriovPFDevice(): fail to set ethtool settings for PF", "device", iface.PciAddress)
+			return err
+		}
+	}
 	return nil
 }
 
@@ -509,6 +696,37 @@ func (s *sriov) configureHWOptionsForSwitchdev(iface *sriovnetworkv1.Interface)
 	return nil
 }
 
+// configDevlinkParams sets the devlink parameters of the PF and reloads the driver when a driverinit
+// parameter changed. The VFs are removed before the reload, they are created again by the caller.
+// permanent parameters are only applied after a reboot that is requested by the config daemon.
+func (s *sriov) configDevlinkParams(iface *sriovnetworkv1.Interface) error {
+	if len(iface.DevlinkParams) == 0 {
+		return nil
+	}
+	needReload, err := s.networkHelper.SetDevlinkParams(iface.PciAddress, iface.DevlinkParams)
+	if err != nil {
+		log.Log.Error(err, "configDevlinkParams(): fail to set devlink parameters", "device", iface.PciAddress)
+		return err
+	}
+	if !needReload {
+		return nil
+	}
+	if s.dputilsLib.GetVFconfigured(iface.PciAddress) > 0 {
+		if err := s.setEswitchModeAndNumVFs(iface.PciAddress, sriovnetworkv1.ESwithModeLegacy, 0); err != nil {
+			log.Log.Error(err, "configDevlinkParams(): fail to remove the VFs before the driver reload", "device", iface.PciAddress)
+			return err
+		}
+	}
+	if err := s.networkHelper.ReloadDevlinkDevice(iface.PciAddress); err != nil {
+		return err
+	}
+	// the netdevice of the PF is recreated by the reload
+	return wait.PollUntilContextTimeout(context.Background(), time.Second, devlinkReloadTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			return s.networkHelper.TryGetInterfaceName(iface.PciAddress) != "", nil
+		})
+}
+
 func (s *sriov) checkExternallyManagedPF(iface *sriovnetworkv1.Interface) error {
 	log.Log.V(2).Info("checkExternallyManagedPF(): configure PF sriov device",
 		"device", iface.PciAddress)
@@ -538,7 +756,7 @@ func (s *sriov) checkExternallyManagedPF(iface *sriovnetworkv1.Interface) error
 	return nil
 }
 
-func (s *sriov) configSriovVFDevices(iface *sriovnetworkv1.Interface) error {
+func (s *sriov) configSriovVFDevices(iface *sriovnetworkv1.Interface, vfPKeysToReset []int) error {
 	log.Log.V(2).Info("configSriovVFDevices(): configure PF sriov device",
 		"device", iface.PciAddress)
 	if iface.NumVfs > 0 {
@@ -551,6 +769,12 @@ func (s *sriov) configSriovVFDevices(iface *sriovnetworkv1.Interface) error {
 			log.Log.Error(err, "configSriovVFDevices(): unable to get PF link for device", "device", iface)
 			return err
 		}
+		// LinkType is an optional field. Let's fallback to current link type
+		// if nothing is specified in the SriovNodePolicy
+		linkType := iface.LinkType
+		if linkType == "" {
+			linkType = s.GetLinkType(iface.Name)
+		}
 
 		for _, addr := range vfAddrs {
 			hasDriver, _ := s.kernelHelper.HasDriver(addr)
@@ -575,6 +799,14 @@ func (s *sriov) configSriovVFDevices(iface *sriovnetworkv1.Interface) error {
 				}
 			}
 
+			// the PKeys removed from the policy are reset on the PF whatever the VF driver is
+			if (group == nil || len(group.PKeys) == 0) && slices.Contains(vfPKeysToReset, vfID) {
+				if err := s.infinibandHelper.ResetVfPKeys(addr, iface.PciAddress); err != nil {
+					log.Log.Error(err, "configSriovVFDevices(): fail to reset VF pkeys", "device", addr)
+					return err
+				}
+			}
+
 			// VF group not found.
 			if group == nil {
 				continue
@@ -583,15 +815,9 @@ func (s *sriov) configSriovVFDevices(iface *sriovnetworkv1.Interface) error {
 			// only set GUID and MAC for VF with default driver
 			// for userspace drivers like vfio we configure the vf mac using the kernel nic mac address
 			// before we switch to the userspace driver
-			if yes, d := s.kernelHelper.HasDriver(addr); yes && !sriovnetworkv1.StringInArray(d, vars.DpdkDrivers) {
-				// LinkType is an optional field. Let's fallback to current link type
-				// if nothing is specified in the SriovNodePolicy
-				linkType := iface.LinkType
-				if linkType == "" {
-					linkType = s.GetLinkType(iface.Name)
-				}
+			if yes, d := s.kernelHelper.HasDriver(addr); yes && !drivers.IsUserspaceDriver(d) {
 				if strings.EqualFold(linkType, consts.LinkTypeIB) {
-					if err := s.infinibandHelper.ConfigureVfGUID(addr, iface.PciAddress, vfID, pfLink); err != nil {
+					if err := s.infinibandHelper.ConfigureVfGUID(addr, iface.PciAddress, vfID, pfLink, iface.IbGUIDAllocation); err != nil {
 						return err
 					}
 					if err := s.kernelHelper.Unbind(addr); err != nil {
@@ -614,13 +840,29 @@ func (s *sriov) configSriovVFDevices(iface *sriovnetworkv1.Interface) error {
 							return err
 						}
 					}
-					if err = s.SetVfAdminMac(addr, pfLink, vfLink); err != nil {
-						log.Log.Error(err, "configSriovVFDevices(): fail to configure VF admin mac", "device", addr)
-						return err
+					// the admin mac from the vfAttributes is configured with the other VF attributes
+					if group.VfAttributes == nil || group.VfAttributes.Mac == "" {
+						if err = s.SetVfAdminMac(addr, pfLink, vfLink); err != nil {
+							log.Log.Error(err, "configSriovVFDevices(): fail to configure VF admin mac", "device", addr)
+							return err
+						}
 					}
 				}
 			}
 
+			// the administrative attributes and the PKeys are configured on the PF whatever the VF driver is
+			if !strings.EqualFold(linkType, consts.LinkTypeIB) {
+				if err := s.setVfAdminAttributes(addr, vfID, group, pfLink); err != nil {
+					log.Log.Error(err, "configSriovVFDevices(): fail to configure VF admin attributes", "device", addr)
+					return err
+				}
+			} else if len(group.PKeys) > 0 {
+				if err := s.infinibandHelper.ConfigureVfPKeys(addr, iface.PciAddress, group.PKeys); err != nil {
+					log.Log.Error(err, "configSriovVFDevices(): fail to configure VF pkeys", "device", addr)
+					return err
+				}
+			}
+
 			if err = s.kernelHelper.UnbindDriverIfNeeded(addr, group.IsRdma); err != nil {
 				return err
 			}
@@ -634,7 +876,7 @@ func (s *sriov) configSriovVFDevices(iface *sriovnetworkv1.Interface) error {
 					return err
 				}
 			}
-			if !sriovnetworkv1.StringInArray(group.DeviceType, vars.DpdkDrivers) {
+			if !drivers.IsUserspaceDeviceType(group.DeviceType) {
 				if err := s.kernelHelper.BindDefaultDriver(addr); err != nil {
 					log.Log.Error(err, "configSriovVFDevices(): fail to bind default driver for device", "device", addr)
 					return err
@@ -647,16 +889,22 @@ func (s *sriov) configSriovVFDevices(iface *sriovnetworkv1.Interface) error {
 					}
 				}
 				if sriovnetworkv1.GetEswitchModeFromSpec(iface) == sriovnetworkv1.ESwithModeSwitchDev && group.VdpaType != "" {
-					if err := s.vdpaHelper.CreateVDPADevice(addr, group.VdpaType); err != nil {
+					vdpaAttrs, err := group.VdpaDeviceAttributes(vfID)
+					if err != nil {
+						log.Log.Error(err, "configSriovVFDevices(): invalid VDPA attributes", "device", addr)
+						return err
+					}
+					if err := s.vdpaHelper.CreateVDPADevice(addr, group.VdpaType, vdpaAttrs); err != nil {
 						log.Log.Error(err, "configSriovVFDevices(): fail to create VDPA device",
 							"vdpaType", group.VdpaType, "device", addr)
 						return err
 					}
 				}
 			} else {
-				if err := s.kernelHelper.BindDpdkDriver(addr, group.DeviceType); err != nil {
+				driver := drivers.GetDriver(group.DeviceType)
+				if err := s.kernelHelper.BindDpdkDriver(addr, driver); err != nil {
 					log.Log.Error(err, "configSriovVFDevices(): fail to bind driver for device",
-						"driver", group.DeviceType, "device", addr)
+						"driver", driver, "device", addr)
 					return err
 				}
 			}
@@ -665,7 +913,7 @@ func (s *sriov) configSriovVFDevices(iface *sriovnetworkv1.Interface) error {
 	return nil
 }
 
-func (s *sriov) configSriovDevice(iface *sriovnetworkv1.Interface, skipVFConfiguration bool) error {
+func (s *sriov) configSriovDevice(iface *sriovnetworkv1.Interface, vfPKeysToReset []int, skipVFConfiguration bool) error {
 	log.Log.V(2).Info("configSriovDevice(): configure sriov device",
 		"device", iface.PciAddress, "config", iface, "skipVFConfiguration", skipVFConfiguration)
 	if !iface.ExternallyManaged {
@@ -690,7 +938,7 @@ func (s *sriov) configSriovDevice(iface *sriovnetworkv1.Interface, skipVFConfigu
 			return err
 		}
 	}
-	if err := s.configSriovVFDevices(iface); err != nil {
+	if err := s.configSriovVFDevices(iface, vfPKeysToReset); err != nil {
 		return err
 	}
 	// Set PF link up
@@ -724,6 +972,16 @@ func (s *sriov) ConfigSriovInterfaces(storeManager store.ManagerInterface,
 		log.Log.Error(err, "cannot configure sriov interfaces")
 		return fmt.Errorf("cannot configure sriov interfaces")
 	}
+	if err := s.configPfSettingsLive(interfaces, ifaceStatuses, toBeConfigured); err != nil {
+		log.Log.Error(err, "cannot configure the PF settings")
+		return fmt.Errorf("cannot configure the PF settings")
+	}
+	if !skipVFConfiguration {
+		if err := s.configVfAttributesLive(interfaces, ifaceStatuses, toBeConfigured); err != nil {
+			log.Log.Error(err, "cannot configure the VF attributes")
+			return fmt.Errorf("cannot configure the VF attributes")
+		}
+	}
 	if sriovnetworkv1.ContainsSwitchdevInterface(interfaces) && len(toBeConfigured) > 0 {
 		// for switchdev devices we create udev rule that renames VF representors
 		// after VFs are created. Reload rules to update interfaces
@@ -745,6 +1003,76 @@ func (s *sriov) ConfigSriovInterfaces(storeManager store.ManagerInterface,
 	return nil
 }
 
+// configPfSettingsLive applies the ethtool settings of the PFs which need no other change, they are applied
+// without reconfiguring the VFs
+func (s *sriov) configPfSettingsLive(interfaces []sriovnetworkv1.Interface, ifaceStatuses []sriovnetworkv1.InterfaceExt,
+	toBeConfigured []interfaceToConfigure) error {
+	for i := range interfaces {
+		iface := &interfaces[i]
+		if iface.PfSettings == nil ||
+			slices.ContainsFunc(toBeConfigured, func(c interfaceToConfigure) bool { return c.Iface.PciAddress == iface.PciAddress }) {
+			continue
+		}
+		idx := slices.IndexFunc(ifaceStatuses, func(st sriovnetworkv1.InterfaceExt) bool { return st.PciAddress == iface.PciAddress })
+		if idx < 0 || !sriovnetworkv1.NeedToUpdatePfSettings(iface.PfSettings, ifaceStatuses[idx].PfSettings) {
+			continue
+		}
+		log.Log.Info("configPfSettingsLive(): configure ethtool settings of the PF", "device", iface.PciAddress)
+		if err := s.networkHelper.SetPfSettings(iface.Name, iface.PfSettings); err != nil {
+			log.Log.Error(err, "configPfSettingsLive(): fail to set ethtool settings for PF", "device", iface.PciAddress)
+			return err
+		}
+	}
+	return nil
+}
+
+// configVfAttributesLive applies the administrative attributes of the VFs of the PFs which need no other change,
+// they are configured through the PF without disrupting the VFs. The VFs allocated to a pod are skipped.
+func (s *sriov) configVfAttributesLive(interfaces []sriovnetworkv1.Interface, ifaceStatuses []sriovnetworkv1.InterfaceExt,
+	toBeConfigured []interfaceToConfigure) error {
+	var allocated map[string]bool
+	for i := range interfaces {
+		iface := &interfaces[i]
+		if !slices.ContainsFunc(iface.VfGroups, func(g sriovnetworkv1.VfGroup) bool { return g.VfAttributes != nil }) ||
+			slices.ContainsFunc(toBeConfigured, func(c interfaceToConfigure) bool { return c.Iface.PciAddress == iface.PciAddress }) {
+			continue
+		}
+		idx := slices.IndexFunc(ifaceStatuses, func(st sriovnetworkv1.InterfaceExt) bool { return st.PciAddress == iface.PciAddress })
+		if idx < 0 {
+			continue
+		}
+		if allocated == nil {
+			var err error
+			if allocated, err = s.GetPodAllocatedDevices(); err != nil {
+				return err
+			}
+		}
+		vfs := sriovnetworkv1.GetVfsToUpdateAttributes(iface, &ifaceStatuses[idx], allocated)
+		if len(vfs) == 0 {
+			continue
+		}
+		pfLink, err := s.netlinkLib.LinkByName(iface.Name)
+		if err != nil {
+			log.Log.Error(err, "configVfAttributesLive(): unable to get PF link for device", "device", iface.PciAddress)
+			return err
+		}
+		for _, vf := range vfs {
+			for g := range iface.VfGroups {
+				if !sriovnetworkv1.IndexInRange(vf.VfID, iface.VfGroups[g].VfRange) {
+					continue
+				}
+				log.Log.Info("configVfAttributesLive(): configure VF admin attributes", "device", vf.PciAddress)
+				if err := s.setVfAdminAttributes(vf.PciAddress, vf.VfID, &iface.VfGroups[g], pfLink); err != nil {
+					log.Log.Error(err, "configVfAttributesLive(): fail to configure VF admin attributes", "device", vf.PciAddress)
+					return err
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
+
 func (s *sriov) getConfigureAndReset(storeManager store.ManagerInterface, interfaces []sriovnetworkv1.Interface,
 	ifaceStatuses []sriovnetworkv1.InterfaceExt) ([]interfaceToConfigure, []sriovnetworkv1.InterfaceExt, error) {
 	toBeConfigured := []interfaceToConfigure{}
@@ -754,17 +1082,25 @@ func (s *sriov) getConfigureAndReset(storeManager store.ManagerInterface, interf
 		for _, iface := range interfaces {
 			if iface.PciAddress == ifaceStatus.PciAddress {
 				configured = true
-				skip, err := skipSriovConfig(&iface, &ifaceStatus, storeManager)
+				// must be loaded before skipSriovConfig saves the desired config as the last applied one
+				vfPKeysToReset, err := getVfPKeysToReset(&iface, &ifaceStatus, storeManager)
 				if err != nil {
-					log.Log.Error(err, "getConfigureAndReset(): failed to check interface")
+					log.Log.Error(err, "getConfigureAndReset(): failed to check the VF pkeys of the interface")
 					return nil, nil, err
 				}
-				if skip {
-					break
+				if len(vfPKeysToReset) == 0 {
+					skip, err := skipSriovConfig(&iface, &ifaceStatus, storeManager)
+					if err != nil {
+						log.Log.Error(err, "getConfigureAndReset(): failed to check interface")
+						return nil, nil, err
+					}
+					if skip {
+						break
+					}
 				}
 				iface := iface
 				ifaceStatus := ifaceStatus
-				toBeConfigured = append(toBeConfigured, interfaceToConfigure{Iface: iface, IfaceStatus: ifaceStatus})
+				toBeConfigured = append(toBeConfigured, interfaceToConfigure{Iface: iface, IfaceStatus: ifaceStatus, VfPKeysToReset: vfPKeysToReset})
 			}
 		}
 
@@ -785,7 +1121,7 @@ func (s *sriov) configSriovInterfacesInParallel(storeManager store.ManagerInterf
 		interfacesToConfigure += 1
 		go func(iface *interfaceToConfigure) {
 			var err error
-			if err = s.configSriovDevice(&iface.Iface, skipVFConfiguration); err != nil {
+			if err = s.configSriovDevice(&iface.Iface, iface.VfPKeysToReset, skipVFConfiguration); err != nil {
 				log.Log.Error(err, "configSriovInterfacesInParallel(): fail to configure sriov interface. resetting interface.", "address", iface.Iface.PciAddress)
 				if iface.Iface.ExternallyManaged {
 					log.Log.V(2).Info("configSriovInterfacesInParallel(): skipping device reset as the nic is marked as externally created")
@@ -849,7 +1185,7 @@ func (s *sriov) resetSriovInterfacesInParallel(storeManager store.ManagerInterfa
 func (s *sriov) configSriovInterfaces(storeManager store.ManagerInterface, interfaces []interfaceToConfigure, skipVFConfiguration bool) error {
 	log.Log.V(2).Info("configSriovInterfaces(): start sriov configuration")
 	for _, iface := range interfaces {
-		if err := s.configSriovDevice(&iface.Iface, skipVFConfiguration); err != nil {
+		if err := s.configSriovDevice(&iface.Iface, iface.VfPKeysToReset, skipVFConfiguration); err != nil {
 			log.Log.Error(err, "configSriovInterfaces(): fail to configure sriov interface. resetting interface.", "address", iface.Iface.PciAddress)
 			if iface.Iface.ExternallyManaged {
 				log.Log.V(2).Info("configSriovInterfaces(): skipping device reset as the nic is marked as externally created")
@@ -885,7 +1221,8 @@ func (s *sriov) resetSriovInterfaces(storeManager store.ManagerInterface, interf
 
 // / skipSriovConfig checks if we need to apply SR-IOV configuration specified specific interface
 func skipSriovConfig(iface *sriovnetworkv1.Interface, ifaceStatus *sriovnetworkv1.InterfaceExt, storeManager store.ManagerInterface) (bool, error) {
-	if !sriovnetworkv1.NeedToUpdateSriov(iface, ifaceStatus) {
+	if !sriovnetworkv1.NeedToUpdateSriov(iface, ifaceStatus) &&
+		sriovnetworkv1.GetDevlinkParamsAction(iface.DevlinkParams, ifaceStatus.DevlinkParams) == sriovnetworkv1.DevlinkParamsActionNone {
 		log.Log.V(2).Info("ConfigSriovInterfaces(): no need update interface", "address", iface.PciAddress)
 
 		// Save the PF status to the host
@@ -900,6 +1237,42 @@ func skipSriovConfig(iface *sriovnetworkv1.Interface, ifaceStatus *sriovnetworkv
 	return false, nil
 }
 
+// getVfPKeysToReset returns the IDs of the VFs of an InfiniBand PF which had PKeys in the last applied configuration
+// and have none in the desired one, their PKey table must be reset as the operator doesn't manage it anymore
+func getVfPKeysToReset(iface *sriovnetworkv1.Interface, ifaceStatus *sriovnetworkv1.InterfaceExt, storeManager store.ManagerInterface) ([]int, error) {
+	if !strings.EqualFold(iface.LinkType, consts.LinkTypeIB) && !strings.EqualFold(ifaceStatus.LinkType, consts.LinkTypeIB) {
+		return nil, nil
+	}
+	lastApplied, exist, err := storeManager.LoadPfsStatus(iface.PciAddress)
+	if err != nil || !exist {
+		return nil, err
+	}
+	var vfIDs []int
+	for vfID := 0; vfID < iface.NumVfs; vfID++ {
+		programmed := false
+		for _, group := range lastApplied.VfGroups {
+			if len(group.PKeys) > 0 && sriovnetworkv1.IndexInRange(vfID, group.VfRange) {
+				programmed = true
+				break
+			}
+		}
+		if !programmed {
+			continue
+		}
+		managed := false
+		for _, group := range iface.VfGroups {
+			if len(group.PKeys) > 0 && sriovnetworkv1.IndexInRange(vfID, group.VfRange) {
+				managed = true
+				break
+			}
+		}
+		if !managed {
+			vfIDs = append(vfIDs, vfID)
+		}
+	}
+	return vfIDs, nil
+}
+
 func (s *sriov) checkForConfigAndReset(ifaceStatus sriovnetworkv1.InterfaceExt, storeManager store.ManagerInterface) error {
 	// load the PF info
 	pfStatus, exist, err := storeManager.LoadPfsStatus(ifaceStatus.PciAddress)
@@ -934,7 +1307,7 @@ func (s *sriov) checkForConfigAndReset(ifaceStatus sriovnetworkv1.InterfaceExt,
 		return err
 	}
 
-	if ifaceStatus.NumVfs > 0 {
+	if ifaceStatus.NumVfs > 0 || ifaceStatus.NumSfs > 0 {
 		if err = s.ResetSriovDevice(ifaceStatus); err != nil {
 			return err
 		}
@@ -983,9 +1356,9 @@ func (s *sriov) ConfigSriovDevicesVirtual(storeManager store.ManagerInterface, i
 			}
 
 			// check if we need to bind to a DPDK driver
-			if sriovnetworkv1.StringInArray(group.DeviceType, vars.DpdkDrivers) {
-				log.Log.V(2).Info("ConfigSriovDeviceVirtual()", "driver", group.DeviceType)
-				driver = group.DeviceType
+			if drivers.IsUserspaceDeviceType(group.DeviceType) {
+				driver = drivers.GetDriver(group.DeviceType)
+				log.Log.V(2).Info("ConfigSriovDeviceVirtual()", "driver", driver)
 			}
 
 			if driver == "" {
@@ -1275,6 +1648,16 @@ func (s *sriov) setEswitchModeAndNumVFsMlx(pciAddr string, desiredEswitchMode st
 		if err := s.detachPFFromBridge(pciAddr); err != nil {
 			return err
 		}
+		// VF-LAG can't be active while the eSwitch mode changes, the bond is restored by ConfigureBonds
+		if err := s.bondHelper.DetachPfFromManagedBond(pciAddr); err != nil {
+			log.Log.Error(err, "setEswitchModeAndNumVFsMlx(): failed to detach PF from the bond", "device", pciAddr, "mode", desiredEswitchMode)
+			return err
+		}
+		// the eSwitch mode can't be changed while SFs exist, they are created again by configSriovPFDevice
+		if err := s.sfHelper.DeleteSfs(pciAddr); err != nil {
+			log.Log.Error(err, "setEswitchModeAndNumVFsMlx(): failed to delete SFs", "device", pciAddr, "mode", desiredEswitchMode)
+			return err
+		}
 		if err := s.unbindAllVFsOnPF(pciAddr); err != nil {
 			log.Log.Error(err, "setEswitchModeAndNumVFsMlx(): failed to unbind VFs", "device", pciAddr, "mode", desiredEswitchMode)
 			return err
@@ -1311,6 +1694,11 @@ func (s *sriov) setEswitchModeAndNumVFsIce(pciAddr string, desiredEswitchMode st
 		"device", pciAddr, "count", numVFs, "mode", desiredEswitchMode)
 
 	if s.GetNicSriovMode(pciAddr) != desiredEswitchMode {
+		if desiredEswitchMode == sriovnetworkv1.ESwithModeSwitchDev {
+			if err := s.ValidateIceSwitchdevSupport(pciAddr); err != nil {
+				return err
+			}
+		}
 		if err := s.SetSriovNumVfs(pciAddr, 0); err != nil {
 			return err
 		}
@@ -1327,6 +1715,132 @@ func (s *sriov) setEswitchModeAndNumVFsIce(pciAddr string, desiredEswitchMode st
 	return nil
 }
 
+// ValidateIceSwitchdevSupport returns an error if the driver or the NVM of the E810 PF don't support the switchdev mode
+func (s *sriov) ValidateIceSwitchdevSupport(pciAddr string) error {
+	info, err := s.networkHelper.GetDevlinkDeviceInfo(pciAddr)
+	if err != nil {
+		return fmt.Errorf("failed to read the devlink info of device %s: %w", pciAddr, err)
+	}
+	if info.Driver != consts.IceDriverName {
+		return fmt.Errorf("switchdev mode is not supported by driver %s of device %s", info.Driver, pciAddr)
+	}
+	supported, err := isNvmVersionAtLeast(info.FwPsidAPI, consts.IceSwitchdevMinNvmVersion)
+	if err != nil {
+		return fmt.Errorf("failed to check the NVM version of device %s: %w", pciAddr, err)
+	}
+	if !supported {
+		return fmt.Errorf("switchdev mode requires NVM version %s or newer, device %s has NVM version %s",
+			consts.IceSwitchdevMinNvmVersion, pciAddr, info.FwPsidAPI)
+	}
+	// the eswitch attributes are not reported by the versions of the driver without switchdev support
+	dev, err := s.netlinkLib.DevLinkGetDeviceByName(consts.BusPci, pciAddr)
+	if err != nil {
+		return fmt.Errorf("failed to get the devlink device %s: %w", pciAddr, err)
+	}
+	if dev.Attrs.Eswitch.Mode == "" {
+		return fmt.Errorf("the ice driver of device %s doesn't support eswitch modes", pciAddr)
+	}
+	return nil
+}
+
+// setHardwareInfo sets the NUMA node, PCIe link, slot, driver and firmware details of the PF,
+// and the NVM version and the active DDP package reported by devlink for the Intel PFs
+func (s *sriov) setHardwareInfo(iface *sriovnetworkv1.InterfaceExt) {
+	devicePath := filepath.Join(vars.FilesystemRoot, consts.SysBusPciDevices, iface.PciAddress)
+	// the kernel reports -1 when the platform has no NUMA information
+	if numaNode, err := readSysfsInt(filepath.Join(devicePath, "numa_node")); err == nil && numaNode >= 0 {
+		iface.NumaNode = &numaNode
+	}
+	if width, err := readSysfsInt(filepath.Join(devicePath, "current_link_width")); err == nil {
+		iface.PcieLinkWidth = width
+	}
+	if speed, err := os.ReadFile(filepath.Join(devicePath, "current_link_speed")); err == nil {
+		iface.PcieLinkSpeed = strings.TrimSpace(string(speed))
+	}
+	iface.PhysicalSlot = getPhysicalSlot(iface.PciAddress)
+
+	driverVersion, firmwareVersion, err := s.networkHelper.GetNetDevDriverInfo(iface.Name)
+	if err != nil {
+		log.Log.V(2).Info("setHardwareInfo(): driver info not available", "device", iface.PciAddress, "reason", err.Error())
+	}
+	iface.DriverVersion = driverVersion
+	iface.FirmwareVersion = firmwareVersion
+
+	info, err := s.networkHelper.GetDevlinkDeviceInfo(iface.PciAddress)
+	if err != nil {
+		log.Log.V(2).Info("setHardwareInfo(): devlink info not available", "device", iface.PciAddress, "reason", err.Error())
+		return
+	}
+	iface.SerialNumber = info.SerialNumber
+	if iface.FirmwareVersion == "" {
+		iface.FirmwareVersion = info.FwMgmt
+	}
+	if iface.Vendor == consts.IntelVendorID {
+		iface.NvmVersion = info.FwPsidAPI
+		// ice reports the DDP package as the firmware application
+		if info.FwAppName != "" {
+			iface.DdpPackage = strings.TrimSpace(info.FwAppName + " " + info.FwApp)
+		}
+	}
+}
+
+// getPhysicalSlot returns the name of the physical slot of the device, the slots report
+// the address of the devices they hold without the function number
+func getPhysicalSlot(pciAddr string) string {
+	idx := strings.LastIndex(pciAddr, ".")
+	if idx == -1 {
+		return ""
+	}
+	slotsPath := filepath.Join(vars.FilesystemRoot, consts.SysBusPciSlots)
+	slots, err := os.ReadDir(slotsPath)
+	if err != nil {
+		return ""
+	}
+	for _, slot := range slots {
+		address, err := os.ReadFile(filepath.Join(slotsPath, slot.Name(), "address"))
+		if err == nil && strings.TrimSpace(string(address)) == pciAddr[:idx] {
+			return slot.Name()
+		}
+	}
+	return ""
+}
+
+func readSysfsInt(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+// isNvmVersionAtLeast compares the Intel NVM versions formatted as <major>.<minor> in hexadecimal
+func isNvmVersionAtLeast(version, minVersion string) (bool, error) {
+	parse := func(v string) ([2]uint64, error) {
+		major, minor, found := strings.Cut(v, ".")
+		if !found {
+			return [2]uint64{}, fmt.Errorf("invalid NVM version %q", v)
+		}
+		majorNum, err := strconv.ParseUint(major, 16, 16)
+		if err != nil {
+			return [2]uint64{}, fmt.Errorf("invalid NVM version %q: %w", v, err)
+		}
+		minorNum, err := strconv.ParseUint(minor, 16, 16)
+		if err != nil {
+			return [2]uint64{}, fmt.Errorf("invalid NVM version %q: %w", v, err)
+		}
+		return [2]uint64{majorNum, minorNum}, nil
+	}
+	current, err := parse(version)
+	if err != nil {
+		return false, err
+	}
+	required, err := parse(minVersion)
+	if err != nil {
+		return false, err
+	}
+	return current[0] > required[0] || (current[0] == required[0] && current[1] >= required[1]), nil
+}
+
 // detach PF from the managed bridge
 func (s *sriov) detachPFFromBridge(pciAddr string) error {
 	log.Log.V(2).Info("detachPFFromBridge(): detach PF", "device", pciAddr)
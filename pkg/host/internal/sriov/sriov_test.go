@@ -25,7 +25,9 @@ import (
 	"github.com/jaypipes/ghw/pkg/pci"
 	"github.com/jaypipes/pcidb"
 	"github.com/vishvananda/netlink"
+	netlinkNlPkg "github.com/vishvananda/netlink/nl"
 	"go.uber.org/mock/gomock"
+	"k8s.io/utils/ptr"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -66,7 +68,7 @@ var _ = Describe("SRIOV", func() {
 		hostMock = hostMockPkg.NewMockHostManagerInterface(testCtrl)
 		storeManagerMode = hostStoreMockPkg.NewMockManagerInterface(testCtrl)
 
-		s = New(nil, hostMock, hostMock, hostMock, hostMock, hostMock, netlinkLibMock, dputilsLibMock, sriovnetLibMock, ghwLibMock, hostMock)
+		s = New(nil, hostMock, hostMock, hostMock, hostMock, hostMock, netlinkLibMock, dputilsLibMock, sriovnetLibMock, ghwLibMock, hostMock, hostMock, hostMock)
 	})
 
 	AfterEach(func() {
@@ -85,6 +87,16 @@ var _ = Describe("SRIOV", func() {
 		})
 
 		It("discovered", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{"/sys/bus/pci/devices/0000:d8:00.0", "/sys/bus/pci/slots/4", "/sys/bus/pci/slots/7"},
+				Files: map[string][]byte{
+					"/sys/bus/pci/devices/0000:d8:00.0/numa_node":          []byte("1\n"),
+					"/sys/bus/pci/devices/0000:d8:00.0/current_link_width": []byte("16\n"),
+					"/sys/bus/pci/devices/0000:d8:00.0/current_link_speed": []byte("16.0 GT/s PCIe\n"),
+					"/sys/bus/pci/slots/4/address":                         []byte("0000:3b:00\n"),
+					"/sys/bus/pci/slots/7/address":                         []byte("0000:d8:00\n"),
+				},
+			})
 			ghwLibMock.EXPECT().PCI().Return(getTestPCIDevices(), nil)
 			dputilsLibMock.EXPECT().IsSriovVF("0000:d8:00.0").Return(false)
 			dputilsLibMock.EXPECT().IsSriovVF("0000:d8:00.2").Return(true)
@@ -117,6 +129,7 @@ var _ = Describe("SRIOV", func() {
 			dputilsLibMock.EXPECT().GetDriverName("0000:d8:00.2").Return("mlx5_core", nil)
 			dputilsLibMock.EXPECT().GetVFID("0000:d8:00.2").Return(0, nil)
 			hostMock.EXPECT().DiscoverVDPAType("0000:d8:00.2").Return("")
+			hostMock.EXPECT().GetIommuGroup("0000:d8:00.2").Return(42, []string{}, nil)
 
 			hostMock.EXPECT().TryGetInterfaceName("0000:d8:00.2").Return("enp216s0f0v0")
 			vfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
@@ -129,6 +142,14 @@ var _ = Describe("SRIOV", func() {
 			}).MinTimes(1)
 
 			sriovnetLibMock.EXPECT().GetVfRepresentor("enp216s0f0np0", 0).Return("enp216s0f0np0_0", nil)
+			hostMock.EXPECT().GetPfSettings("enp216s0f0np0").Return(&sriovnetworkv1.PfSettings{FecMode: "rs"})
+			hostMock.EXPECT().GetDevlinkParams("0000:d8:00.0").Return([]sriovnetworkv1.DevlinkParamStatus{
+				{Name: "flow_steering_mode", Cmode: "runtime", Value: "dmfs"}})
+			hostMock.EXPECT().DiscoverSfs("0000:d8:00.0").Return([]sriovnetworkv1.ScalableFunction{{
+				Name: "enp216s0f0s0", SfNumber: 0, PortIndex: 32768, RepresentorName: "en3f0pf0sf0", State: "active"}}, nil)
+			hostMock.EXPECT().GetNetDevDriverInfo("enp216s0f0np0").Return("6.8.0-45-generic", "", nil)
+			hostMock.EXPECT().GetDevlinkDeviceInfo("0000:d8:00.0").Return(&netlink.DevlinkDeviceInfo{
+				Driver: "mlx5_core", SerialNumber: "MT2146T00TJ8", FwMgmt: "22.39.1002"}, nil)
 
 			ret, err := s.DiscoverSriovDevices(storeManagerMode)
 			Expect(err).NotTo(HaveOccurred())
@@ -149,6 +170,15 @@ var _ = Describe("SRIOV", func() {
 				ExternallyManaged: false,
 				TotalVfs:          1,
 				AltNames:          []string{"alt-enp216s0f0np0", "pf0"},
+				PfSettings:        &sriovnetworkv1.PfSettings{FecMode: "rs"},
+				DevlinkParams:     []sriovnetworkv1.DevlinkParamStatus{{Name: "flow_steering_mode", Cmode: "runtime", Value: "dmfs"}},
+				NumaNode:          ptr.To(1),
+				PcieLinkWidth:     16,
+				PcieLinkSpeed:     "16.0 GT/s PCIe",
+				DriverVersion:     "6.8.0-45-generic",
+				FirmwareVersion:   "22.39.1002",
+				SerialNumber:      "MT2146T00TJ8",
+				PhysicalSlot:      "7",
 				VFs: []sriovnetworkv1.VirtualFunction{{
 					Name:            "enp216s0f0v0",
 					Mac:             "4e:fd:3d:08:59:b1",
@@ -160,11 +190,30 @@ var _ = Describe("SRIOV", func() {
 					VfID:            0,
 					RepresentorName: "enp216s0f0np0_0",
 					GUID:            "guid1",
+					IommuGroup:      ptr.To(42),
 				}},
+				NumSfs: 1,
+				SFs: []sriovnetworkv1.ScalableFunction{{
+					Name: "enp216s0f0s0", SfNumber: 0, PortIndex: 32768, RepresentorName: "en3f0pf0sf0", State: "active"}},
 			}))
 		})
 	})
 
+	Context("DiscoverSriovPfs", func() {
+		It("should discover only the given PFs", func() {
+			// the other devices are not read, the mocks fail on unexpected calls
+			ghwLibMock.EXPECT().PCI().Return(getTestPCIDevices(), nil)
+			ret, err := s.DiscoverSriovPfs(storeManagerMode, []string{"0000:af:00.0"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ret).To(BeEmpty())
+		})
+		It("should not read the PCI devices without PF", func() {
+			ret, err := s.DiscoverSriovPfs(storeManagerMode, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ret).To(BeEmpty())
+		})
+	})
+
 	Context("SetSriovNumVfs", func() {
 		It("set", func() {
 			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
@@ -225,6 +274,81 @@ var _ = Describe("SRIOV", func() {
 		})
 	})
 
+	Context("ValidateIceSwitchdevSupport", func() {
+		It("supported", func() {
+			hostMock.EXPECT().GetDevlinkDeviceInfo("0000:d8:00.0").Return(&netlink.DevlinkDeviceInfo{Driver: "ice", FwPsidAPI: "4.40"}, nil)
+			netlinkLibMock.EXPECT().DevLinkGetDeviceByName("pci", "0000:d8:00.0").Return(
+				&netlink.DevlinkDevice{Attrs: netlink.DevlinkDevAttrs{Eswitch: netlink.DevlinkDevEswitchAttr{Mode: "legacy"}}}, nil)
+			Expect(s.ValidateIceSwitchdevSupport("0000:d8:00.0")).NotTo(HaveOccurred())
+		})
+		It("old NVM", func() {
+			hostMock.EXPECT().GetDevlinkDeviceInfo("0000:d8:00.0").Return(&netlink.DevlinkDeviceInfo{Driver: "ice", FwPsidAPI: "3.20"}, nil)
+			Expect(s.ValidateIceSwitchdevSupport("0000:d8:00.0")).To(MatchError(ContainSubstring("requires NVM version 4.00")))
+		})
+		It("driver without eswitch support", func() {
+			hostMock.EXPECT().GetDevlinkDeviceInfo("0000:d8:00.0").Return(&netlink.DevlinkDeviceInfo{Driver: "ice", FwPsidAPI: "4.0a"}, nil)
+			netlinkLibMock.EXPECT().DevLinkGetDeviceByName("pci", "0000:d8:00.0").Return(&netlink.DevlinkDevice{}, nil)
+			Expect(s.ValidateIceSwitchdevSupport("0000:d8:00.0")).To(MatchError(ContainSubstring("doesn't support eswitch modes")))
+		})
+		It("devlink info not supported", func() {
+			hostMock.EXPECT().GetDevlinkDeviceInfo("0000:d8:00.0").Return(nil, syscall.EOPNOTSUPP)
+			Expect(s.ValidateIceSwitchdevSupport("0000:d8:00.0")).To(MatchError(syscall.EOPNOTSUPP))
+		})
+	})
+
+	Context("configDevlinkParams", func() {
+		var iface *sriovnetworkv1.Interface
+		BeforeEach(func() {
+			iface = &sriovnetworkv1.Interface{PciAddress: "0000:d8:00.0", NumVfs: 2, DevlinkParams: map[string]sriovnetworkv1.DevlinkParam{
+				"flow_steering_mode": {Value: "dmfs"}, "enable_roce": {Value: "false", Cmode: "driverinit"}}}
+		})
+		It("should not reload the driver when only runtime parameters changed", func() {
+			hostMock.EXPECT().SetDevlinkParams("0000:d8:00.0", iface.DevlinkParams).Return(false, nil)
+			Expect(s.(*sriov).configDevlinkParams(iface)).NotTo(HaveOccurred())
+		})
+		It("should reload the driver when a driverinit parameter changed", func() {
+			hostMock.EXPECT().SetDevlinkParams("0000:d8:00.0", iface.DevlinkParams).Return(true, nil)
+			dputilsLibMock.EXPECT().GetVFconfigured("0000:d8:00.0").Return(0)
+			hostMock.EXPECT().ReloadDevlinkDevice("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().TryGetInterfaceName("0000:d8:00.0").Return("enp216s0f0np0")
+			Expect(s.(*sriov).configDevlinkParams(iface)).NotTo(HaveOccurred())
+		})
+		It("should fail when the parameters can't be set", func() {
+			hostMock.EXPECT().SetDevlinkParams("0000:d8:00.0", iface.DevlinkParams).Return(false, testError)
+			Expect(s.(*sriov).configDevlinkParams(iface)).To(MatchError(testError))
+		})
+	})
+	Context("setVfAdminAttributes", func() {
+		It("should configure the VF admin attributes through the PF", func() {
+			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			pfLinkMock.EXPECT().Attrs().Return(&netlink.LinkAttrs{Vfs: []netlink.VfInfo{{ID: 3, MinTxRate: 10, MaxTxRate: 500}}})
+			vfMac, _ := net.ParseMAC("02:00:00:00:01:02")
+			netlinkLibMock.EXPECT().LinkSetVfHardwareAddr(pfLinkMock, 3, vfMac).Return(nil)
+			netlinkLibMock.EXPECT().LinkSetVfVlanQos(pfLinkMock, 3, 100, 2).Return(nil)
+			netlinkLibMock.EXPECT().LinkSetVfSpoofchk(pfLinkMock, 3, false).Return(nil)
+			netlinkLibMock.EXPECT().LinkSetVfTrust(pfLinkMock, 3, true).Return(nil)
+			netlinkLibMock.EXPECT().LinkSetVfState(pfLinkMock, 3, uint32(netlinkNlPkg.IFLA_VF_LINK_STATE_DISABLE)).Return(nil)
+			netlinkLibMock.EXPECT().LinkSetVfRate(pfLinkMock, 3, 10, 1000).Return(nil)
+
+			vlan, maxTxRate := 100, 1000
+			Expect(s.(*sriov).setVfAdminAttributes("0000:d8:00.5", 3, &sriovnetworkv1.VfGroup{
+				VfRange: "1-4",
+				VfAttributes: &sriovnetworkv1.VfAttributes{Mac: "02:00:00:00:01:00", Vlan: &vlan, VlanQoS: 2,
+					SpoofChk: "off", Trust: "on", LinkState: "disable", MaxTxRate: &maxTxRate},
+			}, pfLinkMock)).NotTo(HaveOccurred())
+		})
+		It("should report the VF admin attributes of the PF", func() {
+			vfMac, _ := net.ParseMAC("02:00:00:00:01:02")
+			vf := sriovnetworkv1.VirtualFunction{VfID: 1}
+			setVfAdminStatus(&vf, []netlink.VfInfo{
+				{ID: 0},
+				{ID: 1, Mac: vfMac, Vlan: 100, Qos: 2, Spoofchk: true, Trust: 1,
+					LinkState: netlinkNlPkg.IFLA_VF_LINK_STATE_AUTO, MaxTxRate: 1000}})
+			Expect(vf).To(Equal(sriovnetworkv1.VirtualFunction{VfID: 1, AdminMac: "02:00:00:00:01:02", Vlan: 100, VlanQoS: 2,
+				SpoofChk: "on", Trust: "on", LinkState: "auto", MaxTxRate: 1000}))
+		})
+	})
+
 	Context("ConfigSriovInterfaces", func() {
 		It("should configure", func() {
 			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
@@ -477,10 +601,74 @@ var _ = Describe("SRIOV", func() {
 			hostMock.EXPECT().UnbindDriverIfNeeded("0000:d8:00.2", true).Return(nil)
 			hostMock.EXPECT().BindDefaultDriver("0000:d8:00.2").Return(nil)
 			hostMock.EXPECT().SetNetdevMTU("0000:d8:00.2", 2000).Return(nil)
-			hostMock.EXPECT().ConfigureVfGUID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			hostMock.EXPECT().ConfigureVfGUID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			hostMock.EXPECT().ConfigureVfPKeys("0000:d8:00.2", "0000:d8:00.0", []string{"0xffff", "0x8001"}).Return(nil)
 
 			hostMock.EXPECT().Unbind(gomock.Any()).Return(nil).Times(1)
 
+			storeManagerMode.EXPECT().LoadPfsStatus("0000:d8:00.0").Return(nil, false, nil)
+			storeManagerMode.EXPECT().SaveLastPfAppliedStatus(gomock.Any()).Return(nil)
+
+			Expect(s.ConfigSriovInterfaces(storeManagerMode,
+				[]sriovnetworkv1.Interface{{
+					Name:       "enp216s0f0np0",
+					PciAddress: "0000:d8:00.0",
+					NumVfs:     1,
+					LinkType:   "IB",
+					VfGroups: []sriovnetworkv1.VfGroup{
+						{
+							VfRange:      "0-0",
+							ResourceName: "test-resource0",
+							PolicyName:   "test-policy0",
+							Mtu:          2000,
+							IsRdma:       true,
+							PKeys:        []string{"0xffff", "0x8001"},
+						}},
+				}},
+				[]sriovnetworkv1.InterfaceExt{{PciAddress: "0000:d8:00.0"}},
+				false)).NotTo(HaveOccurred())
+			helpers.GinkgoAssertFileContentsEquals("/sys/bus/pci/devices/0000:d8:00.0/sriov_numvfs", "1")
+		})
+
+		It("should reset the VF pkeys removed from the policy", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs:     []string{"/sys/bus/pci/devices/0000:d8:00.0", "/sys/bus/pci/devices/0000:d8:00.2"},
+				Files:    map[string][]byte{"/sys/bus/pci/devices/0000:d8:00.0/sriov_numvfs": {}},
+				Symlinks: map[string]string{"/sys/bus/pci/devices/0000:d8:00.2/physfn": "../../0000:d8:00.0"},
+			})
+
+			dputilsLibMock.EXPECT().GetSriovVFcapacity("0000:d8:00.0").Return(1)
+			dputilsLibMock.EXPECT().GetVFconfigured("0000:d8:00.0").Return(0)
+			dputilsLibMock.EXPECT().GetDriverName("0000:d8:00.0").Return("mlx5_core", nil)
+			netlinkLibMock.EXPECT().DevLinkGetDeviceByName("pci", "0000:d8:00.0").Return(&netlink.DevlinkDevice{
+				Attrs: netlink.DevlinkDevAttrs{Eswitch: netlink.DevlinkDevEswitchAttr{Mode: "legacy"}}}, nil)
+			hostMock.EXPECT().RemoveDisableNMUdevRule("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().RemovePersistPFNameUdevRule("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().RemoveVfRepresentorUdevRule("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().AddDisableNMUdevRule("0000:d8:00.0").Return(nil)
+			dputilsLibMock.EXPECT().GetVFList("0000:d8:00.0").Return([]string{"0000:d8:00.2"}, nil).AnyTimes()
+			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pfLinkMock, nil).Times(2)
+			netlinkLibMock.EXPECT().IsLinkAdminStateUp(pfLinkMock).Return(false)
+			netlinkLibMock.EXPECT().LinkSetUp(pfLinkMock).Return(nil)
+
+			dputilsLibMock.EXPECT().GetVFID("0000:d8:00.2").Return(0, nil).Times(1)
+			hostMock.EXPECT().HasDriver("0000:d8:00.2").Return(true, "test").Times(2)
+			hostMock.EXPECT().UnbindDriverIfNeeded("0000:d8:00.2", true).Return(nil)
+			hostMock.EXPECT().BindDefaultDriver("0000:d8:00.2").Return(nil)
+			hostMock.EXPECT().SetNetdevMTU("0000:d8:00.2", 2000).Return(nil)
+			hostMock.EXPECT().ConfigureVfGUID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			hostMock.EXPECT().ResetVfPKeys("0000:d8:00.2", "0000:d8:00.0").Return(nil)
+
+			hostMock.EXPECT().Unbind(gomock.Any()).Return(nil).Times(1)
+
+			storeManagerMode.EXPECT().LoadPfsStatus("0000:d8:00.0").Return(&sriovnetworkv1.Interface{
+				Name:       "enp216s0f0np0",
+				PciAddress: "0000:d8:00.0",
+				NumVfs:     1,
+				LinkType:   "IB",
+				VfGroups:   []sriovnetworkv1.VfGroup{{VfRange: "0-0", ResourceName: "test-resource0", IsRdma: true, PKeys: []string{"0x8001"}}},
+			}, true, nil)
 			storeManagerMode.EXPECT().SaveLastPfAppliedStatus(gomock.Any()).Return(nil)
 
 			Expect(s.ConfigSriovInterfaces(storeManagerMode,
@@ -546,7 +734,9 @@ var _ = Describe("SRIOV", func() {
 			hostMock.EXPECT().GetPhysPortName("enp216s0f0np0").Return("p0", nil)
 			hostMock.EXPECT().GetPhysSwitchID("enp216s0f0np0").Return("7cfe90ff2cc0", nil)
 			hostMock.EXPECT().AddVfRepresentorUdevRule("0000:d8:00.0", "enp216s0f0np0", "7cfe90ff2cc0", "p0").Return(nil)
-			hostMock.EXPECT().CreateVDPADevice("0000:d8:00.2", "vhost_vdpa")
+			hostMock.EXPECT().ConfigureSfs("0000:d8:00.0", 0).Return(nil)
+			hostMock.EXPECT().CreateVDPADevice("0000:d8:00.2", "vhost_vdpa", &sriovnetworkv1.VdpaAttributes{
+				Mac: "02:00:00:00:00:10", MaxVqp: ptr.To(8)})
 			hostMock.EXPECT().LoadUdevRules().Return(nil)
 
 			storeManagerMode.EXPECT().SaveLastPfAppliedStatus(gomock.Any()).Return(nil)
@@ -560,12 +750,13 @@ var _ = Describe("SRIOV", func() {
 					EswitchMode: "switchdev",
 					VfGroups: []sriovnetworkv1.VfGroup{
 						{
-							VfRange:      "0-0",
-							ResourceName: "test-resource0",
-							PolicyName:   "test-policy0",
-							Mtu:          2000,
-							IsRdma:       true,
-							VdpaType:     "vhost_vdpa",
+							VfRange:        "0-0",
+							ResourceName:   "test-resource0",
+							PolicyName:     "test-policy0",
+							Mtu:            2000,
+							IsRdma:         true,
+							VdpaType:       "vhost_vdpa",
+							VdpaAttributes: &sriovnetworkv1.VdpaAttributes{Mac: "02:00:00:00:00:10", MaxVqp: ptr.To(8)},
 						}},
 				}},
 				[]sriovnetworkv1.InterfaceExt{{PciAddress: "0000:d8:00.0"}},
@@ -616,7 +807,8 @@ var _ = Describe("SRIOV", func() {
 			hostMock.EXPECT().GetPhysPortName("enp216s0f0np0").Return("p0", nil)
 			hostMock.EXPECT().GetPhysSwitchID("enp216s0f0np0").Return("7cfe90ff2cc0", nil)
 			hostMock.EXPECT().AddVfRepresentorUdevRule("0000:d8:00.0", "enp216s0f0np0", "7cfe90ff2cc0", "p0").Return(nil)
-			hostMock.EXPECT().CreateVDPADevice("0000:d8:00.2", "vhost_vdpa")
+			hostMock.EXPECT().ConfigureSfs("0000:d8:00.0", 0).Return(nil)
+			hostMock.EXPECT().CreateVDPADevice("0000:d8:00.2", "vhost_vdpa", nil)
 			hostMock.EXPECT().LoadUdevRules().Return(nil)
 
 			storeManagerMode.EXPECT().SaveLastPfAppliedStatus(gomock.Any()).Return(nil)
@@ -686,7 +878,8 @@ var _ = Describe("SRIOV", func() {
 			hostMock.EXPECT().GetPhysPortName("enp216s0f0np0").Return("p0", nil)
 			hostMock.EXPECT().GetPhysSwitchID("enp216s0f0np0").Return("7cfe90ff2cc0", nil)
 			hostMock.EXPECT().AddVfRepresentorUdevRule("0000:d8:00.0", "enp216s0f0np0", "7cfe90ff2cc0", "p0").Return(nil)
-			hostMock.EXPECT().CreateVDPADevice("0000:d8:00.2", "vhost_vdpa")
+			hostMock.EXPECT().ConfigureSfs("0000:d8:00.0", 0).Return(nil)
+			hostMock.EXPECT().CreateVDPADevice("0000:d8:00.2", "vhost_vdpa", nil)
 			hostMock.EXPECT().LoadUdevRules().Return(nil)
 
 			storeManagerMode.EXPECT().SaveLastPfAppliedStatus(gomock.Any()).Return(nil)
@@ -740,6 +933,8 @@ var _ = Describe("SRIOV", func() {
 			netlinkLibMock.EXPECT().DevLinkSetEswitchMode(gomock.Any(), "legacy").Return(nil).Times(2)
 			netlinkLibMock.EXPECT().DevLinkSetEswitchMode(gomock.Any(), "switchdev").Return(nil)
 			hostMock.EXPECT().SetDevlinkDeviceParam("0000:d8:00.0", "flow_steering_mode", "smfs").Return(nil)
+			hostMock.EXPECT().DeleteSfs("0000:d8:00.0").Return(nil).Times(2)
+			hostMock.EXPECT().DetachPfFromManagedBond("0000:d8:00.0").Return(nil).Times(2)
 
 			dputilsLibMock.EXPECT().GetVFID("0000:d8:00.2").Return(0, nil).Times(2)
 			hostMock.EXPECT().Unbind("0000:d8:00.2").Return(nil).Times(3)
@@ -758,7 +953,8 @@ var _ = Describe("SRIOV", func() {
 			hostMock.EXPECT().GetPhysPortName("enp216s0f0np0").Return("p0", nil)
 			hostMock.EXPECT().GetPhysSwitchID("enp216s0f0np0").Return("7cfe90ff2cc0", nil)
 			hostMock.EXPECT().AddVfRepresentorUdevRule("0000:d8:00.0", "enp216s0f0np0", "7cfe90ff2cc0", "p0").Return(nil)
-			hostMock.EXPECT().CreateVDPADevice("0000:d8:00.2", "vhost_vdpa")
+			hostMock.EXPECT().ConfigureSfs("0000:d8:00.0", 0).Return(nil)
+			hostMock.EXPECT().CreateVDPADevice("0000:d8:00.2", "vhost_vdpa", nil)
 			hostMock.EXPECT().LoadUdevRules().Return(nil)
 
 			storeManagerMode.EXPECT().SaveLastPfAppliedStatus(gomock.Any()).Return(nil)
@@ -806,8 +1002,9 @@ var _ = Describe("SRIOV", func() {
 			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pfLinkMock, nil).Times(2)
 			netlinkLibMock.EXPECT().IsLinkAdminStateUp(pfLinkMock).Return(false)
 			netlinkLibMock.EXPECT().LinkSetUp(pfLinkMock).Return(nil)
+			hostMock.EXPECT().GetDevlinkDeviceInfo("0000:d8:00.0").Return(&netlink.DevlinkDeviceInfo{Driver: "ice", FwPsidAPI: "4.40"}, nil)
 			netlinkLibMock.EXPECT().DevLinkGetDeviceByName("pci", "0000:d8:00.0").Return(&netlink.DevlinkDevice{
-				Attrs: netlink.DevlinkDevAttrs{Eswitch: netlink.DevlinkDevEswitchAttr{Mode: "legacy"}}}, nil).Times(2)
+				Attrs: netlink.DevlinkDevAttrs{Eswitch: netlink.DevlinkDevEswitchAttr{Mode: "legacy"}}}, nil).Times(3)
 			netlinkLibMock.EXPECT().DevLinkSetEswitchMode(gomock.Any(), "switchdev").Return(nil)
 
 			dputilsLibMock.EXPECT().GetVFID("0000:d8:00.2").Return(0, nil).Times(2)
@@ -826,7 +1023,8 @@ var _ = Describe("SRIOV", func() {
 			hostMock.EXPECT().GetPhysPortName("enp216s0f0np0").Return("p0", nil)
 			hostMock.EXPECT().GetPhysSwitchID("enp216s0f0np0").Return("7cfe90ff2cc0", nil)
 			hostMock.EXPECT().AddVfRepresentorUdevRule("0000:d8:00.0", "enp216s0f0np0", "7cfe90ff2cc0", "p0").Return(nil)
-			hostMock.EXPECT().CreateVDPADevice("0000:d8:00.2", "vhost_vdpa")
+			hostMock.EXPECT().ConfigureSfs("0000:d8:00.0", 0).Return(nil)
+			hostMock.EXPECT().CreateVDPADevice("0000:d8:00.2", "vhost_vdpa", nil)
 			hostMock.EXPECT().LoadUdevRules().Return(nil)
 
 			storeManagerMode.EXPECT().SaveLastPfAppliedStatus(gomock.Any()).Return(nil)
@@ -853,6 +1051,39 @@ var _ = Describe("SRIOV", func() {
 			helpers.GinkgoAssertFileContentsEquals("/sys/bus/pci/devices/0000:d8:00.0/sriov_numvfs", "1")
 		})
 
+		It("should fail to configure switchdev on ice driver with an old NVM", func() {
+			dputilsLibMock.EXPECT().GetSriovVFcapacity("0000:d8:00.0").Return(1)
+			dputilsLibMock.EXPECT().GetVFconfigured("0000:d8:00.0").Return(0)
+			dputilsLibMock.EXPECT().GetDriverName("0000:d8:00.0").Return("ice", nil)
+			hostMock.EXPECT().RemoveDisableNMUdevRule("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().RemovePersistPFNameUdevRule("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().RemoveVfRepresentorUdevRule("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().AddDisableNMUdevRule("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().AddPersistPFNameUdevRule("0000:d8:00.0", "enp216s0f0np0").Return(nil)
+			hostMock.EXPECT().EnableHwTcOffload("enp216s0f0np0").Return(nil)
+			hostMock.EXPECT().GetDevlinkDeviceParam("0000:d8:00.0", "flow_steering_mode").Return("", syscall.EINVAL)
+			netlinkLibMock.EXPECT().DevLinkGetDeviceByName("pci", "0000:d8:00.0").Return(&netlink.DevlinkDevice{
+				Attrs: netlink.DevlinkDevAttrs{Eswitch: netlink.DevlinkDevEswitchAttr{Mode: "legacy"}}}, nil)
+			hostMock.EXPECT().GetDevlinkDeviceInfo("0000:d8:00.0").Return(&netlink.DevlinkDeviceInfo{Driver: "ice", FwPsidAPI: "3.20"}, nil)
+
+			Expect(s.ConfigSriovInterfaces(storeManagerMode,
+				[]sriovnetworkv1.Interface{{
+					Name:        "enp216s0f0np0",
+					PciAddress:  "0000:d8:00.0",
+					NumVfs:      1,
+					LinkType:    "ETH",
+					EswitchMode: "switchdev",
+					VfGroups: []sriovnetworkv1.VfGroup{
+						{
+							VfRange:      "0-0",
+							ResourceName: "test-resource0",
+							PolicyName:   "test-policy0",
+						}},
+				}},
+				[]sriovnetworkv1.InterfaceExt{{PciAddress: "0000:d8:00.0"}},
+				false)).To(HaveOccurred())
+		})
+
 		It("externally managed - wrong VF count", func() {
 			dputilsLibMock.EXPECT().GetVFconfigured("0000:d8:00.0").Return(0)
 
@@ -1068,6 +1299,100 @@ var _ = Describe("SRIOV", func() {
 		})
 	})
 
+	Context("ConfigSriovInterfaces live settings", func() {
+		const checkpoint = "/host/var/lib/kubelet/device-plugins/kubelet_internal_checkpoint"
+		var (
+			iface       sriovnetworkv1.Interface
+			ifaceStatus sriovnetworkv1.InterfaceExt
+		)
+		BeforeEach(func() {
+			iface = sriovnetworkv1.Interface{
+				Name:       "enp216s0f0np0",
+				PciAddress: "0000:d8:00.0",
+				NumVfs:     2,
+				VfGroups: []sriovnetworkv1.VfGroup{{
+					VfRange:      "0-1",
+					ResourceName: "test-resource0",
+					PolicyName:   "test-policy0",
+					DeviceType:   "vfio-pci",
+					VfAttributes: &sriovnetworkv1.VfAttributes{SpoofChk: "on"},
+				}},
+			}
+			ifaceStatus = sriovnetworkv1.InterfaceExt{
+				Name:           "enp216s0f0np0",
+				PciAddress:     "0000:d8:00.0",
+				NumVfs:         2,
+				LinkType:       "ETH",
+				LinkAdminState: "up",
+				VFs: []sriovnetworkv1.VirtualFunction{
+					{VfID: 0, PciAddress: "0000:d8:00.2", Driver: "vfio-pci", SpoofChk: "off"},
+					{VfID: 1, PciAddress: "0000:d8:00.3", Driver: "vfio-pci", SpoofChk: "off"},
+				},
+			}
+			storeManagerMode.EXPECT().SaveLastPfAppliedStatus(gomock.Any()).Return(nil)
+		})
+		It("should configure the drifted attributes of the VFs not allocated to a pod without reconfiguring the PF", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{"/host/var/lib/kubelet/device-plugins"},
+				Files: map[string][]byte{checkpoint: []byte(`{"Data":{"PodDeviceEntries":[` +
+					`{"PodUID":"uid","ContainerName":"test","ResourceName":"openshift.io/test-resource0","DeviceIDs":{"0":["0000:d8:00.3"]}}` +
+					`]},"Checksum":1}`)},
+			})
+			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pfLinkMock, nil)
+			netlinkLibMock.EXPECT().LinkSetVfSpoofchk(pfLinkMock, 0, true).Return(nil)
+
+			Expect(s.ConfigSriovInterfaces(storeManagerMode, []sriovnetworkv1.Interface{iface},
+				[]sriovnetworkv1.InterfaceExt{ifaceStatus}, false)).NotTo(HaveOccurred())
+		})
+		It("should not configure the attributes when they are applied", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{})
+			ifaceStatus.VFs[0].SpoofChk = "on"
+			ifaceStatus.VFs[1].SpoofChk = "on"
+
+			Expect(s.ConfigSriovInterfaces(storeManagerMode, []sriovnetworkv1.Interface{iface},
+				[]sriovnetworkv1.InterfaceExt{ifaceStatus}, false)).NotTo(HaveOccurred())
+		})
+		It("should configure the drifted PF settings without reconfiguring the PF", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{})
+			ifaceStatus.VFs[0].SpoofChk = "on"
+			ifaceStatus.VFs[1].SpoofChk = "on"
+			iface.PfSettings = &sriovnetworkv1.PfSettings{RxRingSize: ptr.To(4096)}
+			ifaceStatus.PfSettings = &sriovnetworkv1.PfSettings{RxRingSize: ptr.To(1024)}
+			hostMock.EXPECT().SetPfSettings("enp216s0f0np0", iface.PfSettings).Return(nil)
+
+			Expect(s.ConfigSriovInterfaces(storeManagerMode, []sriovnetworkv1.Interface{iface},
+				[]sriovnetworkv1.InterfaceExt{ifaceStatus}, false)).NotTo(HaveOccurred())
+		})
+		It("should fail when the kubelet checkpoint is invalid", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs:  []string{"/host/var/lib/kubelet/device-plugins"},
+				Files: map[string][]byte{checkpoint: []byte(`{"Data":{"PodDeviceEntries":[{"DeviceIDs":1}]}}`)},
+			})
+
+			Expect(s.ConfigSriovInterfaces(storeManagerMode, []sriovnetworkv1.Interface{iface},
+				[]sriovnetworkv1.InterfaceExt{ifaceStatus}, false)).To(HaveOccurred())
+		})
+	})
+
+	Context("GetPodAllocatedDevices", func() {
+		It("should return the devices of the kubelet checkpoint", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{"/host/var/lib/kubelet/device-plugins"},
+				Files: map[string][]byte{"/host/var/lib/kubelet/device-plugins/kubelet_internal_checkpoint": []byte(
+					`{"Data":{"PodDeviceEntries":[` +
+						`{"ResourceName":"openshift.io/a","DeviceIDs":{"0":["0000:d8:00.2"],"1":["0000:3b:00.2"]}},` +
+						`{"ResourceName":"openshift.io/b","DeviceIDs":["0000:d8:00.4"]}]}}`)},
+			})
+			Expect(s.(*sriov).GetPodAllocatedDevices()).To(Equal(map[string]bool{
+				"0000:d8:00.2": true, "0000:3b:00.2": true, "0000:d8:00.4": true}))
+		})
+		It("should return no device without checkpoint", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{})
+			Expect(s.(*sriov).GetPodAllocatedDevices()).To(BeEmpty())
+		})
+	})
+
 	Context("VfIsReady", func() {
 		It("Should retry if interface index is -1", func() {
 			hostMock.EXPECT().GetInterfaceIndex("0000:d8:00.2").Return(-1, fmt.Errorf("failed to get interface name")).Times(1)
@@ -1659,6 +1984,7 @@ var _ = Describe("SRIOV", func() {
 
 		It("should configure with different LinkTypes", func() {
 			hostMock.EXPECT().BindDefaultDriver("0000:d8:00.0").Return(nil)
+			storeManagerMode.EXPECT().LoadPfsStatus("0000:d8:00.0").Return(nil, false, nil)
 			storeManagerMode.EXPECT().SaveLastPfAppliedStatus(gomock.Any()).Return(nil)
 
 			Expect(s.ConfigSriovDevicesVirtual(storeManagerMode,
@@ -19,14 +19,20 @@ package vdpa
 import (
 	"errors"
 	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
 	"syscall"
 
 	"github.com/vishvananda/netlink"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	netlinkLibPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
 const (
@@ -46,7 +52,9 @@ func New(k types.KernelInterface, netlinkLib netlinkLibPkg.NetlinkLib) types.Vdp
 // CreateVDPADevice creates VDPA device for VF with required type,
 // pciAddr - PCI address of the VF
 // vdpaType - type of the VDPA device to create: virtio of vhost
-func (v *vdpa) CreateVDPADevice(pciAddr, vdpaType string) error {
+// attrs - attributes of the VDPA device, nil for the defaults. The existing device is re-created
+// if its attributes don't match.
+func (v *vdpa) CreateVDPADevice(pciAddr, vdpaType string, attrs *sriovnetworkv1.VdpaAttributes) error {
 	expectedVDPAName := generateVDPADevName(pciAddr)
 	funcLog := log.Log.WithValues("device", pciAddr, "vdpaType", vdpaType, "name", expectedVDPAName)
 	funcLog.V(2).Info("CreateVDPADevice(): create VDPA device for VF")
@@ -54,24 +62,35 @@ func (v *vdpa) CreateVDPADevice(pciAddr, vdpaType string) error {
 	if expectedDriver == "" {
 		return fmt.Errorf("unknown VDPA device type: %s", vdpaType)
 	}
-	_, err := v.netlinkLib.VDPAGetDevByName(expectedVDPAName)
+	params, err := vdpaNewDevParams(attrs)
+	if err != nil {
+		return err
+	}
+	mgmtBus, mgmtName := constants.BusPci, pciAddr
+	if attrs != nil && attrs.MgmtDevice != "" {
+		var found bool
+		mgmtBus, mgmtName, found = strings.Cut(attrs.MgmtDevice, "/")
+		if !found {
+			return fmt.Errorf("invalid VDPA management device %s, expected <bus>/<name>", attrs.MgmtDevice)
+		}
+	}
+	_, err = v.netlinkLib.VDPAGetDevByName(expectedVDPAName)
+	if err == nil && attrs != nil && !vdpaAttributesMatch(attrs, v.DiscoverVDPAAttributes(pciAddr)) {
+		funcLog.Info("CreateVDPADevice(): VDPA device attributes don't match, re-create the device")
+		if err := v.netlinkLib.VDPADelDev(expectedVDPAName); err != nil {
+			funcLog.Error(err, "CreateVDPADevice(): fail to remove VDPA device")
+			return err
+		}
+		err = syscall.ENODEV
+	}
 	if err != nil {
 		if !errors.Is(err, syscall.ENODEV) {
 			funcLog.Error(err, "CreateVDPADevice(): fail to check if VDPA device exist")
 			return err
 		}
-		// first try to create VDPA device with MaxVQP parameter set to 32 to exactly match HW offloading use-case with the
-		// old swtichdev implementation. Create device without MaxVQP parameter if it is not supported.
-		if err := v.netlinkLib.VDPANewDev(expectedVDPAName, constants.BusPci, pciAddr, netlink.VDPANewDevParams{MaxVQP: 32}); err != nil {
-			if !errors.Is(err, syscall.ENOTSUP) {
-				funcLog.Error(err, "CreateVDPADevice(): fail to create VDPA device with MaxVQP parameter")
-				return err
-			}
-			funcLog.V(2).Info("failed to create VDPA device with MaxVQP parameter, try without it")
-			if err := v.netlinkLib.VDPANewDev(expectedVDPAName, constants.BusPci, pciAddr, netlink.VDPANewDevParams{}); err != nil {
-				funcLog.Error(err, "CreateVDPADevice(): fail to create VDPA device without MaxVQP parameter")
-				return err
-			}
+		if err := v.newVDPADevice(expectedVDPAName, mgmtBus, mgmtName, params, attrs == nil || attrs.MaxVqp == nil); err != nil {
+			funcLog.Error(err, "CreateVDPADevice(): fail to create VDPA device", "mgmtDevice", mgmtBus+"/"+mgmtName)
+			return err
 		}
 	}
 	err = v.kernel.BindDriverByBusAndDevice(constants.BusVdpa, expectedVDPAName, expectedDriver)
@@ -82,6 +101,23 @@ func (v *vdpa) CreateVDPADevice(pciAddr, vdpaType string) error {
 	return nil
 }
 
+// newVDPADevice creates the VDPA device, if the number of virtqueue pairs is not requested the device is
+// created with MaxVQP parameter set to 32 to exactly match HW offloading use-case with the old swtichdev
+// implementation, and without MaxVQP parameter if it is not supported.
+func (v *vdpa) newVDPADevice(name, mgmtBus, mgmtName string, params netlink.VDPANewDevParams, defaultMaxVQP bool) error {
+	if !defaultMaxVQP {
+		return v.netlinkLib.VDPANewDev(name, mgmtBus, mgmtName, params)
+	}
+	params.MaxVQP = 32
+	err := v.netlinkLib.VDPANewDev(name, mgmtBus, mgmtName, params)
+	if err == nil || !errors.Is(err, syscall.ENOTSUP) {
+		return err
+	}
+	log.Log.V(2).Info("failed to create VDPA device with MaxVQP parameter, try without it", "name", name)
+	params.MaxVQP = 0
+	return v.netlinkLib.VDPANewDev(name, mgmtBus, mgmtName, params)
+}
+
 // DeleteVDPADevice removes VDPA device for provided pci address
 // pciAddr - PCI address of the VF
 func (v *vdpa) DeleteVDPADevice(pciAddr string) error {
@@ -138,6 +174,98 @@ func (v *vdpa) DiscoverVDPAType(pciAddr string) string {
 	return vdpaType
 }
 
+// DiscoverVDPAAttributes returns the attributes of the existing VDPA device for VF,
+// returns nil if VDPA device not found or its config can't be read
+// pciAddr - PCI address of the VF
+func (v *vdpa) DiscoverVDPAAttributes(pciAddr string) *sriovnetworkv1.VdpaAttributes {
+	expectedVDPAName := generateVDPADevName(pciAddr)
+	funcLog := log.Log.WithValues("device", pciAddr, "name", expectedVDPAName)
+	config, err := v.netlinkLib.VDPAGetDevConfigByName(expectedVDPAName)
+	if err != nil {
+		if !errors.Is(err, syscall.ENODEV) && !errors.Is(err, syscall.ENOENT) {
+			funcLog.Error(err, "DiscoverVDPAAttributes(): unable to get VDPA device config")
+		}
+		return nil
+	}
+	attrs := &sriovnetworkv1.VdpaAttributes{}
+	if len(config.Net.Cfg.MACAddr) > 0 {
+		attrs.Mac = config.Net.Cfg.MACAddr.String()
+	}
+	if config.Net.Cfg.MTU > 0 {
+		attrs.Mtu = ptr.To(int(config.Net.Cfg.MTU))
+	}
+	if config.Net.Cfg.MaxVQP > 0 {
+		attrs.MaxVqp = ptr.To(int(config.Net.Cfg.MaxVQP))
+	}
+	mgmtDevice, err := getVDPAMgmtDevice(expectedVDPAName)
+	if err != nil {
+		funcLog.Error(err, "DiscoverVDPAAttributes(): unable to get VDPA management device")
+	}
+	attrs.MgmtDevice = mgmtDevice
+	return attrs
+}
+
+// getVDPAMgmtDevice returns the management device of a VDPA device, <bus>/<name>,
+// the VDPA device is a child of its management device in the sysfs
+func getVDPAMgmtDevice(name string) (string, error) {
+	devicePath, err := filepath.EvalSymlinks(filepath.Join(vars.FilesystemRoot, constants.SysBus, constants.BusVdpa, "devices", name))
+	if err != nil {
+		return "", err
+	}
+	parent := filepath.Dir(devicePath)
+	subsystem, err := filepath.EvalSymlinks(filepath.Join(parent, "subsystem"))
+	if err != nil {
+		return "", err
+	}
+	return filepath.Base(subsystem) + "/" + filepath.Base(parent), nil
+}
+
+// vdpaNewDevParams returns the parameters of a new VDPA device with the attributes
+func vdpaNewDevParams(attrs *sriovnetworkv1.VdpaAttributes) (netlink.VDPANewDevParams, error) {
+	params := netlink.VDPANewDevParams{}
+	if attrs == nil {
+		return params, nil
+	}
+	if attrs.Mac != "" {
+		mac, err := net.ParseMAC(attrs.Mac)
+		if err != nil {
+			return params, fmt.Errorf("invalid VDPA MAC address %s: %w", attrs.Mac, err)
+		}
+		params.MACAddr = mac
+	}
+	if attrs.Mtu != nil {
+		params.MTU = uint16(*attrs.Mtu)
+	}
+	if attrs.MaxVqp != nil {
+		params.MaxVQP = uint16(*attrs.MaxVqp)
+	}
+	return params, nil
+}
+
+// vdpaAttributesMatch returns true if the attributes of the existing VDPA device match the desired ones,
+// the unset desired attributes are not checked
+func vdpaAttributesMatch(desired, current *sriovnetworkv1.VdpaAttributes) bool {
+	if desired == nil {
+		return true
+	}
+	if current == nil {
+		current = &sriovnetworkv1.VdpaAttributes{}
+	}
+	if desired.Mac != "" && !strings.EqualFold(desired.Mac, current.Mac) {
+		return false
+	}
+	if desired.Mtu != nil && (current.Mtu == nil || *desired.Mtu != *current.Mtu) {
+		return false
+	}
+	if desired.MaxVqp != nil && (current.MaxVqp == nil || *desired.MaxVqp != *current.MaxVqp) {
+		return false
+	}
+	if desired.MgmtDevice != "" && desired.MgmtDevice != current.MgmtDevice {
+		return false
+	}
+	return true
+}
+
 // generates predictable name for VDPA device, example: vpda:0000:03:00.1
 func generateVDPADevName(pciAddr string) string {
 	return "vdpa:" + pciAddr
@@ -18,18 +18,23 @@ package vdpa
 
 import (
 	"fmt"
+	"net"
 	"syscall"
 
 	"github.com/vishvananda/netlink"
 	"go.uber.org/mock/gomock"
+	"k8s.io/utils/ptr"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	netlinkMock "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink/mock"
 	hostMock "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/mock"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/fakefilesystem"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/helpers"
 )
 
 var _ = Describe("VDPA", func() {
@@ -52,7 +57,7 @@ var _ = Describe("VDPA", func() {
 	})
 	Context("CreateVDPADevice", func() {
 		callFunc := func() error {
-			return v.CreateVDPADevice("0000:d8:00.2", consts.VdpaTypeVhost)
+			return v.CreateVDPADevice("0000:d8:00.2", consts.VdpaTypeVhost, nil)
 		}
 		It("Created", func() {
 			libMock.EXPECT().VDPAGetDevByName("vdpa:0000:d8:00.2").Return(nil, syscall.ENODEV)
@@ -87,6 +92,96 @@ var _ = Describe("VDPA", func() {
 			Expect(callFunc()).To(MatchError(testErr))
 		})
 	})
+	Context("CreateVDPADevice with attributes", func() {
+		var attrs *sriovnetworkv1.VdpaAttributes
+		BeforeEach(func() {
+			attrs = &sriovnetworkv1.VdpaAttributes{Mac: "02:00:00:00:00:10", Mtu: ptr.To(9000), MaxVqp: ptr.To(8)}
+			// the VDPA device of the VF is a child of the VF PCI device
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{"/sys/bus/pci", "/sys/bus/vdpa/devices", "/sys/devices/pci0000:d7/0000:d8:00.2/vdpa:0000:d8:00.2"},
+				Symlinks: map[string]string{
+					"/sys/bus/vdpa/devices/vdpa:0000:d8:00.2":        "../../../devices/pci0000:d7/0000:d8:00.2/vdpa:0000:d8:00.2",
+					"/sys/devices/pci0000:d7/0000:d8:00.2/subsystem": "../../../bus/pci",
+				},
+			})
+		})
+		devConfig := func(mac string, mtu, maxVqp uint16) *netlink.VDPADevConfig {
+			hwAddr, _ := net.ParseMAC(mac)
+			return &netlink.VDPADevConfig{Net: netlink.VDPADevConfigNet{
+				Cfg: netlink.VDPADevConfigNetCfg{MACAddr: hwAddr, MTU: mtu, MaxVQP: maxVqp}}}
+		}
+		It("Created with the attributes", func() {
+			mac, _ := net.ParseMAC("02:00:00:00:00:10")
+			libMock.EXPECT().VDPAGetDevByName("vdpa:0000:d8:00.2").Return(nil, syscall.ENODEV)
+			libMock.EXPECT().VDPANewDev("vdpa:0000:d8:00.2", "pci", "0000:d8:00.2",
+				netlink.VDPANewDevParams{MACAddr: mac, MTU: 9000, MaxVQP: 8}).Return(nil)
+			kernelMock.EXPECT().BindDriverByBusAndDevice(consts.BusVdpa, "vdpa:0000:d8:00.2", "vhost_vdpa").Return(nil)
+			Expect(v.CreateVDPADevice("0000:d8:00.2", consts.VdpaTypeVhost, attrs)).NotTo(HaveOccurred())
+		})
+		It("Created by the management device", func() {
+			attrs = &sriovnetworkv1.VdpaAttributes{MgmtDevice: "auxiliary/mlx5_core.sf.2"}
+			libMock.EXPECT().VDPAGetDevByName("vdpa:0000:d8:00.2").Return(nil, syscall.ENODEV)
+			libMock.EXPECT().VDPANewDev("vdpa:0000:d8:00.2", "auxiliary", "mlx5_core.sf.2",
+				netlink.VDPANewDevParams{MaxVQP: 32}).Return(nil)
+			kernelMock.EXPECT().BindDriverByBusAndDevice(consts.BusVdpa, "vdpa:0000:d8:00.2", "vhost_vdpa").Return(nil)
+			Expect(v.CreateVDPADevice("0000:d8:00.2", consts.VdpaTypeVhost, attrs)).NotTo(HaveOccurred())
+		})
+		It("Not re-created when the attributes match", func() {
+			libMock.EXPECT().VDPAGetDevByName("vdpa:0000:d8:00.2").Return(&netlink.VDPADev{}, nil)
+			libMock.EXPECT().VDPAGetDevConfigByName("vdpa:0000:d8:00.2").Return(devConfig("02:00:00:00:00:10", 9000, 8), nil)
+			kernelMock.EXPECT().BindDriverByBusAndDevice(consts.BusVdpa, "vdpa:0000:d8:00.2", "vhost_vdpa").Return(nil)
+			Expect(v.CreateVDPADevice("0000:d8:00.2", consts.VdpaTypeVhost, attrs)).NotTo(HaveOccurred())
+		})
+		It("Re-created when the attributes drift", func() {
+			mac, _ := net.ParseMAC("02:00:00:00:00:10")
+			libMock.EXPECT().VDPAGetDevByName("vdpa:0000:d8:00.2").Return(&netlink.VDPADev{}, nil)
+			libMock.EXPECT().VDPAGetDevConfigByName("vdpa:0000:d8:00.2").Return(devConfig("02:00:00:00:00:10", 1500, 8), nil)
+			libMock.EXPECT().VDPADelDev("vdpa:0000:d8:00.2").Return(nil)
+			libMock.EXPECT().VDPANewDev("vdpa:0000:d8:00.2", "pci", "0000:d8:00.2",
+				netlink.VDPANewDevParams{MACAddr: mac, MTU: 9000, MaxVQP: 8}).Return(nil)
+			kernelMock.EXPECT().BindDriverByBusAndDevice(consts.BusVdpa, "vdpa:0000:d8:00.2", "vhost_vdpa").Return(nil)
+			Expect(v.CreateVDPADevice("0000:d8:00.2", consts.VdpaTypeVhost, attrs)).NotTo(HaveOccurred())
+		})
+		It("Re-created when the management device drifts", func() {
+			attrs = &sriovnetworkv1.VdpaAttributes{MgmtDevice: "auxiliary/mlx5_core.sf.2"}
+			libMock.EXPECT().VDPAGetDevByName("vdpa:0000:d8:00.2").Return(&netlink.VDPADev{}, nil)
+			libMock.EXPECT().VDPAGetDevConfigByName("vdpa:0000:d8:00.2").Return(devConfig("02:00:00:00:00:10", 1500, 8), nil)
+			libMock.EXPECT().VDPADelDev("vdpa:0000:d8:00.2").Return(nil)
+			libMock.EXPECT().VDPANewDev("vdpa:0000:d8:00.2", "auxiliary", "mlx5_core.sf.2",
+				netlink.VDPANewDevParams{MaxVQP: 32}).Return(nil)
+			kernelMock.EXPECT().BindDriverByBusAndDevice(consts.BusVdpa, "vdpa:0000:d8:00.2", "vhost_vdpa").Return(nil)
+			Expect(v.CreateVDPADevice("0000:d8:00.2", consts.VdpaTypeVhost, attrs)).NotTo(HaveOccurred())
+		})
+		It("Fail to create with the requested max virtqueue pairs", func() {
+			libMock.EXPECT().VDPAGetDevByName("vdpa:0000:d8:00.2").Return(nil, syscall.ENODEV)
+			libMock.EXPECT().VDPANewDev("vdpa:0000:d8:00.2", "pci", "0000:d8:00.2", gomock.Any()).Return(syscall.ENOTSUP)
+			Expect(v.CreateVDPADevice("0000:d8:00.2", consts.VdpaTypeVhost, attrs)).To(MatchError(syscall.ENOTSUP))
+		})
+		It("Fail with an invalid management device", func() {
+			attrs = &sriovnetworkv1.VdpaAttributes{MgmtDevice: "mlx5_core.sf.2"}
+			Expect(v.CreateVDPADevice("0000:d8:00.2", consts.VdpaTypeVhost, attrs)).To(HaveOccurred())
+		})
+	})
+	Context("DiscoverVDPAAttributes", func() {
+		It("No device", func() {
+			libMock.EXPECT().VDPAGetDevConfigByName("vdpa:0000:d8:00.2").Return(nil, syscall.ENODEV)
+			Expect(v.DiscoverVDPAAttributes("0000:d8:00.2")).To(BeNil())
+		})
+		It("Report the attributes", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{"/sys/bus/auxiliary", "/sys/bus/vdpa/devices", "/sys/devices/pci0000:d7/0000:d8:00.0/mlx5_core.sf.2/vdpa:0000:d8:00.2"},
+				Symlinks: map[string]string{
+					"/sys/bus/vdpa/devices/vdpa:0000:d8:00.2":                       "../../../devices/pci0000:d7/0000:d8:00.0/mlx5_core.sf.2/vdpa:0000:d8:00.2",
+					"/sys/devices/pci0000:d7/0000:d8:00.0/mlx5_core.sf.2/subsystem": "../../../../bus/auxiliary",
+				},
+			})
+			mac, _ := net.ParseMAC("02:00:00:00:00:10")
+			libMock.EXPECT().VDPAGetDevConfigByName("vdpa:0000:d8:00.2").Return(&netlink.VDPADevConfig{Net: netlink.VDPADevConfigNet{
+				Cfg: netlink.VDPADevConfigNetCfg{MACAddr: mac, MTU: 1500, MaxVQP: 32}}}, nil)
+			Expect(v.DiscoverVDPAAttributes("0000:d8:00.2")).To(Equal(&sriovnetworkv1.VdpaAttributes{
+				Mac: "02:00:00:00:00:10", Mtu: ptr.To(1500), MaxVqp: ptr.To(32), MgmtDevice: "auxiliary/mlx5_core.sf.2"}))
+		})
+	})
 	Context("DeleteVDPADevice", func() {
 		callFunc := func() error {
 			return v.DeleteVDPADevice("0000:d8:00.2")
@@ -0,0 +1,355 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watcher
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	netlinkLibPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+const (
+	// size of the buffers of the link updates and uevents, the kernel sends bursts of events
+	// when the VFs of a PF are created or removed
+	eventsBufferSize = 256
+	// multicast group of the uevents sent by the kernel, udevd sends its own events to group 2
+	ueventKernelGroup = 1
+	// maximum size of an uevent message
+	ueventBufferSize = 64 * 1024
+
+	subsystemPci = "pci"
+	subsystemNet = "net"
+)
+
+var pciAddressRe = regexp.MustCompile(`^[0-9a-f]{4}:[0-9a-f]{2}:[0-9a-f]{2}\.[0-7]$`)
+
+// ueventReader sends the kernel uevents as key/value maps to the channel until the context is done
+type ueventReader func(ctx context.Context, uevents chan<- map[string]string) error
+
+type watcher struct {
+	netlinkLib  netlinkLibPkg.NetlinkLib
+	readUevents ueventReader
+}
+
+func New(netlinkLib netlinkLibPkg.NetlinkLib) types.HostWatcherInterface {
+	return &watcher{netlinkLib: netlinkLib, readUevents: readKernelUevents}
+}
+
+// linkState is the state of a link compared between two link updates
+type linkState struct {
+	name      string
+	operState netlink.LinkOperState
+	up        bool
+	mtu       int
+	// PCI address of the device of the link, empty for the auxiliary devices
+	pciAddress string
+}
+
+// WatchHostEvents reports the link changes of the network interfaces of PCI and auxiliary devices
+// and the uevents of the PCI and network devices
+func (w *watcher) WatchHostEvents(ctx context.Context, events chan<- types.HostEvent) error {
+	funcLog := log.Log.WithName("WatchHostEvents()")
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	updates := make(chan netlink.LinkUpdate, eventsBufferSize)
+	err := w.netlinkLib.LinkSubscribeWithOptions(updates, ctx.Done(), netlink.LinkSubscribeOptions{
+		ErrorCallback: func(err error) {
+			funcLog.Error(err, "link subscription error")
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to link updates: %w", err)
+	}
+	// the subscription blocks on a full channel, it is drained until it is closed after the cancel
+	defer func() {
+		cancel()
+		for range updates {
+		}
+	}()
+
+	// the links are listed after the subscription to not miss a change between the two
+	links, err := w.netlinkLib.LinkList()
+	if err != nil {
+		return fmt.Errorf("failed to list links: %w", err)
+	}
+	states := map[int]linkState{}
+	for _, link := range links {
+		if state, ok := getLinkState(link); ok {
+			states[link.Attrs().Index] = state
+		}
+	}
+
+	uevents := make(chan map[string]string, eventsBufferSize)
+	ueventErr := make(chan error, 1)
+	go func() {
+		ueventErr <- w.readUevents(ctx, uevents)
+	}()
+
+	funcLog.Info("watching host events")
+	for {
+		var event types.HostEvent
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-ueventErr:
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err == nil {
+				err = fmt.Errorf("uevent reader stopped")
+			}
+			return fmt.Errorf("failed to read uevents: %w", err)
+		case update, open := <-updates:
+			if !open {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return fmt.Errorf("link subscription closed")
+			}
+			event, ok = linkEvent(states, update)
+		case uevent := <-uevents:
+			event, ok = ueventToHostEvent(uevent)
+		}
+		if !ok {
+			continue
+		}
+		funcLog.V(2).Info("host event", "device", event.PciAddress, "interface", event.Interface, "reason", event.Reason)
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// getLinkDevice returns the bus and the name of the device of a link. The kernel reports them in the link
+// attributes since 5.16, on the older kernels the device link of the interface in sysfs is resolved.
+func getLinkDevice(link netlink.Link) (string, string) {
+	attrs := link.Attrs()
+	if attrs.ParentDevBus != "" {
+		return attrs.ParentDevBus, attrs.ParentDev
+	}
+	device, err := filepath.EvalSymlinks(filepath.Join(vars.FilesystemRoot, consts.SysClassNet, attrs.Name, "device"))
+	if err != nil {
+		return "", ""
+	}
+	subsystem, err := filepath.EvalSymlinks(filepath.Join(device, "subsystem"))
+	if err != nil {
+		return "", ""
+	}
+	return filepath.Base(subsystem), filepath.Base(device)
+}
+
+// getLinkState returns the state of the link, false if the link is not the network interface
+// of a PCI device or of an auxiliary device (SF)
+func getLinkState(link netlink.Link) (linkState, bool) {
+	bus, device := getLinkDevice(link)
+	if bus != consts.BusPci && bus != consts.BusAuxiliary {
+		return linkState{}, false
+	}
+	attrs := link.Attrs()
+	state := linkState{
+		name:      attrs.Name,
+		operState: attrs.OperState,
+		up:        attrs.RawFlags&unix.IFF_UP != 0,
+		mtu:       attrs.MTU,
+	}
+	if bus == consts.BusPci {
+		state.pciAddress = device
+	}
+	return state, true
+}
+
+// linkEvent returns the event of a link update, the updates which don't change the name, the state
+// or the MTU of the link are ignored. states is updated with the new state of the link.
+// The kernel doesn't include the VF information in the link notifications, the changes of the VF
+// attributes are caught by the periodic resync of the daemon.
+func linkEvent(states map[int]linkState, update netlink.LinkUpdate) (types.HostEvent, bool) {
+	if update.Link == nil {
+		return types.HostEvent{}, false
+	}
+	attrs := update.Link.Attrs()
+	previous, known := states[attrs.Index]
+	if update.Header.Type == unix.RTM_DELLINK {
+		// the device of a removed link can be gone from sysfs, it is the device of the known link
+		if !known {
+			if previous, known = getLinkState(update.Link); !known {
+				return types.HostEvent{}, false
+			}
+		}
+		delete(states, attrs.Index)
+		return types.HostEvent{PciAddress: previous.pciAddress, Interface: attrs.Name, Reason: "link removed"}, true
+	}
+	current, ok := getLinkState(update.Link)
+	if !ok {
+		return types.HostEvent{}, false
+	}
+	states[attrs.Index] = current
+	event := types.HostEvent{PciAddress: current.pciAddress, Interface: attrs.Name}
+	switch {
+	case !known:
+		event.Reason = "link added"
+	case previous.name != current.name:
+		event.Reason = fmt.Sprintf("link renamed from %s", previous.name)
+	case previous.up != current.up:
+		event.Reason = "link administratively down"
+		if current.up {
+			event.Reason = "link administratively up"
+		}
+	case previous.operState != current.operState:
+		event.Reason = "link " + current.operState.String()
+	case previous.mtu != current.mtu:
+		event.Reason = fmt.Sprintf("link MTU changed from %d to %d", previous.mtu, current.mtu)
+	default:
+		return types.HostEvent{}, false
+	}
+	return event, true
+}
+
+// ueventToHostEvent returns the event of an uevent of a PCI device or of the network interface of a device
+func ueventToHostEvent(uevent map[string]string) (types.HostEvent, bool) {
+	action := uevent["ACTION"]
+	switch uevent["SUBSYSTEM"] {
+	case subsystemPci:
+		event := types.HostEvent{PciAddress: uevent["PCI_SLOT_NAME"]}
+		switch action {
+		case "add":
+			event.Reason = "device added"
+		case "remove":
+			event.Reason = "device removed"
+		case "bind":
+			event.Reason = "driver bound " + uevent["DRIVER"]
+		case "unbind":
+			event.Reason = "driver unbound"
+		default:
+			return types.HostEvent{}, false
+		}
+		return event, event.PciAddress != ""
+	case subsystemNet:
+		pciAddress, ok := getDevpathPciAddress(uevent["DEVPATH"])
+		if !ok {
+			return types.HostEvent{}, false
+		}
+		event := types.HostEvent{PciAddress: pciAddress, Interface: uevent["INTERFACE"]}
+		switch action {
+		case "add":
+			event.Reason = "interface added"
+		case "remove":
+			event.Reason = "interface removed"
+		case "move":
+			event.Reason = "interface renamed"
+		default:
+			return types.HostEvent{}, false
+		}
+		return event, true
+	}
+	return types.HostEvent{}, false
+}
+
+// getDevpathPciAddress returns the PCI address of the device of a network interface from its devpath,
+// e.g. /devices/pci0000:d7/0000:d7:00.0/0000:d8:00.1/net/ens1f1. For the interfaces of auxiliary devices
+// it is the address of the parent PCI device. The virtual interfaces have no PCI device.
+func getDevpathPciAddress(devpath string) (string, bool) {
+	if strings.HasPrefix(devpath, "/devices/virtual/") {
+		return "", false
+	}
+	idx := strings.LastIndex(devpath, "/net/")
+	if idx == -1 {
+		return "", false
+	}
+	parts := strings.Split(devpath[:idx], "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if pciAddressRe.MatchString(parts[i]) {
+			return parts[i], true
+		}
+	}
+	return "", false
+}
+
+// parseUevent returns the key/value pairs of an uevent message, the message is a header
+// (ACTION@DEVPATH) followed by KEY=VALUE strings separated by null characters
+func parseUevent(msg []byte) map[string]string {
+	uevent := map[string]string{}
+	for _, field := range bytes.Split(msg, []byte{0}) {
+		key, value, found := strings.Cut(string(field), "=")
+		if !found {
+			continue
+		}
+		uevent[key] = value
+	}
+	if uevent["ACTION"] == "" {
+		return nil
+	}
+	return uevent
+}
+
+// readKernelUevents reads the uevents sent by the kernel on a NETLINK_KOBJECT_UEVENT socket
+func readKernelUevents(ctx context.Context, uevents chan<- map[string]string) error {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_KOBJECT_UEVENT)
+	if err != nil {
+		return fmt.Errorf("failed to open uevent socket: %w", err)
+	}
+	defer unix.Close(fd)
+	if err := unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK, Groups: ueventKernelGroup}); err != nil {
+		return fmt.Errorf("failed to bind uevent socket: %w", err)
+	}
+	// the receive times out to check the context
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &unix.Timeval{Sec: 1}); err != nil {
+		return fmt.Errorf("failed to set uevent socket timeout: %w", err)
+	}
+
+	buf := make([]byte, ueventBufferSize)
+	for ctx.Err() == nil {
+		n, _, err := unix.Recvfrom(fd, buf, 0)
+		if err != nil {
+			if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+				continue
+			}
+			// the kernel drops the uevents when the socket buffer is full, the periodic resync
+			// of the daemon catches the changes of the lost events
+			if errors.Is(err, unix.ENOBUFS) {
+				log.Log.Info("readKernelUevents(): uevents lost, socket buffer full")
+				continue
+			}
+			return fmt.Errorf("failed to receive uevent: %w", err)
+		}
+		uevent := parseUevent(buf[:n])
+		if uevent == nil {
+			continue
+		}
+		select {
+		case uevents <- uevent:
+		case <-ctx.Done():
+		}
+	}
+	return nil
+}
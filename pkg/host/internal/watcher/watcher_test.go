@@ -0,0 +1,252 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/vishvananda/netlink"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/sys/unix"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	netlinkLibPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink"
+	netlinkMock "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink/mock"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/fakefilesystem"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/helpers"
+)
+
+func newLink(index int, name, parentDev string, operState netlink.LinkOperState, up bool) netlink.Link {
+	attrs := netlink.LinkAttrs{Index: index, Name: name, ParentDev: parentDev, ParentDevBus: "pci", OperState: operState}
+	if up {
+		attrs.RawFlags = unix.IFF_UP
+	}
+	return &netlink.Device{LinkAttrs: attrs}
+}
+
+func newUpdate(msgType uint16, link netlink.Link) netlink.LinkUpdate {
+	return netlink.LinkUpdate{Header: unix.NlMsghdr{Type: msgType}, Link: link}
+}
+
+var _ = Describe("Watcher", func() {
+	var (
+		w        *watcher
+		libMock  *netlinkMock.MockNetlinkLib
+		testCtrl *gomock.Controller
+		testErr  = fmt.Errorf("test-error")
+
+		subscribed  chan chan<- netlink.LinkUpdate
+		uevents     chan map[string]string
+		events      chan types.HostEvent
+		ctx         context.Context
+		cancel      context.CancelFunc
+		watchResult chan error
+	)
+	BeforeEach(func() {
+		testCtrl = gomock.NewController(GinkgoT())
+		libMock = netlinkMock.NewMockNetlinkLib(testCtrl)
+		uevents = make(chan map[string]string)
+		events = make(chan types.HostEvent, 10)
+		w = New(libMock).(*watcher)
+		w.readUevents = func(ctx context.Context, out chan<- map[string]string) error {
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case uevent := <-uevents:
+					out <- uevent
+				}
+			}
+		}
+		ctx, cancel = context.WithCancel(context.Background())
+		watchResult = make(chan error, 1)
+		subscribed = make(chan chan<- netlink.LinkUpdate, 1)
+	})
+	AfterEach(func() {
+		cancel()
+		testCtrl.Finish()
+	})
+
+	// expectSubscribe expects the link subscription, the channel is closed when done is closed if closeOnDone is true
+	expectSubscribe := func(closeOnDone bool) {
+		libMock.EXPECT().LinkSubscribeWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ch chan<- netlink.LinkUpdate, done <-chan struct{}, _ netlink.LinkSubscribeOptions) error {
+				subscribed <- ch
+				if closeOnDone {
+					go func() {
+						<-done
+						close(ch)
+					}()
+				}
+				return nil
+			})
+	}
+	// startWatch starts the watch and returns the channel of the link updates
+	startWatch := func() chan<- netlink.LinkUpdate {
+		go func() {
+			watchResult <- w.WatchHostEvents(ctx, events)
+		}()
+		var updates chan<- netlink.LinkUpdate
+		Eventually(subscribed).Should(Receive(&updates))
+		return updates
+	}
+
+	Context("WatchHostEvents", func() {
+		It("Report the changes of the PCI links", func() {
+			expectSubscribe(true)
+			libMock.EXPECT().LinkList().Return([]netlinkLibPkg.Link{
+				newLink(2, "ens1f0", "0000:d8:00.0", netlink.OperUp, true),
+				newLink(3, "ens1f0v0", "0000:d8:02.0", netlink.OperDown, false),
+			}, nil)
+			updates := startWatch()
+
+			// virtual links and updates without change of state are ignored
+			veth := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Index: 10, Name: "veth0"}}
+			updates <- newUpdate(unix.RTM_NEWLINK, veth)
+			updates <- newUpdate(unix.RTM_NEWLINK, newLink(2, "ens1f0", "0000:d8:00.0", netlink.OperUp, true))
+			updates <- newUpdate(unix.RTM_NEWLINK, newLink(2, "ens1f0", "0000:d8:00.0", netlink.OperDown, true))
+			Eventually(events).Should(Receive(Equal(types.HostEvent{PciAddress: "0000:d8:00.0", Interface: "ens1f0", Reason: "link down"})))
+
+			mtuLink := newLink(2, "ens1f0", "0000:d8:00.0", netlink.OperDown, true)
+			mtuLink.Attrs().MTU = 9000
+			updates <- newUpdate(unix.RTM_NEWLINK, mtuLink)
+			Eventually(events).Should(Receive(Equal(types.HostEvent{PciAddress: "0000:d8:00.0", Interface: "ens1f0", Reason: "link MTU changed from 0 to 9000"})))
+
+			updates <- newUpdate(unix.RTM_NEWLINK, newLink(3, "eth5", "0000:d8:02.0", netlink.OperDown, false))
+			Eventually(events).Should(Receive(Equal(types.HostEvent{PciAddress: "0000:d8:02.0", Interface: "eth5", Reason: "link renamed from ens1f0v0"})))
+
+			updates <- newUpdate(unix.RTM_DELLINK, newLink(3, "eth5", "0000:d8:02.0", netlink.OperDown, false))
+			Eventually(events).Should(Receive(Equal(types.HostEvent{PciAddress: "0000:d8:02.0", Interface: "eth5", Reason: "link removed"})))
+
+			updates <- newUpdate(unix.RTM_NEWLINK, newLink(4, "ens1f0v1", "0000:d8:02.1", netlink.OperDown, false))
+			Eventually(events).Should(Receive(Equal(types.HostEvent{PciAddress: "0000:d8:02.1", Interface: "ens1f0v1", Reason: "link added"})))
+			Consistently(events).ShouldNot(Receive())
+
+			cancel()
+			Eventually(watchResult).Should(Receive(BeNil()))
+		})
+		It("Resolve the device of the links in sysfs on the kernels without parent device attributes", func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{
+					"/sys/devices/pci0000:d7/0000:d8:00.0/mlx5_core.sf.2",
+					"/sys/devices/virtual/net/veth0",
+					"/sys/bus/pci",
+					"/sys/bus/auxiliary",
+					"/sys/class/net/ens1f0",
+					"/sys/class/net/enp216s0f0s2",
+				},
+				Symlinks: map[string]string{
+					"/sys/devices/pci0000:d7/0000:d8:00.0/subsystem":                "../../../bus/pci",
+					"/sys/devices/pci0000:d7/0000:d8:00.0/mlx5_core.sf.2/subsystem": "../../../../bus/auxiliary",
+					"/sys/class/net/ens1f0/device":                                  "../../../devices/pci0000:d7/0000:d8:00.0",
+					"/sys/class/net/enp216s0f0s2/device":                            "../../../devices/pci0000:d7/0000:d8:00.0/mlx5_core.sf.2",
+					"/sys/class/net/veth0":                                          "../../devices/virtual/net/veth0",
+				},
+			})
+			newOldKernelLink := func(index int, name string, operState netlink.LinkOperState) netlink.Link {
+				return &netlink.Device{LinkAttrs: netlink.LinkAttrs{Index: index, Name: name, OperState: operState}}
+			}
+			expectSubscribe(true)
+			libMock.EXPECT().LinkList().Return([]netlinkLibPkg.Link{
+				newOldKernelLink(2, "ens1f0", netlink.OperUp),
+				newOldKernelLink(5, "enp216s0f0s2", netlink.OperUp),
+			}, nil)
+			updates := startWatch()
+
+			updates <- newUpdate(unix.RTM_NEWLINK, newOldKernelLink(10, "veth0", netlink.OperDown))
+			updates <- newUpdate(unix.RTM_NEWLINK, newOldKernelLink(2, "ens1f0", netlink.OperDown))
+			Eventually(events).Should(Receive(Equal(types.HostEvent{PciAddress: "0000:d8:00.0", Interface: "ens1f0", Reason: "link down"})))
+
+			updates <- newUpdate(unix.RTM_NEWLINK, newOldKernelLink(5, "enp216s0f0s2", netlink.OperDown))
+			Eventually(events).Should(Receive(Equal(types.HostEvent{Interface: "enp216s0f0s2", Reason: "link down"})))
+
+			// the sysfs entry of a removed link is gone, the device is the one of the known link
+			Expect(os.Remove(filepath.Join(vars.FilesystemRoot, "/sys/class/net/ens1f0/device"))).To(Succeed())
+			updates <- newUpdate(unix.RTM_DELLINK, newOldKernelLink(2, "ens1f0", netlink.OperDown))
+			Eventually(events).Should(Receive(Equal(types.HostEvent{PciAddress: "0000:d8:00.0", Interface: "ens1f0", Reason: "link removed"})))
+			Consistently(events).ShouldNot(Receive())
+		})
+		It("Report the uevents of the PCI and network devices", func() {
+			expectSubscribe(true)
+			libMock.EXPECT().LinkList().Return(nil, nil)
+			startWatch()
+
+			uevents <- map[string]string{"ACTION": "unbind", "SUBSYSTEM": "pci", "PCI_SLOT_NAME": "0000:d8:00.1"}
+			Eventually(events).Should(Receive(Equal(types.HostEvent{PciAddress: "0000:d8:00.1", Reason: "driver unbound"})))
+
+			uevents <- map[string]string{"ACTION": "bind", "SUBSYSTEM": "pci", "PCI_SLOT_NAME": "0000:d8:00.1", "DRIVER": "vfio-pci"}
+			Eventually(events).Should(Receive(Equal(types.HostEvent{PciAddress: "0000:d8:00.1", Reason: "driver bound vfio-pci"})))
+
+			uevents <- map[string]string{"ACTION": "remove", "SUBSYSTEM": "net", "INTERFACE": "ens1f1",
+				"DEVPATH": "/devices/pci0000:d7/0000:d7:00.0/0000:d8:00.1/net/ens1f1"}
+			Eventually(events).Should(Receive(Equal(types.HostEvent{PciAddress: "0000:d8:00.1", Interface: "ens1f1", Reason: "interface removed"})))
+
+			uevents <- map[string]string{"ACTION": "add", "SUBSYSTEM": "net", "INTERFACE": "enp216s0f1s0",
+				"DEVPATH": "/devices/pci0000:d7/0000:d7:00.0/0000:d8:00.1/mlx5_core.sf.2/net/enp216s0f1s0"}
+			Eventually(events).Should(Receive(Equal(types.HostEvent{PciAddress: "0000:d8:00.1", Interface: "enp216s0f1s0", Reason: "interface added"})))
+
+			// virtual interfaces and other subsystems are ignored
+			uevents <- map[string]string{"ACTION": "add", "SUBSYSTEM": "net", "INTERFACE": "veth0", "DEVPATH": "/devices/virtual/net/veth0"}
+			uevents <- map[string]string{"ACTION": "add", "SUBSYSTEM": "block", "DEVPATH": "/devices/virtual/block/loop0"}
+			Consistently(events).ShouldNot(Receive())
+		})
+		It("Fail when the link subscription fails", func() {
+			libMock.EXPECT().LinkSubscribeWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).Return(testErr)
+			Expect(w.WatchHostEvents(ctx, events)).To(MatchError(testErr))
+		})
+		It("Fail when the link subscription ends", func() {
+			expectSubscribe(false)
+			libMock.EXPECT().LinkList().Return(nil, nil)
+			updates := startWatch()
+			close(updates)
+			Eventually(watchResult).Should(Receive(MatchError(ContainSubstring("link subscription closed"))))
+		})
+		It("Fail when the uevents can't be read", func() {
+			expectSubscribe(true)
+			libMock.EXPECT().LinkList().Return(nil, nil)
+			w.readUevents = func(context.Context, chan<- map[string]string) error {
+				return testErr
+			}
+			Expect(w.WatchHostEvents(ctx, events)).To(MatchError(testErr))
+		})
+	})
+
+	Context("parseUevent", func() {
+		It("Parse the key/value pairs", func() {
+			msg := []byte("bind@/devices/pci0000:d7/0000:d7:00.0/0000:d8:00.1\x00ACTION=bind\x00DEVPATH=/devices/pci0000:d7/0000:d7:00.0/0000:d8:00.1\x00" +
+				"SUBSYSTEM=pci\x00DRIVER=mlx5_core\x00PCI_SLOT_NAME=0000:d8:00.1\x00SEQNUM=4242\x00")
+			Expect(parseUevent(msg)).To(Equal(map[string]string{
+				"ACTION":        "bind",
+				"DEVPATH":       "/devices/pci0000:d7/0000:d7:00.0/0000:d8:00.1",
+				"SUBSYSTEM":     "pci",
+				"DRIVER":        "mlx5_core",
+				"PCI_SLOT_NAME": "0000:d8:00.1",
+				"SEQNUM":        "4242",
+			}))
+		})
+		It("Ignore the messages without action", func() {
+			Expect(parseUevent([]byte("libudev\x00\xfe\xed"))).To(BeNil())
+		})
+	})
+})
@@ -17,6 +17,7 @@
 package host
 
 import (
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/bond"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/bridge"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/cpu"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/infiniband"
@@ -28,10 +29,12 @@ import (
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/sriovnet"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/network"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/service"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/sf"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/sriov"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/systemd"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/udev"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/vdpa"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/watcher"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 )
@@ -46,10 +49,13 @@ type HostManagerInterface interface {
 	types.UdevInterface
 	types.SriovInterface
 	types.VdpaInterface
+	types.SfInterface
+	types.BondInterface
 	types.InfinibandInterface
 	types.BridgeInterface
 	types.CPUInfoProviderInterface
 	types.SystemdInterface
+	types.HostWatcherInterface
 }
 
 type hostManager struct {
@@ -60,10 +66,13 @@ type hostManager struct {
 	types.UdevInterface
 	types.SriovInterface
 	types.VdpaInterface
+	types.SfInterface
+	types.BondInterface
 	types.InfinibandInterface
 	types.BridgeInterface
 	types.CPUInfoProviderInterface
 	types.SystemdInterface
+	types.HostWatcherInterface
 }
 
 func NewDefaultHostManager() (HostManagerInterface, error) {
@@ -82,14 +91,17 @@ func NewHostManager(utilsInterface utils.CmdInterface) (HostManagerInterface, er
 	sv := service.New(utilsInterface)
 	u := udev.New(utilsInterface)
 	v := vdpa.New(k, netlinkLib)
+	sfHelper := sf.New(n, netlinkLib, sriovnetLib)
+	bondHelper := bond.New(n, netlinkLib)
 	ib, err := infiniband.New(netlinkLib, k, n)
 	if err != nil {
 		return nil, err
 	}
 	br := bridge.New()
-	sr := sriov.New(utilsInterface, k, n, u, v, ib, netlinkLib, dpUtils, sriovnetLib, ghwLib, br)
+	sr := sriov.New(utilsInterface, k, n, u, v, ib, netlinkLib, dpUtils, sriovnetLib, ghwLib, br, sfHelper, bondHelper)
 	cpuInfoProvider := cpu.New(ghwLib)
 	s := systemd.New()
+	w := watcher.New(netlinkLib)
 	return &hostManager{
 		utilsInterface,
 		k,
@@ -98,9 +110,12 @@ func NewHostManager(utilsInterface utils.CmdInterface) (HostManagerInterface, er
 		u,
 		sr,
 		v,
+		sfHelper,
+		bondHelper,
 		ib,
 		br,
 		cpuInfoProvider,
 		s,
+		w,
 	}, nil
 }
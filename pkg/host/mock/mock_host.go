@@ -10,6 +10,7 @@
 package mock_host
 
 import (
+	context "context"
 	reflect "reflect"
 
 	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
@@ -57,6 +58,25 @@ func (mr *MockHostManagerInterfaceMockRecorder) AddDisableNMUdevRule(pfPciAddres
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDisableNMUdevRule", reflect.TypeOf((*MockHostManagerInterface)(nil).AddDisableNMUdevRule), pfPciAddress)
 }
 
+// AddKernelArgs mocks base method.
+func (m *MockHostManagerInterface) AddKernelArgs(kargs ...string) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range kargs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddKernelArgs", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddKernelArgs indicates an expected call of AddKernelArgs.
+func (mr *MockHostManagerInterfaceMockRecorder) AddKernelArgs(kargs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddKernelArgs", reflect.TypeOf((*MockHostManagerInterface)(nil).AddKernelArgs), kargs...)
+}
+
 // AddPersistPFNameUdevRule mocks base method.
 func (m *MockHostManagerInterface) AddPersistPFNameUdevRule(pfPciAddress, pfName string) error {
 	m.ctrl.T.Helper()
@@ -199,6 +219,20 @@ func (mr *MockHostManagerInterfaceMockRecorder) ConfigSriovInterfaces(storeManag
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigSriovInterfaces", reflect.TypeOf((*MockHostManagerInterface)(nil).ConfigSriovInterfaces), storeManager, interfaces, ifaceStatuses, skipVFConfiguration)
 }
 
+// ConfigureBonds mocks base method.
+func (m *MockHostManagerInterface) ConfigureBonds(bondsSpec v1.Bonds, bondsStatus []v1.BondStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigureBonds", bondsSpec, bondsStatus)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfigureBonds indicates an expected call of ConfigureBonds.
+func (mr *MockHostManagerInterfaceMockRecorder) ConfigureBonds(bondsSpec, bondsStatus any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureBonds", reflect.TypeOf((*MockHostManagerInterface)(nil).ConfigureBonds), bondsSpec, bondsStatus)
+}
+
 // ConfigureBridges mocks base method.
 func (m *MockHostManagerInterface) ConfigureBridges(bridgesSpec, bridgesStatus v1.Bridges) error {
 	m.ctrl.T.Helper()
@@ -213,32 +247,74 @@ func (mr *MockHostManagerInterfaceMockRecorder) ConfigureBridges(bridgesSpec, br
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureBridges", reflect.TypeOf((*MockHostManagerInterface)(nil).ConfigureBridges), bridgesSpec, bridgesStatus)
 }
 
+// ConfigureSfs mocks base method.
+func (m *MockHostManagerInterface) ConfigureSfs(pciAddr string, numSfs int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigureSfs", pciAddr, numSfs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfigureSfs indicates an expected call of ConfigureSfs.
+func (mr *MockHostManagerInterfaceMockRecorder) ConfigureSfs(pciAddr, numSfs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureSfs", reflect.TypeOf((*MockHostManagerInterface)(nil).ConfigureSfs), pciAddr, numSfs)
+}
+
 // ConfigureVfGUID mocks base method.
-func (m *MockHostManagerInterface) ConfigureVfGUID(vfAddr, pfAddr string, vfID int, pfLink netlink.Link) error {
+func (m *MockHostManagerInterface) ConfigureVfGUID(vfAddr, pfAddr string, vfID int, pfLink netlink.Link, allocation *v1.IbGUIDAllocation) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ConfigureVfGUID", vfAddr, pfAddr, vfID, pfLink)
+	ret := m.ctrl.Call(m, "ConfigureVfGUID", vfAddr, pfAddr, vfID, pfLink, allocation)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ConfigureVfGUID indicates an expected call of ConfigureVfGUID.
-func (mr *MockHostManagerInterfaceMockRecorder) ConfigureVfGUID(vfAddr, pfAddr, vfID, pfLink any) *gomock.Call {
+func (mr *MockHostManagerInterfaceMockRecorder) ConfigureVfGUID(vfAddr, pfAddr, vfID, pfLink, allocation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureVfGUID", reflect.TypeOf((*MockHostManagerInterface)(nil).ConfigureVfGUID), vfAddr, pfAddr, vfID, pfLink, allocation)
+}
+
+// ConfigureVfPKeys mocks base method.
+func (m *MockHostManagerInterface) ConfigureVfPKeys(vfAddr, pfAddr string, pkeys []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigureVfPKeys", vfAddr, pfAddr, pkeys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfigureVfPKeys indicates an expected call of ConfigureVfPKeys.
+func (mr *MockHostManagerInterfaceMockRecorder) ConfigureVfPKeys(vfAddr, pfAddr, pkeys any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureVfGUID", reflect.TypeOf((*MockHostManagerInterface)(nil).ConfigureVfGUID), vfAddr, pfAddr, vfID, pfLink)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureVfPKeys", reflect.TypeOf((*MockHostManagerInterface)(nil).ConfigureVfPKeys), vfAddr, pfAddr, pkeys)
 }
 
 // CreateVDPADevice mocks base method.
-func (m *MockHostManagerInterface) CreateVDPADevice(pciAddr, vdpaType string) error {
+func (m *MockHostManagerInterface) CreateVDPADevice(pciAddr, vdpaType string, attrs *v1.VdpaAttributes) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateVDPADevice", pciAddr, vdpaType)
+	ret := m.ctrl.Call(m, "CreateVDPADevice", pciAddr, vdpaType, attrs)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateVDPADevice indicates an expected call of CreateVDPADevice.
-func (mr *MockHostManagerInterfaceMockRecorder) CreateVDPADevice(pciAddr, vdpaType any) *gomock.Call {
+func (mr *MockHostManagerInterfaceMockRecorder) CreateVDPADevice(pciAddr, vdpaType, attrs any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVDPADevice", reflect.TypeOf((*MockHostManagerInterface)(nil).CreateVDPADevice), pciAddr, vdpaType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVDPADevice", reflect.TypeOf((*MockHostManagerInterface)(nil).CreateVDPADevice), pciAddr, vdpaType, attrs)
+}
+
+// DeleteSfs mocks base method.
+func (m *MockHostManagerInterface) DeleteSfs(pciAddr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSfs", pciAddr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSfs indicates an expected call of DeleteSfs.
+func (mr *MockHostManagerInterfaceMockRecorder) DeleteSfs(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSfs", reflect.TypeOf((*MockHostManagerInterface)(nil).DeleteSfs), pciAddr)
 }
 
 // DeleteVDPADevice mocks base method.
@@ -269,6 +345,35 @@ func (mr *MockHostManagerInterfaceMockRecorder) DetachInterfaceFromManagedBridge
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachInterfaceFromManagedBridge", reflect.TypeOf((*MockHostManagerInterface)(nil).DetachInterfaceFromManagedBridge), pciAddr)
 }
 
+// DetachPfFromManagedBond mocks base method.
+func (m *MockHostManagerInterface) DetachPfFromManagedBond(pciAddr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachPfFromManagedBond", pciAddr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachPfFromManagedBond indicates an expected call of DetachPfFromManagedBond.
+func (mr *MockHostManagerInterfaceMockRecorder) DetachPfFromManagedBond(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachPfFromManagedBond", reflect.TypeOf((*MockHostManagerInterface)(nil).DetachPfFromManagedBond), pciAddr)
+}
+
+// DiscoverBonds mocks base method.
+func (m *MockHostManagerInterface) DiscoverBonds() ([]v1.BondStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscoverBonds")
+	ret0, _ := ret[0].([]v1.BondStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscoverBonds indicates an expected call of DiscoverBonds.
+func (mr *MockHostManagerInterfaceMockRecorder) DiscoverBonds() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverBonds", reflect.TypeOf((*MockHostManagerInterface)(nil).DiscoverBonds))
+}
+
 // DiscoverBridges mocks base method.
 func (m *MockHostManagerInterface) DiscoverBridges() (v1.Bridges, error) {
 	m.ctrl.T.Helper()
@@ -299,6 +404,21 @@ func (mr *MockHostManagerInterfaceMockRecorder) DiscoverRDMASubsystem() *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverRDMASubsystem", reflect.TypeOf((*MockHostManagerInterface)(nil).DiscoverRDMASubsystem))
 }
 
+// DiscoverSfs mocks base method.
+func (m *MockHostManagerInterface) DiscoverSfs(pciAddr string) ([]v1.ScalableFunction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscoverSfs", pciAddr)
+	ret0, _ := ret[0].([]v1.ScalableFunction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscoverSfs indicates an expected call of DiscoverSfs.
+func (mr *MockHostManagerInterfaceMockRecorder) DiscoverSfs(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverSfs", reflect.TypeOf((*MockHostManagerInterface)(nil).DiscoverSfs), pciAddr)
+}
+
 // DiscoverSriovDevices mocks base method.
 func (m *MockHostManagerInterface) DiscoverSriovDevices(storeManager store.ManagerInterface) ([]v1.InterfaceExt, error) {
 	m.ctrl.T.Helper()
@@ -314,6 +434,21 @@ func (mr *MockHostManagerInterfaceMockRecorder) DiscoverSriovDevices(storeManage
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverSriovDevices", reflect.TypeOf((*MockHostManagerInterface)(nil).DiscoverSriovDevices), storeManager)
 }
 
+// DiscoverSriovPfs mocks base method.
+func (m *MockHostManagerInterface) DiscoverSriovPfs(storeManager store.ManagerInterface, pciAddresses []string) ([]v1.InterfaceExt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscoverSriovPfs", storeManager, pciAddresses)
+	ret0, _ := ret[0].([]v1.InterfaceExt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscoverSriovPfs indicates an expected call of DiscoverSriovPfs.
+func (mr *MockHostManagerInterfaceMockRecorder) DiscoverSriovPfs(storeManager, pciAddresses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverSriovPfs", reflect.TypeOf((*MockHostManagerInterface)(nil).DiscoverSriovPfs), storeManager, pciAddresses)
+}
+
 // DiscoverSriovVirtualDevices mocks base method.
 func (m *MockHostManagerInterface) DiscoverSriovVirtualDevices() ([]v1.InterfaceExt, error) {
 	m.ctrl.T.Helper()
@@ -329,6 +464,20 @@ func (mr *MockHostManagerInterfaceMockRecorder) DiscoverSriovVirtualDevices() *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverSriovVirtualDevices", reflect.TypeOf((*MockHostManagerInterface)(nil).DiscoverSriovVirtualDevices))
 }
 
+// DiscoverVDPAAttributes mocks base method.
+func (m *MockHostManagerInterface) DiscoverVDPAAttributes(pciAddr string) *v1.VdpaAttributes {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscoverVDPAAttributes", pciAddr)
+	ret0, _ := ret[0].(*v1.VdpaAttributes)
+	return ret0
+}
+
+// DiscoverVDPAAttributes indicates an expected call of DiscoverVDPAAttributes.
+func (mr *MockHostManagerInterfaceMockRecorder) DiscoverVDPAAttributes(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverVDPAAttributes", reflect.TypeOf((*MockHostManagerInterface)(nil).DiscoverVDPAAttributes), pciAddr)
+}
+
 // DiscoverVDPAType mocks base method.
 func (m *MockHostManagerInterface) DiscoverVDPAType(pciAddr string) string {
 	m.ctrl.T.Helper()
@@ -401,6 +550,21 @@ func (mr *MockHostManagerInterfaceMockRecorder) GetCurrentKernelArgs() *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentKernelArgs", reflect.TypeOf((*MockHostManagerInterface)(nil).GetCurrentKernelArgs))
 }
 
+// GetDevlinkDeviceInfo mocks base method.
+func (m *MockHostManagerInterface) GetDevlinkDeviceInfo(pciAddr string) (*netlink.DevlinkDeviceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDevlinkDeviceInfo", pciAddr)
+	ret0, _ := ret[0].(*netlink.DevlinkDeviceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDevlinkDeviceInfo indicates an expected call of GetDevlinkDeviceInfo.
+func (mr *MockHostManagerInterfaceMockRecorder) GetDevlinkDeviceInfo(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevlinkDeviceInfo", reflect.TypeOf((*MockHostManagerInterface)(nil).GetDevlinkDeviceInfo), pciAddr)
+}
+
 // GetDevlinkDeviceParam mocks base method.
 func (m *MockHostManagerInterface) GetDevlinkDeviceParam(pciAddr, paramName string) (string, error) {
 	m.ctrl.T.Helper()
@@ -416,6 +580,20 @@ func (mr *MockHostManagerInterfaceMockRecorder) GetDevlinkDeviceParam(pciAddr, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevlinkDeviceParam", reflect.TypeOf((*MockHostManagerInterface)(nil).GetDevlinkDeviceParam), pciAddr, paramName)
 }
 
+// GetDevlinkParams mocks base method.
+func (m *MockHostManagerInterface) GetDevlinkParams(pciAddr string) []v1.DevlinkParamStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDevlinkParams", pciAddr)
+	ret0, _ := ret[0].([]v1.DevlinkParamStatus)
+	return ret0
+}
+
+// GetDevlinkParams indicates an expected call of GetDevlinkParams.
+func (mr *MockHostManagerInterfaceMockRecorder) GetDevlinkParams(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevlinkParams", reflect.TypeOf((*MockHostManagerInterface)(nil).GetDevlinkParams), pciAddr)
+}
+
 // GetDriverByBusAndDevice mocks base method.
 func (m *MockHostManagerInterface) GetDriverByBusAndDevice(bus, device string) (string, error) {
 	m.ctrl.T.Helper()
@@ -446,6 +624,37 @@ func (mr *MockHostManagerInterfaceMockRecorder) GetInterfaceIndex(pciAddr any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterfaceIndex", reflect.TypeOf((*MockHostManagerInterface)(nil).GetInterfaceIndex), pciAddr)
 }
 
+// GetIommuGroup mocks base method.
+func (m *MockHostManagerInterface) GetIommuGroup(pciAddr string) (int, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIommuGroup", pciAddr)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetIommuGroup indicates an expected call of GetIommuGroup.
+func (mr *MockHostManagerInterfaceMockRecorder) GetIommuGroup(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIommuGroup", reflect.TypeOf((*MockHostManagerInterface)(nil).GetIommuGroup), pciAddr)
+}
+
+// GetKernelArgsStatus mocks base method.
+func (m *MockHostManagerInterface) GetKernelArgsStatus() (*v1.KernelArgsStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKernelArgsStatus")
+	ret0, _ := ret[0].(*v1.KernelArgsStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKernelArgsStatus indicates an expected call of GetKernelArgsStatus.
+func (mr *MockHostManagerInterfaceMockRecorder) GetKernelArgsStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKernelArgsStatus", reflect.TypeOf((*MockHostManagerInterface)(nil).GetKernelArgsStatus))
+}
+
 // GetLinkType mocks base method.
 func (m *MockHostManagerInterface) GetLinkType(name string) string {
 	m.ctrl.T.Helper()
@@ -460,6 +669,22 @@ func (mr *MockHostManagerInterfaceMockRecorder) GetLinkType(name any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkType", reflect.TypeOf((*MockHostManagerInterface)(nil).GetLinkType), name)
 }
 
+// GetNetDevDriverInfo mocks base method.
+func (m *MockHostManagerInterface) GetNetDevDriverInfo(ifaceName string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetDevDriverInfo", ifaceName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetNetDevDriverInfo indicates an expected call of GetNetDevDriverInfo.
+func (mr *MockHostManagerInterfaceMockRecorder) GetNetDevDriverInfo(ifaceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetDevDriverInfo", reflect.TypeOf((*MockHostManagerInterface)(nil).GetNetDevDriverInfo), ifaceName)
+}
+
 // GetNetDevLinkAdminState mocks base method.
 func (m *MockHostManagerInterface) GetNetDevLinkAdminState(ifaceName string) string {
 	m.ctrl.T.Helper()
@@ -544,6 +769,21 @@ func (mr *MockHostManagerInterfaceMockRecorder) GetNicSriovMode(pciAddr any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNicSriovMode", reflect.TypeOf((*MockHostManagerInterface)(nil).GetNicSriovMode), pciAddr)
 }
 
+// GetOperatorKernelArgs mocks base method.
+func (m *MockHostManagerInterface) GetOperatorKernelArgs() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOperatorKernelArgs")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOperatorKernelArgs indicates an expected call of GetOperatorKernelArgs.
+func (mr *MockHostManagerInterfaceMockRecorder) GetOperatorKernelArgs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperatorKernelArgs", reflect.TypeOf((*MockHostManagerInterface)(nil).GetOperatorKernelArgs))
+}
+
 // GetPciAddressFromInterfaceName mocks base method.
 func (m *MockHostManagerInterface) GetPciAddressFromInterfaceName(interfaceName string) (string, error) {
 	m.ctrl.T.Helper()
@@ -559,6 +799,20 @@ func (mr *MockHostManagerInterfaceMockRecorder) GetPciAddressFromInterfaceName(i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPciAddressFromInterfaceName", reflect.TypeOf((*MockHostManagerInterface)(nil).GetPciAddressFromInterfaceName), interfaceName)
 }
 
+// GetPfSettings mocks base method.
+func (m *MockHostManagerInterface) GetPfSettings(ifaceName string) *v1.PfSettings {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPfSettings", ifaceName)
+	ret0, _ := ret[0].(*v1.PfSettings)
+	return ret0
+}
+
+// GetPfSettings indicates an expected call of GetPfSettings.
+func (mr *MockHostManagerInterfaceMockRecorder) GetPfSettings(ifaceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPfSettings", reflect.TypeOf((*MockHostManagerInterface)(nil).GetPfSettings), ifaceName)
+}
+
 // GetPhysPortName mocks base method.
 func (m *MockHostManagerInterface) GetPhysPortName(name string) (string, error) {
 	m.ctrl.T.Helper()
@@ -589,6 +843,35 @@ func (mr *MockHostManagerInterfaceMockRecorder) GetPhysSwitchID(name any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPhysSwitchID", reflect.TypeOf((*MockHostManagerInterface)(nil).GetPhysSwitchID), name)
 }
 
+// GetPodAllocatedDevices mocks base method.
+func (m *MockHostManagerInterface) GetPodAllocatedDevices() (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPodAllocatedDevices")
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPodAllocatedDevices indicates an expected call of GetPodAllocatedDevices.
+func (mr *MockHostManagerInterfaceMockRecorder) GetPodAllocatedDevices() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodAllocatedDevices", reflect.TypeOf((*MockHostManagerInterface)(nil).GetPodAllocatedDevices))
+}
+
+// GetVfPKeys mocks base method.
+func (m *MockHostManagerInterface) GetVfPKeys(vfAddr string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVfPKeys", vfAddr)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetVfPKeys indicates an expected call of GetVfPKeys.
+func (mr *MockHostManagerInterfaceMockRecorder) GetVfPKeys(vfAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVfPKeys", reflect.TypeOf((*MockHostManagerInterface)(nil).GetVfPKeys), vfAddr)
+}
+
 // HasDriver mocks base method.
 func (m *MockHostManagerInterface) HasDriver(pciAddr string) (bool, string) {
 	m.ctrl.T.Helper()
@@ -604,6 +887,20 @@ func (mr *MockHostManagerInterfaceMockRecorder) HasDriver(pciAddr any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasDriver", reflect.TypeOf((*MockHostManagerInterface)(nil).HasDriver), pciAddr)
 }
 
+// IsIommuEnabled mocks base method.
+func (m *MockHostManagerInterface) IsIommuEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsIommuEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsIommuEnabled indicates an expected call of IsIommuEnabled.
+func (mr *MockHostManagerInterfaceMockRecorder) IsIommuEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsIommuEnabled", reflect.TypeOf((*MockHostManagerInterface)(nil).IsIommuEnabled))
+}
+
 // IsKernelArgsSet mocks base method.
 func (m *MockHostManagerInterface) IsKernelArgsSet(cmdLine, karg string) bool {
 	m.ctrl.T.Helper()
@@ -856,6 +1153,20 @@ func (mr *MockHostManagerInterfaceMockRecorder) RebindVfToDefaultDriver(pciAddr
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebindVfToDefaultDriver", reflect.TypeOf((*MockHostManagerInterface)(nil).RebindVfToDefaultDriver), pciAddr)
 }
 
+// ReloadDevlinkDevice mocks base method.
+func (m *MockHostManagerInterface) ReloadDevlinkDevice(pciAddr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReloadDevlinkDevice", pciAddr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReloadDevlinkDevice indicates an expected call of ReloadDevlinkDevice.
+func (mr *MockHostManagerInterfaceMockRecorder) ReloadDevlinkDevice(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadDevlinkDevice", reflect.TypeOf((*MockHostManagerInterface)(nil).ReloadDevlinkDevice), pciAddr)
+}
+
 // RemoveDisableNMUdevRule mocks base method.
 func (m *MockHostManagerInterface) RemoveDisableNMUdevRule(pfPciAddress string) error {
 	m.ctrl.T.Helper()
@@ -870,6 +1181,25 @@ func (mr *MockHostManagerInterfaceMockRecorder) RemoveDisableNMUdevRule(pfPciAdd
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDisableNMUdevRule", reflect.TypeOf((*MockHostManagerInterface)(nil).RemoveDisableNMUdevRule), pfPciAddress)
 }
 
+// RemoveKernelArgs mocks base method.
+func (m *MockHostManagerInterface) RemoveKernelArgs(kargs ...string) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range kargs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveKernelArgs", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveKernelArgs indicates an expected call of RemoveKernelArgs.
+func (mr *MockHostManagerInterfaceMockRecorder) RemoveKernelArgs(kargs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveKernelArgs", reflect.TypeOf((*MockHostManagerInterface)(nil).RemoveKernelArgs), kargs...)
+}
+
 // RemovePersistPFNameUdevRule mocks base method.
 func (m *MockHostManagerInterface) RemovePersistPFNameUdevRule(pfPciAddress string) error {
 	m.ctrl.T.Helper()
@@ -926,6 +1256,20 @@ func (mr *MockHostManagerInterfaceMockRecorder) ResetSriovDevice(ifaceStatus any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetSriovDevice", reflect.TypeOf((*MockHostManagerInterface)(nil).ResetSriovDevice), ifaceStatus)
 }
 
+// ResetVfPKeys mocks base method.
+func (m *MockHostManagerInterface) ResetVfPKeys(vfAddr, pfAddr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetVfPKeys", vfAddr, pfAddr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetVfPKeys indicates an expected call of ResetVfPKeys.
+func (mr *MockHostManagerInterfaceMockRecorder) ResetVfPKeys(vfAddr, pfAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetVfPKeys", reflect.TypeOf((*MockHostManagerInterface)(nil).ResetVfPKeys), vfAddr, pfAddr)
+}
+
 // SetDevlinkDeviceParam mocks base method.
 func (m *MockHostManagerInterface) SetDevlinkDeviceParam(pciAddr, paramName, value string) error {
 	m.ctrl.T.Helper()
@@ -940,6 +1284,35 @@ func (mr *MockHostManagerInterfaceMockRecorder) SetDevlinkDeviceParam(pciAddr, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDevlinkDeviceParam", reflect.TypeOf((*MockHostManagerInterface)(nil).SetDevlinkDeviceParam), pciAddr, paramName, value)
 }
 
+// SetDevlinkParams mocks base method.
+func (m *MockHostManagerInterface) SetDevlinkParams(pciAddr string, params map[string]v1.DevlinkParam) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDevlinkParams", pciAddr, params)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetDevlinkParams indicates an expected call of SetDevlinkParams.
+func (mr *MockHostManagerInterfaceMockRecorder) SetDevlinkParams(pciAddr, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDevlinkParams", reflect.TypeOf((*MockHostManagerInterface)(nil).SetDevlinkParams), pciAddr, params)
+}
+
+// SetKernelModuleParam mocks base method.
+func (m *MockHostManagerInterface) SetKernelModuleParam(module, param, value string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKernelModuleParam", module, param, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetKernelModuleParam indicates an expected call of SetKernelModuleParam.
+func (mr *MockHostManagerInterfaceMockRecorder) SetKernelModuleParam(module, param, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKernelModuleParam", reflect.TypeOf((*MockHostManagerInterface)(nil).SetKernelModuleParam), module, param, value)
+}
+
 // SetNetdevMTU mocks base method.
 func (m *MockHostManagerInterface) SetNetdevMTU(pciAddr string, mtu int) error {
 	m.ctrl.T.Helper()
@@ -968,6 +1341,20 @@ func (mr *MockHostManagerInterfaceMockRecorder) SetNicSriovMode(pciAddr, mode an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNicSriovMode", reflect.TypeOf((*MockHostManagerInterface)(nil).SetNicSriovMode), pciAddr, mode)
 }
 
+// SetPfSettings mocks base method.
+func (m *MockHostManagerInterface) SetPfSettings(ifaceName string, settings *v1.PfSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPfSettings", ifaceName, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPfSettings indicates an expected call of SetPfSettings.
+func (mr *MockHostManagerInterfaceMockRecorder) SetPfSettings(ifaceName, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPfSettings", reflect.TypeOf((*MockHostManagerInterface)(nil).SetPfSettings), ifaceName, settings)
+}
+
 // SetRDMASubsystem mocks base method.
 func (m *MockHostManagerInterface) SetRDMASubsystem(mode string) error {
 	m.ctrl.T.Helper()
@@ -1133,6 +1520,20 @@ func (mr *MockHostManagerInterfaceMockRecorder) VFIsReady(pciAddr any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VFIsReady", reflect.TypeOf((*MockHostManagerInterface)(nil).VFIsReady), pciAddr)
 }
 
+// ValidateIceSwitchdevSupport mocks base method.
+func (m *MockHostManagerInterface) ValidateIceSwitchdevSupport(pciAddr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateIceSwitchdevSupport", pciAddr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateIceSwitchdevSupport indicates an expected call of ValidateIceSwitchdevSupport.
+func (mr *MockHostManagerInterfaceMockRecorder) ValidateIceSwitchdevSupport(pciAddr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateIceSwitchdevSupport", reflect.TypeOf((*MockHostManagerInterface)(nil).ValidateIceSwitchdevSupport), pciAddr)
+}
+
 // WaitUdevEventsProcessed mocks base method.
 func (m *MockHostManagerInterface) WaitUdevEventsProcessed(timeout int) error {
 	m.ctrl.T.Helper()
@@ -1147,6 +1548,20 @@ func (mr *MockHostManagerInterfaceMockRecorder) WaitUdevEventsProcessed(timeout
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUdevEventsProcessed", reflect.TypeOf((*MockHostManagerInterface)(nil).WaitUdevEventsProcessed), timeout)
 }
 
+// WatchHostEvents mocks base method.
+func (m *MockHostManagerInterface) WatchHostEvents(ctx context.Context, events chan<- types.HostEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchHostEvents", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchHostEvents indicates an expected call of WatchHostEvents.
+func (mr *MockHostManagerInterfaceMockRecorder) WatchHostEvents(ctx, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchHostEvents", reflect.TypeOf((*MockHostManagerInterface)(nil).WatchHostEvents), ctx, events)
+}
+
 // WriteConfFile mocks base method.
 func (m *MockHostManagerInterface) WriteConfFile(newState *v1.SriovNetworkNodeState) (bool, error) {
 	m.ctrl.T.Helper()
@@ -69,6 +69,37 @@ func (mr *MockManagerInterfaceMockRecorder) GetCheckPointNodeState() *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckPointNodeState", reflect.TypeOf((*MockManagerInterface)(nil).GetCheckPointNodeState))
 }
 
+// GetCompletedReboots mocks base method.
+func (m *MockManagerInterface) GetCompletedReboots() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompletedReboots")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompletedReboots indicates an expected call of GetCompletedReboots.
+func (mr *MockManagerInterfaceMockRecorder) GetCompletedReboots() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompletedReboots", reflect.TypeOf((*MockManagerInterface)(nil).GetCompletedReboots))
+}
+
+// GetLastAppliedNodeState mocks base method.
+func (m *MockManagerInterface) GetLastAppliedNodeState() (*v1.SriovNetworkNodeState, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastAppliedNodeState")
+	ret0, _ := ret[0].(*v1.SriovNetworkNodeState)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLastAppliedNodeState indicates an expected call of GetLastAppliedNodeState.
+func (mr *MockManagerInterfaceMockRecorder) GetLastAppliedNodeState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastAppliedNodeState", reflect.TypeOf((*MockManagerInterface)(nil).GetLastAppliedNodeState))
+}
+
 // LoadPfsStatus mocks base method.
 func (m *MockManagerInterface) LoadPfsStatus(pciAddress string) (*v1.Interface, bool, error) {
 	m.ctrl.T.Helper()
@@ -99,6 +130,20 @@ func (mr *MockManagerInterfaceMockRecorder) RemovePfAppliedStatus(pciAddress any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePfAppliedStatus", reflect.TypeOf((*MockManagerInterface)(nil).RemovePfAppliedStatus), pciAddress)
 }
 
+// SaveLastAppliedNodeState mocks base method.
+func (m *MockManagerInterface) SaveLastAppliedNodeState(arg0 *v1.SriovNetworkNodeState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLastAppliedNodeState", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveLastAppliedNodeState indicates an expected call of SaveLastAppliedNodeState.
+func (mr *MockManagerInterfaceMockRecorder) SaveLastAppliedNodeState(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLastAppliedNodeState", reflect.TypeOf((*MockManagerInterface)(nil).SaveLastAppliedNodeState), arg0)
+}
+
 // SaveLastPfAppliedStatus mocks base method.
 func (m *MockManagerInterface) SaveLastPfAppliedStatus(PfInfo *v1.Interface) error {
 	m.ctrl.T.Helper()
@@ -113,6 +158,20 @@ func (mr *MockManagerInterfaceMockRecorder) SaveLastPfAppliedStatus(PfInfo any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLastPfAppliedStatus", reflect.TypeOf((*MockManagerInterface)(nil).SaveLastPfAppliedStatus), PfInfo)
 }
 
+// SaveRebootRequest mocks base method.
+func (m *MockManagerInterface) SaveRebootRequest() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRebootRequest")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRebootRequest indicates an expected call of SaveRebootRequest.
+func (mr *MockManagerInterfaceMockRecorder) SaveRebootRequest() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRebootRequest", reflect.TypeOf((*MockManagerInterface)(nil).SaveRebootRequest))
+}
+
 // WriteCheckpointFile mocks base method.
 func (m *MockManagerInterface) WriteCheckpointFile(arg0 *v1.SriovNetworkNodeState) error {
 	m.ctrl.T.Helper()
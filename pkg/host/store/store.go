@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -41,6 +42,20 @@ type ManagerInterface interface {
 
 	GetCheckPointNodeState() (*sriovnetworkv1.SriovNetworkNodeState, error)
 	WriteCheckpointFile(*sriovnetworkv1.SriovNetworkNodeState) error
+
+	SaveLastAppliedNodeState(*sriovnetworkv1.SriovNetworkNodeState) error
+	GetLastAppliedNodeState() (*sriovnetworkv1.SriovNetworkNodeState, bool, error)
+
+	SaveRebootRequest() error
+	GetCompletedReboots() (int, error)
+}
+
+// rebootsRecord tracks the reboots requested by the config daemon on the node
+type rebootsRecord struct {
+	// boot ID of the kernel running when the last reboot was requested, empty if no reboot is pending
+	RequestedFromBootID string `json:"requestedFromBootID,omitempty"`
+	// number of requested reboots after which the node started with a new boot ID
+	Completed int `json:"completed"`
 }
 
 type manager struct{}
@@ -205,3 +220,114 @@ func (s *manager) WriteCheckpointFile(ns *sriovnetworkv1.SriovNetworkNodeState)
 	}
 	return nil
 }
+
+// SaveLastAppliedNodeState saves the generation and the spec of the last nodeState successfully applied
+// on the node into /etc/sriov-operator/last-applied-node-state.json
+func (s *manager) SaveLastAppliedNodeState(ns *sriovnetworkv1.SriovNetworkNodeState) error {
+	lastApplied := &sriovnetworkv1.SriovNetworkNodeState{}
+	lastApplied.Name = ns.Name
+	lastApplied.Generation = ns.Generation
+	lastApplied.Spec = ns.Spec
+	data, err := json.Marshal(lastApplied)
+	if err != nil {
+		log.Log.Error(err, "failed to marshal last applied node state")
+		return err
+	}
+
+	hostExtension := utils.GetHostExtension()
+	pathFile := filepath.Join(hostExtension, consts.LastAppliedNodeStatePath)
+	return os.WriteFile(pathFile, data, 0o644)
+}
+
+// GetLastAppliedNodeState loads the last nodeState successfully applied on the node,
+// returns false if no configuration was saved.
+func (s *manager) GetLastAppliedNodeState() (*sriovnetworkv1.SriovNetworkNodeState, bool, error) {
+	hostExtension := utils.GetHostExtension()
+	pathFile := filepath.Join(hostExtension, consts.LastAppliedNodeStatePath)
+	data, err := os.ReadFile(pathFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		log.Log.Error(err, "failed to read last applied node state file", "path", pathFile)
+		return nil, false, err
+	}
+
+	lastApplied := &sriovnetworkv1.SriovNetworkNodeState{}
+	if err := json.Unmarshal(data, lastApplied); err != nil {
+		log.Log.Error(err, "failed to unmarshal last applied node state", "data", string(data))
+		return nil, false, err
+	}
+	return lastApplied, true, nil
+}
+
+// SaveRebootRequest records the boot ID of the running kernel before the config daemon reboots the node
+// into /etc/sriov-operator/reboots.json
+func (s *manager) SaveRebootRequest() error {
+	record, err := loadRebootsRecord()
+	if err != nil {
+		return err
+	}
+	record.RequestedFromBootID, err = getBootID()
+	if err != nil {
+		return err
+	}
+	return saveRebootsRecord(record)
+}
+
+// GetCompletedReboots returns the number of reboots requested by the config daemon and completed on the node,
+// a pending request is completed when the node runs with a new boot ID
+func (s *manager) GetCompletedReboots() (int, error) {
+	record, err := loadRebootsRecord()
+	if err != nil {
+		return 0, err
+	}
+	if record.RequestedFromBootID == "" {
+		return record.Completed, nil
+	}
+	bootID, err := getBootID()
+	if err != nil {
+		return 0, err
+	}
+	if bootID == record.RequestedFromBootID {
+		return record.Completed, nil
+	}
+	record.Completed++
+	record.RequestedFromBootID = ""
+	if err := saveRebootsRecord(record); err != nil {
+		return 0, err
+	}
+	return record.Completed, nil
+}
+
+func getBootID() (string, error) {
+	data, err := os.ReadFile(utils.GetHostExtensionPath(consts.BootIDPath))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the boot ID: %v", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func loadRebootsRecord() (*rebootsRecord, error) {
+	record := &rebootsRecord{}
+	pathFile := utils.GetHostExtensionPath(consts.RebootsPath)
+	data, err := os.ReadFile(pathFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return record, nil
+		}
+		return nil, fmt.Errorf("failed to read reboots file %s: %v", pathFile, err)
+	}
+	if err := json.Unmarshal(data, record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reboots file %s: %v", pathFile, err)
+	}
+	return record, nil
+}
+
+func saveRebootsRecord(record *rebootsRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(utils.GetHostExtensionPath(consts.RebootsPath), data, 0o644)
+}
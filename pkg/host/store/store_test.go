@@ -330,4 +330,79 @@ var _ = Describe("Store", func() {
 			Expect(ns.Name).To(Equal("worker-0"))
 		})
 	})
+
+	Context("LastAppliedNodeState", func() {
+		It("should return false if no node state was saved", func() {
+			_, exist, err := m.GetLastAppliedNodeState()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exist).To(BeFalse())
+		})
+
+		It("should save the generation and the spec of the node state", func() {
+			applied := testNodeState.DeepCopy()
+			applied.Generation = 3
+			applied.Spec.Interfaces = sriovnetworkv1.Interfaces{*testInterface}
+
+			err = m.SaveLastAppliedNodeState(applied)
+			Expect(err).ToNot(HaveOccurred())
+
+			loaded, exist, err := m.GetLastAppliedNodeState()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exist).To(BeTrue())
+			Expect(loaded.Generation).To(Equal(int64(3)))
+			Expect(loaded.Spec).To(Equal(applied.Spec))
+			Expect(loaded.Status.Interfaces).To(BeEmpty())
+		})
+
+		It("should return error if not able to parse the file", func() {
+			err = os.WriteFile(utils.GetHostExtensionPath(consts.LastAppliedNodeStatePath), []byte("test"), 0644)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = m.GetLastAppliedNodeState()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Reboots", func() {
+		setBootID := func(bootID string) {
+			bootIDPath := utils.GetHostExtensionPath(consts.BootIDPath)
+			Expect(os.MkdirAll(path.Dir(bootIDPath), 0755)).To(Succeed())
+			Expect(os.WriteFile(bootIDPath, []byte(bootID+"\n"), 0644)).To(Succeed())
+		}
+
+		It("should return no reboot if no reboot was requested", func() {
+			completed, err := m.GetCompletedReboots()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(completed).To(Equal(0))
+		})
+
+		It("should count the requested reboots once the node started with a new boot ID", func() {
+			setBootID("boot-1")
+			Expect(m.SaveRebootRequest()).To(Succeed())
+
+			// the daemon restarted without a reboot
+			completed, err := m.GetCompletedReboots()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(completed).To(Equal(0))
+
+			setBootID("boot-2")
+			completed, err = m.GetCompletedReboots()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(completed).To(Equal(1))
+			// the reboot is counted once
+			completed, err = m.GetCompletedReboots()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(completed).To(Equal(1))
+
+			Expect(m.SaveRebootRequest()).To(Succeed())
+			setBootID("boot-3")
+			completed, err = m.GetCompletedReboots()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(completed).To(Equal(2))
+		})
+
+		It("should return error if not able to read the boot ID", func() {
+			Expect(m.SaveRebootRequest()).To(HaveOccurred())
+		})
+	})
 })
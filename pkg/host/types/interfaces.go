@@ -17,6 +17,8 @@
 package types
 
 import (
+	"context"
+
 	"github.com/vishvananda/netlink"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
@@ -34,6 +36,16 @@ type KernelInterface interface {
 	GetCurrentKernelArgs() (string, error)
 	// IsKernelArgsSet check is the requested kernel arguments are set
 	IsKernelArgsSet(cmdLine, karg string) bool
+	// AddKernelArgs adds the kernel arguments to the boot configuration of the next boot, the arguments
+	// already configured are skipped. It returns true if the boot configuration changed.
+	AddKernelArgs(kargs ...string) (bool, error)
+	// RemoveKernelArgs removes the kernel arguments from the boot configuration of the next boot, the arguments
+	// not configured are skipped. It returns true if the boot configuration changed.
+	RemoveKernelArgs(kargs ...string) (bool, error)
+	// GetOperatorKernelArgs returns the kernel arguments added to the boot configuration by the config daemon
+	GetOperatorKernelArgs() ([]string, error)
+	// GetKernelArgsStatus returns the managed kernel arguments active on the running kernel or pending for the next boot
+	GetKernelArgsStatus() (*sriovnetworkv1.KernelArgsStatus, error)
 	// Unbind unbinds a virtual function from is current driver
 	Unbind(pciAddr string) error
 	// BindDpdkDriver binds the virtual function to a DPDK driver
@@ -64,8 +76,15 @@ type KernelInterface interface {
 	LoadKernelModule(name string, args ...string) error
 	// IsKernelModuleLoaded returns try if the requested kernel module is loaded
 	IsKernelModuleLoaded(name string) (bool, error)
+	// SetKernelModuleParam sets a parameter of a loaded kernel module through the sysfs
+	SetKernelModuleParam(module, param, value string) error
 	// IsKernelLockdownMode returns true if the kernel is in lockdown mode
 	IsKernelLockdownMode() bool
+	// IsIommuEnabled returns true if the IOMMU of the host is enabled, the kernel creates the IOMMU groups
+	IsIommuEnabled() bool
+	// GetIommuGroup returns the IOMMU group of the PCI device and the other devices of the group,
+	// the PCI bridges are excluded as they don't prevent the isolation of the device by vfio-pci
+	GetIommuGroup(pciAddr string) (group int, devices []string, err error)
 }
 
 type NetworkInterface interface {
@@ -100,8 +119,24 @@ type NetworkInterface interface {
 	// as a string. Automatically set CMODE for the parameter and converts the value to the right
 	// type before submitting it.
 	SetDevlinkDeviceParam(pciAddr, paramName, value string) error
+	// GetDevlinkParams returns the values of all the devlink parameters of the device in each
+	// configuration mode they support, the parameters that can't be read are skipped
+	GetDevlinkParams(pciAddr string) []sriovnetworkv1.DevlinkParamStatus
+	// SetDevlinkParams sets the devlink parameters of the device that differ from the current values,
+	// returns true if a parameter was set in driverinit mode and the driver must be reloaded to apply it
+	SetDevlinkParams(pciAddr string, params map[string]sriovnetworkv1.DevlinkParam) (bool, error)
+	// ReloadDevlinkDevice reloads the driver of the device to apply the driverinit devlink parameters
+	ReloadDevlinkDevice(pciAddr string) error
+	// GetDevlinkDeviceInfo returns the driver, serial number and firmware versions reported by devlink for the device
+	GetDevlinkDeviceInfo(pciAddr string) (*netlink.DevlinkDeviceInfo, error)
 	// EnableHwTcOffload make sure that hw-tc-offload feature is enabled if device supports it
 	EnableHwTcOffload(ifaceName string) error
+	// GetPfSettings returns the ethtool settings of the interface
+	GetPfSettings(ifaceName string) *sriovnetworkv1.PfSettings
+	// SetPfSettings configures the ethtool settings of the interface
+	SetPfSettings(ifaceName string, settings *sriovnetworkv1.PfSettings) error
+	// GetNetDevDriverInfo returns the driver and firmware versions of the interface reported by ethtool
+	GetNetDevDriverInfo(ifaceName string) (driverVersion, firmwareVersion string, err error)
 	// GetNetDevLinkAdminState returns the admin state of the interface.
 	GetNetDevLinkAdminState(ifaceName string) string
 	// GetPciAddressFromInterfaceName parses sysfs to get pci address of an interface by name
@@ -145,6 +180,9 @@ type SriovInterface interface {
 	// SetNicSriovMode configure the interface mode
 	// supported modes SR-IOV legacy and switchdev
 	SetNicSriovMode(pciAddr, mode string) error
+	// ValidateIceSwitchdevSupport returns an error if the driver or the NVM of the Intel E810 PF
+	// don't support the switchdev mode
+	ValidateIceSwitchdevSupport(pciAddr string) error
 	// GetLinkType return the link type
 	// supported types are ethernet and infiniband
 	GetLinkType(name string) string
@@ -152,6 +190,9 @@ type SriovInterface interface {
 	ResetSriovDevice(ifaceStatus sriovnetworkv1.InterfaceExt) error
 	// DiscoverSriovDevices returns a list of all the available SR-IOV capable network interfaces on the system
 	DiscoverSriovDevices(storeManager store.ManagerInterface) ([]sriovnetworkv1.InterfaceExt, error)
+	// DiscoverSriovPfs returns the SR-IOV capable network interfaces with the given PCI addresses,
+	// the other devices of the system are not discovered
+	DiscoverSriovPfs(storeManager store.ManagerInterface, pciAddresses []string) ([]sriovnetworkv1.InterfaceExt, error)
 	// DiscoverSriovVirtualDevices returns a list of all the available SR-IOV VF network interfaces on the system.
 	// Only supported on virtual environments where we don't have the physical function.
 	DiscoverSriovVirtualDevices() ([]sriovnetworkv1.InterfaceExt, error)
@@ -159,6 +200,8 @@ type SriovInterface interface {
 	// if skipVFConfiguration flag is set, the function will configure PF and create VFs on it, but will skip VFs configuration
 	ConfigSriovInterfaces(storeManager store.ManagerInterface, interfaces []sriovnetworkv1.Interface,
 		ifaceStatuses []sriovnetworkv1.InterfaceExt, skipVFConfiguration bool) error
+	// GetPodAllocatedDevices returns the PCI addresses of the VFs allocated to the pods by the device plugin
+	GetPodAllocatedDevices() (map[string]bool, error)
 	// ConfigSriovDevicesVirtual configure virtual functions for virtual environments with the desired configuration
 	ConfigSriovDevicesVirtual(storeManager store.ManagerInterface, interfaces []sriovnetworkv1.Interface,
 		ifaceStatuses []sriovnetworkv1.InterfaceExt) error
@@ -190,13 +233,38 @@ type UdevInterface interface {
 }
 
 type VdpaInterface interface {
-	// CreateVDPADevice creates VDPA device for VF with required type
-	CreateVDPADevice(pciAddr, vdpaType string) error
+	// CreateVDPADevice creates VDPA device for VF with required type and attributes,
+	// the existing device is re-created if its attributes don't match
+	CreateVDPADevice(pciAddr, vdpaType string, attrs *sriovnetworkv1.VdpaAttributes) error
 	// DeleteVDPADevice removes VDPA device for provided pci address
 	DeleteVDPADevice(pciAddr string) error
 	// DiscoverVDPAType returns type of existing VDPA device for VF,
 	// returns empty string if VDPA device not found or unknown driver is in use
 	DiscoverVDPAType(pciAddr string) string
+	// DiscoverVDPAAttributes returns the attributes of the existing VDPA device for VF,
+	// returns nil if VDPA device not found
+	DiscoverVDPAAttributes(pciAddr string) *sriovnetworkv1.VdpaAttributes
+}
+
+type SfInterface interface {
+	// ConfigureSfs creates and activates the SFs 0 to numSfs-1 on the PF, the SFs created by the config daemon
+	// with a higher number are deleted. The eSwitch of the PF must be in switchdev mode.
+	ConfigureSfs(pciAddr string, numSfs int) error
+	// DiscoverSfs returns the SFs created on the PF sorted by SF number
+	DiscoverSfs(pciAddr string) ([]sriovnetworkv1.ScalableFunction, error)
+	// DeleteSfs deactivates and deletes the SFs created by the config daemon on the PF
+	DeleteSfs(pciAddr string) error
+}
+
+type BondInterface interface {
+	// DiscoverBonds returns the bonds of PFs created by the operator on the host sorted by name
+	DiscoverBonds() ([]sriovnetworkv1.BondStatus, error)
+	// ConfigureBonds creates the bonds of the spec and enslaves their PFs, the managed bonds
+	// which are not in the spec are removed. The eSwitch of the PFs must be in switchdev mode.
+	ConfigureBonds(bondsSpec sriovnetworkv1.Bonds, bondsStatus []sriovnetworkv1.BondStatus) error
+	// DetachPfFromManagedBond releases the PF from the managed bond it is enslaved to,
+	// this step is required before changing the eSwitch mode of the PF.
+	DetachPfFromManagedBond(pciAddr string) error
 }
 
 type BridgeInterface interface {
@@ -211,8 +279,16 @@ type BridgeInterface interface {
 }
 
 type InfinibandInterface interface {
-	// ConfigureVfGUID configures and sets a GUID for an IB VF device
-	ConfigureVfGUID(vfAddr string, pfAddr string, vfID int, pfLink netlink.Link) error
+	// ConfigureVfGUID configures and sets a GUID for an IB VF device, the GUID is taken from the allocation
+	// of the PF when it is not nil
+	ConfigureVfGUID(vfAddr string, pfAddr string, vfID int, pfLink netlink.Link, allocation *sriovnetworkv1.IbGUIDAllocation) error
+	// ConfigureVfPKeys maps the PKeys of the PKey table of the PF to the PKey table of the VF in the given order,
+	// the remaining entries of the VF table are unmapped. The VFs whose PF driver doesn't expose their table are skipped
+	ConfigureVfPKeys(vfAddr string, pfAddr string, pkeys []string) error
+	// GetVfPKeys returns the PKeys of the PKey table of the VF, nil if the VF has no RDMA device on the host
+	GetVfPKeys(vfAddr string) []string
+	// ResetVfPKeys restores the default PKey table of the VF, which only holds the default PKey of the PF
+	ResetVfPKeys(vfAddr string, pfAddr string) error
 }
 
 type CPUVendor int
@@ -229,6 +305,12 @@ type CPUInfoProviderInterface interface {
 	GetCPUVendor() (CPUVendor, error)
 }
 
+type HostWatcherInterface interface {
+	// WatchHostEvents sends the link changes of the PCI network interfaces and the uevents of the PCI
+	// and network devices to the events channel until the context is done or the watch fails
+	WatchHostEvents(ctx context.Context, events chan<- HostEvent) error
+}
+
 type SystemdInterface interface {
 	ReadConfFile() (spec *SriovConfig, err error)
 	WriteConfFile(newState *sriovnetworkv1.SriovNetworkNodeState) (bool, error)
@@ -59,6 +59,16 @@ type SriovConfig struct {
 	OVSDBSocketPath       string                                   `yaml:"ovsdbSocketPath"`
 }
 
+// HostEvent is a change of a network or PCI device of the host reported by the kernel
+type HostEvent struct {
+	// PCI address of the device, empty if the device is not a PCI device or the address is unknown
+	PciAddress string
+	// name of the network interface, empty for the events of PCI devices
+	Interface string
+	// short description of the change, e.g. "link down" or "driver unbound"
+	Reason string
+}
+
 // SriovResult: Contains the result from the sriov-config service trying to apply the requested policies
 type SriovResult struct {
 	SyncStatus    string `yaml:"syncStatus"`
@@ -3,6 +3,7 @@ package aws
 import (
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -126,6 +127,18 @@ func (a *Aws) DiscoverSriovDevices() ([]sriovnetworkv1.InterfaceExt, error) {
 	return result, nil
 }
 
+// DiscoverSriovPfs discovers the VFs with the given PCI addresses on the AWS virtual platform,
+// the discovery of the platform devices is cheap so they are filtered from the discovery of all the devices.
+func (a *Aws) DiscoverSriovPfs(pciAddresses []string) ([]sriovnetworkv1.InterfaceExt, error) {
+	ifaces, err := a.DiscoverSriovDevices()
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(ifaces, func(iface sriovnetworkv1.InterfaceExt) bool {
+		return !slices.Contains(pciAddresses, iface.PciAddress)
+	}), nil
+}
+
 // DiscoverBridges is not supported on AWS platform.
 // Returns ErrOperationNotSupportedByPlatform as AWS does not support software bridge management.
 func (a *Aws) DiscoverBridges() (sriovnetworkv1.Bridges, error) {
@@ -10,6 +10,7 @@ import (
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/orchestrator"
 	plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins"
+	externalplugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins/external"
 	genericplugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins/generic"
 	intelplugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins/intel"
 	k8splugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins/k8s"
@@ -56,6 +57,11 @@ func (bm *Baremetal) DiscoverSriovDevices() ([]sriovnetworkv1.InterfaceExt, erro
 	return bm.hostHelpers.DiscoverSriovDevices(bm.hostHelpers)
 }
 
+// DiscoverSriovPfs discovers the SR-IOV capable devices with the given PCI addresses on the baremetal host.
+func (bm *Baremetal) DiscoverSriovPfs(pciAddresses []string) ([]sriovnetworkv1.InterfaceExt, error) {
+	return bm.hostHelpers.DiscoverSriovPfs(bm.hostHelpers, pciAddresses)
+}
+
 // DiscoverBridges discovers software bridges on the baremetal host.
 // The caller should check vars.ManageSoftwareBridges before calling this method.
 func (bm *Baremetal) DiscoverBridges() (sriovnetworkv1.Bridges, error) {
@@ -109,11 +115,14 @@ func (bm *Baremetal) SystemdGetVendorPlugin(phase string) (plugin.VendorPlugin,
 }
 
 // loadVendorPlugins loads vendor-specific plugins based on the detected device vendors.
-// Scans the node state interfaces and loads the appropriate vendor plugin for each unique vendor.
+// Scans the node state interfaces and loads the appropriate vendor plugin for each unique vendor,
+// and the external plugins registered for the vendors.
 func (bm *Baremetal) loadVendorPlugins(ns *sriovnetworkv1.SriovNetworkNodeState) ([]plugin.VendorPlugin, error) {
 	loadedPluginsMap := map[string]plugin.VendorPlugin{}
+	vendors := map[string]bool{}
 
 	for _, iface := range ns.Status.Interfaces {
+		vendors[iface.Vendor] = true
 		if val, ok := VendorPluginMap[iface.Vendor]; ok {
 			plug, err := val(bm.hostHelpers)
 			if err != nil {
@@ -129,6 +138,13 @@ func (bm *Baremetal) loadVendorPlugins(ns *sriovnetworkv1.SriovNetworkNodeState)
 		}
 	}
 
+	for _, plug := range externalplugin.LoadPlugins(vendors) {
+		if _, ok := loadedPluginsMap[plug.Name()]; ok {
+			return nil, fmt.Errorf("loadVendorPlugins(): external plugin %s conflicts with an in-tree plugin", plug.Name())
+		}
+		loadedPluginsMap[plug.Name()] = plug
+	}
+
 	vendorPlugins := []plugin.VendorPlugin{}
 	for _, val := range loadedPluginsMap {
 		vendorPlugins = append(vendorPlugins, val)
@@ -15,6 +15,7 @@ import (
 	mock_helper "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper/mock"
 	hosttypes "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
 	plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins"
+	externalplugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins/external"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
@@ -143,6 +144,32 @@ var _ = Describe("Baremetal", func() {
 				Expect(pluginNames).To(ContainElements("intel", "mellanox", "k8s"))
 			})
 
+			It("should load the external plugins registered for the node vendors", func() {
+				externalplugin.SetPlugins([]sriovnetworkv1.ExternalPlugin{
+					{Name: "acme", Vendors: []string{"9999"}, Command: "/usr/local/bin/acme-plugin"},
+					{Name: "other", Vendors: []string{"1234"}, Command: "/usr/local/bin/other-plugin"},
+				})
+				DeferCleanup(func() { externalplugin.SetPlugins(nil) })
+
+				_, addPlugins, err := bm.GetVendorPlugins(ns)
+				Expect(err).NotTo(HaveOccurred())
+				pluginNames := []string{}
+				for _, p := range addPlugins {
+					pluginNames = append(pluginNames, p.Name())
+				}
+				Expect(pluginNames).To(ConsistOf("intel", "mellanox", "k8s", "acme"))
+			})
+
+			It("should reject an external plugin named after an in-tree plugin", func() {
+				externalplugin.SetPlugins([]sriovnetworkv1.ExternalPlugin{
+					{Name: "intel", Vendors: []string{"8086"}, Command: "/usr/local/bin/intel-plugin"},
+				})
+				DeferCleanup(func() { externalplugin.SetPlugins(nil) })
+
+				_, _, err := bm.GetVendorPlugins(ns)
+				Expect(err).To(MatchError(ContainSubstring("conflicts with an in-tree plugin")))
+			})
+
 			It("should return an error if a vendor plugin fails to load", func() {
 				// Temporarily replace the plugin factory with one that returns an error
 				originalIntelPlugin := VendorPluginMap["8086"]
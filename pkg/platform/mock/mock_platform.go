@@ -71,6 +71,21 @@ func (mr *MockInterfaceMockRecorder) DiscoverSriovDevices() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverSriovDevices", reflect.TypeOf((*MockInterface)(nil).DiscoverSriovDevices))
 }
 
+// DiscoverSriovPfs mocks base method.
+func (m *MockInterface) DiscoverSriovPfs(pciAddresses []string) ([]v1.InterfaceExt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscoverSriovPfs", pciAddresses)
+	ret0, _ := ret[0].([]v1.InterfaceExt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscoverSriovPfs indicates an expected call of DiscoverSriovPfs.
+func (mr *MockInterfaceMockRecorder) DiscoverSriovPfs(pciAddresses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverSriovPfs", reflect.TypeOf((*MockInterface)(nil).DiscoverSriovPfs), pciAddresses)
+}
+
 // GetVendorPlugins mocks base method.
 func (m *MockInterface) GetVendorPlugins(ns *v1.SriovNetworkNodeState) (plugin.VendorPlugin, []plugin.VendorPlugin, error) {
 	m.ctrl.T.Helper()
@@ -2,6 +2,7 @@ package openstack
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 
 	"github.com/jaypipes/ghw"
@@ -182,6 +183,18 @@ func (o *Openstack) DiscoverSriovDevices() ([]sriovnetworkv1.InterfaceExt, error
 	return pfList, nil
 }
 
+// DiscoverSriovPfs discovers the VFs with the given PCI addresses on the OpenStack virtual platform,
+// the discovery of the platform devices is cheap so they are filtered from the discovery of all the devices.
+func (o *Openstack) DiscoverSriovPfs(pciAddresses []string) ([]sriovnetworkv1.InterfaceExt, error) {
+	ifaces, err := o.DiscoverSriovDevices()
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(ifaces, func(iface sriovnetworkv1.InterfaceExt) bool {
+		return !slices.Contains(pciAddresses, iface.PciAddress)
+	}), nil
+}
+
 // DiscoverBridges is not supported on OpenStack virtual platforms.
 // Returns ErrOperationNotSupportedByPlatform as OpenStack does not support software bridge management.
 func (o *Openstack) DiscoverBridges() (sriovnetworkv1.Bridges, error) {
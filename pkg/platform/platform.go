@@ -34,6 +34,11 @@ type Interface interface {
 	// Returns a list of discovered interfaces with their SR-IOV capabilities, or an error if discovery fails.
 	DiscoverSriovDevices() ([]sriovnetworkv1.InterfaceExt, error)
 
+	// DiscoverSriovPfs discovers the SR-IOV capable devices with the given PCI addresses only.
+	// This is called to refresh the status of the devices changed on the host, e.g. by host events or a driver reload.
+	// Returns the discovered interfaces, the devices not found on the host are not returned.
+	DiscoverSriovPfs(pciAddresses []string) ([]sriovnetworkv1.InterfaceExt, error)
+
 	// DiscoverBridges discovers software bridges managed by the operator.
 	// This is called during status updates when vars.ManageSoftwareBridges is enabled.
 	// Returns a list of discovered bridges, or an error if discovery fails.
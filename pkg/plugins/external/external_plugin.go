@@ -0,0 +1,283 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+// Operations of the external plugin protocol, they mirror the methods of plugin.VendorPlugin.
+// A command plugin is run with the operation as its only argument, a socket plugin receives
+// a POST request on the /<operation> path.
+const (
+	OperationOnNodeStateChange  = "on-node-state-change"
+	OperationCheckStatusChanges = "check-status-changes"
+	OperationApply              = "apply"
+)
+
+// operations in the order of a sync, the order of the errors reported in the status
+var operations = []string{OperationOnNodeStateChange, OperationCheckStatusChanges, OperationApply}
+
+// DefaultTimeout of a call to an external plugin
+const DefaultTimeout = 5 * time.Minute
+
+// waitDelay is the time given to a killed command plugin to close its output
+const waitDelay = 5 * time.Second
+
+// maxErrorOutput is the maximum length of the plugin output reported in the errors
+const maxErrorOutput = 512
+
+// Request is sent to the plugin as JSON, on the standard input of a command plugin
+// or as the body of the request to a socket plugin
+type Request struct {
+	// NodeState is the desired state passed to the last OnNodeStateChange call
+	NodeState *sriovnetworkv1.SriovNetworkNodeState `json:"nodeState"`
+}
+
+// Response is returned by the plugin as JSON, on the standard output of a command plugin
+// or as the body of the response of a socket plugin
+type Response struct {
+	// NeedDrain is the drain decision of on-node-state-change
+	NeedDrain bool `json:"needDrain,omitempty"`
+	// NeedReboot is the reboot decision of on-node-state-change
+	NeedReboot bool `json:"needReboot,omitempty"`
+	// Changed is the result of check-status-changes
+	Changed bool `json:"changed,omitempty"`
+	// Error reports the failure of the operation
+	Error string `json:"error,omitempty"`
+}
+
+var (
+	pluginsLock sync.Mutex
+	// plugins registered in the SriovOperatorConfig
+	registeredPlugins []sriovnetworkv1.ExternalPlugin
+)
+
+// SetPlugins registers the external plugins of the SriovOperatorConfig,
+// they are loaded by the platform with the vendor plugins
+func SetPlugins(plugins []sriovnetworkv1.ExternalPlugin) {
+	pluginsLock.Lock()
+	defer pluginsLock.Unlock()
+	registeredPlugins = plugins
+}
+
+// LoadPlugins returns the registered external plugins managing at least one of the vendors
+func LoadPlugins(vendors map[string]bool) []plugin.VendorPlugin {
+	pluginsLock.Lock()
+	defer pluginsLock.Unlock()
+	plugins := []plugin.VendorPlugin{}
+	for _, config := range registeredPlugins {
+		for _, vendor := range config.Vendors {
+			if vendors[vendor] {
+				plugins = append(plugins, NewExternalPlugin(config))
+				break
+			}
+		}
+	}
+	return plugins
+}
+
+// ExternalPlugin calls an out-of-tree plugin implementing the external plugin protocol
+type ExternalPlugin struct {
+	config  sriovnetworkv1.ExternalPlugin
+	timeout time.Duration
+
+	// desired state of the last OnNodeStateChange call, it is sent to the apply operation
+	desiredState *sriovnetworkv1.SriovNetworkNodeState
+
+	statusLock sync.Mutex
+	// error of the last call of each operation, a successful call only clears the error of its operation
+	lastErrors map[string]string
+}
+
+// NewExternalPlugin returns the plugin calling the external plugin of the configuration
+func NewExternalPlugin(config sriovnetworkv1.ExternalPlugin) *ExternalPlugin {
+	timeout := DefaultTimeout
+	if config.Timeout != nil && config.Timeout.Duration > 0 {
+		timeout = config.Timeout.Duration
+	}
+	return &ExternalPlugin{config: config, timeout: timeout, lastErrors: map[string]string{}}
+}
+
+// Name returns the name of the plugin
+func (p *ExternalPlugin) Name() string {
+	return p.config.Name
+}
+
+// OnNodeStateChange Invoked when SriovNetworkNodeState CR is created or updated, return if need dain and/or reboot node
+func (p *ExternalPlugin) OnNodeStateChange(new *sriovnetworkv1.SriovNetworkNodeState) (bool, bool, error) {
+	log.Log.Info("external plugin OnNodeStateChange()", "plugin", p.Name())
+	p.desiredState = new
+	resp, err := p.call(OperationOnNodeStateChange, new)
+	if err != nil {
+		return false, false, err
+	}
+	return resp.NeedDrain, resp.NeedReboot, nil
+}
+
+// CheckStatusChanges asks the plugin whether the host drifted from the desired state
+func (p *ExternalPlugin) CheckStatusChanges(new *sriovnetworkv1.SriovNetworkNodeState) (bool, error) {
+	resp, err := p.call(OperationCheckStatusChanges, new)
+	if err != nil {
+		return false, err
+	}
+	return resp.Changed, nil
+}
+
+// Apply config change
+func (p *ExternalPlugin) Apply() error {
+	log.Log.Info("external plugin Apply()", "plugin", p.Name())
+	_, err := p.call(OperationApply, p.desiredState)
+	return err
+}
+
+// Status returns the errors of the operations whose last call to the plugin failed
+func (p *ExternalPlugin) Status() sriovnetworkv1.ExternalPluginStatus {
+	p.statusLock.Lock()
+	defer p.statusLock.Unlock()
+	errs := []string{}
+	for _, operation := range operations {
+		if err, ok := p.lastErrors[operation]; ok {
+			errs = append(errs, err)
+		}
+	}
+	return sriovnetworkv1.ExternalPluginStatus{Name: p.Name(), LastError: strings.Join(errs, "; ")}
+}
+
+// call runs the operation and records its result in the status of the plugin
+func (p *ExternalPlugin) call(operation string, state *sriovnetworkv1.SriovNetworkNodeState) (*Response, error) {
+	resp, err := p.doCall(operation, state)
+	if err == nil && resp.Error != "" {
+		err = errors.New(resp.Error)
+	}
+	if err != nil {
+		err = fmt.Errorf("external plugin %s failed to run %s: %w", p.Name(), operation, err)
+		log.Log.Error(err, "external plugin call failed")
+	}
+
+	p.statusLock.Lock()
+	defer p.statusLock.Unlock()
+	delete(p.lastErrors, operation)
+	if err != nil {
+		p.lastErrors[operation] = err.Error()
+	}
+	return resp, err
+}
+
+func (p *ExternalPlugin) doCall(operation string, state *sriovnetworkv1.SriovNetworkNodeState) (*Response, error) {
+	request, err := json.Marshal(&Request{NodeState: state})
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	var output []byte
+	if p.config.Socket != "" {
+		output, err = p.post(ctx, operation, request)
+	} else {
+		output, err = p.exec(ctx, operation, request)
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("timed out after %s", p.timeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp := &Response{}
+	if err := json.Unmarshal(output, resp); err != nil {
+		return nil, fmt.Errorf("invalid response %q: %w", truncate(output), err)
+	}
+	return resp, nil
+}
+
+// exec runs the plugin binary in the host filesystem with the request on its standard input
+func (p *ExternalPlugin) exec(ctx context.Context, operation string, request []byte) ([]byte, error) {
+	var cmd *exec.Cmd
+	if vars.InChroot {
+		cmd = exec.CommandContext(ctx, p.config.Command, operation)
+	} else {
+		cmd = exec.CommandContext(ctx, "chroot", utils.GetHostExtension(), p.config.Command, operation)
+	}
+	// the children of the plugin can keep the output open after it is killed
+	cmd.WaitDelay = waitDelay
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() > 0 {
+			return nil, fmt.Errorf("%w: %s", err, truncate(stderr.Bytes()))
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+// post sends the request to the plugin server listening on the host unix socket
+func (p *ExternalPlugin) post(ctx context.Context, operation string, request []byte) ([]byte, error) {
+	socket := utils.GetHostExtensionPath(p.config.Socket)
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+	}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://plugin/"+operation, bytes.NewReader(request))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s: %s", resp.Status, truncate(body))
+	}
+	return body, nil
+}
+
+func truncate(output []byte) string {
+	s := strings.TrimSpace(string(output))
+	if len(s) > maxErrorOutput {
+		return s[:maxErrorOutput] + "..."
+	}
+	return s
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package external
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+// writePlugin writes a command plugin running the script and returns its path
+func writePlugin(script string) string {
+	path := filepath.Join(GinkgoT().TempDir(), "plugin")
+	Expect(os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755)).To(Succeed())
+	return path
+}
+
+var _ = Describe("External plugin", func() {
+	var nodeState *sriovnetworkv1.SriovNetworkNodeState
+
+	BeforeEach(func() {
+		origInChroot, origFilesystemRoot := vars.InChroot, vars.FilesystemRoot
+		DeferCleanup(func() { vars.InChroot, vars.FilesystemRoot = origInChroot, origFilesystemRoot })
+		vars.InChroot = true
+		vars.FilesystemRoot = ""
+
+		nodeState = &sriovnetworkv1.SriovNetworkNodeState{ObjectMeta: metav1.ObjectMeta{Name: "worker-0", Generation: 3}}
+	})
+
+	Context("LoadPlugins", func() {
+		It("should load the plugins of the vendors", func() {
+			SetPlugins([]sriovnetworkv1.ExternalPlugin{
+				{Name: "acme", Vendors: []string{"1d0f", "1924"}, Command: "/usr/local/bin/acme"},
+				{Name: "partner", Vendors: []string{"19e5"}, Socket: "/var/run/partner.sock"},
+			})
+			DeferCleanup(func() { SetPlugins(nil) })
+
+			plugins := LoadPlugins(map[string]bool{"1924": true, "8086": true})
+			Expect(plugins).To(HaveLen(1))
+			Expect(plugins[0].Name()).To(Equal("acme"))
+			Expect(LoadPlugins(map[string]bool{"8086": true})).To(BeEmpty())
+		})
+	})
+
+	Context("command plugin", func() {
+		It("should pass the operation and the node state to the plugin", func() {
+			out := filepath.Join(GinkgoT().TempDir(), "request")
+			p := NewExternalPlugin(sriovnetworkv1.ExternalPlugin{Name: "acme", Command: writePlugin(`
+echo "$1" >> ` + out + `.op
+cat > ` + out + `
+case "$1" in
+on-node-state-change) echo '{"needDrain": true}' ;;
+check-status-changes) echo '{"changed": true}' ;;
+*) echo '{}' ;;
+esac
+`)})
+			needDrain, needReboot, err := p.OnNodeStateChange(nodeState)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(needDrain).To(BeTrue())
+			Expect(needReboot).To(BeFalse())
+
+			changed, err := p.CheckStatusChanges(nodeState)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+
+			Expect(p.Apply()).To(Succeed())
+			ops, err := os.ReadFile(out + ".op")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(ops)).To(Equal("on-node-state-change\ncheck-status-changes\napply\n"))
+			data, err := os.ReadFile(out)
+			Expect(err).NotTo(HaveOccurred())
+			req := &Request{}
+			Expect(json.Unmarshal(data, req)).To(Succeed())
+			Expect(req.NodeState.Name).To(Equal("worker-0"))
+			Expect(req.NodeState.Generation).To(Equal(int64(3)))
+			Expect(p.Status()).To(Equal(sriovnetworkv1.ExternalPluginStatus{Name: "acme"}))
+		})
+		It("should report the error returned by the plugin", func() {
+			p := NewExternalPlugin(sriovnetworkv1.ExternalPlugin{Name: "acme", Command: writePlugin(`echo '{"error": "unsupported firmware"}'`)})
+			_, _, err := p.OnNodeStateChange(nodeState)
+			Expect(err).To(MatchError("external plugin acme failed to run on-node-state-change: unsupported firmware"))
+			Expect(p.Status().LastError).To(Equal(err.Error()))
+		})
+		It("should report the standard error of a failed plugin", func() {
+			p := NewExternalPlugin(sriovnetworkv1.ExternalPlugin{Name: "acme", Command: writePlugin("echo 'device not found' >&2; exit 3")})
+			err := p.Apply()
+			Expect(err).To(MatchError(And(ContainSubstring("exit status 3"), ContainSubstring("device not found"))))
+		})
+		It("should fail on an invalid response", func() {
+			p := NewExternalPlugin(sriovnetworkv1.ExternalPlugin{Name: "acme", Command: writePlugin("echo done")})
+			_, err := p.CheckStatusChanges(nodeState)
+			Expect(err).To(MatchError(ContainSubstring(`invalid response "done"`)))
+		})
+		It("should time out", func() {
+			p := NewExternalPlugin(sriovnetworkv1.ExternalPlugin{Name: "acme", Command: writePlugin("exec sleep 10"),
+				Timeout: &metav1.Duration{Duration: 100 * time.Millisecond}})
+			_, _, err := p.OnNodeStateChange(nodeState)
+			Expect(err).To(MatchError(ContainSubstring("timed out after 100ms")))
+		})
+		It("should clear the error after a successful call", func() {
+			p := NewExternalPlugin(sriovnetworkv1.ExternalPlugin{Name: "acme", Command: "/nonexistent/plugin"})
+			Expect(p.Apply()).NotTo(Succeed())
+			Expect(p.Status().LastError).NotTo(BeEmpty())
+			p.config.Command = writePlugin("echo '{}'")
+			Expect(p.Apply()).To(Succeed())
+			Expect(p.Status().LastError).To(BeEmpty())
+		})
+		It("should keep the error of an operation until the operation succeeds", func() {
+			p := NewExternalPlugin(sriovnetworkv1.ExternalPlugin{Name: "acme", Command: writePlugin(
+				`if [ "$1" = apply ]; then echo '{"error": "firmware update failed"}'; else echo '{}'; fi`)})
+			Expect(p.Apply()).To(MatchError("external plugin acme failed to run apply: firmware update failed"))
+			_, err := p.CheckStatusChanges(nodeState)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Status().LastError).To(Equal("external plugin acme failed to run apply: firmware update failed"))
+
+			p.config.Command = writePlugin(`echo '{}'`)
+			Expect(p.Apply()).To(Succeed())
+			Expect(p.Status().LastError).To(BeEmpty())
+		})
+	})
+
+	Context("socket plugin", func() {
+		var socket string
+
+		BeforeEach(func() {
+			// the unix socket path length is limited, the ginkgo temporary directories are too long
+			dir, err := os.MkdirTemp("", "plugin")
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(os.RemoveAll, dir)
+			socket = filepath.Join(dir, "plugin.sock")
+			listener, err := net.Listen("unix", socket)
+			Expect(err).NotTo(HaveOccurred())
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/on-node-state-change", func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				data, err := io.ReadAll(r.Body)
+				Expect(err).NotTo(HaveOccurred())
+				req := &Request{}
+				Expect(json.Unmarshal(data, req)).To(Succeed())
+				Expect(req.NodeState.Name).To(Equal("worker-0"))
+				_, _ = w.Write([]byte(`{"needReboot": true}`))
+			})
+			mux.HandleFunc("/apply", func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "device busy", http.StatusServiceUnavailable)
+			})
+			server := &http.Server{Handler: mux}
+			go func() { _ = server.Serve(listener) }()
+			DeferCleanup(server.Close)
+		})
+
+		It("should call the plugin server", func() {
+			p := NewExternalPlugin(sriovnetworkv1.ExternalPlugin{Name: "partner", Socket: socket})
+			needDrain, needReboot, err := p.OnNodeStateChange(nodeState)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(needDrain).To(BeFalse())
+			Expect(needReboot).To(BeTrue())
+		})
+		It("should report the errors of the plugin server", func() {
+			p := NewExternalPlugin(sriovnetworkv1.ExternalPlugin{Name: "partner", Socket: socket})
+			err := p.Apply()
+			Expect(err).To(MatchError(ContainSubstring("unexpected status 503 Service Unavailable: device busy")))
+			_, err = p.CheckStatusChanges(nodeState)
+			Expect(err).To(MatchError(ContainSubstring("unexpected status 404 Not Found")))
+		})
+	})
+})
@@ -19,31 +19,34 @@ package generic
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"sort"
 	"syscall"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/drivers"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper"
 	hostTypes "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
 	plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins"
-	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
 var PluginName = "generic"
 
-// driver id
+// driver id, the ids of the other userspace drivers of the registry follow userspaceDriversBase
 const (
 	Vfio = iota
 	VirtioVdpa
 	VhostVdpa
+	userspaceDriversBase
 )
 
 // driver name
 const (
-	vfioPciDriver    = "vfio_pci"
 	virtioVdpaDriver = "virtio_vdpa"
 	vhostVdpaDriver  = "vhost_vdpa"
 )
@@ -55,6 +58,7 @@ type DriverState struct {
 	DriverName     string
 	DeviceType     string
 	VdpaType       string
+	ModuleParams   []drivers.ModuleParam
 	NeedDriverFunc needDriver
 	DriverLoaded   bool
 }
@@ -96,8 +100,6 @@ type genericPluginOptions struct {
 	skipBridgeConfiguration bool
 }
 
-const scriptsPath = "bindata/scripts/kargs.sh"
-
 // Initialize our plugin and set up initial values
 func NewGenericPlugin(helpers helper.HostHelpersInterface, options ...Option) (plugin.VendorPlugin, error) {
 	cfg := &genericPluginOptions{}
@@ -105,12 +107,23 @@ func NewGenericPlugin(helpers helper.HostHelpersInterface, options ...Option) (p
 		o(cfg)
 	}
 	driverStateMap := make(map[uint]*DriverState)
-	driverStateMap[Vfio] = &DriverState{
-		DriverName:     vfioPciDriver,
-		DeviceType:     consts.DeviceTypeVfioPci,
-		VdpaType:       "",
-		NeedDriverFunc: needDriverCheckDeviceType,
-		DriverLoaded:   false,
+	id := uint(userspaceDriversBase)
+	for _, driver := range drivers.List() {
+		driverState := &DriverState{
+			DriverName:     driver.Module,
+			DeviceType:     driver.DeviceType,
+			VdpaType:       "",
+			ModuleParams:   driver.ModuleParams,
+			NeedDriverFunc: needDriverCheckDeviceType,
+			DriverLoaded:   false,
+		}
+		// the IOMMU kernel arguments are configured with the state of the vfio-pci driver
+		if driver.DeviceType == consts.DeviceTypeVfioPci {
+			driverStateMap[Vfio] = driverState
+			continue
+		}
+		driverStateMap[id] = driverState
+		id++
 	}
 	driverStateMap[VirtioVdpa] = &DriverState{
 		DriverName:     virtioVdpaDriver,
@@ -173,10 +186,38 @@ func (p *GenericPlugin) OnNodeStateChange(new *sriovnetworkv1.SriovNetworkNodeSt
 	return
 }
 
+// Plan returns if applying the node state requires to drain and/or reboot the node, the desired kernel
+// arguments are computed on a copy of the plugin and compared with the running kernel without updating the bootloader
+func (p *GenericPlugin) Plan(new *sriovnetworkv1.SriovNetworkNodeState) (needDrain bool, needReboot bool, err error) {
+	planner := *p
+	planner.DesiredKernelArgs = maps.Clone(p.DesiredKernelArgs)
+
+	needDrain = planner.needDrainNode(new.Spec, new.Status)
+	if err = planner.addVfioDesiredKernelArg(new); err != nil {
+		return false, false, err
+	}
+	if err = planner.setRdmaKernelArgs(new.Spec.System.RdmaMode); err != nil {
+		return false, false, err
+	}
+	needReboot, err = planner.shouldUpdateKernelArgs()
+	if err != nil {
+		return false, false, err
+	}
+	if slices.Contains(devlinkParamsActions(new.Spec, new.Status), sriovnetworkv1.DevlinkParamsActionReboot) {
+		needReboot = true
+	}
+
+	if needReboot {
+		needDrain = true
+	}
+	return
+}
+
 // CheckStatusChanges verify whether SriovNetworkNodeState CR status present changes on configured VFs.
 func (p *GenericPlugin) CheckStatusChanges(current *sriovnetworkv1.SriovNetworkNodeState) (bool, error) {
 	log.Log.Info("generic-plugin CheckStatusChanges()")
 
+	var allocated map[string]bool
 	for _, iface := range current.Spec.Interfaces {
 		found := false
 		for _, ifaceStatus := range current.Status.Interfaces {
@@ -187,6 +228,25 @@ func (p *GenericPlugin) CheckStatusChanges(current *sriovnetworkv1.SriovNetworkN
 					log.Log.Info("CheckStatusChanges(): status changed for interface", "address", iface.PciAddress)
 					return true, nil
 				}
+				// the PF settings and the VF attributes are applied without drain
+				if sriovnetworkv1.NeedToUpdatePfSettings(iface.PfSettings, ifaceStatus.PfSettings) {
+					log.Log.Info("CheckStatusChanges(): PF settings changed for interface", "address", iface.PciAddress)
+					return true, nil
+				}
+				// the VFs allocated to a pod are configured by the CNI
+				if slices.ContainsFunc(iface.VfGroups, func(g sriovnetworkv1.VfGroup) bool { return g.VfAttributes != nil }) {
+					if allocated == nil {
+						var err error
+						if allocated, err = p.helpers.GetPodAllocatedDevices(); err != nil {
+							log.Log.Error(err, "generic-plugin CheckStatusChanges(): failed to get the VFs allocated to the pods")
+							return false, err
+						}
+					}
+					if len(sriovnetworkv1.GetVfsToUpdateAttributes(&iface, &ifaceStatus, allocated)) > 0 {
+						log.Log.Info("CheckStatusChanges(): VF attributes changed for interface", "address", iface.PciAddress)
+						return true, nil
+					}
+				}
 				break
 			}
 		}
@@ -195,6 +255,16 @@ func (p *GenericPlugin) CheckStatusChanges(current *sriovnetworkv1.SriovNetworkN
 		}
 	}
 
+	if len(devlinkParamsActions(current.Spec, current.Status)) > 0 {
+		log.Log.Info("CheckStatusChanges(): devlink parameters need to be updated")
+		return true, nil
+	}
+
+	if sriovnetworkv1.NeedToUpdateBonds(current.Spec.Bonds, current.Status.Bonds) {
+		log.Log.Info("CheckStatusChanges(): bond configuration needs to be updated")
+		return true, nil
+	}
+
 	if p.shouldConfigureBridges() {
 		if sriovnetworkv1.NeedToUpdateBridges(&current.Spec.Bridges, &current.Status.Bridges) {
 			log.Log.Info("CheckStatusChanges(): bridge configuration needs to be updated")
@@ -219,6 +289,14 @@ func (p *GenericPlugin) syncDriverState() error {
 				log.Log.Error(err, "generic plugin syncDriverState(): fail to load kmod", "name", driverState.DriverName)
 				return err
 			}
+			// the parameters are set through the sysfs as modprobe ignores them when the module is already loaded
+			for _, param := range driverState.ModuleParams {
+				if err := p.helpers.SetKernelModuleParam(param.Module, param.Name, param.Value); err != nil {
+					log.Log.Error(err, "generic plugin syncDriverState(): fail to set kmod parameter",
+						"name", param.Module, "param", param.Name)
+					return err
+				}
+			}
 			driverState.DriverLoaded = true
 		}
 	}
@@ -251,6 +329,11 @@ func (p *GenericPlugin) Apply() error {
 		return err
 	}
 
+	// the PFs are bonded once their eSwitch is in switchdev mode, the bond can then be the uplink of a bridge
+	if err := p.helpers.ConfigureBonds(p.DesireState.Spec.Bonds, p.DesireState.Status.Bonds); err != nil {
+		return err
+	}
+
 	if p.shouldConfigureBridges() {
 		if err := p.helpers.ConfigureBridges(p.DesireState.Spec.Bridges, p.DesireState.Status.Bridges); err != nil {
 			return err
@@ -282,23 +365,6 @@ func needDriverCheckVdpaType(state *sriovnetworkv1.SriovNetworkNodeState, driver
 	return false
 }
 
-// editKernelArg Tries to add the kernel args via ostree or grubby.
-func editKernelArg(helper helper.HostHelpersInterface, mode, karg string) error {
-	log.Log.Info("generic plugin editKernelArg()", "mode", mode, "karg", karg)
-	_, _, err := helper.RunCommand("/bin/sh", scriptsPath, mode, karg)
-	if err != nil {
-		// if grubby is not there log and assume kernel args are set correctly.
-		if utils.IsCommandNotFound(err) {
-			log.Log.Error(err, "generic plugin editKernelArg(): grubby or ostree command not found. Please ensure that kernel arg are correct",
-				"kargs", karg)
-			return nil
-		}
-		log.Log.Error(err, "generic plugin editKernelArg(): fail to edit kernel arg", "karg", karg)
-		return err
-	}
-	return nil
-}
-
 // enableDesiredKernelArgs Should be called to mark a kernel arg as enabled.
 func (p *GenericPlugin) enableDesiredKernelArgs(karg string) {
 	log.Log.Info("generic plugin enableDesiredKernelArgs(): enable kernel arg", "karg", karg)
@@ -338,29 +404,29 @@ func (p *GenericPlugin) syncDesiredKernelArgs() (bool, error) {
 	}
 
 	needReboot := false
+	toAdd := []string{}
+	toRemove := []string{}
 	for karg, kargState := range p.DesiredKernelArgs {
 		if kargState {
-			err = editKernelArg(p.helpers, "add", karg)
-			if err != nil {
-				log.Log.Error(err, "generic-plugin syncDesiredKernelArgs(): fail to set kernel arg", "karg", karg)
-				return false, err
-			}
-
-			if !p.helpers.IsKernelArgsSet(kargs, karg) {
-				needReboot = true
-			}
+			toAdd = append(toAdd, karg)
 		} else {
-			err = editKernelArg(p.helpers, "remove", karg)
-			if err != nil {
-				log.Log.Error(err, "generic-plugin syncDesiredKernelArgs(): fail to remove kernel arg", "karg", karg)
-				return false, err
-			}
-
-			if p.helpers.IsKernelArgsSet(kargs, karg) {
-				needReboot = true
-			}
+			toRemove = append(toRemove, karg)
+		}
+		if kargState != p.helpers.IsKernelArgsSet(kargs, karg) {
+			needReboot = true
 		}
 	}
+	sort.Strings(toAdd)
+	sort.Strings(toRemove)
+
+	if _, err := p.helpers.AddKernelArgs(toAdd...); err != nil {
+		log.Log.Error(err, "generic-plugin syncDesiredKernelArgs(): fail to set kernel args", "kargs", toAdd)
+		return false, err
+	}
+	if _, err := p.helpers.RemoveKernelArgs(toRemove...); err != nil {
+		log.Log.Error(err, "generic-plugin syncDesiredKernelArgs(): fail to remove kernel args", "kargs", toRemove)
+		return false, err
+	}
 	return needReboot, nil
 }
 
@@ -371,6 +437,20 @@ func (p *GenericPlugin) needDrainNode(desired sriovnetworkv1.SriovNetworkNodeSta
 		return true
 	}
 
+	// runtime devlink parameters are applied without drain, the driver reload
+	// and the reboot required by the other parameters disrupt the workloads
+	actions := devlinkParamsActions(desired, current)
+	if slices.Contains(actions, sriovnetworkv1.DevlinkParamsActionReload) ||
+		slices.Contains(actions, sriovnetworkv1.DevlinkParamsActionReboot) {
+		log.Log.V(2).Info("generic plugin needDrainNode(): need drain since devlink parameters need a driver reload or a reboot")
+		return true
+	}
+
+	if sriovnetworkv1.NeedToUpdateBonds(desired.Bonds, current.Bonds) {
+		log.Log.V(2).Info("generic plugin needDrainNode(): need drain since bond configuration needs to be updated")
+		return true
+	}
+
 	if p.shouldConfigureBridges() {
 		if sriovnetworkv1.NeedToUpdateBridges(&desired.Bridges, &current.Bridges) {
 			log.Log.V(2).Info("generic plugin needDrainNode(): need drain since bridge configuration needs to be updated")
@@ -386,8 +466,8 @@ func (p *GenericPlugin) needToUpdateVFs(desired sriovnetworkv1.SriovNetworkNodeS
 		for _, iface := range desired.Interfaces {
 			if iface.PciAddress == ifaceStatus.PciAddress {
 				configured = true
-				if ifaceStatus.NumVfs == 0 {
-					log.Log.V(2).Info("generic plugin needToUpdateVFs(): no need drain, for PCI address, current NumVfs is 0",
+				if ifaceStatus.NumVfs == 0 && ifaceStatus.NumSfs == 0 {
+					log.Log.V(2).Info("generic plugin needToUpdateVFs(): no need drain, for PCI address, current NumVfs and NumSfs are 0",
 						"address", iface.PciAddress)
 					break
 				}
@@ -400,7 +480,7 @@ func (p *GenericPlugin) needToUpdateVFs(desired sriovnetworkv1.SriovNetworkNodeS
 					"address", iface.PciAddress, "expected-vfs", iface.NumVfs, "current-vfs", ifaceStatus.NumVfs)
 			}
 		}
-		if !configured && ifaceStatus.NumVfs > 0 {
+		if !configured && (ifaceStatus.NumVfs > 0 || ifaceStatus.NumSfs > 0) {
 			// load the PF info
 			pfStatus, exist, err := p.helpers.LoadPfsStatus(ifaceStatus.PciAddress)
 			if err != nil {
@@ -435,8 +515,11 @@ func (p *GenericPlugin) shouldConfigureBridges() bool {
 	return vars.ManageSoftwareBridges && !p.skipBridgeConfiguration
 }
 
-func (p *GenericPlugin) addVfioDesiredKernelArg(state *sriovnetworkv1.SriovNetworkNodeState) {
+func (p *GenericPlugin) addVfioDesiredKernelArg(state *sriovnetworkv1.SriovNetworkNodeState) error {
 	driverState := p.DriverStateMap[Vfio]
+	if !driverState.NeedDriverFunc(state, driverState) {
+		return p.removeOperatorIommuKernelArgs()
+	}
 
 	kernelArgFnByCPUVendor := map[hostTypes.CPUVendor]func(){
 		hostTypes.CPUVendorIntel: func() {
@@ -448,7 +531,8 @@ func (p *GenericPlugin) addVfioDesiredKernelArg(state *sriovnetworkv1.SriovNetwo
 		},
 	}
 
-	if !driverState.DriverLoaded && driverState.NeedDriverFunc(state, driverState) {
+	// the arguments are enabled once the driver is loaded, unless they were removed since then
+	if !driverState.DriverLoaded || !p.DesiredKernelArgs[consts.KernelArgIommuPt] {
 		cpuVendor, err := p.helpers.GetCPUVendor()
 		if err != nil {
 			log.Log.Error(err, "can't get CPU vendor, falling back to Intel")
@@ -460,31 +544,58 @@ func (p *GenericPlugin) addVfioDesiredKernelArg(state *sriovnetworkv1.SriovNetwo
 			addKernelArgFn()
 		}
 	}
+	return nil
+}
+
+// removeOperatorIommuKernelArgs disables the IOMMU kernel arguments added by the operator when no policy
+// needs the vfio-pci driver, the arguments configured by the administrator are kept
+func (p *GenericPlugin) removeOperatorIommuKernelArgs() error {
+	operatorKargs, err := p.helpers.GetOperatorKernelArgs()
+	if err != nil {
+		log.Log.Error(err, "generic-plugin removeOperatorIommuKernelArgs(): failed to get the kernel arguments added by the operator")
+		return err
+	}
+	for _, karg := range []string{consts.KernelArgIntelIommu, consts.KernelArgIommuPt} {
+		if slices.Contains(operatorKargs, karg) && p.DesiredKernelArgs[karg] {
+			p.disableDesiredKernelArgs(karg)
+		}
+	}
+	return nil
 }
 
 func (p *GenericPlugin) configRdmaKernelArg(state *sriovnetworkv1.SriovNetworkNodeState) error {
-	if state.Spec.System.RdmaMode == "" {
+	if err := p.setRdmaKernelArgs(state.Spec.System.RdmaMode); err != nil {
+		return err
+	}
+	return p.helpers.SetRDMASubsystem(state.Spec.System.RdmaMode)
+}
+
+// setRdmaKernelArgs updates the desired kernel arguments for the RDMA subsystem mode
+func (p *GenericPlugin) setRdmaKernelArgs(rdmaMode string) error {
+	switch rdmaMode {
+	case "":
 		p.disableDesiredKernelArgs(consts.KernelArgRdmaExclusive)
 		p.disableDesiredKernelArgs(consts.KernelArgRdmaShared)
-	} else if state.Spec.System.RdmaMode == "shared" {
+	case "shared":
 		p.enableDesiredKernelArgs(consts.KernelArgRdmaShared)
 		p.disableDesiredKernelArgs(consts.KernelArgRdmaExclusive)
-	} else if state.Spec.System.RdmaMode == "exclusive" {
+	case "exclusive":
 		p.enableDesiredKernelArgs(consts.KernelArgRdmaExclusive)
 		p.disableDesiredKernelArgs(consts.KernelArgRdmaShared)
-	} else {
-		err := fmt.Errorf("unexpected rdma mode: %s", state.Spec.System.RdmaMode)
+	default:
+		err := fmt.Errorf("unexpected rdma mode: %s", rdmaMode)
 		log.Log.Error(err, "generic-plugin configRdmaKernelArg(): failed to configure kernel arguments for rdma")
 		return err
 	}
-
-	return p.helpers.SetRDMASubsystem(state.Spec.System.RdmaMode)
+	return nil
 }
 
 func (p *GenericPlugin) needRebootNode(state *sriovnetworkv1.SriovNetworkNodeState) (bool, error) {
 	needReboot := false
 
-	p.addVfioDesiredKernelArg(state)
+	if err := p.addVfioDesiredKernelArg(state); err != nil {
+		return false, err
+	}
 	err := p.configRdmaKernelArg(state)
 	if err != nil {
 		return false, err
@@ -499,9 +610,37 @@ func (p *GenericPlugin) needRebootNode(state *sriovnetworkv1.SriovNetworkNodeSta
 		log.Log.V(2).Info("generic-plugin needRebootNode(): need reboot for updating kernel arguments")
 	}
 
+	if slices.Contains(devlinkParamsActions(state.Spec, state.Status), sriovnetworkv1.DevlinkParamsActionReboot) {
+		log.Log.V(2).Info("generic-plugin needRebootNode(): need reboot for applying permanent devlink parameters")
+		needReboot = true
+	}
+
 	return needReboot, nil
 }
 
+// devlinkParamsActions returns the actions required to apply the devlink parameters of the PFs
+func devlinkParamsActions(desired sriovnetworkv1.SriovNetworkNodeStateSpec, current sriovnetworkv1.SriovNetworkNodeStateStatus) []string {
+	var actions []string
+	for _, iface := range desired.Interfaces {
+		if iface.ExternallyManaged || len(iface.DevlinkParams) == 0 {
+			continue
+		}
+		for _, ifaceStatus := range current.Interfaces {
+			if iface.PciAddress != ifaceStatus.PciAddress {
+				continue
+			}
+			action := sriovnetworkv1.GetDevlinkParamsAction(iface.DevlinkParams, ifaceStatus.DevlinkParams)
+			if action != sriovnetworkv1.DevlinkParamsActionNone {
+				log.Log.V(2).Info("generic plugin devlinkParamsActions(): devlink parameters need update",
+					"address", iface.PciAddress, "action", action)
+				actions = append(actions, action)
+			}
+			break
+		}
+	}
+	return actions
+}
+
 // ////////////// for testing purposes only ///////////////////////
 func (p *GenericPlugin) getDriverStateMap() DriverStateMapType {
 	return p.DriverStateMap
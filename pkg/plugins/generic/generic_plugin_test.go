@@ -17,6 +17,7 @@
 package generic
 
 import (
+	"maps"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -43,6 +44,10 @@ var _ = Describe("Generic plugin", func() {
 		err           error
 		ctrl          *gomock.Controller
 		hostHelper    *mock_helper.MockHostHelpersInterface
+
+		operatorKargs []string
+		addedKargs    []string
+		removedKargs  []string
 	)
 
 	BeforeEach(func() {
@@ -59,6 +64,18 @@ var _ = Describe("Generic plugin", func() {
 		hostHelper.EXPECT().IsKernelArgsSet("", consts.KernelArgRdmaShared).Return(false).AnyTimes()
 
 		hostHelper.EXPECT().RunCommand(gomock.Any(), gomock.Any()).Return("", "", nil).AnyTimes()
+		operatorKargs, addedKargs, removedKargs = nil, nil, nil
+		hostHelper.EXPECT().AddKernelArgs(gomock.Any()).DoAndReturn(func(kargs ...string) (bool, error) {
+			addedKargs = kargs
+			return false, nil
+		}).AnyTimes()
+		hostHelper.EXPECT().RemoveKernelArgs(gomock.Any()).DoAndReturn(func(kargs ...string) (bool, error) {
+			removedKargs = kargs
+			return false, nil
+		}).AnyTimes()
+		hostHelper.EXPECT().GetOperatorKernelArgs().DoAndReturn(func() ([]string, error) {
+			return operatorKargs, nil
+		}).AnyTimes()
 
 		genericPlugin, err = NewGenericPlugin(hostHelper)
 		Expect(err).ToNot(HaveOccurred())
@@ -314,6 +331,68 @@ var _ = Describe("Generic plugin", func() {
 			Expect(needDrain).To(BeFalse())
 		})
 
+		It("should drain and reboot depending on the devlink parameters cmode", func() {
+			newState := func(desired map[string]sriovnetworkv1.DevlinkParam) *sriovnetworkv1.SriovNetworkNodeState {
+				return &sriovnetworkv1.SriovNetworkNodeState{
+					Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+						Interfaces: sriovnetworkv1.Interfaces{{
+							PciAddress:    "0000:00:00.0",
+							NumVfs:        1,
+							DevlinkParams: desired,
+							VfGroups: []sriovnetworkv1.VfGroup{{
+								DeviceType:   "netdevice",
+								PolicyName:   "policy-1",
+								ResourceName: "resource-1",
+								VfRange:      "0-0",
+							}}}},
+					},
+					Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+						Interfaces: sriovnetworkv1.InterfaceExts{{
+							PciAddress:     "0000:00:00.0",
+							NumVfs:         1,
+							TotalVfs:       1,
+							Name:           "sriovif1",
+							Driver:         "mlx5_core",
+							EswitchMode:    "legacy",
+							LinkType:       "ETH",
+							LinkAdminState: "up",
+							DevlinkParams: []sriovnetworkv1.DevlinkParamStatus{
+								{Name: "flow_steering_mode", Cmode: "runtime", Value: "dmfs"},
+								{Name: "enable_roce", Cmode: "driverinit", Value: "true"},
+								{Name: "enable_sriov", Cmode: "permanent", Value: "false"},
+							},
+							VFs: []sriovnetworkv1.VirtualFunction{{
+								PciAddress: "0000:00:00.1",
+								VfID:       0,
+								Name:       "sriovif1v0",
+								Driver:     "mlx5_core",
+							}},
+						}},
+					},
+				}
+			}
+
+			needDrain, needReboot, err := genericPlugin.OnNodeStateChange(newState(map[string]sriovnetworkv1.DevlinkParam{
+				"flow_steering_mode": {Value: "smfs"}, "enable_roce": {Value: "true"}}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(needDrain).To(BeFalse())
+			Expect(needReboot).To(BeFalse())
+			Expect(genericPlugin.CheckStatusChanges(newState(map[string]sriovnetworkv1.DevlinkParam{
+				"flow_steering_mode": {Value: "smfs"}}))).To(BeTrue())
+
+			needDrain, needReboot, err = genericPlugin.OnNodeStateChange(newState(map[string]sriovnetworkv1.DevlinkParam{
+				"enable_roce": {Value: "false", Cmode: "driverinit"}}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(needDrain).To(BeTrue())
+			Expect(needReboot).To(BeFalse())
+
+			needDrain, needReboot, err = genericPlugin.OnNodeStateChange(newState(map[string]sriovnetworkv1.DevlinkParam{
+				"enable_sriov": {Value: "true"}}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(needDrain).To(BeTrue())
+			Expect(needReboot).To(BeTrue())
+		})
+
 		It("should drain because driver has changed on VF of type netdevice", func() {
 			networkNodeState := &sriovnetworkv1.SriovNetworkNodeState{
 				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
@@ -876,6 +955,89 @@ var _ = Describe("Generic plugin", func() {
 			Expect(changed).To(BeTrue())
 		})
 
+		It("should detect the drift of the VF attributes without draining the node", func() {
+			networkNodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:00:00.0",
+						NumVfs:     2,
+						VfGroups: []sriovnetworkv1.VfGroup{{
+							DeviceType:   "netdevice",
+							PolicyName:   "policy-1",
+							ResourceName: "resource-1",
+							VfRange:      "0-1",
+							VfAttributes: &sriovnetworkv1.VfAttributes{Trust: "on"},
+						}}}},
+				},
+				Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+					Interfaces: sriovnetworkv1.InterfaceExts{{
+						PciAddress:  "0000:00:00.0",
+						NumVfs:      2,
+						TotalVfs:    2,
+						Name:        "sriovif1",
+						Mtu:         1500,
+						Driver:      "mlx5_core",
+						EswitchMode: "legacy",
+						LinkType:    "ETH",
+						VFs: []sriovnetworkv1.VirtualFunction{{
+							PciAddress: "0000:00:00.1",
+							VfID:       0,
+							Name:       "sriovif1v0",
+							Driver:     "mlx5_core",
+							Trust:      "on",
+						}, {
+							// trust disabled by the CNI for the pod network
+							PciAddress: "0000:00:00.2",
+							VfID:       1,
+							Driver:     "mlx5_core",
+							Trust:      "off",
+						}},
+					}},
+				},
+			}
+
+			hostHelper.EXPECT().GetPodAllocatedDevices().Return(map[string]bool{"0000:00:00.2": true}, nil)
+			changed, err := genericPlugin.CheckStatusChanges(networkNodeState)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeFalse())
+
+			networkNodeState.Status.Interfaces[0].VFs[0].Trust = "off"
+			hostHelper.EXPECT().GetPodAllocatedDevices().Return(map[string]bool{"0000:00:00.2": true}, nil)
+			changed, err = genericPlugin.CheckStatusChanges(networkNodeState)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(genericPlugin.(*GenericPlugin).needDrainNode(networkNodeState.Spec, networkNodeState.Status)).To(BeFalse())
+		})
+
+		It("should detect the drift of the PF settings without draining the node", func() {
+			networkNodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:00:00.0",
+						NumVfs:     1,
+						PfSettings: &sriovnetworkv1.PfSettings{Features: map[string]bool{"rx-gro-hw": false}},
+					}},
+				},
+				Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+					Interfaces: sriovnetworkv1.InterfaceExts{{
+						PciAddress:  "0000:00:00.0",
+						NumVfs:      1,
+						TotalVfs:    1,
+						Name:        "sriovif1",
+						Driver:      "mlx5_core",
+						EswitchMode: "legacy",
+						LinkType:    "ETH",
+						PfSettings:  &sriovnetworkv1.PfSettings{Features: map[string]bool{"rx-gro-hw": true}},
+					}},
+				},
+			}
+
+			changed, err := genericPlugin.CheckStatusChanges(networkNodeState)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(genericPlugin.(*GenericPlugin).needDrainNode(networkNodeState.Spec, networkNodeState.Status)).To(BeFalse())
+		})
+
 		Context("Kernel Args", func() {
 
 			vfioNetworkNodeState := &sriovnetworkv1.SriovNetworkNodeState{
@@ -950,6 +1112,50 @@ var _ = Describe("Generic plugin", func() {
 				Expect(genericPlugin.(*GenericPlugin).DesiredKernelArgs[consts.KernelArgIommuPt]).To(BeTrue())
 			})
 
+			It("should remove the IOMMU kernel args added by the operator once vfio-pci is not needed", func() {
+				hostHelper.EXPECT().GetCPUVendor().Return(hostTypes.CPUVendorIntel, nil).Times(2)
+				Expect(genericPlugin.(*GenericPlugin).addVfioDesiredKernelArg(vfioNetworkNodeState)).To(Succeed())
+				genericPlugin.(*GenericPlugin).DriverStateMap[Vfio].DriverLoaded = true
+
+				// intel_iommu=on was configured by the administrator
+				operatorKargs = []string{consts.KernelArgIommuPt}
+				Expect(genericPlugin.(*GenericPlugin).addVfioDesiredKernelArg(rdmaState)).To(Succeed())
+				Expect(genericPlugin.(*GenericPlugin).DesiredKernelArgs[consts.KernelArgIntelIommu]).To(BeTrue())
+				Expect(genericPlugin.(*GenericPlugin).DesiredKernelArgs[consts.KernelArgIommuPt]).To(BeFalse())
+
+				// the arguments are enabled again by a new vfio-pci policy
+				Expect(genericPlugin.(*GenericPlugin).addVfioDesiredKernelArg(vfioNetworkNodeState)).To(Succeed())
+				Expect(genericPlugin.(*GenericPlugin).DesiredKernelArgs[consts.KernelArgIommuPt]).To(BeTrue())
+			})
+
+			It("should add and remove the desired kernel args", func() {
+				genericPlugin.(*GenericPlugin).enableDesiredKernelArgs(consts.KernelArgIommuPt)
+				genericPlugin.(*GenericPlugin).enableDesiredKernelArgs(consts.KernelArgIntelIommu)
+				needReboot, err := genericPlugin.(*GenericPlugin).syncDesiredKernelArgs()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needReboot).To(BeTrue())
+				Expect(addedKargs).To(Equal([]string{consts.KernelArgIntelIommu, consts.KernelArgIommuPt}))
+				Expect(removedKargs).To(Equal([]string{consts.KernelArgRdmaExclusive, consts.KernelArgRdmaShared, consts.KernelArgPciRealloc}))
+			})
+
+			It("should plan the kernel args without changing the host", func() {
+				hostHelper.EXPECT().GetCPUVendor().Return(hostTypes.CPUVendorIntel, nil)
+				desiredKargs := maps.Clone(genericPlugin.(*GenericPlugin).DesiredKernelArgs)
+				plannedState := vfioNetworkNodeState.DeepCopy()
+				plannedState.Spec.System.RdmaMode = consts.RdmaSubsystemModeShared
+
+				needDrain, needReboot, err := genericPlugin.(plugin.Planner).Plan(plannedState)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(needReboot).To(BeTrue())
+				Expect(needDrain).To(BeTrue())
+
+				// neither the bootloader nor the RDMA subsystem are configured
+				Expect(addedKargs).To(BeNil())
+				Expect(removedKargs).To(BeNil())
+				Expect(genericPlugin.(*GenericPlugin).DesiredKernelArgs).To(Equal(desiredKargs))
+				Expect(genericPlugin.(*GenericPlugin).DesireState).To(BeNil())
+			})
+
 			It("should enable rdma shared mode", func() {
 				hostHelper.EXPECT().SetRDMASubsystem(consts.RdmaSubsystemModeShared).Return(nil)
 				err := genericPlugin.(*GenericPlugin).configRdmaKernelArg(rdmaState)
@@ -1046,6 +1252,59 @@ var _ = Describe("Generic plugin", func() {
 			Expect(driverState.DriverLoaded).To(BeTrue())
 		})
 
+		It("should load vfio_pci driver in no-IOMMU mode", func() {
+			networkNodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:00:00.0",
+						NumVfs:     2,
+						VfGroups: []sriovnetworkv1.VfGroup{{
+							DeviceType:   consts.DeviceTypeVfioPciNoIommu,
+							PolicyName:   "policy-1",
+							ResourceName: "resource-1",
+							VfRange:      "0-1",
+						}}}},
+				},
+			}
+
+			hostHelper.EXPECT().LoadKernelModule("vfio_pci").Return(nil)
+			hostHelper.EXPECT().SetKernelModuleParam("vfio", "enable_unsafe_noiommu_mode", "1").Return(nil)
+
+			concretePlugin := genericPlugin.(*GenericPlugin)
+			concretePlugin.DesireState = networkNodeState
+			Expect(concretePlugin.syncDriverState()).To(Succeed())
+			Expect(concretePlugin.getDriverStateMap()[Vfio].DriverLoaded).To(BeFalse())
+
+			// the IOMMU kernel arguments are not needed
+			Expect(concretePlugin.addVfioDesiredKernelArg(networkNodeState)).To(Succeed())
+			Expect(concretePlugin.DesiredKernelArgs[consts.KernelArgIommuPt]).To(BeFalse())
+			Expect(concretePlugin.DesiredKernelArgs[consts.KernelArgIntelIommu]).To(BeFalse())
+		})
+
+		It("should load uio_pci_generic driver", func() {
+			networkNodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+					Interfaces: sriovnetworkv1.Interfaces{{
+						PciAddress: "0000:00:00.0",
+						NumVfs:     2,
+						VfGroups: []sriovnetworkv1.VfGroup{{
+							DeviceType:   consts.DeviceTypeUioPciGeneric,
+							PolicyName:   "policy-1",
+							ResourceName: "resource-1",
+							VfRange:      "0-1",
+						}}}},
+				},
+			}
+
+			hostHelper.EXPECT().LoadKernelModule("uio_pci_generic").Return(nil)
+
+			concretePlugin := genericPlugin.(*GenericPlugin)
+			concretePlugin.DesireState = networkNodeState
+			Expect(concretePlugin.syncDriverState()).To(Succeed())
+			// the driver is loaded once
+			Expect(concretePlugin.syncDriverState()).To(Succeed())
+		})
+
 		It("should load virtio_vdpa driver", func() {
 			networkNodeState := &sriovnetworkv1.SriovNetworkNodeState{
 				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
@@ -1363,4 +1622,61 @@ var _ = Describe("Generic plugin", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(updated).To(BeTrue())
 	})
+	It("should drain - bond config mismatch", func() {
+		networkNodeState := &sriovnetworkv1.SriovNetworkNodeState{
+			Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+				Interfaces: sriovnetworkv1.Interfaces{{
+					PciAddress:  "0000:d8:00.0",
+					NumVfs:      1,
+					Name:        "enp216s0f0np0",
+					EswitchMode: "switchdev",
+					VfGroups: []sriovnetworkv1.VfGroup{{
+						DeviceType:   "netdevice",
+						PolicyName:   "policy-1",
+						ResourceName: "resource-1",
+						VfRange:      "0-0",
+					}}}},
+				Bonds: sriovnetworkv1.Bonds{{
+					Name: "bond0",
+					Mode: "active-backup",
+					Slaves: []sriovnetworkv1.BondSlave{
+						{PciAddress: "0000:d8:00.0", Name: "enp216s0f0np0"},
+						{PciAddress: "0000:d8:00.1", Name: "enp216s0f1np1"},
+					},
+				}}},
+			Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+				Interfaces: sriovnetworkv1.InterfaceExts{{
+					PciAddress:     "0000:d8:00.0",
+					NumVfs:         1,
+					TotalVfs:       1,
+					DeviceID:       "a2d6",
+					Vendor:         "15b3",
+					Name:           "enp216s0f0np0",
+					Mtu:            1500,
+					Mac:            "0c:42:a1:55:ee:46",
+					Driver:         "mlx5_core",
+					EswitchMode:    "switchdev",
+					LinkSpeed:      "25000 Mb/s",
+					LinkType:       "ETH",
+					LinkAdminState: "up",
+					VFs: []sriovnetworkv1.VirtualFunction{{
+						PciAddress: "0000:d8:00.2",
+						DeviceID:   "101e",
+						Vendor:     "15b3",
+						VfID:       0,
+						Name:       "enp216s0f0v0",
+						Mtu:        1500,
+						Mac:        "8e:d6:2c:62:87:1b",
+						Driver:     "mlx5_core",
+					}},
+				}},
+			}}
+		needDrain, needReboot, err := genericPlugin.OnNodeStateChange(networkNodeState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(needReboot).To(BeFalse())
+		Expect(needDrain).To(BeTrue())
+		updated, err := genericPlugin.CheckStatusChanges(networkNodeState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated).To(BeTrue())
+	})
 })
@@ -17,9 +17,15 @@
 package intel
 
 import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper"
 	plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins"
 )
@@ -27,14 +33,31 @@ import (
 var PluginName = "intel"
 
 type IntelPlugin struct {
-	PluginName  string
-	DesireState *sriovnetworkv1.SriovNetworkNodeState
-	LastState   *sriovnetworkv1.SriovNetworkNodeState
+	PluginName string
+	helpers    helper.HostHelpersInterface
 }
 
+// DDP packages to install by PCI address of the first PF of the adapter, an empty package restores the default package
+var ddpPackagesToInstall map[string]string
+
+// DDP packages of the adapters of the spec by PCI address of the first PF of the adapter
+var desiredDdpPackages map[string]string
+
+// PFs rebound to the ice driver to load the DDP packages, all the PFs of the updated adapters
+var iceDevicesToReload []string
+
+// PFs reloaded by the last Apply
+var reloadedDevices []string
+
+// DDP packages loaded by a reload of the PFs by PCI address of the first PF of the adapter, the PFs are reloaded
+// only once for a package. It is kept across the calls of OnNodeStateChange.
+var reloadedDdpPackages = map[string]string{}
+
+// Initialize our plugin and set up initial values
 func NewIntelPlugin(helpers helper.HostHelpersInterface) (plugin.VendorPlugin, error) {
 	return &IntelPlugin{
 		PluginName: PluginName,
+		helpers:    helpers,
 	}, nil
 }
 
@@ -44,18 +67,256 @@ func (p *IntelPlugin) Name() string {
 }
 
 // OnNodeStateChange Invoked when SriovNetworkNodeState CR is created or updated, return if need dain and/or reboot node
-func (p *IntelPlugin) OnNodeStateChange(*sriovnetworkv1.SriovNetworkNodeState) (bool, bool, error) {
-	log.Log.Info("intel-plugin OnNodeStateChange()")
-	return false, false, nil
+func (p *IntelPlugin) OnNodeStateChange(new *sriovnetworkv1.SriovNetworkNodeState) (needDrain bool, needReboot bool, err error) {
+	log.Log.Info("intel plugin OnNodeStateChange()")
+	c := newDdpChanges()
+	needDrain, needReboot, err = p.evaluate(new, c)
+	ddpPackagesToInstall = c.packagesToInstall
+	desiredDdpPackages = c.desiredPackages
+	iceDevicesToReload = c.devicesToReload
+	return
+}
+
+// Plan returns if applying the node state requires to drain and/or reboot the node,
+// the DDP package changes are not kept for Apply
+func (p *IntelPlugin) Plan(new *sriovnetworkv1.SriovNetworkNodeState) (bool, bool, error) {
+	return p.evaluate(new, newDdpChanges())
 }
 
-// OnNodeStatusChange verify whether SriovNetworkNodeState CR status present changes on configured VFs.
-func (p *IntelPlugin) CheckStatusChanges(*sriovnetworkv1.SriovNetworkNodeState) (bool, error) {
+// ddpChanges holds the DDP package changes required by a node state
+type ddpChanges struct {
+	packagesToInstall map[string]string
+	desiredPackages   map[string]string
+	devicesToReload   []string
+}
+
+func newDdpChanges() *ddpChanges {
+	return &ddpChanges{
+		packagesToInstall: map[string]string{},
+		desiredPackages:   map[string]string{},
+	}
+}
+
+// iceAdapter is an E810 adapter, the ice driver loads the same DDP package for all its PFs
+type iceAdapter struct {
+	// sorted PCI addresses of the PFs of the adapter
+	pciAddresses []string
+	// DDP package requested by the PFs of the spec, empty if none of them requests a package
+	ddpPackage string
+	// true if at least one PF of the adapter is configured by the spec
+	configured bool
+}
+
+// evaluate fills the DDP package changes required by the node state, return if need drain and/or reboot node.
+// The packages are loaded by the probe of the PFs, only the PFs of the updated adapters are reloaded.
+func (p *IntelPlugin) evaluate(new *sriovnetworkv1.SriovNetworkNodeState, c *ddpChanges) (needDrain bool, needReboot bool, err error) {
+	for _, ifaceSpec := range new.Spec.Interfaces {
+		ifaceStatus, ok := intelInterfaceStatus(new, ifaceSpec.PciAddress)
+		if !ok {
+			continue
+		}
+		if ifaceSpec.EswitchMode == sriovnetworkv1.ESwithModeSwitchDev && ifaceStatus.EswitchMode != sriovnetworkv1.ESwithModeSwitchDev {
+			if ifaceStatus.Driver != consts.IceDriverName {
+				return false, false, fmt.Errorf("switchdev mode is not supported by driver %s of interface %s", ifaceStatus.Driver, ifaceSpec.PciAddress)
+			}
+			if err := p.helpers.ValidateIceSwitchdevSupport(ifaceSpec.PciAddress); err != nil {
+				return false, false, err
+			}
+		}
+		if ifaceStatus.Driver != consts.IceDriverName && ifaceSpec.DdpPackage != "" {
+			return false, false, fmt.Errorf("DDP packages are not supported by driver %s of interface %s", ifaceStatus.Driver, ifaceSpec.PciAddress)
+		}
+	}
+
+	adapters, err := p.desiredAdapters(new)
+	if err != nil {
+		return false, false, err
+	}
+	for _, adapter := range adapters {
+		pciAddress := adapter.pciAddresses[0]
+		installed, installedBeforeBoot, err := p.helpers.GetInstalledDDPPackage(pciAddress)
+		if err != nil {
+			// the packages installed by the administrator are ignored when the spec doesn't request a package
+			if adapter.ddpPackage == "" {
+				log.Log.V(2).Info("can't read the installed DDP package, skip", "device", pciAddress, "reason", err.Error())
+				continue
+			}
+			return false, false, err
+		}
+		c.desiredPackages[pciAddress] = adapter.ddpPackage
+		if installed != adapter.ddpPackage {
+			log.Log.V(2).Info("DDP package needs update", "devices", adapter.pciAddresses, "installed", installed, "desired", adapter.ddpPackage)
+			c.packagesToInstall[pciAddress] = adapter.ddpPackage
+			c.devicesToReload = append(c.devicesToReload, adapter.pciAddresses...)
+			continue
+		}
+		if adapter.ddpPackage == "" {
+			continue
+		}
+
+		// the package is installed, verify it is the active one
+		pkg, err := p.helpers.GetDDPPackage(adapter.ddpPackage)
+		if err != nil {
+			return false, false, err
+		}
+		active := true
+		for _, address := range adapter.pciAddresses {
+			if ifaceStatus, _ := intelInterfaceStatus(new, address); ifaceStatus.DdpPackage != pkg.String() {
+				log.Log.V(2).Info("DDP package not active", "device", address, "active", ifaceStatus.DdpPackage, "desired", pkg.String())
+				active = false
+			}
+		}
+		if active {
+			continue
+		}
+		// the driver already tried to load the package, loading it again would fail the same way
+		if installedBeforeBoot || reloadedDdpPackages[pciAddress] == adapter.ddpPackage {
+			return false, false, fmt.Errorf("DDP package %s is installed for interfaces %s but it is not active, check the ice driver logs",
+				adapter.ddpPackage, strings.Join(adapter.pciAddresses, ", "))
+		}
+		c.devicesToReload = append(c.devicesToReload, adapter.pciAddresses...)
+	}
+
+	// the VFs of the reloaded PFs are removed
+	needDrain = len(c.devicesToReload) > 0
+	log.Log.V(2).Info("intel plugin", "need-drain", needDrain, "need-reboot", needReboot)
+	return
+}
+
+// desiredAdapters returns the E810 adapters with PFs configured by the spec sorted by the PCI address of their
+// first PF. The PFs of an adapter share the serial number reported by devlink. A PF without DDP package doesn't
+// manage the package of the adapter, the default package is restored once none of the PFs requests a package.
+func (p *IntelPlugin) desiredAdapters(state *sriovnetworkv1.SriovNetworkNodeState) ([]*iceAdapter, error) {
+	adapters := map[string]*iceAdapter{}
+	serials := map[string]string{}
+	for _, iface := range state.Status.Interfaces {
+		if iface.Vendor != consts.IntelVendorID || iface.Driver != consts.IceDriverName {
+			continue
+		}
+		info, err := p.helpers.GetDevlinkDeviceInfo(iface.PciAddress)
+		if err != nil || info.SerialNumber == "" {
+			log.Log.V(2).Info("can't read the serial number of the device, skip", "device", iface.PciAddress)
+			continue
+		}
+		if _, ok := adapters[info.SerialNumber]; !ok {
+			adapters[info.SerialNumber] = &iceAdapter{}
+		}
+		adapters[info.SerialNumber].pciAddresses = append(adapters[info.SerialNumber].pciAddresses, iface.PciAddress)
+		serials[iface.PciAddress] = info.SerialNumber
+	}
+
+	for _, ifaceSpec := range state.Spec.Interfaces {
+		ifaceStatus, ok := intelInterfaceStatus(state, ifaceSpec.PciAddress)
+		if !ok || ifaceStatus.Driver != consts.IceDriverName {
+			continue
+		}
+		serial, ok := serials[ifaceSpec.PciAddress]
+		if !ok {
+			if ifaceSpec.DdpPackage != "" {
+				return nil, fmt.Errorf("can't install DDP package %s for interface %s, the device has no serial number",
+					ifaceSpec.DdpPackage, ifaceSpec.PciAddress)
+			}
+			continue
+		}
+		adapter := adapters[serial]
+		adapter.configured = true
+		if ifaceSpec.DdpPackage == "" {
+			continue
+		}
+		if adapter.ddpPackage != "" && adapter.ddpPackage != ifaceSpec.DdpPackage {
+			return nil, fmt.Errorf("conflicting DDP packages %s and %s requested for interfaces of the same adapter: %s",
+				adapter.ddpPackage, ifaceSpec.DdpPackage, strings.Join(adapter.pciAddresses, ", "))
+		}
+		adapter.ddpPackage = ifaceSpec.DdpPackage
+	}
+
+	configured := []*iceAdapter{}
+	for _, adapter := range adapters {
+		if adapter.configured {
+			sort.Strings(adapter.pciAddresses)
+			configured = append(configured, adapter)
+		}
+	}
+	sort.Slice(configured, func(i, j int) bool { return configured[i].pciAddresses[0] < configured[j].pciAddresses[0] })
+	return configured, nil
+}
+
+// CheckStatusChanges verify whether the DDP packages installed on the host changed.
+func (p *IntelPlugin) CheckStatusChanges(new *sriovnetworkv1.SriovNetworkNodeState) (bool, error) {
+	adapters, err := p.desiredAdapters(new)
+	if err != nil {
+		return false, err
+	}
+	for _, adapter := range adapters {
+		installed, _, err := p.helpers.GetInstalledDDPPackage(adapter.pciAddresses[0])
+		if err != nil {
+			if adapter.ddpPackage == "" {
+				continue
+			}
+			return false, err
+		}
+		if installed != adapter.ddpPackage {
+			log.Log.Info("DDP package changed", "devices", adapter.pciAddresses, "installed", installed, "desired", adapter.ddpPackage)
+			return true, nil
+		}
+	}
 	return false, nil
 }
 
+// PendingFirmwareChanges returns the DDP package changes Apply will configure
+func (p *IntelPlugin) PendingFirmwareChanges() []string {
+	changes := []string{}
+	for pciAddress, pkgFile := range ddpPackagesToInstall {
+		if pkgFile == "" {
+			pkgFile = "default"
+		}
+		changes = append(changes, fmt.Sprintf("%s: ddpPackage=%s (requires reload of the PFs of the adapter)", pciAddress, pkgFile))
+	}
+	sort.Strings(changes)
+	return changes
+}
+
 // Apply config change
 func (p *IntelPlugin) Apply() error {
 	log.Log.Info("intel plugin Apply()")
+	reloadedDevices = nil
+	for pciAddress, pkgFile := range ddpPackagesToInstall {
+		var err error
+		if pkgFile == "" {
+			err = p.helpers.RemoveDDPPackage(pciAddress)
+		} else {
+			err = p.helpers.InstallDDPPackage(pciAddress, pkgFile)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if len(iceDevicesToReload) == 0 {
+		return nil
+	}
+	if err := p.helpers.ReloadIceDevices(iceDevicesToReload); err != nil {
+		return err
+	}
+	reloadedDevices = slices.Clone(iceDevicesToReload)
+	for pciAddress, pkgFile := range desiredDdpPackages {
+		if slices.Contains(iceDevicesToReload, pciAddress) {
+			reloadedDdpPackages[pciAddress] = pkgFile
+		}
+	}
 	return nil
 }
+
+// ReloadedDevices returns the PFs reloaded by the last Apply to load the DDP packages, their VFs are removed
+func (p *IntelPlugin) ReloadedDevices() []string {
+	return reloadedDevices
+}
+
+// intelInterfaceStatus returns the status of the Intel PF
+func intelInterfaceStatus(state *sriovnetworkv1.SriovNetworkNodeState, pciAddress string) (sriovnetworkv1.InterfaceExt, bool) {
+	for _, iface := range state.Status.Interfaces {
+		if iface.PciAddress == pciAddress {
+			return iface, iface.Vendor == consts.IntelVendorID
+		}
+	}
+	return sriovnetworkv1.InterfaceExt{}, false
+}
@@ -0,0 +1,241 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package intel
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vishvananda/netlink"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	mock_helper "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper/mock"
+	plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins"
+	intel "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vendors/intel"
+)
+
+var _ = Describe("Intel plugin", func() {
+	var (
+		p        plugin.VendorPlugin
+		h        *mock_helper.MockHostHelpersInterface
+		err      error
+		testCtrl *gomock.Controller
+
+		nodeState *sriovnetworkv1.SriovNetworkNodeState
+		serials   map[string]string
+		commsPkg  = &intel.DDPPackage{Name: "ICE COMMS Package", Version: "1.3.45.0"}
+		testError = fmt.Errorf("test")
+	)
+
+	BeforeEach(func() {
+		testCtrl = gomock.NewController(GinkgoT())
+		h = mock_helper.NewMockHostHelpersInterface(testCtrl)
+		p, err = NewIntelPlugin(h)
+		Expect(err).ToNot(HaveOccurred())
+		reloadedDdpPackages = map[string]string{}
+
+		// 0000:d8:00.0 and 0000:d8:00.1 are the ports of the same adapter
+		serials = map[string]string{
+			"0000:d8:00.0": "00-01-02-ff-ff-03-04-05",
+			"0000:d8:00.1": "00-01-02-ff-ff-03-04-05",
+			"0000:3b:00.0": "00-01-02-ff-ff-03-04-06",
+		}
+		h.EXPECT().GetDevlinkDeviceInfo(gomock.Any()).DoAndReturn(func(pciAddress string) (*netlink.DevlinkDeviceInfo, error) {
+			return &netlink.DevlinkDeviceInfo{SerialNumber: serials[pciAddress]}, nil
+		}).AnyTimes()
+
+		nodeState = &sriovnetworkv1.SriovNetworkNodeState{ObjectMeta: corev1.ObjectMeta{Name: "worker-0", Namespace: "test"},
+			Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{
+				Interfaces: sriovnetworkv1.Interfaces{{PciAddress: "0000:d8:00.0", NumVfs: 4, DdpPackage: "ice_comms-1.3.45.0.pkg"}},
+			},
+			Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+				Interfaces: sriovnetworkv1.InterfaceExts{
+					{PciAddress: "0000:3b:00.0", Vendor: "8086", Driver: "ice",
+						NvmVersion: "4.40", DdpPackage: "ICE OS Default Package 1.3.36.0"},
+					{PciAddress: "0000:d8:00.0", Vendor: "8086", Driver: "ice",
+						NvmVersion: "4.40", DdpPackage: "ICE OS Default Package 1.3.36.0"},
+					{PciAddress: "0000:d8:00.1", Vendor: "8086", Driver: "ice",
+						NvmVersion: "4.40", DdpPackage: "ICE OS Default Package 1.3.36.0"},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		testCtrl.Finish()
+	})
+
+	setActivePackage := func(pkg string) {
+		for i := range nodeState.Status.Interfaces {
+			if nodeState.Status.Interfaces[i].PciAddress != "0000:3b:00.0" {
+				nodeState.Status.Interfaces[i].DdpPackage = pkg
+			}
+		}
+	}
+
+	Context("OnNodeStateChange", func() {
+		It("should install a DDP package and reload the PFs of the adapter", func() {
+			h.EXPECT().GetInstalledDDPPackage("0000:d8:00.0").Return("", false, nil)
+			needDrain, needReboot, err := p.OnNodeStateChange(nodeState)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(needDrain).To(BeTrue())
+			Expect(needReboot).To(BeFalse())
+			Expect(p.(plugin.FirmwareChangeReporter).PendingFirmwareChanges()).To(Equal(
+				[]string{"0000:d8:00.0: ddpPackage=ice_comms-1.3.45.0.pkg (requires reload of the PFs of the adapter)"}))
+
+			h.EXPECT().InstallDDPPackage("0000:d8:00.0", "ice_comms-1.3.45.0.pkg").Return(nil)
+			h.EXPECT().ReloadIceDevices([]string{"0000:d8:00.0", "0000:d8:00.1"}).Return(nil)
+			Expect(p.Apply()).To(Succeed())
+			Expect(reloadedDdpPackages).To(Equal(map[string]string{"0000:d8:00.0": "ice_comms-1.3.45.0.pkg"}))
+			Expect(p.(plugin.DeviceReloader).ReloadedDevices()).To(Equal([]string{"0000:d8:00.0", "0000:d8:00.1"}))
+		})
+		It("should restore the default package", func() {
+			nodeState.Spec.Interfaces[0].DdpPackage = ""
+			h.EXPECT().GetInstalledDDPPackage("0000:d8:00.0").Return("ice_comms-1.3.45.0.pkg", false, nil)
+			needDrain, _, err := p.OnNodeStateChange(nodeState)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(needDrain).To(BeTrue())
+
+			h.EXPECT().RemoveDDPPackage("0000:d8:00.0").Return(nil)
+			h.EXPECT().ReloadIceDevices([]string{"0000:d8:00.0", "0000:d8:00.1"}).Return(nil)
+			Expect(p.Apply()).To(Succeed())
+		})
+		It("should do nothing when the package is active", func() {
+			setActivePackage("ICE COMMS Package 1.3.45.0")
+			h.EXPECT().GetInstalledDDPPackage("0000:d8:00.0").Return("ice_comms-1.3.45.0.pkg", true, nil)
+			h.EXPECT().GetDDPPackage("ice_comms-1.3.45.0.pkg").Return(commsPkg, nil)
+			needDrain, needReboot, err := p.OnNodeStateChange(nodeState)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(needDrain).To(BeFalse())
+			Expect(needReboot).To(BeFalse())
+			Expect(p.Apply()).To(Succeed())
+			Expect(p.(plugin.DeviceReloader).ReloadedDevices()).To(BeEmpty())
+		})
+		It("should keep the package of the adapter for a PF without package", func() {
+			setActivePackage("ICE COMMS Package 1.3.45.0")
+			nodeState.Spec.Interfaces = append(nodeState.Spec.Interfaces, sriovnetworkv1.Interface{PciAddress: "0000:d8:00.1", NumVfs: 4})
+			h.EXPECT().GetInstalledDDPPackage("0000:d8:00.0").Return("ice_comms-1.3.45.0.pkg", true, nil)
+			h.EXPECT().GetDDPPackage("ice_comms-1.3.45.0.pkg").Return(commsPkg, nil)
+			needDrain, _, err := p.OnNodeStateChange(nodeState)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(needDrain).To(BeFalse())
+		})
+		It("should fail on different packages for the PFs of an adapter", func() {
+			nodeState.Spec.Interfaces = append(nodeState.Spec.Interfaces,
+				sriovnetworkv1.Interface{PciAddress: "0000:d8:00.1", NumVfs: 4, DdpPackage: "ice_wireless_edge-1.3.10.0.pkg"})
+			_, _, err := p.OnNodeStateChange(nodeState)
+			Expect(err).To(MatchError(ContainSubstring("conflicting DDP packages")))
+		})
+		It("should fail on a DDP package for a device without serial number", func() {
+			delete(serials, "0000:d8:00.0")
+			_, _, err := p.OnNodeStateChange(nodeState)
+			Expect(err).To(MatchError(ContainSubstring("has no serial number")))
+		})
+		It("should plan a DDP package change without keeping it for Apply", func() {
+			setActivePackage("ICE COMMS Package 1.3.45.0")
+			h.EXPECT().GetInstalledDDPPackage("0000:d8:00.0").Return("ice_comms-1.3.45.0.pkg", true, nil).Times(2)
+			h.EXPECT().GetDDPPackage("ice_comms-1.3.45.0.pkg").Return(commsPkg, nil)
+			_, _, err := p.OnNodeStateChange(nodeState)
+			Expect(err).ToNot(HaveOccurred())
+
+			planned := nodeState.DeepCopy()
+			planned.Spec.Interfaces[0].DdpPackage = ""
+			needDrain, needReboot, err := p.(plugin.Planner).Plan(planned)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(needDrain).To(BeTrue())
+			Expect(needReboot).To(BeFalse())
+
+			Expect(p.(plugin.FirmwareChangeReporter).PendingFirmwareChanges()).To(BeEmpty())
+			Expect(p.Apply()).To(Succeed())
+		})
+		It("should reload the PFs when the installed package is not active", func() {
+			h.EXPECT().GetInstalledDDPPackage("0000:d8:00.0").Return("ice_comms-1.3.45.0.pkg", false, nil)
+			h.EXPECT().GetDDPPackage("ice_comms-1.3.45.0.pkg").Return(commsPkg, nil)
+			needDrain, _, err := p.OnNodeStateChange(nodeState)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(needDrain).To(BeTrue())
+
+			h.EXPECT().ReloadIceDevices([]string{"0000:d8:00.0", "0000:d8:00.1"}).Return(nil)
+			Expect(p.Apply()).To(Succeed())
+		})
+		It("should fail when the reloaded PFs didn't load the package", func() {
+			reloadedDdpPackages["0000:d8:00.0"] = "ice_comms-1.3.45.0.pkg"
+			h.EXPECT().GetInstalledDDPPackage("0000:d8:00.0").Return("ice_comms-1.3.45.0.pkg", false, nil)
+			h.EXPECT().GetDDPPackage("ice_comms-1.3.45.0.pkg").Return(commsPkg, nil)
+			_, _, err := p.OnNodeStateChange(nodeState)
+			Expect(err).To(MatchError(ContainSubstring("check the ice driver logs")))
+		})
+		It("should fail when the package was installed before the boot and is not active", func() {
+			h.EXPECT().GetInstalledDDPPackage("0000:d8:00.0").Return("ice_comms-1.3.45.0.pkg", true, nil)
+			h.EXPECT().GetDDPPackage("ice_comms-1.3.45.0.pkg").Return(commsPkg, nil)
+			_, _, err := p.OnNodeStateChange(nodeState)
+			Expect(err).To(HaveOccurred())
+		})
+		It("should ignore the devices without DDP package when the installed package can't be read", func() {
+			nodeState.Spec.Interfaces[0].DdpPackage = ""
+			h.EXPECT().GetInstalledDDPPackage("0000:d8:00.0").Return("", false, testError)
+			needDrain, _, err := p.OnNodeStateChange(nodeState)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(needDrain).To(BeFalse())
+		})
+		It("should fail on a DDP package for an i40e device", func() {
+			nodeState.Status.Interfaces[1].Driver = "i40e"
+			_, _, err := p.OnNodeStateChange(nodeState)
+			Expect(err).To(MatchError(ContainSubstring("not supported by driver i40e")))
+		})
+		It("should validate the switchdev support", func() {
+			nodeState.Spec.Interfaces[0].DdpPackage = ""
+			nodeState.Spec.Interfaces[0].EswitchMode = "switchdev"
+			h.EXPECT().ValidateIceSwitchdevSupport("0000:d8:00.0").Return(testError)
+			_, _, err := p.OnNodeStateChange(nodeState)
+			Expect(err).To(MatchError(testError))
+		})
+		It("should ignore the devices of other vendors", func() {
+			nodeState.Status.Interfaces[1].Vendor = "15b3"
+			needDrain, needReboot, err := p.OnNodeStateChange(nodeState)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(needDrain).To(BeFalse())
+			Expect(needReboot).To(BeFalse())
+		})
+	})
+
+	Context("CheckStatusChanges", func() {
+		It("should detect a change of the installed package", func() {
+			h.EXPECT().GetInstalledDDPPackage("0000:d8:00.0").Return("ice_wireless_edge-1.3.10.0.pkg", false, nil)
+			changed, err := p.CheckStatusChanges(nodeState)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeTrue())
+		})
+		It("should not report a change when the package is installed", func() {
+			h.EXPECT().GetInstalledDDPPackage("0000:d8:00.0").Return("ice_comms-1.3.45.0.pkg", false, nil)
+			changed, err := p.CheckStatusChanges(nodeState)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeFalse())
+		})
+		It("should not report a change for a PF without package of an adapter with a package", func() {
+			nodeState.Spec.Interfaces = append(nodeState.Spec.Interfaces, sriovnetworkv1.Interface{PciAddress: "0000:d8:00.1", NumVfs: 4})
+			h.EXPECT().GetInstalledDDPPackage("0000:d8:00.0").Return("ice_comms-1.3.45.0.pkg", false, nil)
+			changed, err := p.CheckStatusChanges(nodeState)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeFalse())
+		})
+	})
+})
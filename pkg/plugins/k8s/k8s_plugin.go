@@ -164,6 +164,14 @@ func (p *K8sPlugin) OnNodeStateChange(new *sriovnetworkv1.SriovNetworkNodeState)
 	return
 }
 
+// Plan returns if applying the node state requires to drain and/or reboot the node,
+// the services are checked on a copy of the plugin so the update target used by Apply is kept
+func (p *K8sPlugin) Plan(new *sriovnetworkv1.SriovNetworkNodeState) (bool, bool, error) {
+	planner := *p
+	planner.updateTarget = &k8sUpdateTarget{}
+	return planner.OnNodeStateChange(new)
+}
+
 // TODO: implement - https://github.com/k8snetworkplumbingwg/sriov-network-operator/issues/630
 // OnNodeStatusChange verify whether SriovNetworkNodeState CR status present changes on configured VFs.
 func (p *K8sPlugin) CheckStatusChanges(*sriovnetworkv1.SriovNetworkNodeState) (bool, error) {
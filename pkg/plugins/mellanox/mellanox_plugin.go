@@ -18,6 +18,8 @@ package mellanox
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -60,40 +62,67 @@ func (p *MellanoxPlugin) Name() string {
 func (p *MellanoxPlugin) OnNodeStateChange(new *sriovnetworkv1.SriovNetworkNodeState) (needDrain bool, needReboot bool, err error) {
 	log.Log.Info("mellanox plugin OnNodeStateChange()")
 
-	needDrain = false
-	needReboot = false
-	err = nil
-	pciAddressesToReset = []string{}
-	attributesToChange = map[string]mlx.MlxNic{}
-	mellanoxNicsStatus = map[string]map[string]sriovnetworkv1.InterfaceExt{}
-	mellanoxNicsSpec = map[string]sriovnetworkv1.Interface{}
+	c := newFwChanges()
+	needDrain, needReboot, err = p.evaluate(new, c)
+	pciAddressesToReset = c.pciAddressesToReset
+	attributesToChange = c.attributesToChange
+	mellanoxNicsStatus = c.nicsStatus
+	mellanoxNicsSpec = c.nicsSpec
+	return
+}
+
+// Plan returns if applying the node state requires to drain and/or reboot the node,
+// the firmware changes are not kept for Apply
+func (p *MellanoxPlugin) Plan(new *sriovnetworkv1.SriovNetworkNodeState) (bool, bool, error) {
+	return p.evaluate(new, newFwChanges())
+}
+
+// fwChanges holds the firmware changes required by a node state
+type fwChanges struct {
+	pciAddressesToReset []string
+	attributesToChange  map[string]mlx.MlxNic
+	nicsStatus          map[string]map[string]sriovnetworkv1.InterfaceExt
+	nicsSpec            map[string]sriovnetworkv1.Interface
+}
+
+func newFwChanges() *fwChanges {
+	return &fwChanges{
+		pciAddressesToReset: []string{},
+		attributesToChange:  map[string]mlx.MlxNic{},
+		nicsStatus:          map[string]map[string]sriovnetworkv1.InterfaceExt{},
+		nicsSpec:            map[string]sriovnetworkv1.Interface{},
+	}
+}
+
+// evaluate fills the firmware changes required by the node state, return if need drain and/or reboot node
+func (p *MellanoxPlugin) evaluate(new *sriovnetworkv1.SriovNetworkNodeState, c *fwChanges) (needDrain bool, needReboot bool, err error) {
 	processedNics := map[string]bool{}
 
-	// fill mellanoxNicsStatus
+	// fill the status of the mellanox NICs
 	for _, iface := range new.Status.Interfaces {
 		if iface.Vendor != mlx.MellanoxVendorID {
 			continue
 		}
 
 		pciPrefix := mlx.GetPciAddressPrefix(iface.PciAddress)
-		if ifaces, ok := mellanoxNicsStatus[pciPrefix]; ok {
+		if ifaces, ok := c.nicsStatus[pciPrefix]; ok {
 			ifaces[iface.PciAddress] = iface
 		} else {
-			mellanoxNicsStatus[pciPrefix] = map[string]sriovnetworkv1.InterfaceExt{iface.PciAddress: iface}
+			c.nicsStatus[pciPrefix] = map[string]sriovnetworkv1.InterfaceExt{iface.PciAddress: iface}
 		}
 	}
 
 	// Add only mellanox cards that required changes in the map, to help track dual port NICs
 	for _, iface := range new.Spec.Interfaces {
 		pciPrefix := mlx.GetPciAddressPrefix(iface.PciAddress)
-		if _, ok := mellanoxNicsStatus[pciPrefix]; !ok {
+		if _, ok := c.nicsStatus[pciPrefix]; !ok {
 			continue
 		}
-		mellanoxNicsSpec[iface.PciAddress] = iface
+		c.nicsSpec[iface.PciAddress] = iface
 	}
 
 	if p.helpers.IsKernelLockdownMode() {
-		if len(mellanoxNicsSpec) > 0 {
+		if len(c.nicsSpec) > 0 {
 			log.Log.Info("Lockdown mode detected, failing on interface update for mellanox devices")
 			return false, false, fmt.Errorf("mellanox device detected when in lockdown mode")
 		}
@@ -101,7 +130,7 @@ func (p *MellanoxPlugin) OnNodeStateChange(new *sriovnetworkv1.SriovNetworkNodeS
 		return
 	}
 
-	for _, ifaceSpec := range mellanoxNicsSpec {
+	for _, ifaceSpec := range c.nicsSpec {
 		pciPrefix := mlx.GetPciAddressPrefix(ifaceSpec.PciAddress)
 		// skip processed nics, help not running the same logic 2 times for dual port NICs
 		if _, ok := processedNics[pciPrefix]; ok {
@@ -113,17 +142,17 @@ func (p *MellanoxPlugin) OnNodeStateChange(new *sriovnetworkv1.SriovNetworkNodeS
 			return false, false, err
 		}
 
-		isDualPort := mlx.IsDualPort(ifaceSpec.PciAddress, mellanoxNicsStatus)
+		isDualPort := mlx.IsDualPort(ifaceSpec.PciAddress, c.nicsStatus)
 		// Attributes to change
 		attrs := &mlx.MlxNic{TotalVfs: -1}
 		var changeWithoutReboot bool
 
-		totalVfs, totalVfsNeedReboot, totalVfsChangeWithoutReboot := mlx.HandleTotalVfs(fwCurrent, fwNext, attrs, ifaceSpec, isDualPort, mellanoxNicsSpec)
+		totalVfs, totalVfsNeedReboot, totalVfsChangeWithoutReboot := mlx.HandleTotalVfs(fwCurrent, fwNext, attrs, ifaceSpec, isDualPort, c.nicsSpec)
 		sriovEnNeedReboot, sriovEnChangeWithoutReboot := mlx.HandleEnableSriov(totalVfs, fwCurrent, fwNext, attrs)
 		needReboot = totalVfsNeedReboot || sriovEnNeedReboot
 		changeWithoutReboot = totalVfsChangeWithoutReboot || sriovEnChangeWithoutReboot
 
-		needLinkChange, err := mlx.HandleLinkType(pciPrefix, fwCurrent, attrs, mellanoxNicsSpec, mellanoxNicsStatus)
+		needLinkChange, err := mlx.HandleLinkType(pciPrefix, fwCurrent, attrs, c.nicsSpec, c.nicsStatus)
 		if err != nil {
 			return false, false, err
 		}
@@ -142,16 +171,16 @@ func (p *MellanoxPlugin) OnNodeStateChange(new *sriovnetworkv1.SriovNetworkNodeS
 		}
 
 		if needReboot || changeWithoutReboot {
-			attributesToChange[ifaceSpec.PciAddress] = *attrs
+			c.attributesToChange[ifaceSpec.PciAddress] = *attrs
 		}
 
 		if needReboot {
-			pciAddressesToReset = append(pciAddressesToReset, ifaceSpec.PciAddress)
+			c.pciAddressesToReset = append(c.pciAddressesToReset, ifaceSpec.PciAddress)
 		}
 	}
 
 	// Set total VFs to 0 for mellanox interfaces with no spec
-	for pciPrefix, portsMap := range mellanoxNicsStatus {
+	for pciPrefix, portsMap := range c.nicsStatus {
 		if _, ok := processedNics[pciPrefix]; ok {
 			continue
 		}
@@ -193,7 +222,7 @@ func (p *MellanoxPlugin) OnNodeStateChange(new *sriovnetworkv1.SriovNetworkNodeS
 		}
 
 		if fwNext.TotalVfs > 0 || fwNext.EnableSriov {
-			attributesToChange[pciAddress] = mlx.MlxNic{TotalVfs: 0}
+			c.attributesToChange[pciAddress] = mlx.MlxNic{TotalVfs: 0}
 			log.Log.V(2).Info("Changing TotalVfs to 0, doesn't require rebooting", "fwNext.totalVfs", fwNext.TotalVfs)
 		}
 	}
@@ -211,6 +240,30 @@ func (p *MellanoxPlugin) CheckStatusChanges(*sriovnetworkv1.SriovNetworkNodeStat
 	return false, nil
 }
 
+// PendingFirmwareChanges returns the firmware changes Apply will configure
+func (p *MellanoxPlugin) PendingFirmwareChanges() []string {
+	changes := []string{}
+	for pciAddress, attrs := range attributesToChange {
+		change := fmt.Sprintf("%s: enableSriov=%t", pciAddress, attrs.EnableSriov)
+		// TotalVfs is -1 when the firmware value doesn't change
+		if attrs.TotalVfs >= 0 {
+			change += fmt.Sprintf(" totalVfs=%d", attrs.TotalVfs)
+		}
+		if attrs.LinkTypeP1 != "" {
+			change += fmt.Sprintf(" linkTypeP1=%s", attrs.LinkTypeP1)
+		}
+		if attrs.LinkTypeP2 != "" {
+			change += fmt.Sprintf(" linkTypeP2=%s", attrs.LinkTypeP2)
+		}
+		if slices.Contains(pciAddressesToReset, pciAddress) {
+			change += " (requires reboot)"
+		}
+		changes = append(changes, change)
+	}
+	sort.Strings(changes)
+	return changes
+}
+
 // Apply config change
 func (p *MellanoxPlugin) Apply() error {
 	if p.helpers.IsKernelLockdownMode() {
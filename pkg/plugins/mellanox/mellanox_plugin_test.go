@@ -172,6 +172,38 @@ var _ = Describe("SRIOV", Ordered, func() {
 			Expect(needReboot).To(BeTrue())
 		})
 
+		It("should plan the firmware changes without keeping them for Apply", func() {
+			h.EXPECT().IsKernelLockdownMode().Return(false)
+			h.EXPECT().GetMlxNicFwData("0000:d8:00.0").Return(&mlx.MlxNic{TotalVfs: 0}, &mlx.MlxNic{TotalVfs: 0}, nil)
+			attributesToChange = map[string]mlx.MlxNic{}
+			pciAddressesToReset = []string{}
+			sriovNetworkNodeState.Spec.Interfaces = sriovnetworkv1.Interfaces{
+				{Name: "eno1",
+					NumVfs:     10,
+					PciAddress: "0000:d8:00.0", VfGroups: []sriovnetworkv1.VfGroup{
+						{ResourceName: "test",
+							PolicyName: "test",
+							VfRange:    "eno1#0-9"},
+					},
+				},
+			}
+			sriovNetworkNodeState.Status.Interfaces = sriovnetworkv1.InterfaceExts{
+				{
+					Name:       "eno1",
+					NumVfs:     0,
+					PciAddress: "0000:d8:00.0",
+					Vendor:     "15b3",
+				},
+			}
+
+			needDrain, needReboot, err := m.(plugin.Planner).Plan(sriovNetworkNodeState)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(needDrain).To(BeTrue())
+			Expect(needReboot).To(BeTrue())
+			Expect(attributesToChange).To(BeEmpty())
+			Expect(pciAddressesToReset).To(BeEmpty())
+		})
+
 		It("should return true on reboot adding vfs for one PF and removing for the other", func() {
 			h.EXPECT().IsKernelLockdownMode().Return(false)
 			h.EXPECT().GetMlxNicFwData("0000:d8:00.0").Return(&mlx.MlxNic{TotalVfs: 0}, &mlx.MlxNic{TotalVfs: 0}, nil)
@@ -341,6 +373,20 @@ var _ = Describe("SRIOV", Ordered, func() {
 		})
 	})
 
+	Context("PendingFirmwareChanges", func() {
+		It("should describe the firmware changes found by OnNodeStateChange", func() {
+			attributesToChange = map[string]mlx.MlxNic{
+				"0000:d9:00.0": {TotalVfs: -1, LinkTypeP1: "ETH"},
+				"0000:d8:00.0": {EnableSriov: true, TotalVfs: 10},
+			}
+			pciAddressesToReset = []string{"0000:d8:00.0"}
+			Expect(m.(plugin.FirmwareChangeReporter).PendingFirmwareChanges()).To(Equal([]string{
+				"0000:d8:00.0: enableSriov=true totalVfs=10 (requires reboot)",
+				"0000:d9:00.0: enableSriov=false linkTypeP1=ETH",
+			}))
+		})
+	})
+
 	Context("Apply", func() {
 		It("should not call fw configuration for mlx devices in secure boot active environment", func() {
 			h.EXPECT().IsKernelLockdownMode().Return(true)
@@ -98,3 +98,119 @@ func (mr *MockVendorPluginMockRecorder) OnNodeStateChange(arg0 any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnNodeStateChange", reflect.TypeOf((*MockVendorPlugin)(nil).OnNodeStateChange), arg0)
 }
+
+// MockPlanner is a mock of Planner interface.
+type MockPlanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlannerMockRecorder
+	isgomock struct{}
+}
+
+// MockPlannerMockRecorder is the mock recorder for MockPlanner.
+type MockPlannerMockRecorder struct {
+	mock *MockPlanner
+}
+
+// NewMockPlanner creates a new mock instance.
+func NewMockPlanner(ctrl *gomock.Controller) *MockPlanner {
+	mock := &MockPlanner{ctrl: ctrl}
+	mock.recorder = &MockPlannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlanner) EXPECT() *MockPlannerMockRecorder {
+	return m.recorder
+}
+
+// Plan mocks base method.
+func (m *MockPlanner) Plan(arg0 *v1.SriovNetworkNodeState) (bool, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Plan", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Plan indicates an expected call of Plan.
+func (mr *MockPlannerMockRecorder) Plan(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Plan", reflect.TypeOf((*MockPlanner)(nil).Plan), arg0)
+}
+
+// MockFirmwareChangeReporter is a mock of FirmwareChangeReporter interface.
+type MockFirmwareChangeReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockFirmwareChangeReporterMockRecorder
+	isgomock struct{}
+}
+
+// MockFirmwareChangeReporterMockRecorder is the mock recorder for MockFirmwareChangeReporter.
+type MockFirmwareChangeReporterMockRecorder struct {
+	mock *MockFirmwareChangeReporter
+}
+
+// NewMockFirmwareChangeReporter creates a new mock instance.
+func NewMockFirmwareChangeReporter(ctrl *gomock.Controller) *MockFirmwareChangeReporter {
+	mock := &MockFirmwareChangeReporter{ctrl: ctrl}
+	mock.recorder = &MockFirmwareChangeReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFirmwareChangeReporter) EXPECT() *MockFirmwareChangeReporterMockRecorder {
+	return m.recorder
+}
+
+// PendingFirmwareChanges mocks base method.
+func (m *MockFirmwareChangeReporter) PendingFirmwareChanges() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingFirmwareChanges")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// PendingFirmwareChanges indicates an expected call of PendingFirmwareChanges.
+func (mr *MockFirmwareChangeReporterMockRecorder) PendingFirmwareChanges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingFirmwareChanges", reflect.TypeOf((*MockFirmwareChangeReporter)(nil).PendingFirmwareChanges))
+}
+
+// MockExternalPluginStatusReporter is a mock of ExternalPluginStatusReporter interface.
+type MockExternalPluginStatusReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockExternalPluginStatusReporterMockRecorder
+	isgomock struct{}
+}
+
+// MockExternalPluginStatusReporterMockRecorder is the mock recorder for MockExternalPluginStatusReporter.
+type MockExternalPluginStatusReporterMockRecorder struct {
+	mock *MockExternalPluginStatusReporter
+}
+
+// NewMockExternalPluginStatusReporter creates a new mock instance.
+func NewMockExternalPluginStatusReporter(ctrl *gomock.Controller) *MockExternalPluginStatusReporter {
+	mock := &MockExternalPluginStatusReporter{ctrl: ctrl}
+	mock.recorder = &MockExternalPluginStatusReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExternalPluginStatusReporter) EXPECT() *MockExternalPluginStatusReporterMockRecorder {
+	return m.recorder
+}
+
+// Status mocks base method.
+func (m *MockExternalPluginStatusReporter) Status() v1.ExternalPluginStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].(v1.ExternalPluginStatus)
+	return ret0
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockExternalPluginStatusReporterMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockExternalPluginStatusReporter)(nil).Status))
+}
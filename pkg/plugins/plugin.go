@@ -31,3 +31,33 @@ type VendorPlugin interface {
 	// CheckStatusChanges checks status changes on the SriovNetworkNodeState CR for configured VFs.
 	CheckStatusChanges(*sriovnetworkv1.SriovNetworkNodeState) (bool, error)
 }
+
+// Planner is implemented by the plugins able to evaluate a node state without applying it,
+// the config daemon uses it to evaluate the configuration planned with the dry-run policies
+type Planner interface {
+	// Plan returns if applying the node state requires to drain and/or reboot the node,
+	// unlike OnNodeStateChange it changes neither the host nor the state used by Apply
+	Plan(*sriovnetworkv1.SriovNetworkNodeState) (bool, bool, error)
+}
+
+// FirmwareChangeReporter is implemented by the plugins that change the NIC firmware configuration,
+// the config daemon reports the pending changes in the node events.
+type FirmwareChangeReporter interface {
+	// PendingFirmwareChanges returns a description of the firmware changes found by the last OnNodeStateChange
+	PendingFirmwareChanges() []string
+}
+
+// DeviceReloader is implemented by the plugins that reload the driver of PFs in Apply, the reload removes the VFs
+// of the PFs and resets their eSwitch mode. The config daemon rediscovers the reloaded PFs before the main plugin
+// applies the configuration, so the main plugin recreates their VFs.
+type DeviceReloader interface {
+	// ReloadedDevices returns the PCI addresses of the PFs reloaded by the last Apply
+	ReloadedDevices() []string
+}
+
+// ExternalPluginStatusReporter is implemented by the external plugins,
+// the config daemon reports their status in the node state
+type ExternalPluginStatusReporter interface {
+	// Status returns the result of the last call to the plugin
+	Status() sriovnetworkv1.ExternalPluginStatus
+}
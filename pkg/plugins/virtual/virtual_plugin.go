@@ -22,6 +22,7 @@ import (
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	consts "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/drivers"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper"
 	plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins"
 )
@@ -72,6 +73,11 @@ func (p *VirtualPlugin) OnNodeStateChange(new *sriovnetworkv1.SriovNetworkNodeSt
 	return p.needDrainNode(new.Spec, new.Status), false, nil
 }
 
+// Plan returns if applying the node state requires to drain the node
+func (p *VirtualPlugin) Plan(new *sriovnetworkv1.SriovNetworkNodeState) (bool, bool, error) {
+	return p.needDrainNode(new.Spec, new.Status), false, nil
+}
+
 func (p *VirtualPlugin) needDrainNode(desired sriovnetworkv1.SriovNetworkNodeStateSpec, current sriovnetworkv1.SriovNetworkNodeStateStatus) bool {
 	log.Log.V(2).Info("virtual plugin needDrainNode()", "current", current, "desired", desired)
 	for _, ifaceStatus := range current.Interfaces {
@@ -166,7 +172,8 @@ func (p *VirtualPlugin) Apply() error {
 func needVfioDriver(state *sriovnetworkv1.SriovNetworkNodeState) bool {
 	for _, iface := range state.Spec.Interfaces {
 		for i := range iface.VfGroups {
-			if iface.VfGroups[i].DeviceType == consts.DeviceTypeVfioPci {
+			// the vfio-pci driver is always loaded in no-IOMMU mode in the virtual deployments
+			if drivers.GetDriver(iface.VfGroups[i].DeviceType) == drivers.GetDriver(consts.DeviceTypeVfioPci) {
 				return true
 			}
 		}
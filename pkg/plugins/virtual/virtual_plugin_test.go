@@ -92,6 +92,25 @@ var _ = Describe("SRIOV", Ordered, func() {
 			Expect(v.LoadVfioDriver).To(Equal(uint(1)))
 		})
 
+		It("should mark the vfio driver as loading for the no-IOMMU device type", func() {
+			sriovNetworkNodeState.Spec.Interfaces = sriovnetworkv1.Interfaces{
+				{Name: "eno1",
+					NumVfs:     10,
+					PciAddress: "0000:d8:00.0", VfGroups: []sriovnetworkv1.VfGroup{
+						{
+							ResourceName: "test",
+							PolicyName:   "test",
+							VfRange:      "eno1#0-9",
+							DeviceType:   consts.DeviceTypeVfioPciNoIommu,
+						},
+					},
+				},
+			}
+			_, _, err := v.OnNodeStateChange(sriovNetworkNodeState)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(v.LoadVfioDriver).To(Equal(uint(1)))
+		})
+
 		It("should remain loading even if no vfio exist", func() {
 			sriovNetworkNodeState.Spec.Interfaces = sriovnetworkv1.Interfaces{
 				{Name: "eno1",
@@ -59,9 +59,6 @@ var (
 	// loaded on daemon initialization by reading the supported-nics configmap
 	SupportedVfIds []string
 
-	// DpdkDrivers supported DPDK drivers for virtual functions
-	DpdkDrivers = []string{"igb_uio", "vfio-pci", "uio_pci_generic"}
-
 	// InChroot global variable to mark that the config-daemon code is inside chroot on the host file system
 	InChroot = false
 
@@ -96,6 +93,10 @@ var (
 	// DisableDrain controls if the daemon will drain the node before configuration
 	DisableDrain = false
 
+	// AutoRollbackMaxFailures is the number of consecutive failures to apply a nodeState generation
+	// before the config daemon restores the last applied configuration, 0 disables the rollback
+	AutoRollbackMaxFailures = 0
+
 	// FeatureGates interface to interact with feature gates
 	FeatureGate featuregate.FeatureGate
 
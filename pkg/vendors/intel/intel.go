@@ -0,0 +1,229 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package intelutils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
+)
+
+const (
+	// layout of the DDP package file, see struct ice_pkg_hdr and ice_global_metadata_seg of the ice driver
+	ddpSegmentTypeMetadata = 1
+	ddpPackageNameSize     = 32
+	ddpSegmentHeaderSize   = 12 + ddpPackageNameSize
+	ddpMetadataSegmentSize = ddpSegmentHeaderSize + 8 + ddpPackageNameSize
+)
+
+// DDPPackage contains the name and version of a DDP package as reported by devlink when the package is active
+type DDPPackage struct {
+	Name    string
+	Version string
+}
+
+// String returns the package as reported in the DdpPackage field of the interface status
+func (p *DDPPackage) String() string {
+	return p.Name + " " + p.Version
+}
+
+//go:generate ../../../bin/mockgen -destination mock/mock_intel.go -source intel.go
+type IntelInterface interface {
+	// GetDDPPackage returns the name and version of a DDP package file of the host DDP directory
+	GetDDPPackage(pkgFile string) (*DDPPackage, error)
+	// GetInstalledDDPPackage returns the DDP package file installed for the device, empty if the device loads
+	// the default package. installedBeforeBoot is true if the package was installed before the last boot
+	// of the node, the driver already tried to load it.
+	GetInstalledDDPPackage(pciAddress string) (pkgFile string, installedBeforeBoot bool, err error)
+	// InstallDDPPackage installs the DDP package file for the device, it is loaded on the next load of the ice driver
+	InstallDDPPackage(pciAddress, pkgFile string) error
+	// RemoveDDPPackage removes the DDP package installed for the device, the default package is loaded
+	// on the next load of the ice driver
+	RemoveDDPPackage(pciAddress string) error
+	// ReloadIceDevices rebinds the PFs to the ice driver to load the installed DDP packages, the package of an
+	// adapter is loaded by the probe of its first PF so all the PFs of the adapter must be given. The VFs of
+	// the PFs are removed, the other E810 PFs of the node are not affected.
+	ReloadIceDevices(pciAddresses []string) error
+}
+
+type intelHelper struct {
+	utils      utils.CmdInterface
+	hostHelper host.HostManagerInterface
+}
+
+func New(utilsHelper utils.CmdInterface, hostHelper host.HostManagerInterface) IntelInterface {
+	return &intelHelper{
+		utils:      utilsHelper,
+		hostHelper: hostHelper,
+	}
+}
+
+// getBootTime returns the time of the last boot of the node, it is a variable to be replaced in the tests
+var getBootTime = func() (time.Time, error) {
+	info := &unix.Sysinfo_t{}
+	if err := unix.Sysinfo(info); err != nil {
+		return time.Time{}, err
+	}
+	return time.Now().Add(-time.Duration(info.Uptime) * time.Second), nil
+}
+
+func (i *intelHelper) GetDDPPackage(pkgFile string) (*DDPPackage, error) {
+	data, err := os.ReadFile(filepath.Join(utils.GetHostExtensionPath(consts.IceDdpPackagePath), pkgFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read DDP package %s: %w", pkgFile, err)
+	}
+	pkg, err := ParseDDPPackage(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DDP package %s: %w", pkgFile, err)
+	}
+	return pkg, nil
+}
+
+func (i *intelHelper) GetInstalledDDPPackage(pciAddress string) (string, bool, error) {
+	path, err := i.devicePackagePath(pciAddress)
+	if err != nil {
+		return "", false, err
+	}
+	info, err := os.Lstat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	// the operator installs the packages as links to the package files of the same directory
+	if info.Mode()&os.ModeSymlink == 0 {
+		return "", false, fmt.Errorf("DDP package %s of device %s is not managed by the operator", path, pciAddress)
+	}
+	target, err := os.Readlink(path)
+	if err != nil {
+		return "", false, err
+	}
+	bootTime, err := getBootTime()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get the boot time: %w", err)
+	}
+	return target, info.ModTime().Before(bootTime), nil
+}
+
+func (i *intelHelper) InstallDDPPackage(pciAddress, pkgFile string) error {
+	log.Log.Info("InstallDDPPackage(): install DDP package", "device", pciAddress, "package", pkgFile)
+	if _, err := os.Stat(filepath.Join(utils.GetHostExtensionPath(consts.IceDdpPackagePath), pkgFile)); err != nil {
+		return fmt.Errorf("DDP package %s not found: %w", pkgFile, err)
+	}
+	if err := i.RemoveDDPPackage(pciAddress); err != nil {
+		return err
+	}
+	path, err := i.devicePackagePath(pciAddress)
+	if err != nil {
+		return err
+	}
+	if err := os.Symlink(pkgFile, path); err != nil {
+		log.Log.Error(err, "InstallDDPPackage(): failed to install DDP package", "device", pciAddress, "package", pkgFile)
+		return err
+	}
+	return nil
+}
+
+func (i *intelHelper) RemoveDDPPackage(pciAddress string) error {
+	path, err := i.devicePackagePath(pciAddress)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Log.Error(err, "RemoveDDPPackage(): failed to remove DDP package", "device", pciAddress)
+		return err
+	}
+	return nil
+}
+
+func (i *intelHelper) ReloadIceDevices(pciAddresses []string) error {
+	log.Log.Info("ReloadIceDevices(): reload ice devices", "devices", pciAddresses)
+	for _, pciAddress := range pciAddresses {
+		if err := i.hostHelper.UnbindDriverByBusAndDevice(consts.BusPci, pciAddress); err != nil {
+			log.Log.Error(err, "ReloadIceDevices(): failed to unbind device", "device", pciAddress)
+			return err
+		}
+	}
+	for _, pciAddress := range pciAddresses {
+		if err := i.hostHelper.BindDriverByBusAndDevice(consts.BusPci, pciAddress, consts.IceDriverName); err != nil {
+			log.Log.Error(err, "ReloadIceDevices(): failed to bind device", "device", pciAddress)
+			return err
+		}
+	}
+	return nil
+}
+
+// devicePackagePath returns the path of the DDP package loaded by the ice driver for the device,
+// the package is named after the serial number of the device
+func (i *intelHelper) devicePackagePath(pciAddress string) (string, error) {
+	info, err := i.hostHelper.GetDevlinkDeviceInfo(pciAddress)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the serial number of device %s: %w", pciAddress, err)
+	}
+	if info.SerialNumber == "" {
+		return "", fmt.Errorf("device %s has no serial number", pciAddress)
+	}
+	// devlink reports the serial number as bytes separated by dashes, e.g. 00-01-02-ff-ff-03-04-05
+	serial := strings.ToLower(strings.ReplaceAll(info.SerialNumber, "-", ""))
+	return filepath.Join(utils.GetHostExtensionPath(consts.IceDdpPackagePath), fmt.Sprintf("ice-%s.pkg", serial)), nil
+}
+
+// ParseDDPPackage returns the name and version from the metadata segment of a DDP package file
+func ParseDDPPackage(data []byte) (*DDPPackage, error) {
+	if len(data) < 8 {
+		return nil, fmt.Errorf("invalid DDP package header")
+	}
+	segCount := binary.LittleEndian.Uint32(data[4:8])
+	if uint64(len(data)) < 8+4*uint64(segCount) {
+		return nil, fmt.Errorf("invalid DDP package segment count %d", segCount)
+	}
+	for n := uint32(0); n < segCount; n++ {
+		offset := uint64(binary.LittleEndian.Uint32(data[8+4*n:]))
+		if uint64(len(data)) < offset+ddpSegmentHeaderSize {
+			return nil, fmt.Errorf("invalid DDP package segment offset %d", offset)
+		}
+		if binary.LittleEndian.Uint32(data[offset:]) != ddpSegmentTypeMetadata {
+			continue
+		}
+		if uint64(len(data)) < offset+ddpMetadataSegmentSize {
+			return nil, fmt.Errorf("invalid DDP package metadata segment")
+		}
+		version := data[offset+ddpSegmentHeaderSize : offset+ddpSegmentHeaderSize+4]
+		name := data[offset+ddpSegmentHeaderSize+8 : offset+ddpMetadataSegmentSize]
+		if idx := bytes.IndexByte(name, 0); idx != -1 {
+			name = name[:idx]
+		}
+		return &DDPPackage{
+			Name:    string(name),
+			Version: fmt.Sprintf("%d.%d.%d.%d", version[0], version[1], version[2], version[3]),
+		}, nil
+	}
+	return nil, fmt.Errorf("DDP package has no metadata segment")
+}
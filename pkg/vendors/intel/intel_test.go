@@ -0,0 +1,167 @@
+// Copyright 2025 sriov-network-device-plugin authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package intelutils
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vishvananda/netlink"
+	"go.uber.org/mock/gomock"
+
+	mock_host "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/mock"
+	mock_utils "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils/mock"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/fakefilesystem"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/helpers"
+)
+
+const (
+	testDdpDir     = "/host/lib/firmware/updates/intel/ice/ddp"
+	testDevicePath = testDdpDir + "/ice-000102ffff030405.pkg"
+)
+
+// newTestDDPPackage returns a DDP package with a segment of the ice driver and the metadata segment
+func newTestDDPPackage(name string, version [4]byte) []byte {
+	data := make([]byte, 16+ddpSegmentHeaderSize+ddpMetadataSegmentSize)
+	binary.LittleEndian.PutUint32(data[4:], 2)
+	binary.LittleEndian.PutUint32(data[8:], 16)
+	binary.LittleEndian.PutUint32(data[12:], 16+ddpSegmentHeaderSize)
+	binary.LittleEndian.PutUint32(data[16:], 0x10)
+	metadata := data[16+ddpSegmentHeaderSize:]
+	binary.LittleEndian.PutUint32(metadata, ddpSegmentTypeMetadata)
+	copy(metadata[ddpSegmentHeaderSize:], version[:])
+	copy(metadata[ddpSegmentHeaderSize+8:], name)
+	return data
+}
+
+var _ = Describe("Intel", func() {
+	var (
+		i              IntelInterface
+		u              *mock_utils.MockCmdInterface
+		mockHostHelper *mock_host.MockHostManagerInterface
+		testCtrl       *gomock.Controller
+
+		testError = fmt.Errorf("test")
+	)
+	BeforeEach(func() {
+		testCtrl = gomock.NewController(GinkgoT())
+		u = mock_utils.NewMockCmdInterface(testCtrl)
+		mockHostHelper = mock_host.NewMockHostManagerInterface(testCtrl)
+		i = New(u, mockHostHelper)
+	})
+
+	AfterEach(func() {
+		testCtrl.Finish()
+	})
+
+	Context("ParseDDPPackage", func() {
+		It("should return the name and version of the package", func() {
+			pkg, err := ParseDDPPackage(newTestDDPPackage("ICE COMMS Package", [4]byte{1, 3, 45, 0}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pkg).To(Equal(&DDPPackage{Name: "ICE COMMS Package", Version: "1.3.45.0"}))
+			Expect(pkg.String()).To(Equal("ICE COMMS Package 1.3.45.0"))
+		})
+		It("should fail on a truncated package", func() {
+			data := newTestDDPPackage("ICE COMMS Package", [4]byte{1, 3, 45, 0})
+			_, err := ParseDDPPackage(data[:len(data)-10])
+			Expect(err).To(HaveOccurred())
+		})
+		It("should fail when the package has no metadata", func() {
+			_, err := ParseDDPPackage([]byte{1, 0, 0, 0, 0, 0, 0, 0})
+			Expect(err).To(MatchError(ContainSubstring("no metadata segment")))
+		})
+	})
+
+	Context("DDP packages", func() {
+		BeforeEach(func() {
+			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+				Dirs: []string{testDdpDir},
+				Files: map[string][]byte{
+					testDdpDir + "/ice_comms-1.3.45.0.pkg": newTestDDPPackage("ICE COMMS Package", [4]byte{1, 3, 45, 0}),
+				},
+			})
+			mockHostHelper.EXPECT().GetDevlinkDeviceInfo("0000:d8:00.0").Return(
+				&netlink.DevlinkDeviceInfo{Driver: "ice", SerialNumber: "00-01-02-FF-FF-03-04-05"}, nil).AnyTimes()
+		})
+		It("should read a package", func() {
+			pkg, err := i.GetDDPPackage("ice_comms-1.3.45.0.pkg")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pkg.String()).To(Equal("ICE COMMS Package 1.3.45.0"))
+			_, err = i.GetDDPPackage("ice_wireless_edge-1.3.10.0.pkg")
+			Expect(err).To(HaveOccurred())
+		})
+		It("should install and remove a package", func() {
+			installed, _, err := i.GetInstalledDDPPackage("0000:d8:00.0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(installed).To(BeEmpty())
+
+			Expect(i.InstallDDPPackage("0000:d8:00.0", "ice_comms-1.3.45.0.pkg")).To(Succeed())
+			target, err := os.Readlink(filepath.Join(vars.FilesystemRoot, testDevicePath))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(target).To(Equal("ice_comms-1.3.45.0.pkg"))
+			installed, beforeBoot, err := i.GetInstalledDDPPackage("0000:d8:00.0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(installed).To(Equal("ice_comms-1.3.45.0.pkg"))
+			Expect(beforeBoot).To(BeFalse())
+
+			Expect(i.RemoveDDPPackage("0000:d8:00.0")).To(Succeed())
+			helpers.GinkgoAssertFileDoesNotExist(testDevicePath)
+			Expect(i.RemoveDDPPackage("0000:d8:00.0")).To(Succeed())
+		})
+		It("should report a package installed before the boot", func() {
+			Expect(i.InstallDDPPackage("0000:d8:00.0", "ice_comms-1.3.45.0.pkg")).To(Succeed())
+			origGetBootTime := getBootTime
+			DeferCleanup(func() { getBootTime = origGetBootTime })
+			getBootTime = func() (time.Time, error) { return time.Now().Add(time.Minute), nil }
+			_, beforeBoot, err := i.GetInstalledDDPPackage("0000:d8:00.0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(beforeBoot).To(BeTrue())
+		})
+		It("should not install a missing package", func() {
+			Expect(i.InstallDDPPackage("0000:d8:00.0", "ice_wireless_edge-1.3.10.0.pkg")).To(HaveOccurred())
+			helpers.GinkgoAssertFileDoesNotExist(testDevicePath)
+		})
+		It("should fail on a package not installed by the operator", func() {
+			Expect(os.WriteFile(filepath.Join(vars.FilesystemRoot, testDevicePath), []byte{}, 0o600)).To(Succeed())
+			_, _, err := i.GetInstalledDDPPackage("0000:d8:00.0")
+			Expect(err).To(MatchError(ContainSubstring("not managed by the operator")))
+		})
+	})
+
+	Context("ReloadIceDevices", func() {
+		It("should unbind all the PFs before binding them to ice", func() {
+			gomock.InOrder(
+				mockHostHelper.EXPECT().UnbindDriverByBusAndDevice("pci", "0000:d8:00.0").Return(nil),
+				mockHostHelper.EXPECT().UnbindDriverByBusAndDevice("pci", "0000:d8:00.1").Return(nil),
+				mockHostHelper.EXPECT().BindDriverByBusAndDevice("pci", "0000:d8:00.0", "ice").Return(nil),
+				mockHostHelper.EXPECT().BindDriverByBusAndDevice("pci", "0000:d8:00.1", "ice").Return(nil),
+			)
+			Expect(i.ReloadIceDevices([]string{"0000:d8:00.0", "0000:d8:00.1"})).To(Succeed())
+		})
+		It("should fail when a PF can't be unbound", func() {
+			mockHostHelper.EXPECT().UnbindDriverByBusAndDevice("pci", "0000:d8:00.0").Return(testError)
+			Expect(i.ReloadIceDevices([]string{"0000:d8:00.0", "0000:d8:00.1"})).To(MatchError(testError))
+		})
+	})
+})
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: intel.go
+//
+// Generated by this command:
+//
+//	mockgen -destination mock/mock_intel.go -source intel.go
+//
+
+// Package mock_intelutils is a generated GoMock package.
+package mock_intelutils
+
+import (
+	reflect "reflect"
+
+	intelutils "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vendors/intel"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIntelInterface is a mock of IntelInterface interface.
+type MockIntelInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockIntelInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockIntelInterfaceMockRecorder is the mock recorder for MockIntelInterface.
+type MockIntelInterfaceMockRecorder struct {
+	mock *MockIntelInterface
+}
+
+// NewMockIntelInterface creates a new mock instance.
+func NewMockIntelInterface(ctrl *gomock.Controller) *MockIntelInterface {
+	mock := &MockIntelInterface{ctrl: ctrl}
+	mock.recorder = &MockIntelInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIntelInterface) EXPECT() *MockIntelInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetDDPPackage mocks base method.
+func (m *MockIntelInterface) GetDDPPackage(pkgFile string) (*intelutils.DDPPackage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDDPPackage", pkgFile)
+	ret0, _ := ret[0].(*intelutils.DDPPackage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDDPPackage indicates an expected call of GetDDPPackage.
+func (mr *MockIntelInterfaceMockRecorder) GetDDPPackage(pkgFile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDDPPackage", reflect.TypeOf((*MockIntelInterface)(nil).GetDDPPackage), pkgFile)
+}
+
+// GetInstalledDDPPackage mocks base method.
+func (m *MockIntelInterface) GetInstalledDDPPackage(pciAddress string) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstalledDDPPackage", pciAddress)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetInstalledDDPPackage indicates an expected call of GetInstalledDDPPackage.
+func (mr *MockIntelInterfaceMockRecorder) GetInstalledDDPPackage(pciAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstalledDDPPackage", reflect.TypeOf((*MockIntelInterface)(nil).GetInstalledDDPPackage), pciAddress)
+}
+
+// InstallDDPPackage mocks base method.
+func (m *MockIntelInterface) InstallDDPPackage(pciAddress, pkgFile string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallDDPPackage", pciAddress, pkgFile)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallDDPPackage indicates an expected call of InstallDDPPackage.
+func (mr *MockIntelInterfaceMockRecorder) InstallDDPPackage(pciAddress, pkgFile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallDDPPackage", reflect.TypeOf((*MockIntelInterface)(nil).InstallDDPPackage), pciAddress, pkgFile)
+}
+
+// ReloadIceDevices mocks base method.
+func (m *MockIntelInterface) ReloadIceDevices(pciAddresses []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReloadIceDevices", pciAddresses)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReloadIceDevices indicates an expected call of ReloadIceDevices.
+func (mr *MockIntelInterfaceMockRecorder) ReloadIceDevices(pciAddresses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadIceDevices", reflect.TypeOf((*MockIntelInterface)(nil).ReloadIceDevices), pciAddresses)
+}
+
+// RemoveDDPPackage mocks base method.
+func (m *MockIntelInterface) RemoveDDPPackage(pciAddress string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDDPPackage", pciAddress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDDPPackage indicates an expected call of RemoveDDPPackage.
+func (mr *MockIntelInterfaceMockRecorder) RemoveDDPPackage(pciAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDDPPackage", reflect.TypeOf((*MockIntelInterface)(nil).RemoveDDPPackage), pciAddress)
+}
@@ -24,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/drivers"
 )
 
 var (
@@ -58,10 +59,10 @@ func mutateSriovNetworkNodePolicy(cr map[string]interface{}) (*v1.AdmissionRespo
 		log.Log.V(2).Info("mutateSriovNetworkNodePolicy(): set default isRdma to false for policy", "policy-name", name)
 		patchs = append(patchs, defaultIsRdmaPatch)
 	}
-	// Device with InfiniBand link type requires isRdma to be true, except for vfio-pci device type
+	// Device with InfiniBand link type requires isRdma to be true, except for the userspace device types
 	if str, ok := specMap["linkType"].(string); ok && strings.EqualFold(str, constants.LinkTypeIB) {
 		devType, _ := specMap["deviceType"].(string)
-		if !strings.EqualFold(devType, constants.DeviceTypeVfioPci) {
+		if !drivers.IsUserspaceDeviceType(devType) {
 			log.Log.V(2).Info("mutateSriovNetworkNodePolicy(): set isRdma to true for policy since ib link type is detected", "policy-name", name)
 			patchs = append(patchs, InfiniBandIsRdmaPatch)
 		}
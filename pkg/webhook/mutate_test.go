@@ -105,6 +105,21 @@ func TestMutateSriovNetworkNodePolicy_InfiniBandVfioPciDoesNotSetIsRdma(t *testi
 		"isRdma should default to false when deviceType is vfio-pci with IB linkType")
 }
 
+func TestMutateSriovNetworkNodePolicy_InfiniBandUserspaceDriverDoesNotSetIsRdma(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cr := buildPolicyMap("ib-noiommu-policy", map[string]interface{}{
+		"linkType":   constants.LinkTypeIB,
+		"deviceType": constants.DeviceTypeVfioPciNoIommu,
+	})
+
+	resp, err := mutateSriovNetworkNodePolicy(cr)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	patches := patchesFromResponse(g, resp.Patch)
+	g.Expect(hasPatch(patches, "/spec/isRdma", true)).To(BeFalse(),
+		"isRdma should NOT be set to true when the deviceType is a userspace driver")
+}
+
 func TestMutateSriovNetworkNodePolicy_EthernetDoesNotSetIsRdma(t *testing.T) {
 	g := NewGomegaWithT(t)
 	cr := buildPolicyMap("eth-policy", map[string]interface{}{
@@ -19,8 +19,10 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -34,6 +36,7 @@ import (
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/drivers"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
@@ -69,9 +72,43 @@ func validateSriovOperatorConfig(cr *sriovnetworkv1.SriovOperatorConfig, operati
 		return false, warnings, err
 	}
 
+	err = validateSriovOperatorConfigExternalPlugins(cr)
+	if err != nil {
+		return false, warnings, err
+	}
+
 	return true, warnings, nil
 }
 
+// inTreePluginNames are the names of the plugins compiled in the config daemon
+var inTreePluginNames = []string{"generic", "k8s", "virtual", "intel", "mellanox"}
+
+// vendorIDRe matches a PCI vendor ID as reported in the node state
+var vendorIDRe = regexp.MustCompile(`^[0-9a-f]{4}$`)
+
+// validateSriovOperatorConfigExternalPlugins checks the external plugins can be loaded by the config daemon
+func validateSriovOperatorConfigExternalPlugins(cr *sriovnetworkv1.SriovOperatorConfig) error {
+	names := map[string]bool{}
+	for _, p := range cr.Spec.ExternalPlugins {
+		if slices.Contains(inTreePluginNames, p.Name) {
+			return fmt.Errorf("external plugin name %s is reserved for an in-tree plugin", p.Name)
+		}
+		if names[p.Name] {
+			return fmt.Errorf("external plugin %s is registered more than once", p.Name)
+		}
+		names[p.Name] = true
+		if (p.Command == "") == (p.Socket == "") {
+			return fmt.Errorf("external plugin %s must set exactly one of command and socket", p.Name)
+		}
+		for _, vendor := range p.Vendors {
+			if !vendorIDRe.MatchString(vendor) {
+				return fmt.Errorf("external plugin %s has an invalid vendor ID %q, expected 4 hexadecimal digits", p.Name, vendor)
+			}
+		}
+	}
+	return nil
+}
+
 // validateSriovOperatorConfigDisableDrain checks if the user is setting `.Spec.DisableDrain` from false to true while
 // operator is updating one or more nodes. Disabling the drain at this stage would prevent the operator to uncordon a node at
 // the end of the update operation, keeping nodes un-schedulable until manual intervention.
@@ -125,6 +162,18 @@ func validateSriovNetworkPoolConfig(cr *sriovnetworkv1.SriovNetworkPoolConfig, o
 		}
 	}
 
+	for i := range cr.Spec.MaintenanceWindows {
+		if err := cr.Spec.MaintenanceWindows[i].Validate(); err != nil {
+			return false, warnings, fmt.Errorf("SriovNetworkPoolConfig invalid maintenance window: %v", err)
+		}
+	}
+
+	if cr.Spec.RolloutStrategy != nil {
+		if _, err := cr.CanaryNodes(nil); err != nil {
+			return false, warnings, fmt.Errorf("SriovNetworkPoolConfig invalid rolloutStrategy: %v", err)
+		}
+	}
+
 	return true, warnings, nil
 }
 
@@ -224,8 +273,9 @@ func staticValidateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePol
 	// To configure RoCE on baremetal or virtual machine:
 	// BM: DeviceType = netdevice && isRdma = true
 	// VM: DeviceType = vfio-pci && isRdma = false
-	if cr.Spec.DeviceType == consts.DeviceTypeVfioPci && cr.Spec.IsRdma {
-		return false, fmt.Errorf("'deviceType: vfio-pci' conflicts with 'isRdma: true'; Set 'deviceType' to (string)'netdevice' Or Set 'isRdma' to (bool)'false'")
+	// the VFs bound to a userspace driver have no RDMA device
+	if drivers.IsUserspaceDeviceType(cr.Spec.DeviceType) && cr.Spec.IsRdma {
+		return false, fmt.Errorf("'deviceType: %s' conflicts with 'isRdma: true'; Set 'deviceType' to (string)'netdevice' Or Set 'isRdma' to (bool)'false'", cr.Spec.DeviceType)
 	}
 
 	// switchdev mode can be used only with ethernet links
@@ -241,6 +291,12 @@ func staticValidateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePol
 	if (cr.Spec.VdpaType == consts.VdpaTypeVirtio || cr.Spec.VdpaType == consts.VdpaTypeVhost) && cr.Spec.EswitchMode != sriovnetworkv1.ESwithModeSwitchDev {
 		return false, fmt.Errorf("vdpa requires the device to be configured in switchdev mode")
 	}
+	// vdpa: the attributes are set on the VDPA devices of the VFs
+	if cr.Spec.VdpaAttributes != nil {
+		if err := validateVdpaAttributes(cr); err != nil {
+			return false, err
+		}
+	}
 	// software bridge management: device must be configured in switchdev mode
 	if !cr.Spec.Bridge.IsEmpty() && cr.Spec.EswitchMode != sriovnetworkv1.ESwithModeSwitchDev {
 		return false, fmt.Errorf("software bridge management requires the device to be configured in switchdev mode")
@@ -249,9 +305,193 @@ func staticValidateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePol
 	if !cr.Spec.Bridge.IsEmpty() && cr.Spec.ExternallyManaged {
 		return false, fmt.Errorf("software bridge management can't be used when the device externally managed")
 	}
+	// ethtool settings: the operator doesn't configure externally managed PFs
+	if cr.Spec.PfSettings != nil && cr.Spec.ExternallyManaged {
+		return false, fmt.Errorf("'pfSettings' can't be used when the device externally managed")
+	}
+	// devlink parameters: the operator doesn't configure externally managed PFs
+	if len(cr.Spec.DevlinkParams) > 0 && cr.Spec.ExternallyManaged {
+		return false, fmt.Errorf("'devlinkParams' can't be used when the device externally managed")
+	}
+	// the operator configures the flow steering mode required by switchdev
+	if _, ok := cr.Spec.DevlinkParams["flow_steering_mode"]; ok && cr.Spec.EswitchMode == sriovnetworkv1.ESwithModeSwitchDev {
+		return false, fmt.Errorf("'devlinkParams.flow_steering_mode' can't be used with 'eSwitchMode: switchdev'")
+	}
+	if cr.Spec.VfAttributes != nil {
+		if err := validateVfAttributes(cr); err != nil {
+			return false, err
+		}
+	}
+	if len(cr.Spec.PKeys) > 0 {
+		if err := validatePKeys(cr); err != nil {
+			return false, err
+		}
+	}
+	if cr.Spec.NumSfs > 0 && cr.Spec.DeviceType != consts.DeviceTypeSf {
+		return false, fmt.Errorf("'numSfs' can be used only with 'deviceType: sf'")
+	}
+	if cr.Spec.DeviceType == consts.DeviceTypeSf {
+		if err := validateSfPolicy(cr); err != nil {
+			return false, err
+		}
+	}
+	if cr.Spec.Bond != nil {
+		if err := validateBondPolicy(cr); err != nil {
+			return false, err
+		}
+	}
 	return true, nil
 }
 
+// validateBondPolicy checks a policy bonding the PFs it selects
+func validateBondPolicy(cr *sriovnetworkv1.SriovNetworkNodePolicy) error {
+	if cr.Spec.EswitchMode != sriovnetworkv1.ESwithModeSwitchDev {
+		return fmt.Errorf("'bond' requires the device to be configured in switchdev mode")
+	}
+	if cr.Spec.ExternallyManaged {
+		return fmt.Errorf("'bond' can't be used when the device externally managed")
+	}
+	if cr.Spec.LinkType != "" && !strings.EqualFold(cr.Spec.LinkType, consts.LinkTypeETH) {
+		return fmt.Errorf("'bond' requires 'linkType: eth'")
+	}
+	if cr.Spec.Bond.XmitHashPolicy != "" && (cr.Spec.Bond.Mode == "" || cr.Spec.Bond.Mode == consts.BondModeActiveBackup) {
+		return fmt.Errorf("'bond.xmitHashPolicy' can't be used with the active-backup mode")
+	}
+	for _, pf := range cr.Spec.NicSelector.PfNames {
+		if strings.Contains(pf, "#") {
+			return fmt.Errorf("VF range in PF name %s can't be used with 'bond', the policy must configure the whole PF", pf)
+		}
+	}
+	return nil
+}
+
+// validateSfPolicy checks a policy configuring scalable functions instead of VFs
+func validateSfPolicy(cr *sriovnetworkv1.SriovNetworkNodePolicy) error {
+	if cr.Spec.NumSfs == 0 {
+		return fmt.Errorf("'deviceType: sf' requires 'numSfs' to be greater than 0")
+	}
+	if cr.Spec.NumVfs != 0 {
+		return fmt.Errorf("'deviceType: sf' conflicts with 'numVfs: %d'; VFs must be configured by another policy", cr.Spec.NumVfs)
+	}
+	if cr.Spec.EswitchMode != sriovnetworkv1.ESwithModeSwitchDev {
+		return fmt.Errorf("'deviceType: sf' requires the device to be configured in switchdev mode")
+	}
+	if cr.Spec.ExternallyManaged {
+		return fmt.Errorf("'deviceType: sf' can't be used when the device externally managed")
+	}
+	if cr.Spec.VfAttributes != nil {
+		return fmt.Errorf("'vfAttributes' can't be used with 'deviceType: sf'")
+	}
+	for _, pf := range cr.Spec.NicSelector.PfNames {
+		if strings.Contains(pf, "#") {
+			return fmt.Errorf("VF range in PF name %s can't be used with 'deviceType: sf'", pf)
+		}
+	}
+	return nil
+}
+
+// validateVfAttributes checks the administrative attributes of the VFs of the policy
+func validateVfAttributes(cr *sriovnetworkv1.SriovNetworkNodePolicy) error {
+	attrs := cr.Spec.VfAttributes
+	if cr.Spec.LinkType != "" && !strings.EqualFold(cr.Spec.LinkType, consts.LinkTypeETH) {
+		return fmt.Errorf("'vfAttributes' can be used only with ethernet links")
+	}
+	if attrs.Mac != "" {
+		if err := validateVfMac(cr, "vfAttributes.mac", attrs.Mac); err != nil {
+			return err
+		}
+	}
+	if (attrs.Vlan == nil || *attrs.Vlan == 0) && attrs.VlanQoS != 0 {
+		return fmt.Errorf("'vfAttributes.vlanQoS' requires 'vfAttributes.vlan' to be set")
+	}
+	if attrs.MinTxRate != nil && attrs.MaxTxRate != nil && *attrs.MaxTxRate != 0 && *attrs.MinTxRate > *attrs.MaxTxRate {
+		return fmt.Errorf("'vfAttributes.minTxRate' %d is greater than 'vfAttributes.maxTxRate' %d", *attrs.MinTxRate, *attrs.MaxTxRate)
+	}
+	return nil
+}
+
+// validatePKeys checks the InfiniBand partition keys of the VFs of the policy
+func validatePKeys(cr *sriovnetworkv1.SriovNetworkNodePolicy) error {
+	if !strings.EqualFold(cr.Spec.LinkType, consts.LinkTypeIB) {
+		return fmt.Errorf("'pKeys' can be used only with 'linkType: ib'")
+	}
+	pkeys := map[uint64]bool{}
+	for _, pkey := range cr.Spec.PKeys {
+		v, err := strconv.ParseUint(pkey, 0, 16)
+		if err != nil {
+			return fmt.Errorf("invalid pkey %s: %v", pkey, err)
+		}
+		// the partition number of the key is its 15 low bits, the high bit is the full membership
+		if v&0x7fff == 0 {
+			return fmt.Errorf("invalid pkey %s: the partition number can't be 0", pkey)
+		}
+		if pkeys[v] {
+			return fmt.Errorf("duplicated pkey %s", pkey)
+		}
+		pkeys[v] = true
+	}
+	return nil
+}
+
+// validateVdpaAttributes checks the attributes of the VDPA devices of the policy
+func validateVdpaAttributes(cr *sriovnetworkv1.SriovNetworkNodePolicy) error {
+	attrs := cr.Spec.VdpaAttributes
+	if cr.Spec.VdpaType == "" {
+		return fmt.Errorf("'vdpaAttributes' requires 'vdpaType' to be set")
+	}
+	if attrs.Mac != "" {
+		if err := validateVfMac(cr, "vdpaAttributes.mac", attrs.Mac); err != nil {
+			return err
+		}
+	}
+	if attrs.MgmtDevice != "" {
+		// a management device is a device of a node, e.g. a SF of the PF, it can't create the devices of several VFs
+		if err := validateSinglePf(cr, "vdpaAttributes.mgmtDevice"); err != nil {
+			return err
+		}
+		if policyVfCount(cr) != 1 {
+			return fmt.Errorf("'vdpaAttributes.mgmtDevice' requires the policy to select a single VF, " +
+				"with 'numVfs: 1' or a VF range of a single VF in the nicSelector")
+		}
+	}
+	return nil
+}
+
+// validateVfMac checks the MAC address configured on the first VF of the policy, the following VFs
+// use the next addresses so the policy must select a single PF to not configure the same addresses twice
+func validateVfMac(cr *sriovnetworkv1.SriovNetworkNodePolicy, field, value string) error {
+	mac, err := net.ParseMAC(value)
+	if err != nil {
+		return fmt.Errorf("invalid '%s' %s: %v", field, value, err)
+	}
+	if mac[0]&0x01 != 0 {
+		return fmt.Errorf("'%s' %s must be a unicast address", field, value)
+	}
+	return validateSinglePf(cr, field)
+}
+
+// validateSinglePf returns an error if the policy may select several PFs, on the same node or on several nodes
+func validateSinglePf(cr *sriovnetworkv1.SriovNetworkNodePolicy, field string) error {
+	if cr.Spec.NodeSelector[corev1.LabelHostname] == "" {
+		return fmt.Errorf("'%s' requires the nodeSelector to select a single node with the %s label", field, corev1.LabelHostname)
+	}
+	if len(cr.Spec.NicSelector.PfNames)+len(cr.Spec.NicSelector.RootDevices) != 1 {
+		return fmt.Errorf("'%s' requires the nicSelector to select a single PF with pfNames or rootDevices", field)
+	}
+	return nil
+}
+
+// policyVfCount returns the number of VFs of each PF selected by the policy, from the VF range of the nicSelector
+// or numVfs
+func policyVfCount(cr *sriovnetworkv1.SriovNetworkNodePolicy) int {
+	for _, device := range append(slices.Clone(cr.Spec.NicSelector.PfNames), cr.Spec.NicSelector.RootDevices...) {
+		if _, rngSt, rngEnd, err := sriovnetworkv1.ParseVfRange(device); err == nil && rngSt >= 0 {
+			return rngEnd - rngSt + 1
+		}
+	}
+	return cr.Spec.NumVfs
+}
+
 func dynamicValidateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePolicy) (bool, error) {
 	nodesSelected = false
 	interfaceSelected = false
@@ -318,6 +558,9 @@ func validatePolicyForNodeStateAndPolicy(nsList *sriovnetworkv1.SriovNetworkNode
 			if err := validatePolicyForNodePolicy(cr, &np, currentNodeState); err != nil {
 				return err
 			}
+			if err := validateDdpPackage(cr, &np, currentNodeState, node); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -327,13 +570,15 @@ func validatePolicyForNodeState(policy *sriovnetworkv1.SriovNetworkNodePolicy, s
 	log.Log.V(2).Info("validatePolicyForNodeState(): validate policy for node", "policy-name",
 		policy.GetName(), "node-name", state.GetName())
 	interfaceSelectedForNode := false
+	selectedCount := 0
 	var noInterfacesSelectedLog []string
 	for _, iface := range state.Status.Interfaces {
 		err := validateNicModel(&policy.Spec.NicSelector, &iface, node)
 		if err == nil {
 			interfaceSelected = true
 			interfaceSelectedForNode = true
-			if policy.GetName() != consts.DefaultPolicyName && policy.Spec.NumVfs == 0 {
+			selectedCount++
+			if policy.GetName() != consts.DefaultPolicyName && policy.Spec.NumVfs == 0 && policy.Spec.DeviceType != consts.DeviceTypeSf {
 				return nil, fmt.Errorf("numVfs(%d) in CR %s is not allowed", policy.Spec.NumVfs, policy.GetName())
 			}
 			if policy.Spec.NumVfs > iface.TotalVfs && iface.Vendor == IntelID {
@@ -361,6 +606,26 @@ func validatePolicyForNodeState(policy *sriovnetworkv1.SriovNetworkNodePolicy, s
 			if (policy.Spec.VdpaType == consts.VdpaTypeVirtio || policy.Spec.VdpaType == consts.VdpaTypeVhost) && iface.Vendor != MellanoxID {
 				return nil, fmt.Errorf("vendor(%s) in CR %s not supported for vdpa interface(%s)", iface.Vendor, policy.GetName(), iface.Name)
 			}
+			// SFs: only mellanox cards are supported
+			if policy.Spec.DeviceType == consts.DeviceTypeSf && iface.Vendor != MellanoxID {
+				return nil, fmt.Errorf("vendor(%s) in CR %s not supported for scalable functions interface(%s)", iface.Vendor, policy.GetName(), iface.Name)
+			}
+			// VF-LAG: only mellanox cards are supported
+			if policy.Spec.Bond != nil && iface.Vendor != MellanoxID {
+				return nil, fmt.Errorf("vendor(%s) in CR %s not supported for bond interface(%s)", iface.Vendor, policy.GetName(), iface.Name)
+			}
+			// DDP packages: only E810 cards are supported
+			if policy.Spec.DdpPackage != "" && (iface.Vendor != IntelID || iface.Driver != consts.IceDriverName) {
+				return nil, fmt.Errorf("DDP package in CR %s not supported for interface(%s) with driver %s", policy.GetName(), iface.Name, iface.Driver)
+			}
+			// PKeys: only the PFs exposing the PKey table of their VFs are supported
+			if len(policy.Spec.PKeys) > 0 && iface.Driver != consts.Mlx4DriverName {
+				return nil, fmt.Errorf("pKeys in CR %s not supported for interface(%s) with driver %s, the PKeys of its VFs must be assigned to their GUID by the subnet manager",
+					policy.GetName(), iface.Name, iface.Driver)
+			}
+			if err := validateVfioIsolation(policy, &iface); err != nil {
+				return nil, err
+			}
 		} else {
 			errorMessage := fmt.Sprintf("Interface: %s was not selected, since NIC model could not be validated due to the following error: %s \n", iface.Name, err)
 			noInterfacesSelectedLog = append(noInterfacesSelectedLog, errorMessage)
@@ -370,9 +635,37 @@ func validatePolicyForNodeState(policy *sriovnetworkv1.SriovNetworkNodePolicy, s
 	if !interfaceSelectedForNode {
 		return noInterfacesSelectedLog, nil
 	}
+	if policy.Spec.Bond != nil && selectedCount != 2 {
+		return nil, fmt.Errorf("bond %s in CR %s requires exactly two PFs, %d PFs are selected on node %s",
+			policy.Spec.Bond.Name, policy.GetName(), selectedCount, state.GetName())
+	}
 	return nil, nil
 }
 
+// validateVfioIsolation refuses the vfio-pci policies selecting VFs which share their IOMMU group with other devices,
+// such VFs can't be safely isolated. The VFs not created yet are reported by the VfioUnsafe condition of the node state.
+// The drivers which don't use the IOMMU, e.g. vfio-pci in no-IOMMU mode, are not checked.
+func validateVfioIsolation(policy *sriovnetworkv1.SriovNetworkNodePolicy, iface *sriovnetworkv1.InterfaceExt) error {
+	if driver, ok := drivers.Get(policy.Spec.DeviceType); !ok || !driver.NeedIommu {
+		return nil
+	}
+	rngStart, rngEnd := 0, policy.Spec.NumVfs-1
+	for _, pfName := range policy.Spec.NicSelector.PfNames {
+		name, start, end, err := sriovnetworkv1.ParseVfRange(pfName)
+		if err == nil && name == iface.Name && start >= 0 {
+			rngStart, rngEnd = start, end
+		}
+	}
+	for _, vf := range iface.VFs {
+		if vf.VfID < rngStart || vf.VfID > rngEnd || vf.IommuGroup == nil || len(vf.IommuGroupDevices) == 0 {
+			continue
+		}
+		return fmt.Errorf("VF %s of interface(%s) selected by CR %s shares IOMMU group %d with devices %s, it can't be isolated with vfio-pci",
+			vf.PciAddress, iface.Name, policy.GetName(), *vf.IommuGroup, strings.Join(vf.IommuGroupDevices, ", "))
+	}
+	return nil
+}
+
 func validatePolicyForNodePolicy(current *sriovnetworkv1.SriovNetworkNodePolicy, previous *sriovnetworkv1.SriovNetworkNodePolicy, nodeState *sriovnetworkv1.SriovNetworkNodeState) error {
 	log.Log.V(2).Info("validateConflictPolicy(): validate policy against policy",
 		"source", current.GetName(), "target", previous.GetName())
@@ -396,9 +689,50 @@ func validatePolicyForNodePolicy(current *sriovnetworkv1.SriovNetworkNodePolicy,
 		return err
 	}
 
+	err = validateSfResourceName(current, previous)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateDdpPackage rejects policies requesting different DDP packages for the PFs of an E810 adapter, the ice driver
+// loads the same package for all the PFs of the adapter. The PFs of an adapter are the functions of the same PCI device.
+func validateDdpPackage(current, previous *sriovnetworkv1.SriovNetworkNodePolicy, nodeState *sriovnetworkv1.SriovNetworkNodeState, node *corev1.Node) error {
+	if nodeState == nil || current.Spec.DdpPackage == "" || previous.Spec.DdpPackage == "" ||
+		current.Spec.DdpPackage == previous.Spec.DdpPackage {
+		return nil
+	}
+	for i := range nodeState.Status.Interfaces {
+		cur := &nodeState.Status.Interfaces[i]
+		if validateNicModel(&current.Spec.NicSelector, cur, node) != nil {
+			continue
+		}
+		for j := range nodeState.Status.Interfaces {
+			prev := &nodeState.Status.Interfaces[j]
+			// the policy with the highest priority sets the package of a PF
+			if prev.PciAddress == cur.PciAddress || pciDeviceAddress(prev.PciAddress) != pciDeviceAddress(cur.PciAddress) {
+				continue
+			}
+			if validateNicModel(&previous.Spec.NicSelector, prev, node) != nil {
+				continue
+			}
+			return fmt.Errorf("DDP package %s in CR %s conflicts with DDP package %s of policy %s, interfaces %s and %s are ports of the same adapter",
+				current.Spec.DdpPackage, current.GetName(), previous.Spec.DdpPackage, previous.GetName(), cur.Name, prev.Name)
+		}
+	}
 	return nil
 }
 
+// pciDeviceAddress returns the PCI address without the function number
+func pciDeviceAddress(pciAddress string) string {
+	if idx := strings.LastIndex(pciAddress, "."); idx != -1 {
+		return pciAddress[:idx]
+	}
+	return pciAddress
+}
+
 func validatePfNames(current *sriovnetworkv1.SriovNetworkNodePolicy, previous *sriovnetworkv1.SriovNetworkNodePolicy, nodeState *sriovnetworkv1.SriovNetworkNodeState) error {
 	for _, curPf := range current.Spec.NicSelector.PfNames {
 		curName, curRngSt, curRngEnd, err := sriovnetworkv1.ParseVfRange(curPf)
@@ -469,6 +803,20 @@ func validateExludeTopologyField(current *sriovnetworkv1.SriovNetworkNodePolicy,
 		current.Spec.ExcludeTopology, previous.GetName(), previous.Spec.ExcludeTopology, current.Spec.ResourceName)
 }
 
+// validateSfResourceName checks that a resource of the device plugin contains either SFs or VFs
+func validateSfResourceName(current *sriovnetworkv1.SriovNetworkNodePolicy, previous *sriovnetworkv1.SriovNetworkNodePolicy) error {
+	if current.Spec.ResourceName != previous.Spec.ResourceName {
+		return nil
+	}
+
+	if (current.Spec.DeviceType == consts.DeviceTypeSf) == (previous.Spec.DeviceType == consts.DeviceTypeSf) {
+		return nil
+	}
+
+	return fmt.Errorf("deviceType[%s] field conflicts with policy [%s].DeviceType[%s] as they target the same resource[%s], SFs and VFs can't be in the same resource",
+		current.Spec.DeviceType, previous.GetName(), previous.Spec.DeviceType, current.Spec.ResourceName)
+}
+
 func validateNicModel(selector *sriovnetworkv1.SriovNetworkNicSelector, iface *sriovnetworkv1.InterfaceExt, node *corev1.Node) error {
 	if selector.Vendor != "" && selector.Vendor != iface.Vendor {
 		return fmt.Errorf("selector vendor: %s is not equal to the interface vendor: %s", selector.Vendor, iface.Vendor)
@@ -21,12 +21,14 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	. "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
@@ -204,6 +206,38 @@ func TestValidateSriovOperatorConfigWithDefaultOperatorConfig(t *testing.T) {
 	g.Expect(ok).To(Equal(true))
 }
 
+func TestValidateSriovOperatorConfigExternalPlugins(t *testing.T) {
+	g := NewGomegaWithT(t)
+	client = fake.NewClientBuilder().WithScheme(vars.Scheme).Build()
+
+	config := newDefaultOperatorConfig()
+	config.Spec.ExternalPlugins = []ExternalPlugin{
+		{Name: "acme", Vendors: []string{"1d0f"}, Command: "/usr/local/bin/acme-sriov-plugin"},
+		{Name: "partner", Vendors: []string{"19e5", "1924"}, Socket: "/var/run/partner/plugin.sock"},
+	}
+	ok, _, err := validateSriovOperatorConfig(config, "UPDATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	config.Spec.ExternalPlugins[1].Name = "acme"
+	_, _, err = validateSriovOperatorConfig(config, "UPDATE")
+	g.Expect(err).To(MatchError(ContainSubstring("registered more than once")))
+
+	config.Spec.ExternalPlugins[1].Name = "mellanox"
+	_, _, err = validateSriovOperatorConfig(config, "UPDATE")
+	g.Expect(err).To(MatchError(ContainSubstring("reserved for an in-tree plugin")))
+
+	config.Spec.ExternalPlugins[1].Name = "partner"
+	config.Spec.ExternalPlugins[1].Command = "/usr/local/bin/partner-sriov-plugin"
+	_, _, err = validateSriovOperatorConfig(config, "UPDATE")
+	g.Expect(err).To(MatchError(ContainSubstring("exactly one of command and socket")))
+
+	config.Spec.ExternalPlugins[1].Socket = ""
+	config.Spec.ExternalPlugins[1].Vendors = []string{"19E5"}
+	_, _, err = validateSriovOperatorConfig(config, "UPDATE")
+	g.Expect(err).To(MatchError(ContainSubstring("invalid vendor ID")))
+}
+
 func TestValidateSriovOperatorConfigDisableDrain(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -253,6 +287,37 @@ func TestValidateSriovNetworkPoolConfigWithDefault(t *testing.T) {
 	g.Expect(ok).To(Equal(true))
 }
 
+func TestValidateSriovNetworkPoolConfigWithMaintenanceWindows(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	config := newDefaultNetworkPoolConfig()
+	config.Spec.MaintenanceWindows = []MaintenanceWindow{{Days: []string{"Sat", "Sun"}, StartTime: "22:00", EndTime: "04:00", TimeZone: "America/New_York"}}
+	ok, _, err := validateSriovNetworkPoolConfig(config, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+
+	config.Spec.MaintenanceWindows[0].TimeZone = "Invalid/Zone"
+	ok, _, err = validateSriovNetworkPoolConfig(config, "CREATE")
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(ok).To(Equal(false))
+}
+
+func TestValidateSriovNetworkPoolConfigWithRolloutStrategy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	canaryCount := intstr.FromString("10%")
+	config := newDefaultNetworkPoolConfig()
+	config.Spec.RolloutStrategy = &RolloutStrategy{CanaryCount: &canaryCount, SoakTime: &metav1.Duration{Duration: time.Hour}}
+	ok, _, err := validateSriovNetworkPoolConfig(config, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+
+	canaryCount = intstr.FromInt32(0)
+	ok, _, err = validateSriovNetworkPoolConfig(config, "CREATE")
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(ok).To(Equal(false))
+}
+
 func TestValidateSriovNetworkPoolConfigWithParallelAndHWOffload(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -350,6 +415,54 @@ func TestValidatePolicyForNodeStateWithInvalidNumVfsPolicy(t *testing.T) {
 	g.Expect(err).To(MatchError("numVfs(65) in CR p1 exceed the maximum allowed value(64) interface(ens803f0)"))
 }
 
+func TestValidatePolicyForNodeStateWithSharedIommuGroup(t *testing.T) {
+	state := newNodeState()
+	state.Status.Interfaces[0].VFs = []VirtualFunction{
+		{PciAddress: "0000:86:02.0", VfID: 0, IommuGroup: ptr.To(42)},
+		{PciAddress: "0000:86:02.1", VfID: 1, IommuGroup: ptr.To(42), IommuGroupDevices: []string{"0000:86:00.0"}},
+	}
+	policy := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "p1",
+		},
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "vfio-pci",
+			NicSelector: SriovNetworkNicSelector{
+				PfNames:     []string{"ens803f0"},
+				RootDevices: []string{"0000:86:00.0"},
+				Vendor:      "8086",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       4,
+			Priority:     99,
+			ResourceName: "p0",
+		},
+	}
+	g := NewGomegaWithT(t)
+	_, err := validatePolicyForNodeState(policy, state, NewNode())
+	g.Expect(err).To(MatchError("VF 0000:86:02.1 of interface(ens803f0) selected by CR p1 shares IOMMU group 42 with devices 0000:86:00.0, it can't be isolated with vfio-pci"))
+
+	// the VFs out of the VF range of the policy are not checked
+	policy.Spec.NicSelector.PfNames = []string{"ens803f0#0-0"}
+	_, err = validatePolicyForNodeState(policy, state, NewNode())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// netdevice VFs don't need the isolation
+	policy.Spec.NicSelector.PfNames = []string{"ens803f0"}
+	policy.Spec.DeviceType = "netdevice"
+	_, err = validatePolicyForNodeState(policy, state, NewNode())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// neither do the userspace drivers which don't use the IOMMU
+	for _, deviceType := range []string{constants.DeviceTypeVfioPciNoIommu, constants.DeviceTypeUioPciGeneric, constants.DeviceTypeIgbUio} {
+		policy.Spec.DeviceType = deviceType
+		_, err = validatePolicyForNodeState(policy, state, NewNode())
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+}
+
 func TestValidatePolicyForNodeStateWithInvalidNumVfsExternallyCreated(t *testing.T) {
 	state := newNodeState()
 	policy := &SriovNetworkNodePolicy{
@@ -730,6 +843,31 @@ func TestValidatePoliciesWithSameExcludeTopologyForTheSameResource(t *testing.T)
 	g.Expect(err).NotTo(HaveOccurred())
 }
 
+func TestValidatePoliciesWithSfsAndVfsForTheSameResource(t *testing.T) {
+	current := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "currentPolicy"},
+		Spec: SriovNetworkNodePolicySpec{
+			ResourceName: "resourceX",
+			DeviceType:   "sf",
+			NumSfs:       16,
+		},
+	}
+
+	previous := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "previousPolicy"},
+		Spec: SriovNetworkNodePolicySpec{
+			ResourceName: "resourceX",
+			DeviceType:   "netdevice",
+			NumVfs:       10,
+		},
+	}
+
+	err := validatePolicyForNodePolicy(current, previous, nil)
+
+	g := NewGomegaWithT(t)
+	g.Expect(err).To(MatchError("deviceType[sf] field conflicts with policy [previousPolicy].DeviceType[netdevice] as they target the same resource[resourceX], SFs and VFs can't be in the same resource"))
+}
+
 func TestValidatePoliciesWithDifferentNumVfForTheSameResourceAndTheSameRootDevice(t *testing.T) {
 	current := &SriovNetworkNodePolicy{
 		ObjectMeta: metav1.ObjectMeta{Name: "currentPolicy"},
@@ -884,6 +1022,11 @@ func TestStaticValidateSriovNetworkNodePolicyWithConflictIsRdmaAndDeviceType(t *
 	ok, err := staticValidateSriovNetworkNodePolicy(policy)
 	g.Expect(err).To(MatchError(ContainSubstring("'deviceType: vfio-pci' conflicts with 'isRdma: true'")))
 	g.Expect(ok).To(Equal(false))
+
+	policy.Spec.DeviceType = constants.DeviceTypeUioPciGeneric
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("'deviceType: uio_pci_generic' conflicts with 'isRdma: true'")))
+	g.Expect(ok).To(Equal(false))
 }
 
 func TestStaticValidateSriovNetworkNodePolicyWithConflictDeviceTypeAndVirtioVdpaType(t *testing.T) {
@@ -980,6 +1123,130 @@ func TestStaticValidateSriovNetworkNodePolicyVhostVdpaMustSpecifySwitchDev(t *te
 	g.Expect(ok).To(Equal(false))
 }
 
+func TestStaticValidateSriovNetworkNodePolicyPKeys(t *testing.T) {
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				Vendor:   "15b3",
+				DeviceID: "101d",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       1,
+			Priority:     99,
+			ResourceName: "p0",
+			IsRdma:       true,
+			PKeys:        []string{"0xffff", "0x8001"},
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("'pKeys' can be used only with 'linkType: ib'")))
+	g.Expect(ok).To(Equal(false))
+
+	policy.Spec.LinkType = "ib"
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+
+	policy.Spec.PKeys = []string{"0x8001", "0x8001"}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("duplicated pkey 0x8001")))
+	g.Expect(ok).To(Equal(false))
+
+	policy.Spec.PKeys = []string{"0x8000"}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("invalid pkey 0x8000: the partition number can't be 0")))
+	g.Expect(ok).To(Equal(false))
+}
+
+func TestStaticValidateSriovNetworkNodePolicyVdpaAttributesMustSpecifyVdpaType(t *testing.T) {
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				Vendor:   "15b3",
+				DeviceID: "101d",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:         1,
+			Priority:       99,
+			ResourceName:   "p0",
+			EswitchMode:    "switchdev",
+			VdpaAttributes: &VdpaAttributes{Mtu: ptr.To(9000)},
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("'vdpaAttributes' requires 'vdpaType' to be set")))
+	g.Expect(ok).To(Equal(false))
+
+	policy.Spec.VdpaType = constants.VdpaTypeVhost
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+
+	policy.Spec.VdpaAttributes.Mac = "02:00:00:00:10:00"
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("'vdpaAttributes.mac' requires the nodeSelector to select a single node")))
+	g.Expect(ok).To(Equal(false))
+
+	policy.Spec.NodeSelector[corev1.LabelHostname] = "worker-0"
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("'vdpaAttributes.mac' requires the nicSelector to select a single PF")))
+	g.Expect(ok).To(Equal(false))
+
+	policy.Spec.NicSelector.PfNames = []string{"ens1f0"}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+}
+
+func TestStaticValidateSriovNetworkNodePolicyVdpaMgmtDeviceMustSelectSingleVf(t *testing.T) {
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				PfNames: []string{"ens1f0#0-3"},
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+				corev1.LabelHostname: "worker-0",
+			},
+			NumVfs:         4,
+			Priority:       99,
+			ResourceName:   "p0",
+			EswitchMode:    "switchdev",
+			VdpaType:       constants.VdpaTypeVhost,
+			VdpaAttributes: &VdpaAttributes{MgmtDevice: "auxiliary/mlx5_core.sf.2"},
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("'vdpaAttributes.mgmtDevice' requires the policy to select a single VF")))
+	g.Expect(ok).To(Equal(false))
+
+	policy.Spec.NicSelector.PfNames = []string{"ens1f0#2-2"}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+
+	policy.Spec.NicSelector.PfNames = []string{"ens1f0"}
+	policy.Spec.NumVfs = 1
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+
+	delete(policy.Spec.NodeSelector, corev1.LabelHostname)
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("'vdpaAttributes.mgmtDevice' requires the nodeSelector to select a single node")))
+	g.Expect(ok).To(Equal(false))
+}
+
 func TestValidatePolicyForNodeStateVirtioVdpaWithNotSupportedVendor(t *testing.T) {
 	state := newNodeState()
 	policy := &SriovNetworkNodePolicy{
@@ -1183,6 +1450,460 @@ func TestStaticValidateSriovNetworkNodePolicyWithBridgeConfigWithExternallyManag
 	g.Expect(ok).To(Equal(false))
 }
 
+func TestStaticValidateSriovNetworkNodePolicyWithVfAttributes(t *testing.T) {
+	testtable := []struct {
+		tname       string
+		pfNames     []string
+		hostname    string
+		linkType    string
+		attrs       *VfAttributes
+		expectError bool
+	}{
+		{
+			tname:    "valid attributes",
+			pfNames:  []string{"ens803f1#0-3"},
+			hostname: "worker-0",
+			attrs: &VfAttributes{Mac: "02:00:00:00:01:00", Vlan: ptr.To(100), VlanQoS: 3, Trust: "on",
+				SpoofChk: "off", LinkState: "enable", MinTxRate: ptr.To(100), MaxTxRate: ptr.To(1000)},
+		},
+		{
+			tname:   "attributes without mac on several nodes",
+			pfNames: []string{"ens803f0", "ens803f1"},
+			attrs:   &VfAttributes{Vlan: ptr.To(100), Trust: "on"},
+		},
+		{
+			tname:       "infiniband link",
+			pfNames:     []string{"ens803f1"},
+			linkType:    "ib",
+			attrs:       &VfAttributes{Trust: "on"},
+			expectError: true,
+		},
+		{
+			tname:       "multicast mac",
+			pfNames:     []string{"ens803f1"},
+			attrs:       &VfAttributes{Mac: "01:00:00:00:01:00"},
+			expectError: true,
+		},
+		{
+			tname:       "mac with multiple PFs",
+			pfNames:     []string{"ens803f0", "ens803f1"},
+			hostname:    "worker-0",
+			attrs:       &VfAttributes{Mac: "02:00:00:00:01:00"},
+			expectError: true,
+		},
+		{
+			tname:       "mac on several nodes",
+			pfNames:     []string{"ens803f1"},
+			attrs:       &VfAttributes{Mac: "02:00:00:00:01:00"},
+			expectError: true,
+		},
+		{
+			tname:       "qos without vlan",
+			pfNames:     []string{"ens803f1"},
+			attrs:       &VfAttributes{VlanQoS: 3},
+			expectError: true,
+		},
+		{
+			tname:       "min tx rate greater than max",
+			pfNames:     []string{"ens803f1"},
+			attrs:       &VfAttributes{MinTxRate: ptr.To(1000), MaxTxRate: ptr.To(100)},
+			expectError: true,
+		},
+	}
+	for _, tc := range testtable {
+		t.Run(tc.tname, func(t *testing.T) {
+			policy := &SriovNetworkNodePolicy{
+				Spec: SriovNetworkNodePolicySpec{
+					DeviceType: "netdevice",
+					LinkType:   tc.linkType,
+					NicSelector: SriovNetworkNicSelector{
+						PfNames: tc.pfNames,
+					},
+					NodeSelector: map[string]string{
+						"feature.node.kubernetes.io/network-sriov.capable": "true",
+					},
+					NumVfs:       4,
+					ResourceName: "p0",
+					VfAttributes: tc.attrs,
+				},
+			}
+			if tc.hostname != "" {
+				policy.Spec.NodeSelector[corev1.LabelHostname] = tc.hostname
+			}
+			g := NewGomegaWithT(t)
+			ok, err := staticValidateSriovNetworkNodePolicy(policy)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(ok).To(BeFalse())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(ok).To(BeTrue())
+			}
+		})
+	}
+}
+
+func TestStaticValidateSriovNetworkNodePolicyWithPfSettingsAndExternallyManaged(t *testing.T) {
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				PfNames: []string{"ens803f1"},
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:            4,
+			ResourceName:      "p0",
+			ExternallyManaged: true,
+			PfSettings:        &PfSettings{RxRingSize: ptr.To(4096)},
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(ok).To(BeFalse())
+
+	policy.Spec.ExternallyManaged = false
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+}
+
+func TestStaticValidateSriovNetworkNodePolicyWithDevlinkParams(t *testing.T) {
+	testtable := []struct {
+		tname             string
+		eswitchMode       string
+		externallyManaged bool
+		params            map[string]DevlinkParam
+		expectError       bool
+	}{
+		{
+			tname:  "valid parameters",
+			params: map[string]DevlinkParam{"flow_steering_mode": {Value: "dmfs"}, "esw_multiport": {Value: "true"}},
+		},
+		{
+			tname:             "externally managed",
+			externallyManaged: true,
+			params:            map[string]DevlinkParam{"esw_multiport": {Value: "true"}},
+			expectError:       true,
+		},
+		{
+			tname:       "flow steering mode with switchdev",
+			eswitchMode: "switchdev",
+			params:      map[string]DevlinkParam{"flow_steering_mode": {Value: "dmfs"}},
+			expectError: true,
+		},
+	}
+	for _, tc := range testtable {
+		t.Run(tc.tname, func(t *testing.T) {
+			policy := &SriovNetworkNodePolicy{
+				Spec: SriovNetworkNodePolicySpec{
+					DeviceType: "netdevice",
+					NicSelector: SriovNetworkNicSelector{
+						PfNames: []string{"ens803f1"},
+					},
+					NodeSelector: map[string]string{
+						"feature.node.kubernetes.io/network-sriov.capable": "true",
+					},
+					NumVfs:            4,
+					ResourceName:      "p0",
+					EswitchMode:       tc.eswitchMode,
+					ExternallyManaged: tc.externallyManaged,
+					DevlinkParams:     tc.params,
+				},
+			}
+			g := NewGomegaWithT(t)
+			ok, err := staticValidateSriovNetworkNodePolicy(policy)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(ok).To(BeFalse())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(ok).To(BeTrue())
+			}
+		})
+	}
+}
+
+func TestStaticValidateSriovNetworkNodePolicyWithSfs(t *testing.T) {
+	testtable := []struct {
+		tname       string
+		deviceType  string
+		eswitchMode string
+		numVfs      int
+		numSfs      int
+		pfName      string
+		expectError bool
+	}{
+		{
+			tname:       "valid SF policy",
+			deviceType:  "sf",
+			eswitchMode: "switchdev",
+			numSfs:      16,
+		},
+		{
+			tname:       "numSfs without SF device type",
+			deviceType:  "netdevice",
+			eswitchMode: "switchdev",
+			numVfs:      4,
+			numSfs:      16,
+			expectError: true,
+		},
+		{
+			tname:       "SF device type without numSfs",
+			deviceType:  "sf",
+			eswitchMode: "switchdev",
+			expectError: true,
+		},
+		{
+			tname:       "SF device type with numVfs",
+			deviceType:  "sf",
+			eswitchMode: "switchdev",
+			numVfs:      4,
+			numSfs:      16,
+			expectError: true,
+		},
+		{
+			tname:       "SF device type in legacy mode",
+			deviceType:  "sf",
+			eswitchMode: "legacy",
+			numSfs:      16,
+			expectError: true,
+		},
+		{
+			tname:       "SF device type with VF range",
+			deviceType:  "sf",
+			eswitchMode: "switchdev",
+			numSfs:      16,
+			pfName:      "ens803f1#0-3",
+			expectError: true,
+		},
+	}
+	for _, tc := range testtable {
+		t.Run(tc.tname, func(t *testing.T) {
+			pfName := "ens803f1"
+			if tc.pfName != "" {
+				pfName = tc.pfName
+			}
+			policy := &SriovNetworkNodePolicy{
+				Spec: SriovNetworkNodePolicySpec{
+					DeviceType: tc.deviceType,
+					NicSelector: SriovNetworkNicSelector{
+						PfNames: []string{pfName},
+					},
+					NodeSelector: map[string]string{
+						"feature.node.kubernetes.io/network-sriov.capable": "true",
+					},
+					NumVfs:       tc.numVfs,
+					NumSfs:       tc.numSfs,
+					ResourceName: "p0",
+					EswitchMode:  tc.eswitchMode,
+				},
+			}
+			g := NewGomegaWithT(t)
+			ok, err := staticValidateSriovNetworkNodePolicy(policy)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(ok).To(BeFalse())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(ok).To(BeTrue())
+			}
+		})
+	}
+}
+
+func TestValidatePolicyForNodeStateWithSfsOnIntelNic(t *testing.T) {
+	state := newNodeState()
+	policy := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "p1",
+		},
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "sf",
+			NicSelector: SriovNetworkNicSelector{
+				PfNames: []string{"ens803f0"},
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumSfs:       16,
+			EswitchMode:  "switchdev",
+			ResourceName: "p0",
+		},
+	}
+	g := NewGomegaWithT(t)
+	_, err := validatePolicyForNodeState(policy, state, NewNode())
+	g.Expect(err).To(MatchError("vendor(8086) in CR p1 not supported for scalable functions interface(ens803f0)"))
+}
+
+func TestStaticValidateSriovNetworkNodePolicyWithBond(t *testing.T) {
+	testtable := []struct {
+		tname       string
+		eswitchMode string
+		bond        BondConfig
+		pfName      string
+		expectError bool
+	}{
+		{
+			tname:       "valid bond policy",
+			eswitchMode: "switchdev",
+			bond:        BondConfig{Name: "bond0", Mode: "802.3ad", XmitHashPolicy: "layer3+4"},
+		},
+		{
+			tname:       "bond in legacy mode",
+			eswitchMode: "legacy",
+			bond:        BondConfig{Name: "bond0", Mode: "active-backup"},
+			expectError: true,
+		},
+		{
+			tname:       "hash policy with active-backup mode",
+			eswitchMode: "switchdev",
+			bond:        BondConfig{Name: "bond0", Mode: "active-backup", XmitHashPolicy: "layer3+4"},
+			expectError: true,
+		},
+		{
+			tname:       "bond with VF range",
+			eswitchMode: "switchdev",
+			bond:        BondConfig{Name: "bond0", Mode: "active-backup"},
+			pfName:      "ens803f1#0-3",
+			expectError: true,
+		},
+	}
+	for _, tc := range testtable {
+		t.Run(tc.tname, func(t *testing.T) {
+			pfName := "ens803f1"
+			if tc.pfName != "" {
+				pfName = tc.pfName
+			}
+			policy := &SriovNetworkNodePolicy{
+				Spec: SriovNetworkNodePolicySpec{
+					DeviceType: "netdevice",
+					NicSelector: SriovNetworkNicSelector{
+						PfNames: []string{"ens803f0", pfName},
+					},
+					NodeSelector: map[string]string{
+						"feature.node.kubernetes.io/network-sriov.capable": "true",
+					},
+					NumVfs:       4,
+					ResourceName: "p0",
+					EswitchMode:  tc.eswitchMode,
+					Bond:         &tc.bond,
+				},
+			}
+			g := NewGomegaWithT(t)
+			ok, err := staticValidateSriovNetworkNodePolicy(policy)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(ok).To(BeFalse())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(ok).To(BeTrue())
+			}
+		})
+	}
+}
+
+func TestValidatePolicyForNodeStateWithBond(t *testing.T) {
+	state := &SriovNetworkNodeState{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-0"},
+		Status: SriovNetworkNodeStateStatus{
+			Interfaces: []InterfaceExt{
+				{Name: "ens1f0np0", PciAddress: "0000:3b:00.0", Vendor: "15b3", DeviceID: "101d", TotalVfs: 8},
+				{Name: "ens1f1np1", PciAddress: "0000:3b:00.1", Vendor: "15b3", DeviceID: "101d", TotalVfs: 8},
+			},
+		},
+	}
+	policy := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "p1",
+		},
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				PfNames: []string{"ens1f0np0", "ens1f1np1"},
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       4,
+			EswitchMode:  "switchdev",
+			ResourceName: "p0",
+			Bond:         &BondConfig{Name: "bond0", Mode: "active-backup"},
+		},
+	}
+	g := NewGomegaWithT(t)
+	_, err := validatePolicyForNodeState(policy, state, NewNode())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	policy.Spec.NicSelector.PfNames = []string{"ens1f0np0"}
+	_, err = validatePolicyForNodeState(policy, state, NewNode())
+	g.Expect(err).To(MatchError("bond bond0 in CR p1 requires exactly two PFs, 1 PFs are selected on node worker-0"))
+
+	policy.Spec.NicSelector.PfNames = []string{"ens803f0", "ens803f1"}
+	_, err = validatePolicyForNodeState(policy, newNodeState(), NewNode())
+	g.Expect(err).To(MatchError("vendor(8086) in CR p1 not supported for bond interface(ens803f0)"))
+}
+
+func TestValidatePolicyForNodeStateWithDdpPackage(t *testing.T) {
+	state := newNodeState()
+	policy := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "p1",
+		},
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				PfNames: []string{"ens803f0"},
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       4,
+			ResourceName: "p0",
+			DdpPackage:   "ice_comms-1.3.45.0.pkg",
+		},
+	}
+	g := NewGomegaWithT(t)
+	_, err := validatePolicyForNodeState(policy, state, NewNode())
+	g.Expect(err).To(MatchError("DDP package in CR p1 not supported for interface(ens803f0) with driver i40e"))
+
+	state.Status.Interfaces[0].Driver = "ice"
+	_, err = validatePolicyForNodeState(policy, state, NewNode())
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestValidateDdpPackageForPFsOfTheSameAdapter(t *testing.T) {
+	state := newNodeState()
+	current := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "p1"},
+		Spec: SriovNetworkNodePolicySpec{
+			NicSelector: SriovNetworkNicSelector{PfNames: []string{"ens803f0"}},
+			DdpPackage:  "ice_comms-1.3.45.0.pkg",
+		},
+	}
+	previous := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "p2"},
+		Spec: SriovNetworkNodePolicySpec{
+			NicSelector: SriovNetworkNicSelector{PfNames: []string{"ens803f1"}},
+			DdpPackage:  "ice_wireless_edge-1.3.10.0.pkg",
+		},
+	}
+	g := NewGomegaWithT(t)
+	g.Expect(validateDdpPackage(current, previous, state, NewNode())).To(MatchError(
+		"DDP package ice_comms-1.3.45.0.pkg in CR p1 conflicts with DDP package ice_wireless_edge-1.3.10.0.pkg of policy p2, interfaces ens803f0 and ens803f1 are ports of the same adapter"))
+
+	// a PF without package doesn't manage the package of the adapter
+	previous.Spec.DdpPackage = ""
+	g.Expect(validateDdpPackage(current, previous, state, NewNode())).To(Succeed())
+
+	// the package of a PF is set by the policy with the highest priority
+	previous.Spec.DdpPackage = "ice_wireless_edge-1.3.10.0.pkg"
+	previous.Spec.NicSelector.PfNames = []string{"ens803f0"}
+	g.Expect(validateDdpPackage(current, previous, state, NewNode())).To(Succeed())
+}
+
 func TestValidatePolicyForNodeStateWithValidNetFilter(t *testing.T) {
 	interfaceSelected = false
 	state := newNodeState()
@@ -1594,3 +2315,38 @@ func TestValidatePolicyForNodePolicyWithAltNameConflict(t *testing.T) {
 	err = validatePolicyForNodePolicy(current, previous, nil)
 	g.Expect(err).NotTo(HaveOccurred())
 }
+
+func TestValidatePolicyForNodeStateWithPKeys(t *testing.T) {
+	state := newNodeState()
+	state.Status.Interfaces[0].Vendor = "15b3"
+	state.Status.Interfaces[0].DeviceID = "101b"
+	state.Status.Interfaces[0].Driver = "mlx5_core"
+	state.Status.Interfaces[0].LinkType = "IB"
+	policy := &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "p1",
+		},
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				PfNames: []string{"ens803f0"},
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       4,
+			ResourceName: "p0",
+			IsRdma:       true,
+			LinkType:     "ib",
+			PKeys:        []string{"0x8001"},
+		},
+	}
+	g := NewGomegaWithT(t)
+	_, err := validatePolicyForNodeState(policy, state, NewNode())
+	g.Expect(err).To(MatchError("pKeys in CR p1 not supported for interface(ens803f0) with driver mlx5_core, " +
+		"the PKeys of its VFs must be assigned to their GUID by the subnet manager"))
+
+	state.Status.Interfaces[0].Driver = "mlx4_core"
+	_, err = validatePolicyForNodeState(policy, state, NewNode())
+	g.Expect(err).NotTo(HaveOccurred())
+}